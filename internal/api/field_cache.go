@@ -0,0 +1,120 @@
+package api
+
+import (
+	"sync"
+	"time"
+)
+
+type fieldCacheConfig interface {
+	GetResolverCacheFields() []string
+	GetResolverCacheTTL() int
+}
+
+type fieldCacheKey struct {
+	field string
+	id    int
+}
+
+type fieldCacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// fieldCache is an in-memory cache of the values of expensive resolver
+// fields. Only the fields configured by the user are cached, and entries
+// expire after the configured TTL. All entries are cleared when a mutation
+// is executed.
+type fieldCache struct {
+	config fieldCacheConfig
+
+	mutex   sync.Mutex
+	entries map[fieldCacheKey]fieldCacheEntry
+}
+
+func newFieldCache(config fieldCacheConfig) *fieldCache {
+	return &fieldCache{
+		config:  config,
+		entries: make(map[fieldCacheKey]fieldCacheEntry),
+	}
+}
+
+// ttl returns the duration that values of the provided field are cached for.
+// Returns 0 if the field should not be cached.
+func (c *fieldCache) ttl(field string) time.Duration {
+	if c == nil {
+		return 0
+	}
+
+	for _, f := range c.config.GetResolverCacheFields() {
+		if f == field {
+			return time.Duration(c.config.GetResolverCacheTTL()) * time.Second
+		}
+	}
+
+	return 0
+}
+
+func (c *fieldCache) get(key fieldCacheKey) (interface{}, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	e, found := c.entries[key]
+	if !found {
+		return nil, false
+	}
+
+	if time.Now().After(e.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	return e.value, true
+}
+
+func (c *fieldCache) set(key fieldCacheKey, value interface{}, ttl time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries[key] = fieldCacheEntry{
+		value:   value,
+		expires: time.Now().Add(ttl),
+	}
+}
+
+// resolveInt returns the value of the field for the object with the provided
+// id. If the field is cached, then the cached value is returned if present.
+// Otherwise, the value is resolved using fn.
+func (c *fieldCache) resolveInt(field string, id int, fn func() (int, error)) (*int, error) {
+	ttl := c.ttl(field)
+	key := fieldCacheKey{field: field, id: id}
+
+	if ttl > 0 {
+		if v, found := c.get(key); found {
+			ret := v.(int)
+			return &ret, nil
+		}
+	}
+
+	ret, err := fn()
+	if err != nil {
+		return nil, err
+	}
+
+	if ttl > 0 {
+		c.set(key, ret, ttl)
+	}
+
+	return &ret, nil
+}
+
+// clear removes all cached values.
+func (c *fieldCache) clear() {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = make(map[fieldCacheKey]fieldCacheEntry)
+}
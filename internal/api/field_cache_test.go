@@ -0,0 +1,73 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testFieldCacheConfig struct {
+	fields []string
+	ttl    int
+}
+
+func (c testFieldCacheConfig) GetResolverCacheFields() []string {
+	return c.fields
+}
+
+func (c testFieldCacheConfig) GetResolverCacheTTL() int {
+	return c.ttl
+}
+
+func TestFieldCache_resolveInt(t *testing.T) {
+	const (
+		cachedField   = "Tag.scene_count"
+		uncachedField = "Tag.image_count"
+	)
+
+	c := newFieldCache(testFieldCacheConfig{
+		fields: []string{cachedField},
+		ttl:    60,
+	})
+
+	calls := 0
+	resolve := func(field string, id int, v int) int {
+		ret, err := c.resolveInt(field, id, func() (int, error) {
+			calls++
+			return v, nil
+		})
+		assert.Nil(t, err)
+		return *ret
+	}
+
+	assert.Equal(t, 1, resolve(cachedField, 1, 1))
+	assert.Equal(t, 1, resolve(cachedField, 1, 2), "expected cached value")
+	assert.Equal(t, 3, resolve(cachedField, 2, 3), "expected value for other id")
+	assert.Equal(t, 2, calls)
+
+	assert.Equal(t, 4, resolve(uncachedField, 1, 4))
+	assert.Equal(t, 5, resolve(uncachedField, 1, 5), "expected field not to be cached")
+
+	c.clear()
+	assert.Equal(t, 6, resolve(cachedField, 1, 6), "expected value to be cleared")
+
+	// errors are not cached
+	_, err := c.resolveInt(cachedField, 3, func() (int, error) {
+		return 0, errors.New("error")
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, 7, resolve(cachedField, 3, 7))
+}
+
+func TestFieldCache_nil(t *testing.T) {
+	var c *fieldCache
+
+	ret, err := c.resolveInt("Tag.scene_count", 1, func() (int, error) {
+		return 1, nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, *ret)
+
+	c.clear()
+}
@@ -9,6 +9,7 @@
 //go:generate go run -mod=vendor github.com/vektah/dataloaden SceneFileIDsLoader int []github.com/stashapp/stash/pkg/file.ID
 //go:generate go run -mod=vendor github.com/vektah/dataloaden ImageFileIDsLoader int []github.com/stashapp/stash/pkg/file.ID
 //go:generate go run -mod=vendor github.com/vektah/dataloaden GalleryFileIDsLoader int []github.com/stashapp/stash/pkg/file.ID
+//go:generate go run -mod=vendor github.com/vektah/dataloaden CountLoader int int
 
 package loaders
 
@@ -47,6 +48,12 @@ type Loaders struct {
 	TagByID       *TagLoader
 	MovieByID     *MovieLoader
 	FileByID      *FileLoader
+
+	// scene counts of objects, batched to avoid a query per object in
+	// list views
+	PerformerSceneCount *CountLoader
+	StudioSceneCount    *CountLoader
+	TagSceneCount       *CountLoader
 }
 
 type Middleware struct {
@@ -113,6 +120,21 @@ func (m Middleware) Middleware(next http.Handler) http.Handler {
 				maxBatch: maxBatch,
 				fetch:    m.fetchGalleriesFileIDs(ctx),
 			},
+			PerformerSceneCount: &CountLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchCounts(ctx, m.Repository.Scene.CountByPerformerIDs),
+			},
+			StudioSceneCount: &CountLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchCounts(ctx, m.Repository.Scene.CountByStudioIDs),
+			},
+			TagSceneCount: &CountLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchCounts(ctx, m.Repository.Scene.CountByTagIDs),
+			},
 		}
 
 		newCtx := context.WithValue(r.Context(), loadersCtxKey, ldrs)
@@ -259,3 +281,14 @@ func (m Middleware) fetchGalleriesFileIDs(ctx context.Context) func(keys []int)
 		return ret, toErrorSlice(err)
 	}
 }
+
+func (m Middleware) fetchCounts(ctx context.Context, countFn func(ctx context.Context, ids []int) ([]int, error)) func(keys []int) ([]int, []error) {
+	return func(keys []int) (ret []int, errs []error) {
+		err := m.withTxn(ctx, func(ctx context.Context) error {
+			var err error
+			ret, err = countFn(ctx, keys)
+			return err
+		})
+		return ret, toErrorSlice(err)
+	}
+}
@@ -38,6 +38,7 @@ type Resolver struct {
 	galleryService manager.GalleryService
 
 	hookExecutor hookExecutor
+	fieldCache   *fieldCache
 }
 
 func (r *Resolver) scraperCache() *scraper.Cache {
@@ -65,6 +66,9 @@ func (r *Resolver) Image() ImageResolver {
 func (r *Resolver) SceneMarker() SceneMarkerResolver {
 	return &sceneMarkerResolver{r}
 }
+func (r *Resolver) SceneMarkerCoverage() SceneMarkerCoverageResolver {
+	return &sceneMarkerCoverageResolver{r}
+}
 func (r *Resolver) Studio() StudioResolver {
 	return &studioResolver{r}
 }
@@ -86,6 +90,7 @@ type galleryResolver struct{ *Resolver }
 type performerResolver struct{ *Resolver }
 type sceneResolver struct{ *Resolver }
 type sceneMarkerResolver struct{ *Resolver }
+type sceneMarkerCoverageResolver struct{ *Resolver }
 type imageResolver struct{ *Resolver }
 type studioResolver struct{ *Resolver }
 type movieResolver struct{ *Resolver }
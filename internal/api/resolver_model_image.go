@@ -8,6 +8,7 @@ import (
 
 	"github.com/stashapp/stash/internal/api/loaders"
 	"github.com/stashapp/stash/internal/api/urlbuilders"
+	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/models"
 )
@@ -90,6 +91,7 @@ func (r *imageResolver) Files(ctx context.Context, obj *models.Image) ([]*ImageF
 	}
 
 	ret := make([]*ImageFile, len(files))
+	breakpoints := config.GetInstance().GetResolutionLabelBreakpoints()
 
 	for i, f := range files {
 		ret[i] = &ImageFile{
@@ -101,6 +103,7 @@ func (r *imageResolver) Files(ctx context.Context, obj *models.Image) ([]*ImageF
 			Size:           f.Size,
 			Width:          f.Width,
 			Height:         f.Height,
+			Oversized:      f.Oversized,
 			CreatedAt:      f.CreatedAt,
 			UpdatedAt:      f.UpdatedAt,
 			Fingerprints:   resolveFingerprints(f.Base()),
@@ -110,6 +113,8 @@ func (r *imageResolver) Files(ctx context.Context, obj *models.Image) ([]*ImageF
 			zipFileID := strconv.Itoa(int(*f.ZipFileID))
 			ret[i].ZipFileID = &zipFileID
 		}
+
+		ret[i].ResolutionLabel = models.GetResolutionLabel(f.Width, f.Height, breakpoints)
 	}
 
 	return ret, nil
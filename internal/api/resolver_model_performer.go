@@ -7,9 +7,11 @@ import (
 
 	"github.com/stashapp/stash/internal/api/loaders"
 	"github.com/stashapp/stash/internal/api/urlbuilders"
+	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/gallery"
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
 )
 
 // Checksum is deprecated
@@ -65,6 +67,29 @@ func (r *performerResolver) Birthdate(ctx context.Context, obj *models.Performer
 func (r *performerResolver) ImagePath(ctx context.Context, obj *models.Performer) (*string, error) {
 	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
 	imagePath := urlbuilders.NewPerformerURLBuilder(baseURL, obj).GetPerformerImageURL()
+
+	if !config.GetInstance().GetPerformerImageSceneFallback() {
+		return &imagePath, nil
+	}
+
+	// fall back to the cover of one of the performer's scenes
+	var coverScene *models.Scene
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		hasImage, err := r.repository.Performer.HasImage(ctx, obj.ID)
+		if err != nil || hasImage {
+			return err
+		}
+
+		coverScene, err = scene.FindPerformerCoverScene(ctx, r.repository.Scene, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if coverScene != nil {
+		imagePath = urlbuilders.NewSceneURLBuilder(baseURL, coverScene.ID).GetScreenshotURL(coverScene.UpdatedAt)
+	}
+
 	return &imagePath, nil
 }
 
@@ -83,11 +108,8 @@ func (r *performerResolver) Tags(ctx context.Context, obj *models.Performer) (re
 }
 
 func (r *performerResolver) SceneCount(ctx context.Context, obj *models.Performer) (ret *int, err error) {
-	var res int
-	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-		res, err = r.repository.Scene.CountByPerformerID(ctx, obj.ID)
-		return err
-	}); err != nil {
+	res, err := loaders.From(ctx).PerformerSceneCount.Load(obj.ID)
+	if err != nil {
 		return nil, err
 	}
 
@@ -159,6 +181,10 @@ func (r *performerResolver) DeathDate(ctx context.Context, obj *models.Performer
 	return nil, nil
 }
 
+func (r *performerResolver) Active(ctx context.Context, obj *models.Performer) (bool, error) {
+	return obj.DeathDate == nil, nil
+}
+
 func (r *performerResolver) Movies(ctx context.Context, obj *models.Performer) (ret []*models.Movie, err error) {
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.repository.Movie.FindByPerformerID(ctx, obj.ID)
@@ -181,3 +207,39 @@ func (r *performerResolver) MovieCount(ctx context.Context, obj *models.Performe
 
 	return &res, nil
 }
+
+func (r *performerResolver) TagProfile(ctx context.Context, obj *models.Performer, limit *int) ([]*PerformerTagCount, error) {
+	l := 0
+	if limit != nil {
+		l = *limit
+	}
+
+	var counts []*models.TagCount
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		counts, err = r.repository.Performer.TagProfile(ctx, obj.ID, l)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	tagIDs := make([]int, len(counts))
+	for i, c := range counts {
+		tagIDs[i] = c.TagID
+	}
+
+	tags, errs := loaders.From(ctx).TagByID.LoadAll(tagIDs)
+	if err := firstError(errs); err != nil {
+		return nil, err
+	}
+
+	ret := make([]*PerformerTagCount, len(counts))
+	for i, c := range counts {
+		ret[i] = &PerformerTagCount{
+			Tag:        tags[i],
+			SceneCount: c.Count,
+		}
+	}
+
+	return ret, nil
+}
@@ -4,13 +4,17 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/stashapp/stash/internal/api/loaders"
 	"github.com/stashapp/stash/internal/api/urlbuilders"
 	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/performer"
+	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/utils"
 )
 
@@ -112,35 +116,48 @@ func (r *sceneResolver) Files(ctx context.Context, obj *models.Scene) ([]*VideoF
 	ret := make([]*VideoFile, len(files))
 
 	for i, f := range files {
-		ret[i] = &VideoFile{
-			ID:             strconv.Itoa(int(f.ID)),
-			Path:           f.Path,
-			Basename:       f.Basename,
-			ParentFolderID: strconv.Itoa(int(f.ParentFolderID)),
-			ModTime:        f.ModTime,
-			Format:         f.Format,
-			Size:           f.Size,
-			Duration:       handleFloat64Value(f.Duration),
-			VideoCodec:     f.VideoCodec,
-			AudioCodec:     f.AudioCodec,
-			Width:          f.Width,
-			Height:         f.Height,
-			FrameRate:      handleFloat64Value(f.FrameRate),
-			BitRate:        int(f.BitRate),
-			CreatedAt:      f.CreatedAt,
-			UpdatedAt:      f.UpdatedAt,
-			Fingerprints:   resolveFingerprints(f.Base()),
-		}
-
-		if f.ZipFileID != nil {
-			zipFileID := strconv.Itoa(int(*f.ZipFileID))
-			ret[i].ZipFileID = &zipFileID
-		}
+		ret[i] = convertVideoFile(f)
 	}
 
 	return ret, nil
 }
 
+func convertVideoFile(f *file.VideoFile) *VideoFile {
+	ret := &VideoFile{
+		ID:             strconv.Itoa(int(f.ID)),
+		Path:           f.Path,
+		Basename:       f.Basename,
+		ParentFolderID: strconv.Itoa(int(f.ParentFolderID)),
+		ModTime:        f.ModTime,
+		Format:         f.Format,
+		Size:           f.Size,
+		Duration:       handleFloat64Value(f.Duration),
+		VideoCodec:     f.VideoCodec,
+		AudioCodec:     f.AudioCodec,
+		Width:          f.Width,
+		Height:         f.Height,
+		FrameRate:      handleFloat64Value(f.FrameRate),
+		BitRate:        int(f.BitRate),
+		CreatedAt:      f.CreatedAt,
+		UpdatedAt:      f.UpdatedAt,
+		Fingerprints:   resolveFingerprints(f.Base()),
+	}
+
+	if f.ZipFileID != nil {
+		zipFileID := strconv.Itoa(int(*f.ZipFileID))
+		ret.ZipFileID = &zipFileID
+	}
+
+	if bpp := f.BitratePerPixel(); bpp != 0 {
+		ret.BitratePerPixel = &bpp
+	}
+
+	ret.ResolutionLabel = models.GetResolutionLabel(f.Width, f.Height, config.GetInstance().GetResolutionLabelBreakpoints())
+	ret.Loudness = f.Loudness
+
+	return ret
+}
+
 func (r *sceneResolver) Rating(ctx context.Context, obj *models.Scene) (*int, error) {
 	if obj.Rating != nil {
 		rating := models.Rating100To5(*obj.Rating)
@@ -190,6 +207,7 @@ func (r *sceneResolver) Paths(ctx context.Context, obj *models.Scene) (*ScenePat
 	funscriptPath := builder.GetFunscriptURL()
 	captionBasePath := builder.GetCaptionURL()
 	interactiveHeatmap := builder.GetInteractiveHeatmapURL()
+	contactSheet := builder.GetContactSheetURL()
 
 	return &ScenePathsType{
 		Screenshot:         &screenshotPath,
@@ -202,6 +220,7 @@ func (r *sceneResolver) Paths(ctx context.Context, obj *models.Scene) (*ScenePat
 		Funscript:          &funscriptPath,
 		InteractiveHeatmap: &interactiveHeatmap,
 		Caption:            &captionBasePath,
+		ContactSheet:       &contactSheet,
 	}, nil
 }
 
@@ -249,6 +268,44 @@ func (r *sceneResolver) Galleries(ctx context.Context, obj *models.Scene) (ret [
 	return ret, firstError(errs)
 }
 
+func (r *sceneResolver) DisplayTitle(ctx context.Context, obj *models.Scene) (string, error) {
+	if obj.Title != "" {
+		return obj.Title, nil
+	}
+
+	return scene.FormatDisplayTitle(config.GetInstance().GetSceneTitleFallback(), func(token string) (string, error) {
+		switch token {
+		case scene.TitleTokenStudio:
+			studio, err := r.Studio(ctx, obj)
+			if err != nil || studio == nil {
+				return "", err
+			}
+			return studio.Name.String, nil
+		case scene.TitleTokenDate:
+			if obj.Date == nil {
+				return "", nil
+			}
+			return obj.Date.String(), nil
+		case scene.TitleTokenCode:
+			return obj.Code, nil
+		case scene.TitleTokenPerformers:
+			performers, err := r.Performers(ctx, obj)
+			if err != nil {
+				return "", err
+			}
+			return strings.Join(performer.GetNames(performers), ", "), nil
+		case scene.TitleTokenBasename:
+			primaryFile, err := r.getPrimaryFile(ctx, obj)
+			if err != nil || primaryFile == nil {
+				return "", err
+			}
+			return primaryFile.Basename, nil
+		}
+
+		return "", nil
+	})
+}
+
 func (r *sceneResolver) Studio(ctx context.Context, obj *models.Scene) (ret *models.Studio, err error) {
 	if obj.StudioID == nil {
 		return nil, nil
@@ -399,3 +456,39 @@ func (r *sceneResolver) InteractiveSpeed(ctx context.Context, obj *models.Scene)
 
 	return primaryFile.InteractiveSpeed, nil
 }
+
+func (r *sceneResolver) InteractiveAverageSpeed(ctx context.Context, obj *models.Scene) (*int, error) {
+	primaryFile, err := r.getPrimaryFile(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+	if primaryFile == nil {
+		return nil, nil
+	}
+
+	return primaryFile.InteractiveAverageSpeed, nil
+}
+
+func (r *sceneResolver) InteractiveRange(ctx context.Context, obj *models.Scene) (*int, error) {
+	primaryFile, err := r.getPrimaryFile(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+	if primaryFile == nil {
+		return nil, nil
+	}
+
+	return primaryFile.InteractiveRange, nil
+}
+
+func (r *sceneResolver) InteractiveDensity(ctx context.Context, obj *models.Scene) (*int, error) {
+	primaryFile, err := r.getPrimaryFile(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+	if primaryFile == nil {
+		return nil, nil
+	}
+
+	return primaryFile.InteractiveDensity, nil
+}
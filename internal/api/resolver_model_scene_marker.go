@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/stashapp/stash/internal/api/loaders"
 	"github.com/stashapp/stash/internal/api/urlbuilders"
 	"github.com/stashapp/stash/pkg/models"
 )
@@ -71,3 +72,7 @@ func (r *sceneMarkerResolver) CreatedAt(ctx context.Context, obj *models.SceneMa
 func (r *sceneMarkerResolver) UpdatedAt(ctx context.Context, obj *models.SceneMarker) (*time.Time, error) {
 	return &obj.UpdatedAt.Timestamp, nil
 }
+
+func (r *sceneMarkerCoverageResolver) Scene(ctx context.Context, obj *models.SceneMarkerCoverage) (*models.Scene, error) {
+	return loaders.From(ctx).SceneByID.Load(obj.SceneID)
+}
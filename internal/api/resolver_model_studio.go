@@ -6,9 +6,11 @@ import (
 
 	"github.com/stashapp/stash/internal/api/loaders"
 	"github.com/stashapp/stash/internal/api/urlbuilders"
+	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/gallery"
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/studio"
 )
 
 func (r *studioResolver) Name(ctx context.Context, obj *models.Studio) (string, error) {
@@ -27,17 +29,29 @@ func (r *studioResolver) URL(ctx context.Context, obj *models.Studio) (*string,
 
 func (r *studioResolver) ImagePath(ctx context.Context, obj *models.Studio) (*string, error) {
 	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
-	imagePath := urlbuilders.NewStudioURLBuilder(baseURL, obj).GetStudioImageURL()
 
+	imageStudio := obj
 	var hasImage bool
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
 		var err error
 		hasImage, err = r.repository.Studio.HasImage(ctx, obj.ID)
+		if err != nil || hasImage || !config.GetInstance().GetStudioImageInheritance() {
+			return err
+		}
+
+		// fall back to the image of the nearest ancestor with an image
+		ancestor, err := studio.FindImageAncestor(ctx, r.repository.Studio, obj)
+		if ancestor != nil {
+			imageStudio = ancestor
+			hasImage = true
+		}
 		return err
 	}); err != nil {
 		return nil, err
 	}
 
+	imagePath := urlbuilders.NewStudioURLBuilder(baseURL, imageStudio).GetStudioImageURL()
+
 	// indicate that image is missing by setting default query param to true
 	if !hasImage {
 		imagePath += "?default=true"
@@ -58,39 +72,37 @@ func (r *studioResolver) Aliases(ctx context.Context, obj *models.Studio) (ret [
 }
 
 func (r *studioResolver) SceneCount(ctx context.Context, obj *models.Studio) (ret *int, err error) {
-	var res int
-	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-		res, err = r.repository.Scene.CountByStudioID(ctx, obj.ID)
-		return err
-	}); err != nil {
-		return nil, err
-	}
-
-	return &res, err
+	return r.fieldCache.resolveInt("Studio.scene_count", obj.ID, func() (int, error) {
+		return loaders.From(ctx).StudioSceneCount.Load(obj.ID)
+	})
 }
 
 func (r *studioResolver) ImageCount(ctx context.Context, obj *models.Studio) (ret *int, err error) {
-	var res int
-	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-		res, err = image.CountByStudioID(ctx, r.repository.Image, obj.ID)
-		return err
-	}); err != nil {
-		return nil, err
-	}
+	return r.fieldCache.resolveInt("Studio.image_count", obj.ID, func() (int, error) {
+		var res int
+		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+			res, err = image.CountByStudioID(ctx, r.repository.Image, obj.ID)
+			return err
+		}); err != nil {
+			return 0, err
+		}
 
-	return &res, nil
+		return res, nil
+	})
 }
 
 func (r *studioResolver) GalleryCount(ctx context.Context, obj *models.Studio) (ret *int, err error) {
-	var res int
-	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-		res, err = gallery.CountByStudioID(ctx, r.repository.Gallery, obj.ID)
-		return err
-	}); err != nil {
-		return nil, err
-	}
+	return r.fieldCache.resolveInt("Studio.gallery_count", obj.ID, func() (int, error) {
+		var res int
+		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+			res, err = gallery.CountByStudioID(ctx, r.repository.Gallery, obj.ID)
+			return err
+		}); err != nil {
+			return 0, err
+		}
 
-	return &res, nil
+		return res, nil
+	})
 }
 
 func (r *studioResolver) ParentStudio(ctx context.Context, obj *models.Studio) (ret *models.Studio, err error) {
@@ -168,13 +180,15 @@ func (r *studioResolver) Movies(ctx context.Context, obj *models.Studio) (ret []
 }
 
 func (r *studioResolver) MovieCount(ctx context.Context, obj *models.Studio) (ret *int, err error) {
-	var res int
-	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-		res, err = r.repository.Movie.CountByStudioID(ctx, obj.ID)
-		return err
-	}); err != nil {
-		return nil, err
-	}
-
-	return &res, nil
+	return r.fieldCache.resolveInt("Studio.movie_count", obj.ID, func() (int, error) {
+		var res int
+		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+			res, err = r.repository.Movie.CountByStudioID(ctx, obj.ID)
+			return err
+		}); err != nil {
+			return 0, err
+		}
+
+		return res, nil
+	})
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/stashapp/stash/internal/api/loaders"
 	"github.com/stashapp/stash/internal/api/urlbuilders"
 	"github.com/stashapp/stash/pkg/gallery"
 	"github.com/stashapp/stash/pkg/image"
@@ -50,64 +51,77 @@ func (r *tagResolver) Aliases(ctx context.Context, obj *models.Tag) (ret []strin
 	return ret, err
 }
 
-func (r *tagResolver) SceneCount(ctx context.Context, obj *models.Tag) (ret *int, err error) {
-	var count int
+func (r *tagResolver) AutoTagKeywords(ctx context.Context, obj *models.Tag) (ret []string, err error) {
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-		count, err = r.repository.Scene.CountByTagID(ctx, obj.ID)
+		ret, err = r.repository.Tag.GetAutoTagKeywords(ctx, obj.ID)
 		return err
 	}); err != nil {
 		return nil, err
 	}
 
-	return &count, err
+	return ret, err
 }
 
-func (r *tagResolver) SceneMarkerCount(ctx context.Context, obj *models.Tag) (ret *int, err error) {
-	var count int
-	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-		count, err = r.repository.SceneMarker.CountByTagID(ctx, obj.ID)
-		return err
-	}); err != nil {
-		return nil, err
-	}
+func (r *tagResolver) SceneCount(ctx context.Context, obj *models.Tag) (ret *int, err error) {
+	return r.fieldCache.resolveInt("Tag.scene_count", obj.ID, func() (int, error) {
+		return loaders.From(ctx).TagSceneCount.Load(obj.ID)
+	})
+}
 
-	return &count, err
+func (r *tagResolver) SceneMarkerCount(ctx context.Context, obj *models.Tag) (ret *int, err error) {
+	return r.fieldCache.resolveInt("Tag.scene_marker_count", obj.ID, func() (int, error) {
+		var count int
+		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+			count, err = r.repository.SceneMarker.CountByTagID(ctx, obj.ID)
+			return err
+		}); err != nil {
+			return 0, err
+		}
+
+		return count, nil
+	})
 }
 
 func (r *tagResolver) ImageCount(ctx context.Context, obj *models.Tag) (ret *int, err error) {
-	var res int
-	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-		res, err = image.CountByTagID(ctx, r.repository.Image, obj.ID)
-		return err
-	}); err != nil {
-		return nil, err
-	}
-
-	return &res, nil
+	return r.fieldCache.resolveInt("Tag.image_count", obj.ID, func() (int, error) {
+		var res int
+		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+			res, err = image.CountByTagID(ctx, r.repository.Image, obj.ID)
+			return err
+		}); err != nil {
+			return 0, err
+		}
+
+		return res, nil
+	})
 }
 
 func (r *tagResolver) GalleryCount(ctx context.Context, obj *models.Tag) (ret *int, err error) {
-	var res int
-	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-		res, err = gallery.CountByTagID(ctx, r.repository.Gallery, obj.ID)
-		return err
-	}); err != nil {
-		return nil, err
-	}
-
-	return &res, nil
+	return r.fieldCache.resolveInt("Tag.gallery_count", obj.ID, func() (int, error) {
+		var res int
+		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+			res, err = gallery.CountByTagID(ctx, r.repository.Gallery, obj.ID)
+			return err
+		}); err != nil {
+			return 0, err
+		}
+
+		return res, nil
+	})
 }
 
 func (r *tagResolver) PerformerCount(ctx context.Context, obj *models.Tag) (ret *int, err error) {
-	var count int
-	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
-		count, err = r.repository.Performer.CountByTagID(ctx, obj.ID)
-		return err
-	}); err != nil {
-		return nil, err
-	}
-
-	return &count, err
+	return r.fieldCache.resolveInt("Tag.performer_count", obj.ID, func() (int, error) {
+		var count int
+		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+			count, err = r.repository.Performer.CountByTagID(ctx, obj.ID)
+			return err
+		}); err != nil {
+			return 0, err
+		}
+
+		return count, nil
+	})
 }
 
 func (r *tagResolver) ImagePath(ctx context.Context, obj *models.Tag) (*string, error) {
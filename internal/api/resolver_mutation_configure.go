@@ -5,12 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"regexp"
+	"strings"
 
+	"github.com/stashapp/stash/internal/identify"
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/scraper"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 )
 
 var ErrOverriddenConfig = errors.New("cannot set overridden value")
@@ -45,6 +51,12 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 					return makeConfigGeneralResult(), err
 				}
 			}
+
+			if s.GeneratedPath != "" {
+				if err := fsutil.EnsureDir(s.GeneratedPath); err != nil {
+					return makeConfigGeneralResult(), err
+				}
+			}
 		}
 		c.Set(config.Stash, input.Stashes)
 	}
@@ -158,6 +170,24 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		c.Set(config.ParallelTasks, *input.ParallelTasks)
 	}
 
+	if input.AutoTagParallelTasks != nil {
+		c.Set(config.AutoTagParallelTasks, *input.AutoTagParallelTasks)
+	}
+
+	if input.HashWorkers != nil {
+		if *input.HashWorkers < 0 {
+			return makeConfigGeneralResult(), fmt.Errorf("hash workers must not be negative")
+		}
+		c.Set(config.HashWorkers, *input.HashWorkers)
+	}
+
+	if input.GalleryParallelTasks != nil {
+		if *input.GalleryParallelTasks < 0 {
+			return makeConfigGeneralResult(), fmt.Errorf("gallery parallel tasks must not be negative")
+		}
+		c.Set(config.GalleryParallelTasks, *input.GalleryParallelTasks)
+	}
+
 	if input.PreviewAudio != nil {
 		c.Set(config.PreviewAudio, *input.PreviewAudio)
 	}
@@ -178,6 +208,10 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		c.Set(config.PreviewPreset, input.PreviewPreset.String())
 	}
 
+	if input.CoverAvoidBlankFrames != nil {
+		c.Set(config.CoverAvoidBlankFrames, *input.CoverAvoidBlankFrames)
+	}
+
 	if input.MaxTranscodeSize != nil {
 		c.Set(config.MaxTranscodeSize, input.MaxTranscodeSize.String())
 	}
@@ -186,10 +220,42 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		c.Set(config.MaxStreamingTranscodeSize, input.MaxStreamingTranscodeSize.String())
 	}
 
+	if input.StreamingTranscodeSizeNetworks != nil {
+		if err := config.ValidateStreamingTranscodeSizeNetworks(input.StreamingTranscodeSizeNetworks); err != nil {
+			return makeConfigGeneralResult(), err
+		}
+		c.Set(config.StreamingTranscodeSizeNetworks, input.StreamingTranscodeSizeNetworks)
+	}
+
+	if input.HlsSegmentDuration != nil {
+		if *input.HlsSegmentDuration < 1 {
+			return makeConfigGeneralResult(), fmt.Errorf("hls segment duration must be at least 1")
+		}
+		c.Set(config.HLSSegmentDuration, *input.HlsSegmentDuration)
+	}
+
 	if input.WriteImageThumbnails != nil {
 		c.Set(config.WriteImageThumbnails, *input.WriteImageThumbnails)
 	}
 
+	if input.MaxImageDimension != nil {
+		if *input.MaxImageDimension < 0 {
+			return makeConfigGeneralResult(), fmt.Errorf("max image dimension must not be negative")
+		}
+		c.Set(config.MaxImageDimension, *input.MaxImageDimension)
+	}
+
+	if input.ResolverCacheFields != nil {
+		c.Set(config.ResolverCacheFields, input.ResolverCacheFields)
+	}
+
+	if input.ResolverCacheTTL != nil {
+		if *input.ResolverCacheTTL < 0 {
+			return makeConfigGeneralResult(), fmt.Errorf("resolver cache ttl must not be negative")
+		}
+		c.Set(config.ResolverCacheTTL, *input.ResolverCacheTTL)
+	}
+
 	if input.Username != nil {
 		c.Set(config.Username, input.Username)
 	}
@@ -208,6 +274,10 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		c.Set(config.MaxSessionAge, *input.MaxSessionAge)
 	}
 
+	if input.HTTPCompression != nil {
+		c.Set(config.HTTPCompression, *input.HTTPCompression)
+	}
+
 	if input.LogFile != nil {
 		c.Set(config.LogFile, input.LogFile)
 	}
@@ -220,6 +290,27 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		c.Set(config.LogAccess, *input.LogAccess)
 	}
 
+	if input.LogMaxSize != nil {
+		if *input.LogMaxSize < 0 {
+			return makeConfigGeneralResult(), fmt.Errorf("log max size must not be negative")
+		}
+		c.Set(config.LogMaxSize, *input.LogMaxSize)
+	}
+
+	if input.LogRotateInterval != nil {
+		if *input.LogRotateInterval < 0 {
+			return makeConfigGeneralResult(), fmt.Errorf("log rotate interval must not be negative")
+		}
+		c.Set(config.LogRotateInterval, *input.LogRotateInterval)
+	}
+
+	if input.LogMaxBackups != nil {
+		if *input.LogMaxBackups < 0 {
+			return makeConfigGeneralResult(), fmt.Errorf("log max backups must not be negative")
+		}
+		c.Set(config.LogMaxBackups, *input.LogMaxBackups)
+	}
+
 	if input.LogLevel != nil && *input.LogLevel != c.GetLogLevel() {
 		c.Set(config.LogLevel, input.LogLevel)
 		logger := manager.GetInstance().Logger
@@ -250,6 +341,134 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		c.Set(config.CreateGalleriesFromFolders, input.CreateGalleriesFromFolders)
 	}
 
+	if input.GalleryCoverMinResolution != nil {
+		c.Set(config.GalleryCoverMinResolution, input.GalleryCoverMinResolution.String())
+	}
+
+	if input.GalleryImageNaturalSort != nil {
+		c.Set(config.GalleryImageNaturalSort, *input.GalleryImageNaturalSort)
+	}
+
+	if input.SyncLinkedDatesOnScan != nil {
+		c.Set(config.SyncLinkedDatesOnScan, *input.SyncLinkedDatesOnScan)
+	}
+
+	if input.LinkFolderGalleriesOnScan != nil {
+		c.Set(config.LinkFolderGalleriesOnScan, *input.LinkFolderGalleriesOnScan)
+	}
+
+	if input.LinkFolderGalleriesMatchStem != nil {
+		c.Set(config.LinkFolderGalleriesMatchStem, *input.LinkFolderGalleriesMatchStem)
+	}
+
+	if input.PrimaryFilePolicy != nil {
+		c.Set(config.PrimaryFilePolicy, input.PrimaryFilePolicy.String())
+	}
+
+	if input.PrimaryFilePathPriority != nil {
+		c.Set(config.PrimaryFilePathPriority, input.PrimaryFilePathPriority)
+	}
+
+	if input.PrimaryFileExtensionPriority != nil {
+		c.Set(config.PrimaryFileExtensionPriority, input.PrimaryFileExtensionPriority)
+	}
+
+	if input.IdentifyAutoOrganize != nil {
+		c.Set(config.IdentifyAutoOrganize, *input.IdentifyAutoOrganize)
+	}
+
+	if input.IdentifyAutoOrganizeRequiredFields != nil {
+		for _, f := range input.IdentifyAutoOrganizeRequiredFields {
+			if !stringslice.StrInclude(identify.AutoOrganizeFields, f) {
+				return makeConfigGeneralResult(), fmt.Errorf("invalid identify auto-organize field: %s", f)
+			}
+		}
+		c.Set(config.IdentifyAutoOrganizeRequiredFields, input.IdentifyAutoOrganizeRequiredFields)
+	}
+
+	if input.NestedZipMaxDepth != nil {
+		if *input.NestedZipMaxDepth < 0 {
+			return makeConfigGeneralResult(), fmt.Errorf("nested zip max depth must not be negative")
+		}
+		c.Set(config.NestedZipMaxDepth, *input.NestedZipMaxDepth)
+	}
+
+	if input.ZipMaxEntries != nil {
+		if *input.ZipMaxEntries < 0 {
+			return makeConfigGeneralResult(), fmt.Errorf("zip max entries must not be negative")
+		}
+		c.Set(config.ZipMaxEntries, *input.ZipMaxEntries)
+	}
+
+	if input.StudioFromMetadataTag != nil {
+		c.Set(config.StudioFromMetadataTag, strings.TrimSpace(*input.StudioFromMetadataTag))
+	}
+
+	if input.StudioFromMetadataTagPattern != nil {
+		if _, err := regexp.Compile(*input.StudioFromMetadataTagPattern); err != nil {
+			return makeConfigGeneralResult(), fmt.Errorf("invalid studio from metadata tag pattern: %w", err)
+		}
+		c.Set(config.StudioFromMetadataTagPattern, *input.StudioFromMetadataTagPattern)
+	}
+
+	if input.ScanProtectedFields != nil {
+		for _, f := range input.ScanProtectedFields {
+			if !stringslice.StrInclude(scene.ScanFields, f) {
+				return makeConfigGeneralResult(), fmt.Errorf("invalid scan protected field: %s", f)
+			}
+		}
+		c.Set(config.ScanProtectedFields, input.ScanProtectedFields)
+	}
+
+	if input.ScanPathTagRules != nil {
+		for _, r := range input.ScanPathTagRules {
+			r.Component = strings.TrimSpace(r.Component)
+			r.Tag = strings.TrimSpace(r.Tag)
+			if r.Component == "" {
+				return makeConfigGeneralResult(), errors.New("scan path tag rule component is required")
+			}
+		}
+		c.Set(config.ScanPathTagRules, input.ScanPathTagRules)
+	}
+
+	if input.RegenerateChangedFiles != nil {
+		c.Set(config.RegenerateChangedFiles, *input.RegenerateChangedFiles)
+	}
+
+	if input.DuplicatePhashDistance != nil {
+		if *input.DuplicatePhashDistance < 0 {
+			return makeConfigGeneralResult(), errors.New("duplicate phash distance must not be negative")
+		}
+		c.Set(config.DuplicatePhashDistance, *input.DuplicatePhashDistance)
+	}
+
+	if input.DuplicateDurationTolerance != nil {
+		c.Set(config.DuplicateDurationTolerance, *input.DuplicateDurationTolerance)
+	}
+
+	if input.ResolutionLabelBreakpoints != nil {
+		if err := models.ValidateResolutionLabelBreakpoints(input.ResolutionLabelBreakpoints); err != nil {
+			return makeConfigGeneralResult(), err
+		}
+		c.Set(config.ResolutionLabelBreakpoints, input.ResolutionLabelBreakpoints)
+	}
+
+	if input.SceneDateFloorYear != nil {
+		c.Set(config.SceneDateFloorYear, *input.SceneDateFloorYear)
+	}
+
+	if input.SceneDateModTimeTolerance != nil {
+		c.Set(config.SceneDateModTimeTolerance, *input.SceneDateModTimeTolerance)
+	}
+
+	if input.BulkDownloadMaxSize != nil {
+		c.Set(config.BulkDownloadMaxSize, *input.BulkDownloadMaxSize)
+	}
+
+	if input.GenerateLoudness != nil {
+		c.Set(config.GenerateLoudness, *input.GenerateLoudness)
+	}
+
 	if input.CustomPerformerImageLocation != nil {
 		c.Set(config.CustomPerformerImageLocation, *input.CustomPerformerImageLocation)
 		initialiseCustomImages()
@@ -276,6 +495,10 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		c.Set(config.StashBoxes, input.StashBoxes)
 	}
 
+	if input.StashBoxSubmitOrganizedOnly != nil {
+		c.Set(config.StashBoxSubmitOrganizedOnly, *input.StashBoxSubmitOrganizedOnly)
+	}
+
 	if input.PythonPath != nil {
 		c.Set(config.PythonPath, input.PythonPath)
 	}
@@ -311,6 +534,15 @@ func (r *mutationResolver) ConfigureInterface(ctx context.Context, input ConfigI
 		c.Set(config.MenuItems, input.MenuItems)
 	}
 
+	if input.SceneTitleFallback != nil {
+		for _, f := range input.SceneTitleFallback {
+			if err := scene.ValidateTitleFormat(f); err != nil {
+				return makeConfigInterfaceResult(), err
+			}
+		}
+		c.Set(config.SceneTitleFallback, input.SceneTitleFallback)
+	}
+
 	setBool(config.SoundOnPreview, input.SoundOnPreview)
 	setBool(config.WallShowTitle, input.WallShowTitle)
 
@@ -330,6 +562,8 @@ func (r *mutationResolver) ConfigureInterface(ctx context.Context, input ConfigI
 
 	setBool(config.AutostartVideo, input.AutostartVideo)
 	setBool(config.ShowStudioAsText, input.ShowStudioAsText)
+	setBool(config.StudioImageInheritance, input.StudioImageInheritance)
+	setBool(config.PerformerImageSceneFallback, input.PerformerImageSceneFallback)
 	setBool(config.AutostartVideoOnPlaySelected, input.AutostartVideoOnPlaySelected)
 	setBool(config.ContinuePlaylistDefault, input.ContinuePlaylistDefault)
 
@@ -458,6 +692,21 @@ func (r *mutationResolver) ConfigureScraping(ctx context.Context, input ConfigSc
 		c.Set(config.ScraperCertCheck, input.ScraperCertCheck)
 	}
 
+	if input.DetailsTemplate != nil {
+		if err := scraper.ValidateDetailsTemplate(*input.DetailsTemplate); err != nil {
+			return makeConfigScrapingResult(), fmt.Errorf("invalid details template: %w", err)
+		}
+		c.Set(config.ScraperDetailsTemplate, *input.DetailsTemplate)
+	}
+
+	if input.DetailsTemplateForce != nil {
+		c.Set(config.ScraperDetailsTemplateForce, *input.DetailsTemplateForce)
+	}
+
+	if input.SanitizeOutput != nil {
+		c.Set(config.ScraperSanitizeOutput, *input.SanitizeOutput)
+	}
+
 	if refreshScraperCache {
 		manager.GetInstance().RefreshScraperCache()
 	}
@@ -489,3 +489,43 @@ func (r *mutationResolver) RemoveGalleryImages(ctx context.Context, input Galler
 
 	return true, nil
 }
+
+func (r *mutationResolver) SplitGallery(ctx context.Context, input GallerySplitInput) ([]*models.Gallery, error) {
+	galleryID, err := strconv.Atoi(input.GalleryID)
+	if err != nil {
+		return nil, err
+	}
+
+	imageGroups := make([][]int, len(input.ImageGroups))
+	for i, group := range input.ImageGroups {
+		imageGroups[i], err = stringslice.StringSliceToIntSlice(group)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var ret []*models.Gallery
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Gallery
+		gallery, err := qb.Find(ctx, galleryID)
+		if err != nil {
+			return err
+		}
+
+		if gallery == nil {
+			return errors.New("gallery not found")
+		}
+
+		ret, err = r.galleryService.Split(ctx, gallery, imageGroups)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, g := range ret {
+		r.hookExecutor.ExecutePostHooks(ctx, g.ID, plugin.GalleryCreatePost, input, nil)
+	}
+	r.hookExecutor.ExecutePostHooks(ctx, galleryID, plugin.GalleryUpdatePost, input, nil)
+
+	return ret, nil
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 )
 
 func (r *mutationResolver) MetadataScan(ctx context.Context, input manager.ScanMetadataInput) (string, error) {
@@ -84,12 +86,28 @@ func (r *mutationResolver) MetadataGenerate(ctx context.Context, input manager.G
 	return strconv.Itoa(jobID), nil
 }
 
+func (r *mutationResolver) GenerateMissingPhashes(ctx context.Context, sceneFilter *models.SceneFilterType) (string, error) {
+	jobID := manager.GetInstance().GenerateMissingPhashes(ctx, sceneFilter)
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) FixZeroDurationScenes(ctx context.Context) (string, error) {
+	jobID := manager.GetInstance().FixZeroDurationScenes(ctx)
+	return strconv.Itoa(jobID), nil
+}
+
 func (r *mutationResolver) MetadataAutoTag(ctx context.Context, input manager.AutoTagMetadataInput) (string, error) {
 	jobID := manager.GetInstance().AutoTag(ctx, input)
 	return strconv.Itoa(jobID), nil
 }
 
 func (r *mutationResolver) MetadataIdentify(ctx context.Context, input identify.Options) (string, error) {
+	for _, f := range input.SkipIdentifiedRequiredFields {
+		if !stringslice.StrInclude(identify.AutoOrganizeFields, f) {
+			return "", fmt.Errorf("invalid skip identified required field: %s", f)
+		}
+	}
+
 	t := manager.CreateIdentifyJob(input)
 	jobID := manager.GetInstance().JobManager.Add(ctx, "Identifying...", t)
 
@@ -106,6 +124,15 @@ func (r *mutationResolver) MigrateHashNaming(ctx context.Context) (string, error
 	return strconv.Itoa(jobID), nil
 }
 
+func (r *mutationResolver) RebuildSceneDuplicateGroups(ctx context.Context) (string, error) {
+	jobID := manager.GetInstance().RebuildSceneDuplicateGroups(ctx)
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) RelinkGeneratedFiles(ctx context.Context) ([]*manager.UnlinkedGeneratedFiles, error) {
+	return manager.GetInstance().FindUnlinkedGeneratedFiles(ctx, true)
+}
+
 func (r *mutationResolver) BackupDatabase(ctx context.Context, input BackupDatabaseInput) (*string, error) {
 	// if download is true, then backup to temporary file and return a link
 	download := input.Download != nil && *input.Download
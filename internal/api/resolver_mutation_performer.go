@@ -473,3 +473,37 @@ func (r *mutationResolver) PerformersDestroy(ctx context.Context, performerIDs [
 
 	return true, nil
 }
+
+func (r *mutationResolver) RenumberPerformerDisambiguation(ctx context.Context, name string) ([]*models.PerformerDisambiguationChange, error) {
+	var ret []*models.PerformerDisambiguationChange
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		var err error
+		ret, err = performer.RenumberDisambiguations(ctx, r.repository.Performer, name)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, c := range ret {
+		r.hookExecutor.ExecutePostHooks(ctx, c.Performer.ID, plugin.PerformerUpdatePost, nil, nil)
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) NormalizePerformerAliases(ctx context.Context) ([]*models.Performer, error) {
+	var ret []*models.Performer
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		var err error
+		ret, err = performer.NormalizeAllAliases(ctx, r.repository.Performer)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, p := range ret {
+		r.hookExecutor.ExecutePostHooks(ctx, p.ID, plugin.PerformerUpdatePost, nil, []string{"alias_list"})
+	}
+
+	return ret, nil
+}
@@ -3,8 +3,10 @@ package api
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -12,6 +14,7 @@ import (
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/jsonschema"
 	"github.com/stashapp/stash/pkg/plugin"
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/sliceutil/intslice"
@@ -176,6 +179,9 @@ func scenePartialFromInput(input models.SceneUpdateInput, translator changesetTr
 	updatedScene.OCounter = translator.optionalInt(input.OCounter, "o_counter")
 	updatedScene.PlayCount = translator.optionalInt(input.PlayCount, "play_count")
 	updatedScene.PlayDuration = translator.optionalFloat64(input.PlayDuration, "play_duration")
+	updatedScene.IntroEnd = translator.optionalFloat64(input.IntroEnd, "intro_end")
+	updatedScene.OutroStart = translator.optionalFloat64(input.OutroStart, "outro_start")
+
 	var err error
 	updatedScene.StudioID, err = translator.optionalIntFromString(input.StudioID, "studio_id")
 	if err != nil {
@@ -259,6 +265,10 @@ func (r *mutationResolver) sceneUpdate(ctx context.Context, input models.SceneUp
 		return nil, err
 	}
 
+	if err := scene.ValidateUpdateSkipRange(s, *updatedScene); err != nil {
+		return nil, err
+	}
+
 	// ensure that title is set where scene has no file
 	if updatedScene.Title.Set && updatedScene.Title.Value == "" {
 		if err := s.LoadFiles(ctx, r.repository.Scene); err != nil {
@@ -312,6 +322,25 @@ func (r *mutationResolver) sceneUpdate(ctx context.Context, input models.SceneUp
 	return s, nil
 }
 
+// validateSceneSkipRange validates the skip range of the scene with the
+// given id after applying partial.
+func (r *mutationResolver) validateSceneSkipRange(ctx context.Context, sceneID int, partial models.ScenePartial) error {
+	s, err := r.repository.Scene.Find(ctx, sceneID)
+	if err != nil {
+		return err
+	}
+
+	if s == nil {
+		return fmt.Errorf("scene with id %d not found", sceneID)
+	}
+
+	if err := scene.ValidateUpdateSkipRange(s, partial); err != nil {
+		return fmt.Errorf("scene %d: %w", sceneID, err)
+	}
+
+	return nil
+}
+
 func (r *mutationResolver) sceneUpdateCoverImage(ctx context.Context, s *models.Scene, coverImageData []byte) error {
 	if len(coverImageData) > 0 {
 		qb := r.repository.Scene
@@ -324,7 +353,7 @@ func (r *mutationResolver) sceneUpdateCoverImage(ctx context.Context, s *models.
 		if s.Path != "" {
 			// update the file-based screenshot after commit
 			txn.AddPostCommitHook(ctx, func(ctx context.Context) error {
-				return scene.SetScreenshot(manager.GetInstance().Paths, s.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()), coverImageData)
+				return scene.SetScreenshot(manager.GetInstance().Paths.ForPath(s.Path), s.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()), coverImageData)
 			})
 		}
 	}
@@ -351,6 +380,8 @@ func (r *mutationResolver) BulkSceneUpdate(ctx context.Context, input BulkSceneU
 	updatedScene.URL = translator.optionalString(input.URL, "url")
 	updatedScene.Date = translator.optionalDate(input.Date, "date")
 	updatedScene.Rating = translator.ratingConversionOptional(input.Rating, input.Rating100)
+	updatedScene.IntroEnd = translator.optionalFloat64(input.IntroEnd, "intro_end")
+	updatedScene.OutroStart = translator.optionalFloat64(input.OutroStart, "outro_start")
 	updatedScene.StudioID, err = translator.optionalIntFromString(input.StudioID, "studio_id")
 	if err != nil {
 		return nil, fmt.Errorf("converting studio id: %w", err)
@@ -394,6 +425,12 @@ func (r *mutationResolver) BulkSceneUpdate(ctx context.Context, input BulkSceneU
 		qb := r.repository.Scene
 
 		for _, sceneID := range sceneIDs {
+			if updatedScene.IntroEnd.Set || updatedScene.OutroStart.Set {
+				if err := r.validateSceneSkipRange(ctx, sceneID, updatedScene); err != nil {
+					return err
+				}
+			}
+
 			scene, err := qb.UpdatePartial(ctx, sceneID, updatedScene)
 			if err != nil {
 				return err
@@ -593,6 +630,12 @@ func (r *mutationResolver) SceneMerge(ctx context.Context, input SceneMergeInput
 
 	var ret *models.Scene
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		if values.IntroEnd.Set || values.OutroStart.Set {
+			if err := r.validateSceneSkipRange(ctx, destID, *values); err != nil {
+				return err
+			}
+		}
+
 		if err := r.Resolver.sceneService.Merge(ctx, srcIDs, destID, *values); err != nil {
 			return err
 		}
@@ -611,6 +654,81 @@ func (r *mutationResolver) SceneMerge(ctx context.Context, input SceneMergeInput
 	return ret, nil
 }
 
+func (r *mutationResolver) MergeScenes(ctx context.Context, ids []string, rules []*models.SceneMergeRule) (*models.Scene, error) {
+	sceneIDs, err := stringslice.StringSliceToIntSlice(ids)
+	if err != nil {
+		return nil, fmt.Errorf("converting ids: %w", err)
+	}
+
+	sceneIDs = intslice.IntAppendUniques(nil, sceneIDs)
+	if len(sceneIDs) < 2 {
+		return nil, errors.New("at least two scenes are required to merge")
+	}
+
+	destID := sceneIDs[0]
+	sourceIDs := sceneIDs[1:]
+
+	var (
+		ret     *models.Scene
+		sources []*models.Scene
+	)
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.Resolver.repository.Scene
+
+		// the source scenes are destroyed by the merge
+		sources, err = qb.FindMany(ctx, sourceIDs)
+		if err != nil {
+			return err
+		}
+
+		if err := r.Resolver.sceneService.MergeWithRules(ctx, sceneIDs, rules); err != nil {
+			return err
+		}
+
+		ret, err = qb.Find(ctx, destID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, s := range sources {
+		r.hookExecutor.ExecutePostHooks(ctx, s.ID, plugin.SceneDestroyPost, plugin.SceneDestroyInput{
+			SceneDestroyInput: models.SceneDestroyInput{
+				ID: strconv.Itoa(s.ID),
+			},
+			Checksum: s.Checksum,
+			OSHash:   s.OSHash,
+			Path:     s.Path,
+		}, nil)
+	}
+
+	input := SceneMergeInput{
+		Source:      intslice.IntSliceToStringSlice(sourceIDs),
+		Destination: strconv.Itoa(destID),
+	}
+	r.hookExecutor.ExecutePostHooks(ctx, destID, plugin.SceneMergePost, input, nil)
+
+	return ret, nil
+}
+
+func (r *mutationResolver) RepairScenesSharingFiles(ctx context.Context) (string, error) {
+	jobID := manager.GetInstance().RepairScenesSharingFiles(ctx)
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) BackfillPhashesFromOSHash(ctx context.Context) (int, error) {
+	var ret int
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		var err error
+		ret, err = r.repository.Scene.BackfillPhashFromOSHash(ctx)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
+
 func (r *mutationResolver) getSceneMarker(ctx context.Context, id int) (ret *models.SceneMarker, err error) {
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.repository.SceneMarker.Find(ctx, id)
@@ -657,6 +775,116 @@ func (r *mutationResolver) SceneMarkerCreate(ctx context.Context, input SceneMar
 	return r.getSceneMarker(ctx, ret.ID)
 }
 
+func (r *mutationResolver) SceneMarkersImportChapters(ctx context.Context, input SceneMarkerChaptersImportInput) (*SceneMarkerChaptersImportResult, error) {
+	sceneID, err := strconv.Atoi(input.SceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	var primaryTagID *int
+	if input.PrimaryTagID != nil {
+		id, err := strconv.Atoi(*input.PrimaryTagID)
+		if err != nil {
+			return nil, err
+		}
+		primaryTagID = &id
+	}
+
+	tagIDs, err := stringslice.StringSliceToIntSlice(input.TagIds)
+	if err != nil {
+		return nil, err
+	}
+
+	chapters, unparsed := scene.ParseChapters(input.Text)
+
+	ret := &SceneMarkerChaptersImportResult{
+		Markers:  []*models.SceneMarker{},
+		Unparsed: []string{},
+	}
+
+	for _, line := range unparsed {
+		ret.Unparsed = append(ret.Unparsed, fmt.Sprintf("%s: invalid chapter format", line))
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.SceneMarker
+
+		s, err := r.repository.Scene.Find(ctx, sceneID)
+		if err != nil {
+			return err
+		}
+
+		if s == nil {
+			return fmt.Errorf("scene with id %d not found", sceneID)
+		}
+
+		existing, err := qb.FindBySceneID(ctx, sceneID)
+		if err != nil {
+			return err
+		}
+
+		existingSeconds := make(map[float64]bool)
+		for _, m := range existing {
+			existingSeconds[m.Seconds] = true
+		}
+
+		currentTime := time.Now()
+		for _, c := range chapters {
+			line := fmt.Sprintf("%s (%g)", c.Title, c.Seconds)
+
+			if existingSeconds[c.Seconds] {
+				ret.Unparsed = append(ret.Unparsed, fmt.Sprintf("%s: marker already exists at this time", line))
+				continue
+			}
+
+			var tagID int
+			if primaryTagID != nil {
+				tagID = *primaryTagID
+			} else {
+				t, err := r.repository.Tag.FindByName(ctx, c.Title, true)
+				if err != nil {
+					return err
+				}
+
+				if t == nil {
+					ret.Unparsed = append(ret.Unparsed, fmt.Sprintf("%s: no tag matching title", line))
+					continue
+				}
+				tagID = t.ID
+			}
+
+			marker, err := qb.Create(ctx, models.SceneMarker{
+				Title:        c.Title,
+				Seconds:      c.Seconds,
+				PrimaryTagID: tagID,
+				SceneID:      sql.NullInt64{Int64: int64(sceneID), Valid: true},
+				CreatedAt:    models.SQLiteTimestamp{Timestamp: currentTime},
+				UpdatedAt:    models.SQLiteTimestamp{Timestamp: currentTime},
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := qb.UpdateTags(ctx, marker.ID, intslice.IntExclude(tagIDs, []int{tagID})); err != nil {
+				return err
+			}
+
+			existingSeconds[c.Seconds] = true
+			ret.Markers = append(ret.Markers, marker)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, m := range ret.Markers {
+		r.hookExecutor.ExecutePostHooks(ctx, m.ID, plugin.SceneMarkerCreatePost, input, nil)
+	}
+
+	return ret, nil
+}
+
 func (r *mutationResolver) SceneMarkerUpdate(ctx context.Context, input SceneMarkerUpdateInput) (*models.SceneMarker, error) {
 	// Populate scene marker from the input
 	sceneMarkerID, err := strconv.Atoi(input.ID)
@@ -700,6 +928,75 @@ func (r *mutationResolver) SceneMarkerUpdate(ctx context.Context, input SceneMar
 	return r.getSceneMarker(ctx, ret.ID)
 }
 
+func (r *mutationResolver) BulkUpdateMarkers(ctx context.Context, markerFilter *models.SceneMarkerFilterType, newPrimaryTagID string) (string, error) {
+	jobID, err := manager.GetInstance().BulkUpdateMarkers(ctx, markerFilter, newPrimaryTagID)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) ExportMarkerClip(ctx context.Context, input ExportMarkerClipInput) (*ExportMarkerClipResult, error) {
+	markerID, err := strconv.Atoi(input.MarkerID)
+	if err != nil {
+		return nil, err
+	}
+
+	mgr := manager.GetInstance()
+
+	if input.OutputDirectory != nil && *input.OutputDirectory != "" {
+		jobID, err := mgr.ExportMarkerClip(ctx, markerID, input.Duration, *input.OutputDirectory)
+		if err != nil {
+			return nil, err
+		}
+
+		id := strconv.Itoa(jobID)
+		return &ExportMarkerClipResult{
+			JobID: &id,
+		}, nil
+	}
+
+	downloadHash, fn, err := mgr.DownloadMarkerClip(ctx, markerID, input.Duration)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
+
+	ret := baseURL + "/downloads/" + downloadHash + "/" + url.PathEscape(fn)
+	return &ExportMarkerClipResult{
+		DownloadURL: &ret,
+	}, nil
+}
+
+func (r *mutationResolver) BulkAddPerformerToScenes(ctx context.Context, sceneFilter *models.SceneFilterType, performerID string) (string, error) {
+	jobID, err := manager.GetInstance().BulkAddPerformerToScenes(ctx, sceneFilter, performerID)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) BulkRemovePerformerFromScenes(ctx context.Context, sceneFilter *models.SceneFilterType, performerID string) (string, error) {
+	jobID, err := manager.GetInstance().BulkRemovePerformerFromScenes(ctx, sceneFilter, performerID)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) BulkSetStudioByPath(ctx context.Context, pathPattern string, studioID string, onlyMissing *bool) (string, error) {
+	jobID, err := manager.GetInstance().BulkSetStudioByPath(ctx, pathPattern, studioID, onlyMissing != nil && *onlyMissing)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
 func (r *mutationResolver) SceneMarkerDestroy(ctx context.Context, id string) (bool, error) {
 	markerID, err := strconv.Atoi(id)
 	if err != nil {
@@ -907,3 +1204,247 @@ func (r *mutationResolver) SceneGenerateScreenshot(ctx context.Context, id strin
 
 	return "todo", nil
 }
+
+func (r *mutationResolver) SyncLinkedDates(ctx context.Context, input SyncLinkedDatesInput) (int, error) {
+	if (input.SceneID == nil) == (input.GalleryID == nil) {
+		return 0, errors.New("exactly one of scene_id and gallery_id must be set")
+	}
+
+	var updatedScenes, updatedGalleries []int
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		var (
+			scenes    []*models.Scene
+			galleries []*models.Gallery
+		)
+
+		if input.SceneID != nil {
+			id, err := strconv.Atoi(*input.SceneID)
+			if err != nil {
+				return err
+			}
+
+			s, err := r.repository.Scene.Find(ctx, id)
+			if err != nil {
+				return err
+			}
+			if s == nil {
+				return fmt.Errorf("scene with id %d not found", id)
+			}
+
+			if err := s.LoadGalleryIDs(ctx, r.repository.Scene); err != nil {
+				return err
+			}
+
+			scenes = []*models.Scene{s}
+			galleries, err = r.repository.Gallery.FindMany(ctx, s.GalleryIDs.List())
+			if err != nil {
+				return err
+			}
+		} else {
+			id, err := strconv.Atoi(*input.GalleryID)
+			if err != nil {
+				return err
+			}
+
+			g, err := r.repository.Gallery.Find(ctx, id)
+			if err != nil {
+				return err
+			}
+			if g == nil {
+				return fmt.Errorf("gallery with id %d not found", id)
+			}
+
+			galleries = []*models.Gallery{g}
+			scenes, err = r.repository.Scene.FindByGalleryID(ctx, id)
+			if err != nil {
+				return err
+			}
+		}
+
+		switch input.Direction {
+		case LinkedDateSyncDirectionSceneToGallery:
+			dates := make([]*models.Date, len(scenes))
+			for i, s := range scenes {
+				dates[i] = s.Date
+			}
+			date, err := linkedSourceDate(dates)
+			if err != nil {
+				return fmt.Errorf("scenes: %w", err)
+			}
+
+			for _, g := range galleries {
+				if g.Date != nil && g.Date.String() == date.String() {
+					continue
+				}
+
+				partial := models.NewGalleryPartial()
+				partial.Date = models.NewOptionalDate(*date)
+				if _, err := r.repository.Gallery.UpdatePartial(ctx, g.ID, partial); err != nil {
+					return err
+				}
+				updatedGalleries = append(updatedGalleries, g.ID)
+			}
+		case LinkedDateSyncDirectionGalleryToScene:
+			dates := make([]*models.Date, len(galleries))
+			for i, g := range galleries {
+				dates[i] = g.Date
+			}
+			date, err := linkedSourceDate(dates)
+			if err != nil {
+				return fmt.Errorf("galleries: %w", err)
+			}
+
+			for _, s := range scenes {
+				if s.Date != nil && s.Date.String() == date.String() {
+					continue
+				}
+
+				partial := models.NewScenePartial()
+				partial.Date = models.NewOptionalDate(*date)
+				if _, err := r.repository.Scene.UpdatePartial(ctx, s.ID, partial); err != nil {
+					return err
+				}
+				updatedScenes = append(updatedScenes, s.ID)
+			}
+		default:
+			return fmt.Errorf("invalid direction %q", input.Direction)
+		}
+
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	// execute post hooks outside txn
+	for _, id := range updatedScenes {
+		r.hookExecutor.ExecutePostHooks(ctx, id, plugin.SceneUpdatePost, input, nil)
+	}
+	for _, id := range updatedGalleries {
+		r.hookExecutor.ExecutePostHooks(ctx, id, plugin.GalleryUpdatePost, input, nil)
+	}
+
+	return len(updatedScenes) + len(updatedGalleries), nil
+}
+
+// linkedSourceDate returns the date to copy from the source objects of a
+// linked date sync. Objects without a date are ignored. Returns an error if
+// none of the objects have a date, or if they have different dates.
+func linkedSourceDate(dates []*models.Date) (*models.Date, error) {
+	var ret *models.Date
+	for _, d := range dates {
+		switch {
+		case d == nil:
+			continue
+		case ret == nil:
+			ret = d
+		case ret.String() != d.String():
+			return nil, fmt.Errorf("different dates %s and %s", ret, d)
+		}
+	}
+
+	if ret == nil {
+		return nil, errors.New("no date to copy")
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) ImportSceneMetadataJSON(ctx context.Context, input ImportSceneMetadataJSONInput) (*models.Scene, error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var sceneJSON jsonschema.Scene
+	if err := json.Unmarshal([]byte(input.JSON), &sceneJSON); err != nil {
+		return nil, fmt.Errorf("invalid scene metadata JSON: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Scene
+		s, err := qb.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		if s == nil {
+			return fmt.Errorf("scene with id %d not found", id)
+		}
+
+		importer := &scene.Importer{
+			Input:               sceneJSON,
+			MissingRefBehaviour: models.ImportMissingRefEnumCreate,
+			StudioWriter:        r.repository.Studio,
+			PerformerWriter:     r.repository.Performer,
+			TagWriter:           r.repository.Tag,
+		}
+
+		partial, err := importer.MetadataPartial(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = qb.UpdatePartial(ctx, id, partial)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, id, plugin.SceneUpdatePost, input, nil)
+	return r.getScene(ctx, id)
+}
+
+func (r *mutationResolver) RelinkSceneFiles(ctx context.Context) (string, error) {
+	jobID := manager.GetInstance().RelinkSceneFiles(ctx)
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) ImportSceneRatings(ctx context.Context, input ImportSceneRatingsInput) (*ImportSceneRatingsResult, error) {
+	ratings, err := scene.ReadFingerprintRatings(input.File.File)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &ImportSceneRatingsResult{
+		Unmatched: []string{},
+	}
+
+	var updatedIDs []int
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Scene
+
+		for _, rating := range ratings {
+			scenes, err := scene.FindByFingerprintRating(ctx, qb, rating)
+			if err != nil {
+				return fmt.Errorf("finding scenes for %s: %w", rating.Fingerprint, err)
+			}
+
+			if len(scenes) == 0 {
+				ret.Unmatched = append(ret.Unmatched, rating.Fingerprint)
+				continue
+			}
+
+			for _, s := range scenes {
+				updatedScene := models.NewScenePartial()
+				updatedScene.Rating = models.NewOptionalInt(rating.Rating)
+
+				if _, err := qb.UpdatePartial(ctx, s.ID, updatedScene); err != nil {
+					return fmt.Errorf("updating rating of scene %d: %w", s.ID, err)
+				}
+
+				updatedIDs = intslice.IntAppendUnique(updatedIDs, s.ID)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// execute post hooks outside of txn
+	for _, id := range updatedIDs {
+		r.hookExecutor.ExecutePostHooks(ctx, id, plugin.SceneUpdatePost, nil, []string{"rating100"})
+	}
+
+	ret.Updated = len(updatedIDs)
+	return ret, nil
+}
@@ -0,0 +1,306 @@
+package api
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/scene"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// sceneUpdateRW forwards the methods used by sceneUpdate to a mock. Other
+// methods panic.
+type sceneUpdateRW struct {
+	manager.SceneReaderWriter
+	mock *mocks.SceneReaderWriter
+}
+
+func (s *sceneUpdateRW) Find(ctx context.Context, id int) (*models.Scene, error) {
+	return s.mock.Find(ctx, id)
+}
+
+func (s *sceneUpdateRW) FindMany(ctx context.Context, ids []int) ([]*models.Scene, error) {
+	return s.mock.FindMany(ctx, ids)
+}
+
+func (s *sceneUpdateRW) GetGalleryIDs(ctx context.Context, relatedID int) ([]int, error) {
+	return s.mock.GetGalleryIDs(ctx, relatedID)
+}
+
+func (s *sceneUpdateRW) FindByGalleryID(ctx context.Context, galleryID int) ([]*models.Scene, error) {
+	return s.mock.FindByGalleryID(ctx, galleryID)
+}
+
+func (s *sceneUpdateRW) UpdatePartial(ctx context.Context, id int, partial models.ScenePartial) (*models.Scene, error) {
+	return s.mock.UpdatePartial(ctx, id, partial)
+}
+
+// galleryUpdateRW forwards the methods used by the scene resolvers to a
+// mock. Other methods panic.
+type galleryUpdateRW struct {
+	manager.GalleryReaderWriter
+	mock *mocks.GalleryReaderWriter
+}
+
+func (g *galleryUpdateRW) Find(ctx context.Context, id int) (*models.Gallery, error) {
+	return g.mock.Find(ctx, id)
+}
+
+func (g *galleryUpdateRW) FindMany(ctx context.Context, ids []int) ([]*models.Gallery, error) {
+	return g.mock.FindMany(ctx, ids)
+}
+
+func (g *galleryUpdateRW) UpdatePartial(ctx context.Context, id int, partial models.GalleryPartial) (*models.Gallery, error) {
+	return g.mock.UpdatePartial(ctx, id, partial)
+}
+
+func newSceneUpdateResolver() (*mutationResolver, *mocks.SceneReaderWriter) {
+	r := newResolver()
+	sceneRW := &mocks.SceneReaderWriter{}
+	r.repository.Scene = &sceneUpdateRW{mock: sceneRW}
+	return &mutationResolver{r}, sceneRW
+}
+
+func TestSceneUpdateSkipRange(t *testing.T) {
+	const sceneID = 1
+
+	introEnd := 600.0
+	stored := &models.Scene{
+		ID:       sceneID,
+		IntroEnd: &introEnd,
+	}
+
+	outroStart := func(v float64) models.SceneUpdateInput {
+		return models.SceneUpdateInput{
+			ID:         strconv.Itoa(sceneID),
+			OutroStart: &v,
+		}
+	}
+
+	translator := changesetTranslator{
+		inputMap: map[string]interface{}{
+			"outro_start": nil,
+		},
+	}
+
+	t.Run("outro before stored intro", func(t *testing.T) {
+		r, sceneRW := newSceneUpdateResolver()
+		sceneRW.On("Find", testCtx, sceneID).Return(stored, nil).Once()
+
+		_, err := r.sceneUpdate(testCtx, outroStart(30), translator)
+		assert.ErrorIs(t, err, scene.ErrInvalidSkipRange)
+
+		sceneRW.AssertNotCalled(t, "UpdatePartial", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("outro after stored intro", func(t *testing.T) {
+		r, sceneRW := newSceneUpdateResolver()
+		sceneRW.On("Find", testCtx, sceneID).Return(stored, nil).Once()
+		sceneRW.On("UpdatePartial", testCtx, sceneID, mock.MatchedBy(func(p models.ScenePartial) bool {
+			return p.OutroStart.Set && p.OutroStart.Value == 900 && !p.IntroEnd.Set
+		})).Return(stored, nil).Once()
+
+		_, err := r.sceneUpdate(testCtx, outroStart(900), translator)
+		assert.NoError(t, err)
+
+		sceneRW.AssertExpectations(t)
+	})
+}
+
+func TestLinkedSourceDate(t *testing.T) {
+	date := models.NewDate("2020-01-02")
+	sameDate := models.NewDate("2020-01-02")
+	otherDate := models.NewDate("2021-03-04")
+
+	tests := []struct {
+		name    string
+		dates   []*models.Date
+		want    *models.Date
+		wantErr bool
+	}{
+		{"single", []*models.Date{&date}, &date, false},
+		{"ignores missing", []*models.Date{nil, &date, nil}, &date, false},
+		{"same dates", []*models.Date{&date, &sameDate}, &date, false},
+		{"different dates", []*models.Date{&date, &otherDate}, nil, true},
+		{"no dates", []*models.Date{nil}, nil, true},
+		{"empty", nil, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := linkedSourceDate(tt.dates)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("linkedSourceDate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSyncLinkedDates(t *testing.T) {
+	const (
+		sceneID           = 1
+		galleryID         = 2
+		datedGalleryID    = 3
+		sameDateGalleryID = 4
+	)
+
+	date := models.NewDate("2020-01-02")
+	sceneIDStr := strconv.Itoa(sceneID)
+	galleryIDStr := strconv.Itoa(galleryID)
+
+	t.Run("requires exactly one id", func(t *testing.T) {
+		r, _ := newSceneUpdateResolver()
+
+		_, err := r.SyncLinkedDates(testCtx, SyncLinkedDatesInput{
+			Direction: LinkedDateSyncDirectionSceneToGallery,
+		})
+		assert.Error(t, err)
+
+		_, err = r.SyncLinkedDates(testCtx, SyncLinkedDatesInput{
+			SceneID:   &sceneIDStr,
+			GalleryID: &galleryIDStr,
+			Direction: LinkedDateSyncDirectionSceneToGallery,
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("scene to galleries", func(t *testing.T) {
+		r, sceneRW := newSceneUpdateResolver()
+		galleryRW := &mocks.GalleryReaderWriter{}
+		r.repository.Gallery = &galleryUpdateRW{mock: galleryRW}
+
+		otherDate := models.NewDate("2021-03-04")
+		galleries := []*models.Gallery{
+			{ID: galleryID},
+			{ID: datedGalleryID, Date: &otherDate},
+			{ID: sameDateGalleryID, Date: &date},
+		}
+
+		sceneRW.On("Find", mock.Anything, sceneID).Return(&models.Scene{ID: sceneID, Date: &date}, nil).Once()
+		sceneRW.On("GetGalleryIDs", mock.Anything, sceneID).Return([]int{galleryID, datedGalleryID, sameDateGalleryID}, nil).Once()
+		galleryRW.On("FindMany", mock.Anything, []int{galleryID, datedGalleryID, sameDateGalleryID}).Return(galleries, nil).Once()
+
+		// galleries with the same date are not updated
+		dateMatcher := mock.MatchedBy(func(p models.GalleryPartial) bool {
+			return p.Date.Set && p.Date.Value.String() == date.String()
+		})
+		galleryRW.On("UpdatePartial", mock.Anything, galleryID, dateMatcher).Return(nil, nil).Once()
+		galleryRW.On("UpdatePartial", mock.Anything, datedGalleryID, dateMatcher).Return(nil, nil).Once()
+
+		got, err := r.SyncLinkedDates(testCtx, SyncLinkedDatesInput{
+			SceneID:   &sceneIDStr,
+			Direction: LinkedDateSyncDirectionSceneToGallery,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, got)
+
+		galleryRW.AssertExpectations(t)
+	})
+
+	t.Run("gallery from its scenes", func(t *testing.T) {
+		r, sceneRW := newSceneUpdateResolver()
+		galleryRW := &mocks.GalleryReaderWriter{}
+		r.repository.Gallery = &galleryUpdateRW{mock: galleryRW}
+
+		otherDate := models.NewDate("2021-03-04")
+
+		galleryRW.On("Find", mock.Anything, galleryID).Return(&models.Gallery{ID: galleryID, Date: &date}, nil).Once()
+		sceneRW.On("FindByGalleryID", mock.Anything, galleryID).Return([]*models.Scene{{ID: sceneID, Date: &otherDate}}, nil).Once()
+
+		// the scene is the only source, so its date is copied
+		galleryRW.On("UpdatePartial", mock.Anything, galleryID, mock.MatchedBy(func(p models.GalleryPartial) bool {
+			return p.Date.Set && p.Date.Value.String() == otherDate.String()
+		})).Return(nil, nil).Once()
+
+		got, err := r.SyncLinkedDates(testCtx, SyncLinkedDatesInput{
+			GalleryID: &galleryIDStr,
+			Direction: LinkedDateSyncDirectionSceneToGallery,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, got)
+
+		galleryRW.AssertExpectations(t)
+	})
+}
+
+// mergeSceneService records the scenes merged with rules. Other methods
+// panic.
+type mergeSceneService struct {
+	manager.SceneService
+	merged []int
+}
+
+func (s *mergeSceneService) MergeWithRules(ctx context.Context, ids []int, rules []*models.SceneMergeRule) error {
+	s.merged = ids
+	return nil
+}
+
+type executedHook struct {
+	id       int
+	hookType plugin.HookTriggerEnum
+	input    interface{}
+}
+
+type recordingHookExecutor struct {
+	hooks []executedHook
+}
+
+func (e *recordingHookExecutor) ExecutePostHooks(ctx context.Context, id int, hookType plugin.HookTriggerEnum, input interface{}, inputFields []string) {
+	e.hooks = append(e.hooks, executedHook{id, hookType, input})
+}
+
+func TestMergeScenesPostHooks(t *testing.T) {
+	const (
+		destID   = 1
+		sourceID = 2
+	)
+
+	r, sceneRW := newSceneUpdateResolver()
+	sceneService := &mergeSceneService{}
+	hooks := &recordingHookExecutor{}
+	r.sceneService = sceneService
+	r.hookExecutor = hooks
+
+	source := &models.Scene{ID: sourceID, Checksum: "checksum", OSHash: "oshash", Path: "source.mp4"}
+	dest := &models.Scene{ID: destID}
+
+	sceneRW.On("FindMany", mock.Anything, []int{sourceID}).Return([]*models.Scene{source}, nil).Once()
+	sceneRW.On("Find", mock.Anything, destID).Return(dest, nil).Once()
+
+	got, err := r.MergeScenes(testCtx, []string{"1", "2", "2"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, dest, got)
+	assert.Equal(t, []int{destID, sourceID}, sceneService.merged)
+
+	assert.Equal(t, []executedHook{
+		{sourceID, plugin.SceneDestroyPost, plugin.SceneDestroyInput{
+			SceneDestroyInput: models.SceneDestroyInput{ID: "2"},
+			Checksum:          "checksum",
+			OSHash:            "oshash",
+			Path:              "source.mp4",
+		}},
+		{destID, plugin.SceneMergePost, SceneMergeInput{
+			Source:      []string{"2"},
+			Destination: "1",
+		}},
+	}, hooks.hooks)
+
+	sceneRW.AssertExpectations(t)
+
+	t.Run("single scene", func(t *testing.T) {
+		hooks.hooks = nil
+		_, err := r.MergeScenes(testCtx, []string{"1", "1"}, nil)
+		assert.Error(t, err)
+		assert.Empty(t, hooks.hooks)
+	})
+}
@@ -7,7 +7,14 @@ import (
 
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/scraper"
 	"github.com/stashapp/stash/pkg/scraper/stashbox"
+	"github.com/stashapp/stash/pkg/sliceutil/intslice"
+	"github.com/stashapp/stash/pkg/utils"
 )
 
 func (r *Resolver) stashboxRepository() stashbox.Repository {
@@ -19,16 +26,38 @@ func (r *Resolver) stashboxRepository() stashbox.Repository {
 	}
 }
 
-func (r *mutationResolver) SubmitStashBoxFingerprints(ctx context.Context, input StashBoxFingerprintSubmissionInput) (bool, error) {
-	boxes := config.GetInstance().GetStashBoxes()
+// submitStashBoxFingerprints submits the fingerprints of the scenes and
+// returns the ids of the scenes that were skipped.
+func (r *mutationResolver) submitStashBoxFingerprints(ctx context.Context, input StashBoxFingerprintSubmissionInput) ([]int, error) {
+	c := config.GetInstance()
+	boxes := c.GetStashBoxes()
 
 	if input.StashBoxIndex < 0 || input.StashBoxIndex >= len(boxes) {
-		return false, fmt.Errorf("invalid stash_box_index %d", input.StashBoxIndex)
+		return nil, fmt.Errorf("invalid stash_box_index %d", input.StashBoxIndex)
 	}
 
 	client := stashbox.NewClient(*boxes[input.StashBoxIndex], r.txnManager, r.stashboxRepository())
 
-	return client.SubmitStashBoxFingerprints(ctx, input.SceneIds, boxes[input.StashBoxIndex].Endpoint)
+	return client.SubmitStashBoxFingerprints(ctx, input.SceneIds, boxes[input.StashBoxIndex].Endpoint, c.IsStashBoxSubmitOrganizedOnly())
+}
+
+func (r *mutationResolver) SubmitStashBoxFingerprints(ctx context.Context, input StashBoxFingerprintSubmissionInput) (bool, error) {
+	if _, err := r.submitStashBoxFingerprints(ctx, input); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) SubmitStashBoxFingerprintsWithResult(ctx context.Context, input StashBoxFingerprintSubmissionInput) (*StashBoxFingerprintSubmissionResult, error) {
+	skipped, err := r.submitStashBoxFingerprints(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StashBoxFingerprintSubmissionResult{
+		SkippedSceneIds: intslice.IntSliceToStringSlice(skipped),
+	}, nil
 }
 
 func (r *mutationResolver) StashBoxBatchPerformerTag(ctx context.Context, input manager.StashBoxBatchPerformerTagInput) (string, error) {
@@ -36,6 +65,47 @@ func (r *mutationResolver) StashBoxBatchPerformerTag(ctx context.Context, input
 	return strconv.Itoa(jobID), nil
 }
 
+// getStashBox returns the stash-box instance with the provided index.
+func getStashBox(index int) (*models.StashBox, error) {
+	boxes := config.GetInstance().GetStashBoxes()
+
+	if index < 0 || index >= len(boxes) {
+		return nil, fmt.Errorf("%w: invalid stash_box_index %d", ErrInput, index)
+	}
+
+	return boxes[index], nil
+}
+
+func (r *mutationResolver) CheckSceneStashIDs(ctx context.Context, input CheckSceneStashIDsInput) (string, error) {
+	box, err := getStashBox(input.StashBoxIndex)
+	if err != nil {
+		return "", err
+	}
+
+	jobID := manager.GetInstance().CheckSceneStashIDs(ctx, box, input.SceneFilter)
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) CheckSceneStudioConflicts(ctx context.Context, input SceneStudioConflictsInput) (string, error) {
+	box, err := getStashBox(input.StashBoxIndex)
+	if err != nil {
+		return "", err
+	}
+
+	jobID := manager.GetInstance().CheckSceneStudioConflicts(ctx, box, input.SceneFilter)
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) CheckPerformerStashBoxDiffs(ctx context.Context, input PerformerStashBoxDiffsInput) (string, error) {
+	box, err := getStashBox(input.StashBoxIndex)
+	if err != nil {
+		return "", err
+	}
+
+	jobID := manager.GetInstance().CheckPerformerStashBoxDiffs(ctx, box, input.PerformerFilter)
+	return strconv.Itoa(jobID), nil
+}
+
 func (r *mutationResolver) SubmitStashBoxSceneDraft(ctx context.Context, input StashBoxDraftSubmissionInput) (*string, error) {
 	boxes := config.GetInstance().GetStashBoxes()
 
@@ -58,7 +128,7 @@ func (r *mutationResolver) SubmitStashBoxSceneDraft(ctx context.Context, input S
 			return err
 		}
 
-		filepath := manager.GetInstance().Paths.Scene.GetScreenshotPath(scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()))
+		filepath := manager.GetInstance().Paths.ForPath(scene.Path).Scene.GetScreenshotPath(scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()))
 
 		res, err = client.SubmitSceneDraft(ctx, scene, boxes[input.StashBoxIndex].Endpoint, filepath)
 		return err
@@ -95,3 +165,173 @@ func (r *mutationResolver) SubmitStashBoxPerformerDraft(ctx context.Context, inp
 
 	return res, err
 }
+
+func (r *mutationResolver) RemoveStashIDs(ctx context.Context, input manager.RemoveStashIDsInput) (string, error) {
+	jobID, err := manager.GetInstance().RemoveStashIDs(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) CreateSceneFromStashbox(ctx context.Context, stashID string, endpoint string) (*models.Scene, error) {
+	var box *models.StashBox
+	for _, b := range config.GetInstance().GetStashBoxes() {
+		if b.Endpoint == endpoint {
+			box = b
+			break
+		}
+	}
+
+	if box == nil {
+		return nil, fmt.Errorf("%w: stash-box endpoint %s not configured", ErrInput, endpoint)
+	}
+
+	client := stashbox.NewClient(*box, r.txnManager, r.stashboxRepository())
+
+	scraped, err := client.FindStashBoxSceneByID(ctx, stashID)
+	if err != nil {
+		return nil, fmt.Errorf("finding stash-box scene: %w", err)
+	}
+
+	if scraped == nil {
+		return nil, fmt.Errorf("%w: stash-box scene %s not found", ErrInput, stashID)
+	}
+
+	newScene := models.Scene{
+		Stub: true,
+		StashIDs: models.NewRelatedStashIDs([]models.StashID{
+			{
+				StashID:  stashID,
+				Endpoint: endpoint,
+			},
+		}),
+	}
+
+	if scraped.Title != nil {
+		newScene.Title = *scraped.Title
+	}
+	if scraped.Code != nil {
+		newScene.Code = *scraped.Code
+	}
+	if scraped.Details != nil {
+		newScene.Details = *scraped.Details
+	}
+	if scraped.Director != nil {
+		newScene.Director = *scraped.Director
+	}
+	if scraped.URL != nil {
+		newScene.URL = *scraped.URL
+	}
+	if scraped.Date != nil {
+		d := models.NewDate(*scraped.Date)
+		newScene.Date = &d
+	}
+
+	// only link to existing objects
+	newScene.StudioID, newScene.PerformerIDs, newScene.TagIDs, err = scrapedSceneStoredIDs(scraped)
+	if err != nil {
+		return nil, err
+	}
+
+	var coverImageData []byte
+	if scraped.Image != nil {
+		coverImageData, err = utils.ProcessImageInput(ctx, *scraped.Image)
+		if err != nil {
+			logger.Warnf("Error processing stash-box scene image: %v", err)
+		}
+	}
+
+	var ret *models.Scene
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Scene
+
+		result, err := qb.Query(ctx, scene.QueryOptions(&models.SceneFilterType{
+			StashIDEndpoint: &models.StashIDCriterionInput{
+				Endpoint: &endpoint,
+				StashID:  &stashID,
+				Modifier: models.CriterionModifierEquals,
+			},
+		}, nil, true))
+		if err != nil {
+			return err
+		}
+
+		if result.Count > 0 {
+			return fmt.Errorf("%w: scene with stash id %s already exists", ErrInput, stashID)
+		}
+
+		ret, err = r.Resolver.sceneService.Create(ctx, &newScene, nil, coverImageData)
+		if err != nil {
+			return err
+		}
+
+		return qb.AddStubFingerprints(ctx, ret.ID, stashBoxFingerprintsToFingerprints(scraped.Fingerprints))
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func scrapedSceneStoredIDs(scraped *scraper.ScrapedScene) (studioID *int, performerIDs models.RelatedIDs, tagIDs models.RelatedIDs, err error) {
+	if scraped.Studio != nil && scraped.Studio.StoredID != nil {
+		id, err := strconv.Atoi(*scraped.Studio.StoredID)
+		if err != nil {
+			return nil, performerIDs, tagIDs, fmt.Errorf("converting studio id: %w", err)
+		}
+		studioID = &id
+	}
+
+	var ids []int
+	for _, p := range scraped.Performers {
+		if p.StoredID != nil {
+			id, err := strconv.Atoi(*p.StoredID)
+			if err != nil {
+				return nil, performerIDs, tagIDs, fmt.Errorf("converting performer id: %w", err)
+			}
+			ids = append(ids, id)
+		}
+	}
+	performerIDs = models.NewRelatedIDs(ids)
+
+	ids = nil
+	for _, t := range scraped.Tags {
+		if t.StoredID != nil {
+			id, err := strconv.Atoi(*t.StoredID)
+			if err != nil {
+				return nil, performerIDs, tagIDs, fmt.Errorf("converting tag id: %w", err)
+			}
+			ids = append(ids, id)
+		}
+	}
+	tagIDs = models.NewRelatedIDs(ids)
+
+	return studioID, performerIDs, tagIDs, nil
+}
+
+// stashBoxFingerprintsToFingerprints converts the exact stash-box
+// fingerprints to file fingerprints. Perceptual hashes are excluded, since
+// they cannot identify a file.
+func stashBoxFingerprintsToFingerprints(fps []*models.StashBoxFingerprint) []file.Fingerprint {
+	var ret []file.Fingerprint
+	for _, fp := range fps {
+		var t string
+		switch fp.Algorithm {
+		case "MD5":
+			t = file.FingerprintTypeMD5
+		case "OSHASH":
+			t = file.FingerprintTypeOshash
+		default:
+			continue
+		}
+
+		ret = append(ret, file.Fingerprint{
+			Type:        t,
+			Fingerprint: fp.Hash,
+		})
+	}
+
+	return ret
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"strconv"
 	"time"
 
@@ -214,6 +215,168 @@ func (r *mutationResolver) StudioUpdate(ctx context.Context, input StudioUpdateI
 	return r.getStudio(ctx, s.ID)
 }
 
+// findOrCreateScrapedStudioParent returns the id of the studio to use as the
+// parent of a scraped studio. If the parent has no stored id, then it is
+// found by name or alias, or created if it does not exist.
+func (r *mutationResolver) findOrCreateScrapedStudioParent(ctx context.Context, input ScrapedStudioParentInput) (int, error) {
+	qb := r.repository.Studio
+
+	if input.StoredID != nil {
+		id, err := strconv.Atoi(*input.StoredID)
+		if err != nil {
+			return 0, err
+		}
+
+		s, err := qb.Find(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+
+		if s == nil {
+			return 0, fmt.Errorf("%w: studio with id %d not found", ErrInput, id)
+		}
+
+		return id, nil
+	}
+
+	s, err := studio.ByName(ctx, qb, input.Name)
+	if err != nil {
+		return 0, err
+	}
+
+	if s == nil {
+		s, err = studio.ByAlias(ctx, qb, input.Name)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if s != nil {
+		return s.ID, nil
+	}
+
+	currentTime := time.Now()
+	newStudio := models.Studio{
+		Checksum:  md5.FromString(input.Name),
+		Name:      sql.NullString{String: input.Name, Valid: true},
+		CreatedAt: models.SQLiteTimestamp{Timestamp: currentTime},
+		UpdatedAt: models.SQLiteTimestamp{Timestamp: currentTime},
+	}
+	if input.URL != nil {
+		newStudio.URL = sql.NullString{String: *input.URL, Valid: true}
+	}
+
+	s, err = qb.Create(ctx, newStudio)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.ID, nil
+}
+
+func (r *mutationResolver) StudioSaveScraped(ctx context.Context, input StudioSaveScrapedInput) (*models.Studio, error) {
+	var imageData []byte
+	if input.Image != nil {
+		var err error
+		imageData, err = utils.ProcessImageInput(ctx, *input.Image)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var id int
+	created := input.StoredID == nil
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Studio
+
+		var parentID *int
+		if input.Parent != nil {
+			pid, err := r.findOrCreateScrapedStudioParent(ctx, *input.Parent)
+			if err != nil {
+				return err
+			}
+			parentID = &pid
+		}
+
+		checksum := md5.FromString(input.Name)
+		currentTime := time.Now()
+
+		if created {
+			if err := studio.EnsureStudioNameUnique(ctx, 0, input.Name, qb); err != nil {
+				return err
+			}
+
+			newStudio := models.Studio{
+				Checksum:  checksum,
+				Name:      sql.NullString{String: input.Name, Valid: true},
+				CreatedAt: models.SQLiteTimestamp{Timestamp: currentTime},
+				UpdatedAt: models.SQLiteTimestamp{Timestamp: currentTime},
+			}
+			if input.URL != nil {
+				newStudio.URL = sql.NullString{String: *input.URL, Valid: true}
+			}
+			if parentID != nil {
+				newStudio.ParentID = sql.NullInt64{Int64: int64(*parentID), Valid: true}
+			}
+
+			s, err := qb.Create(ctx, newStudio)
+			if err != nil {
+				return err
+			}
+			id = s.ID
+		} else {
+			var err error
+			id, err = strconv.Atoi(*input.StoredID)
+			if err != nil {
+				return err
+			}
+
+			if err := studio.EnsureStudioNameUnique(ctx, id, input.Name, qb); err != nil {
+				return err
+			}
+
+			updatedStudio := models.StudioPartial{
+				ID:        id,
+				Checksum:  &checksum,
+				Name:      &sql.NullString{String: input.Name, Valid: true},
+				UpdatedAt: &models.SQLiteTimestamp{Timestamp: currentTime},
+			}
+			if input.URL != nil {
+				updatedStudio.URL = &sql.NullString{String: *input.URL, Valid: true}
+			}
+			if parentID != nil {
+				updatedStudio.ParentID = &sql.NullInt64{Int64: int64(*parentID), Valid: true}
+			}
+
+			if err := manager.ValidateModifyStudio(ctx, updatedStudio, qb); err != nil {
+				return err
+			}
+
+			if _, err := qb.Update(ctx, updatedStudio); err != nil {
+				return err
+			}
+		}
+
+		if len(imageData) > 0 {
+			if err := qb.UpdateImage(ctx, id, imageData); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if created {
+		r.hookExecutor.ExecutePostHooks(ctx, id, plugin.StudioCreatePost, input, nil)
+	} else {
+		r.hookExecutor.ExecutePostHooks(ctx, id, plugin.StudioUpdatePost, input, nil)
+	}
+
+	return r.getStudio(ctx, id)
+}
+
 func (r *mutationResolver) StudioDestroy(ctx context.Context, input StudioDestroyInput) (bool, error) {
 	id, err := strconv.Atoi(input.ID)
 	if err != nil {
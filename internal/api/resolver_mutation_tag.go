@@ -3,13 +3,17 @@ package api
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/jsonschema"
 	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/sliceutil/intslice"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 	"github.com/stashapp/stash/pkg/tag"
 	"github.com/stashapp/stash/pkg/utils"
@@ -103,6 +107,12 @@ func (r *mutationResolver) TagCreate(ctx context.Context, input TagCreateInput)
 			}
 		}
 
+		if len(input.AutoTagKeywords) > 0 {
+			if err := qb.UpdateAutoTagKeywords(ctx, t.ID, stringslice.StrUnique(input.AutoTagKeywords)); err != nil {
+				return err
+			}
+		}
+
 		if len(parentIDs) > 0 {
 			if err := qb.UpdateParentTags(ctx, t.ID, parentIDs); err != nil {
 				return err
@@ -229,6 +239,12 @@ func (r *mutationResolver) TagUpdate(ctx context.Context, input TagUpdateInput)
 			}
 		}
 
+		if translator.hasField("auto_tag_keywords") {
+			if err := qb.UpdateAutoTagKeywords(ctx, tagID, stringslice.StrUnique(input.AutoTagKeywords)); err != nil {
+				return err
+			}
+		}
+
 		if parentIDs != nil {
 			if err := qb.UpdateParentTags(ctx, tagID, parentIDs); err != nil {
 				return err
@@ -276,6 +292,77 @@ func (r *mutationResolver) TagDestroy(ctx context.Context, input TagDestroyInput
 	return true, nil
 }
 
+func (r *mutationResolver) PurgeTag(ctx context.Context, id string) (*models.TagPurgeCounts, error) {
+	tagID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret *models.TagPurgeCounts
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		t, err := r.repository.Tag.Find(ctx, tagID)
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			return fmt.Errorf("tag with id %d not found", tagID)
+		}
+
+		ret, err = r.repository.Tag.Purge(ctx, tagID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, tagID, plugin.TagDestroyPost, id, nil)
+
+	return ret, nil
+}
+
+func (r *mutationResolver) ExportTagHierarchy(ctx context.Context) (string, error) {
+	var nodes []*jsonschema.TagHierarchyNode
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		nodes, err = tag.HierarchyToJSON(ctx, r.repository.Tag)
+		return err
+	}); err != nil {
+		return "", err
+	}
+
+	if nodes == nil {
+		nodes = []*jsonschema.TagHierarchyNode{}
+	}
+
+	ret, err := json.MarshalIndent(nodes, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(ret), nil
+}
+
+func (r *mutationResolver) ImportTagHierarchy(ctx context.Context, input string) (*models.TagHierarchyImportCounts, error) {
+	var nodes []*jsonschema.TagHierarchyNode
+	if err := json.Unmarshal([]byte(input), &nodes); err != nil {
+		return nil, fmt.Errorf("invalid tag hierarchy: %w", err)
+	}
+
+	var ret *models.TagHierarchyImportCounts
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		importer := &tag.HierarchyImporter{
+			ReaderWriter: r.repository.Tag,
+		}
+
+		var err error
+		ret, err = importer.Import(ctx, nodes)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (r *mutationResolver) TagsDestroy(ctx context.Context, tagIDs []string) (bool, error) {
 	ids, err := stringslice.StringSliceToIntSlice(tagIDs)
 	if err != nil {
@@ -317,6 +404,18 @@ func (r *mutationResolver) TagsMerge(ctx context.Context, input TagsMergeInput)
 		return nil, nil
 	}
 
+	t, err := r.mergeTags(ctx, source, destination)
+	if err != nil {
+		return nil, err
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, t.ID, plugin.TagMergePost, input, nil)
+	return t, nil
+}
+
+// mergeTags merges the source tags into the destination tag, combining
+// their hierarchies.
+func (r *mutationResolver) mergeTags(ctx context.Context, source []int, destination int) (*models.Tag, error) {
 	var t *models.Tag
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Tag
@@ -360,6 +459,38 @@ func (r *mutationResolver) TagsMerge(ctx context.Context, input TagsMergeInput)
 		return nil, err
 	}
 
+	return t, nil
+}
+
+func (r *mutationResolver) MergeTagCluster(ctx context.Context, tagIds []string, canonicalID string) (*models.Tag, error) {
+	ids, err := stringslice.StringSliceToIntSlice(tagIds)
+	if err != nil {
+		return nil, err
+	}
+
+	destination, err := strconv.Atoi(canonicalID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !intslice.IntInclude(ids, destination) {
+		return nil, errors.New("canonical tag must be in the tag cluster")
+	}
+
+	source := intslice.IntExclude(intslice.IntAppendUniques(nil, ids), []int{destination})
+	if len(source) == 0 {
+		return nil, errors.New("tag cluster must contain a tag other than the canonical tag")
+	}
+
+	t, err := r.mergeTags(ctx, source, destination)
+	if err != nil {
+		return nil, err
+	}
+
+	input := TagsMergeInput{
+		Source:      intslice.IntSliceToStringSlice(source),
+		Destination: canonicalID,
+	}
 	r.hookExecutor.ExecutePostHooks(ctx, t.ID, plugin.TagMergePost, input, nil)
 	return t, nil
 }
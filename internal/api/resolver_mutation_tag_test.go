@@ -116,3 +116,30 @@ func TestTagCreate(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, tag)
 }
+
+func TestMergeTagCluster_invalid(t *testing.T) {
+	r := newResolver()
+	tagRW := r.repository.Tag.(*mocks.TagReaderWriter)
+	mr := &mutationResolver{r}
+
+	tests := []struct {
+		name        string
+		tagIDs      []string
+		canonicalID string
+	}{
+		{"canonical not in cluster", []string{"1", "2"}, "3"},
+		{"canonical only", []string{"1", "1"}, "1"},
+		{"invalid tag id", []string{"1", "a"}, "1"},
+		{"invalid canonical id", []string{"1", "2"}, "a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mr.MergeTagCluster(testCtx, tt.tagIDs, tt.canonicalID)
+			assert.Error(t, err)
+		})
+	}
+
+	// no tags are merged
+	tagRW.AssertExpectations(t)
+}
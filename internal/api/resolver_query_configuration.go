@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/models"
@@ -17,6 +18,19 @@ func (r *queryResolver) Configuration(ctx context.Context) (*ConfigResult, error
 	return makeConfigResult(), nil
 }
 
+func (r *queryResolver) EffectiveConfiguration(ctx context.Context) (map[string]interface{}, error) {
+	c := config.GetInstance()
+	ret := c.GetRedactedSettings()
+
+	mgr := manager.GetInstance()
+	ret["config_file"] = c.GetConfigFile()
+	ret["ffmpeg_path"] = string(mgr.FFMPEG)
+	ret["ffprobe_path"] = string(mgr.FFProbe)
+	ret["parallel_tasks_resolved"] = c.GetParallelTasksWithAutoDetection()
+
+	return ret, nil
+}
+
 func (r *queryResolver) Directory(ctx context.Context, path, locale *string) (*Directory, error) {
 
 	directory := &Directory{}
@@ -83,52 +97,91 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 	scraperCDPPath := config.GetScraperCDPPath()
 
 	return &ConfigGeneralResult{
-		Stashes:                      config.GetStashPaths(),
-		DatabasePath:                 config.GetDatabasePath(),
-		BackupDirectoryPath:          config.GetBackupDirectoryPath(),
-		GeneratedPath:                config.GetGeneratedPath(),
-		MetadataPath:                 config.GetMetadataPath(),
-		ConfigFilePath:               config.GetConfigFile(),
-		ScrapersPath:                 config.GetScrapersPath(),
-		CachePath:                    config.GetCachePath(),
-		CalculateMd5:                 config.IsCalculateMD5(),
-		VideoFileNamingAlgorithm:     config.GetVideoFileNamingAlgorithm(),
-		ParallelTasks:                config.GetParallelTasks(),
-		PreviewAudio:                 config.GetPreviewAudio(),
-		PreviewSegments:              config.GetPreviewSegments(),
-		PreviewSegmentDuration:       config.GetPreviewSegmentDuration(),
-		PreviewExcludeStart:          config.GetPreviewExcludeStart(),
-		PreviewExcludeEnd:            config.GetPreviewExcludeEnd(),
-		PreviewPreset:                config.GetPreviewPreset(),
-		MaxTranscodeSize:             &maxTranscodeSize,
-		MaxStreamingTranscodeSize:    &maxStreamingTranscodeSize,
-		WriteImageThumbnails:         config.IsWriteImageThumbnails(),
-		APIKey:                       config.GetAPIKey(),
-		Username:                     config.GetUsername(),
-		Password:                     config.GetPasswordHash(),
-		MaxSessionAge:                config.GetMaxSessionAge(),
-		LogFile:                      &logFile,
-		LogOut:                       config.GetLogOut(),
-		LogLevel:                     config.GetLogLevel(),
-		LogAccess:                    config.GetLogAccess(),
-		VideoExtensions:              config.GetVideoExtensions(),
-		ImageExtensions:              config.GetImageExtensions(),
-		GalleryExtensions:            config.GetGalleryExtensions(),
-		CreateGalleriesFromFolders:   config.GetCreateGalleriesFromFolders(),
-		Excludes:                     config.GetExcludes(),
-		ImageExcludes:                config.GetImageExcludes(),
-		CustomPerformerImageLocation: &customPerformerImageLocation,
-		ScraperUserAgent:             &scraperUserAgent,
-		ScraperCertCheck:             config.GetScraperCertCheck(),
-		ScraperCDPPath:               &scraperCDPPath,
-		StashBoxes:                   config.GetStashBoxes(),
-		PythonPath:                   config.GetPythonPath(),
+		Stashes:                            config.GetStashPaths(),
+		DatabasePath:                       config.GetDatabasePath(),
+		BackupDirectoryPath:                config.GetBackupDirectoryPath(),
+		GeneratedPath:                      config.GetGeneratedPath(),
+		MetadataPath:                       config.GetMetadataPath(),
+		ConfigFilePath:                     config.GetConfigFile(),
+		ScrapersPath:                       config.GetScrapersPath(),
+		CachePath:                          config.GetCachePath(),
+		CalculateMd5:                       config.IsCalculateMD5(),
+		VideoFileNamingAlgorithm:           config.GetVideoFileNamingAlgorithm(),
+		ParallelTasks:                      config.GetParallelTasks(),
+		AutoTagParallelTasks:               config.GetAutoTagParallelTasks(),
+		HashWorkers:                        config.GetHashWorkers(),
+		GalleryParallelTasks:               config.GetGalleryParallelTasks(),
+		PreviewAudio:                       config.GetPreviewAudio(),
+		PreviewSegments:                    config.GetPreviewSegments(),
+		PreviewSegmentDuration:             config.GetPreviewSegmentDuration(),
+		PreviewExcludeStart:                config.GetPreviewExcludeStart(),
+		PreviewExcludeEnd:                  config.GetPreviewExcludeEnd(),
+		PreviewPreset:                      config.GetPreviewPreset(),
+		CoverAvoidBlankFrames:              config.IsCoverAvoidBlankFrames(),
+		MaxTranscodeSize:                   &maxTranscodeSize,
+		MaxStreamingTranscodeSize:          &maxStreamingTranscodeSize,
+		StreamingTranscodeSizeNetworks:     config.GetStreamingTranscodeSizeNetworks(),
+		HlsSegmentDuration:                 config.GetHLSSegmentDuration(),
+		WriteImageThumbnails:               config.IsWriteImageThumbnails(),
+		MaxImageDimension:                  config.GetMaxImageDimension(),
+		ResolverCacheFields:                config.GetResolverCacheFields(),
+		ResolverCacheTTL:                   config.GetResolverCacheTTL(),
+		APIKey:                             config.GetAPIKey(),
+		Username:                           config.GetUsername(),
+		Password:                           config.GetPasswordHash(),
+		MaxSessionAge:                      config.GetMaxSessionAge(),
+		LogFile:                            &logFile,
+		LogOut:                             config.GetLogOut(),
+		LogLevel:                           config.GetLogLevel(),
+		LogAccess:                          config.GetLogAccess(),
+		HTTPCompression:                    config.IsHTTPCompression(),
+		LogMaxSize:                         config.GetLogMaxSize(),
+		LogRotateInterval:                  config.GetLogRotateInterval(),
+		LogMaxBackups:                      config.GetLogMaxBackups(),
+		VideoExtensions:                    config.GetVideoExtensions(),
+		ImageExtensions:                    config.GetImageExtensions(),
+		GalleryExtensions:                  config.GetGalleryExtensions(),
+		CreateGalleriesFromFolders:         config.GetCreateGalleriesFromFolders(),
+		GalleryCoverMinResolution:          config.GetGalleryCoverMinResolution(),
+		GalleryImageNaturalSort:            config.GetGalleryImageNaturalSort(),
+		SyncLinkedDatesOnScan:              config.GetSyncLinkedDatesOnScan(),
+		LinkFolderGalleriesOnScan:          config.GetLinkFolderGalleriesOnScan(),
+		LinkFolderGalleriesMatchStem:       config.GetLinkFolderGalleriesMatchStem(),
+		PrimaryFilePolicy:                  config.GetPrimaryFilePolicy(),
+		PrimaryFilePathPriority:            config.GetPrimaryFilePathPriority(),
+		PrimaryFileExtensionPriority:       config.GetPrimaryFileExtensionPriority(),
+		IdentifyAutoOrganize:               config.GetIdentifyAutoOrganize(),
+		IdentifyAutoOrganizeRequiredFields: config.GetIdentifyAutoOrganizeRequiredFields(),
+		NestedZipMaxDepth:                  config.GetNestedZipMaxDepth(),
+		ZipMaxEntries:                      config.GetZipMaxEntries(),
+		StudioFromMetadataTag:              config.GetStudioFromMetadataTag(),
+		StudioFromMetadataTagPattern:       config.GetStudioFromMetadataTagPattern(),
+		ScanProtectedFields:                config.GetScanProtectedFields(),
+		ScanPathTagRules:                   config.GetScanPathTagRules(),
+		RegenerateChangedFiles:             config.IsRegenerateChangedFiles(),
+		DuplicatePhashDistance:             config.GetDuplicatePhashDistance(),
+		DuplicateDurationTolerance:         config.GetDuplicateDurationTolerance(),
+		ResolutionLabelBreakpoints:         config.GetResolutionLabelBreakpoints(),
+		SceneDateFloorYear:                 config.GetSceneDateFloorYear(),
+		SceneDateModTimeTolerance:          config.GetSceneDateModTimeTolerance(),
+		BulkDownloadMaxSize:                config.GetBulkDownloadMaxSize(),
+		GenerateLoudness:                   config.IsGenerateLoudness(),
+		Excludes:                           config.GetExcludes(),
+		ImageExcludes:                      config.GetImageExcludes(),
+		CustomPerformerImageLocation:       &customPerformerImageLocation,
+		ScraperUserAgent:                   &scraperUserAgent,
+		ScraperCertCheck:                   config.GetScraperCertCheck(),
+		ScraperCDPPath:                     &scraperCDPPath,
+		StashBoxes:                         config.GetStashBoxes(),
+		StashBoxSubmitOrganizedOnly:        config.IsStashBoxSubmitOrganizedOnly(),
+		PythonPath:                         config.GetPythonPath(),
 	}
 }
 
 func makeConfigInterfaceResult() *ConfigInterfaceResult {
 	config := config.GetInstance()
 	menuItems := config.GetMenuItems()
+	sceneTitleFallback := config.GetSceneTitleFallback()
 	soundOnPreview := config.GetSoundOnPreview()
 	wallShowTitle := config.GetWallShowTitle()
 	showScrubber := config.GetShowScrubber()
@@ -140,6 +193,8 @@ func makeConfigInterfaceResult() *ConfigInterfaceResult {
 	autostartVideoOnPlaySelected := config.GetAutostartVideoOnPlaySelected()
 	continuePlaylistDefault := config.GetContinuePlaylistDefault()
 	showStudioAsText := config.GetShowStudioAsText()
+	studioImageInheritance := config.GetStudioImageInheritance()
+	performerImageSceneFallback := config.GetPerformerImageSceneFallback()
 	css := config.GetCSS()
 	cssEnabled := config.GetCSSEnabled()
 	javascript := config.GetJavascript()
@@ -155,6 +210,7 @@ func makeConfigInterfaceResult() *ConfigInterfaceResult {
 
 	return &ConfigInterfaceResult{
 		MenuItems:                    menuItems,
+		SceneTitleFallback:           sceneTitleFallback,
 		SoundOnPreview:               &soundOnPreview,
 		WallShowTitle:                &wallShowTitle,
 		WallPlayback:                 &wallPlayback,
@@ -164,6 +220,8 @@ func makeConfigInterfaceResult() *ConfigInterfaceResult {
 		NotificationsEnabled:         &notificationsEnabled,
 		AutostartVideo:               &autostartVideo,
 		ShowStudioAsText:             &showStudioAsText,
+		StudioImageInheritance:       &studioImageInheritance,
+		PerformerImageSceneFallback:  &performerImageSceneFallback,
 		AutostartVideoOnPlaySelected: &autostartVideoOnPlaySelected,
 		ContinuePlaylistDefault:      &continuePlaylistDefault,
 		CSS:                          &css,
@@ -203,10 +261,13 @@ func makeConfigScrapingResult() *ConfigScrapingResult {
 	scraperCDPPath := config.GetScraperCDPPath()
 
 	return &ConfigScrapingResult{
-		ScraperUserAgent:   &scraperUserAgent,
-		ScraperCertCheck:   config.GetScraperCertCheck(),
-		ScraperCDPPath:     &scraperCDPPath,
-		ExcludeTagPatterns: config.GetScraperExcludeTagPatterns(),
+		ScraperUserAgent:     &scraperUserAgent,
+		ScraperCertCheck:     config.GetScraperCertCheck(),
+		ScraperCDPPath:       &scraperCDPPath,
+		ExcludeTagPatterns:   config.GetScraperExcludeTagPatterns(),
+		DetailsTemplate:      config.GetScraperDetailsTemplate(),
+		DetailsTemplateForce: config.IsScraperDetailsTemplateForced(),
+		SanitizeOutput:       config.IsScraperSanitizeOutput(),
 	}
 }
 
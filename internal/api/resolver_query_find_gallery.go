@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"errors"
 	"strconv"
 
 	"github.com/stashapp/stash/pkg/models"
@@ -41,3 +42,22 @@ func (r *queryResolver) FindGalleries(ctx context.Context, galleryFilter *models
 
 	return ret, nil
 }
+
+func (r *queryResolver) FindDuplicateGalleries(ctx context.Context, overlap *float64) (ret []*models.GalleryDuplicateGroup, err error) {
+	o := 0.9
+	if overlap != nil {
+		o = *overlap
+	}
+	if o <= 0 || o > 1 {
+		return nil, errors.New("overlap must be greater than 0 and at most 1")
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Gallery.FindDuplicates(ctx, o)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -42,6 +42,14 @@ func (r *queryResolver) FindPerformers(ctx context.Context, performerFilter *mod
 	return ret, nil
 }
 
+func (r *queryResolver) FindPerformerCoverageGaps(ctx context.Context, gap models.PerformerCoverageGapEnum, filter *models.FindFilterType) (ret *FindPerformersResultType, err error) {
+	performerFilter := &models.PerformerFilterType{
+		CoverageGap: &gap,
+	}
+
+	return r.FindPerformers(ctx, performerFilter, filter)
+}
+
 func (r *queryResolver) AllPerformers(ctx context.Context) (ret []*models.Performer, err error) {
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.repository.Performer.All(ctx)
@@ -52,3 +60,56 @@ func (r *queryResolver) AllPerformers(ctx context.Context) (ret []*models.Perfor
 
 	return ret, nil
 }
+
+func (r *queryResolver) FindPerformerStudioScenes(ctx context.Context, performerID string, excludeMostCommon *bool) ([]*PerformerStudioScenes, error) {
+	idInt, err := strconv.Atoi(performerID)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []*PerformerStudioScenes{}
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		groups, err := r.repository.Scene.FindStudioGroupsByPerformerID(ctx, idInt)
+		if err != nil {
+			return err
+		}
+
+		if excludeMostCommon != nil && *excludeMostCommon {
+			// groups are ordered by scene count, so the first group with a
+			// studio is the most common studio
+			for i, g := range groups {
+				if g.StudioID != nil {
+					groups = append(groups[:i:i], groups[i+1:]...)
+					break
+				}
+			}
+		}
+
+		for _, g := range groups {
+			scenes, err := r.repository.Scene.FindMany(ctx, g.SceneIDs)
+			if err != nil {
+				return err
+			}
+
+			var studio *models.Studio
+			if g.StudioID != nil {
+				studio, err = r.repository.Studio.Find(ctx, *g.StudioID)
+				if err != nil {
+					return err
+				}
+			}
+
+			ret = append(ret, &PerformerStudioScenes{
+				Studio:     studio,
+				SceneCount: len(g.SceneIDs),
+				Scenes:     scenes,
+			})
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -2,11 +2,21 @@ package api
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
 	"strconv"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/hash/audiofingerprint"
+	"github.com/stashapp/stash/pkg/match"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/sliceutil/intslice"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 )
 
@@ -232,3 +242,370 @@ func (r *queryResolver) FindDuplicateScenes(ctx context.Context, distance *int)
 
 	return ret, nil
 }
+
+func (r *queryResolver) FindSceneDuplicateGroups(ctx context.Context) (ret [][]*models.Scene, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Scene.FindDuplicateGroups(ctx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) FindAudioDuplicateScenes(ctx context.Context, similarity *float64, durationDiff *float64) (ret [][]*models.Scene, err error) {
+	sim := 0.9
+	if similarity != nil {
+		sim = *similarity
+	}
+	if sim < 0 || sim > 1 {
+		return nil, errors.New("similarity must be between 0 and 1")
+	}
+
+	diff := 10.0
+	if durationDiff != nil {
+		diff = *durationDiff
+	}
+
+	var fingerprints []audiofingerprint.Fingerprint
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		fingerprints, err = r.repository.Scene.AllAudioFingerprints(ctx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	// compare the fingerprints outside of the transaction, so that the
+	// database is not held while comparing
+	groups := audiofingerprint.FindDuplicates(fingerprints, sim, diff)
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		for _, sceneIDs := range groups {
+			scenes, err := r.repository.Scene.FindMany(ctx, sceneIDs)
+			if err != nil {
+				return err
+			}
+			ret = append(ret, scenes)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) FindScenesSharingFiles(ctx context.Context) ([]*SceneSharingFile, error) {
+	var ret []*SceneSharingFile
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		shared, err := r.repository.Scene.FindSharedFiles(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, sf := range shared {
+			files, err := r.repository.File.Find(ctx, sf.FileID)
+			if err != nil {
+				return err
+			}
+
+			if len(files) == 0 {
+				continue
+			}
+
+			vf, ok := files[0].(*file.VideoFile)
+			if !ok {
+				continue
+			}
+
+			scenes, err := r.repository.Scene.FindMany(ctx, sf.SceneIDs)
+			if err != nil {
+				return err
+			}
+
+			ret = append(ret, &SceneSharingFile{
+				File:   convertVideoFile(vf),
+				Scenes: scenes,
+			})
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) FindLargestScenes(ctx context.Context, input LargestScenesInput) ([]*SceneSizeEstimate, error) {
+	if input.TargetBitrate <= 0 {
+		return nil, errors.New("target_bitrate must be greater than 0")
+	}
+
+	limit := 25
+	if input.Limit != nil {
+		limit = *input.Limit
+	}
+
+	sort := "filesize"
+	direction := models.SortDirectionEnumDesc
+
+	ret := []*SceneSizeEstimate{}
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		scenes, err := scene.Query(ctx, r.repository.Scene, input.SceneFilter, &models.FindFilterType{
+			PerPage:   &limit,
+			Sort:      &sort,
+			Direction: &direction,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, s := range scenes {
+			if err := s.LoadPrimaryFile(ctx, r.repository.File); err != nil {
+				return err
+			}
+
+			f := s.Files.Primary()
+			if f == nil {
+				continue
+			}
+
+			ret = append(ret, &SceneSizeEstimate{
+				Scene:                      s,
+				Size:                       f.Size,
+				Bitrate:                    f.BitRate,
+				ApproximateReclaimableSize: scene.EstimateReclaimableSize(f.Size, f.Duration, input.TargetBitrate),
+			})
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) SceneMetadataJSON(ctx context.Context, id string) (string, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return "", err
+	}
+
+	var ret []byte
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		s, err := r.repository.Scene.Find(ctx, idInt)
+		if err != nil {
+			return err
+		}
+		if s == nil {
+			return fmt.Errorf("scene with id %d not found", idInt)
+		}
+
+		if err := s.LoadPrimaryFile(ctx, r.repository.File); err != nil {
+			return err
+		}
+		if err := s.LoadStashIDs(ctx, r.repository.Scene); err != nil {
+			return err
+		}
+
+		sceneJSON, err := scene.ToMetadataJSON(ctx, r.repository.Studio, r.repository.Performer, r.repository.Tag, s)
+		if err != nil {
+			return err
+		}
+
+		ret, err = json.Marshal(sceneJSON)
+		return err
+	}); err != nil {
+		return "", err
+	}
+
+	return string(ret), nil
+}
+
+func (r *queryResolver) FindSceneDateAnomalies(ctx context.Context, sceneFilter *models.SceneFilterType) ([]*SceneDateAnomalies, error) {
+	// only scenes with a date can have anomalies
+	filter := &models.SceneFilterType{}
+	if sceneFilter != nil {
+		f := *sceneFilter
+		filter = &f
+	}
+	if filter.Date == nil {
+		filter.Date = &models.DateCriterionInput{
+			Modifier: models.CriterionModifierNotNull,
+		}
+	}
+
+	cfg := manager.GetInstance().Config
+	options := scene.DateAnomalyOptions{
+		Now:                  time.Now(),
+		FloorYear:            cfg.GetSceneDateFloorYear(),
+		FileModTimeTolerance: time.Duration(cfg.GetSceneDateModTimeTolerance()) * 24 * time.Hour,
+	}
+
+	ret := []*SceneDateAnomalies{}
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		return scene.BatchProcess(ctx, r.repository.Scene, filter, nil, func(s *models.Scene) error {
+			if options.FileModTimeTolerance > 0 {
+				if err := s.LoadPrimaryFile(ctx, r.repository.File); err != nil {
+					return err
+				}
+			}
+
+			anomalies := scene.DateAnomalies(s, options)
+			if len(anomalies) == 0 {
+				return nil
+			}
+
+			v := &SceneDateAnomalies{
+				Scene: s,
+			}
+			for _, a := range anomalies {
+				v.Anomalies = append(v.Anomalies, SceneDateAnomaly(a))
+			}
+			ret = append(ret, v)
+
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) FindScenesWithUnlinkedPerformers(ctx context.Context, sceneFilter *models.SceneFilterType) ([]*SceneUnlinkedPerformers, error) {
+	ret := []*SceneUnlinkedPerformers{}
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		// only check the scenes which may name an unlinked performer
+		candidates, err := r.repository.Scene.FindUnlinkedPerformerCandidates(ctx)
+		if err != nil {
+			return err
+		}
+
+		var sceneIDs []int
+		for id := range candidates {
+			sceneIDs = append(sceneIDs, id)
+		}
+		sort.Ints(sceneIDs)
+
+		if sceneFilter != nil && len(sceneIDs) > 0 {
+			all := -1
+			result, err := r.repository.Scene.Query(ctx, models.SceneQueryOptions{
+				QueryOptions: models.QueryOptions{
+					FindFilter: &models.FindFilterType{
+						PerPage: &all,
+					},
+				},
+				SceneFilter: sceneFilter,
+			})
+			if err != nil {
+				return err
+			}
+
+			filtered := make(map[int]bool, len(result.IDs))
+			for _, id := range result.IDs {
+				filtered[id] = true
+			}
+
+			var filteredIDs []int
+			for _, id := range sceneIDs {
+				if filtered[id] {
+					filteredIDs = append(filteredIDs, id)
+				}
+			}
+			sceneIDs = filteredIDs
+		}
+
+		if len(sceneIDs) == 0 {
+			return nil
+		}
+
+		var performerIDs []int
+		for _, id := range sceneIDs {
+			performerIDs = intslice.IntAppendUniques(performerIDs, candidates[id])
+		}
+
+		performers, err := r.repository.Performer.FindMany(ctx, performerIDs)
+		if err != nil {
+			return err
+		}
+
+		matcher, err := match.NewPerformerTextMatcher(ctx, performers, r.repository.Performer)
+		if err != nil {
+			return err
+		}
+
+		scenes, err := r.repository.Scene.FindMany(ctx, sceneIDs)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range scenes {
+			var unlinked []*models.Performer
+			for _, p := range matcher.Match(s.Title, s.Details) {
+				if intslice.IntInclude(candidates[s.ID], p.ID) {
+					unlinked = append(unlinked, p)
+				}
+			}
+
+			if len(unlinked) > 0 {
+				ret = append(ret, &SceneUnlinkedPerformers{
+					Scene:      s,
+					Performers: unlinked,
+				})
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) FindScenesMissingPhashWithOSHashMatch(ctx context.Context) (ret []*models.Scene, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Scene.FindMissingPhashWithOSHashMatch(ctx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) FindOshashCollisions(ctx context.Context) ([]*OshashCollision, error) {
+	results := manager.GetInstance().OshashCollisions.Results()
+
+	ret := []*OshashCollision{}
+
+	// scenes are loaded individually since they may have been deleted since
+	// the scan
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		for _, res := range results {
+			v := &OshashCollision{
+				Path:      res.Path,
+				OtherPath: res.OtherPath,
+			}
+
+			if res.SceneID != nil {
+				s, err := r.repository.Scene.Find(ctx, *res.SceneID)
+				if err != nil {
+					return err
+				}
+				v.Scene = s
+			}
+
+			ret = append(ret, v)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
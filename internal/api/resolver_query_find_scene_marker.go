@@ -24,3 +24,32 @@ func (r *queryResolver) FindSceneMarkers(ctx context.Context, sceneMarkerFilter
 
 	return ret, nil
 }
+
+func (r *queryResolver) FindUnderMarkedScenes(ctx context.Context, input UnderMarkedScenesInput, filter *models.FindFilterType) (ret *FindUnderMarkedScenesResultType, err error) {
+	options := models.SceneMarkerCoverageOptions{
+		SceneFilter: input.SceneFilter,
+		FindFilter:  filter,
+		MaxCoverage: input.MaxCoverage,
+	}
+	if input.MaxMarkerLength != nil {
+		options.MaxMarkerLength = *input.MaxMarkerLength
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		scenes, count, err := r.repository.Scene.QueryMarkerCoverage(ctx, options)
+		if err != nil {
+			return err
+		}
+
+		ret = &FindUnderMarkedScenesResultType{
+			Count:  count,
+			Scenes: scenes,
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
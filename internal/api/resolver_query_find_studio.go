@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/studio"
 )
 
 func (r *queryResolver) FindStudio(ctx context.Context, id string) (ret *models.Studio, err error) {
@@ -54,3 +55,42 @@ func (r *queryResolver) AllStudios(ctx context.Context) (ret []*models.Studio, e
 
 	return ret, nil
 }
+
+func (r *queryResolver) FindStudioNetworks(ctx context.Context) (ret []*StudioNetwork, err error) {
+	ret = []*StudioNetwork{}
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		studios, err := r.repository.Studio.All(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, n := range studio.Networks(studios) {
+			ids := []int{n.Studio.ID}
+			for _, m := range n.Members {
+				ids = append(ids, m.ID)
+			}
+
+			counts, err := r.repository.Scene.CountByStudioIDs(ctx, ids)
+			if err != nil {
+				return err
+			}
+
+			sceneCount := 0
+			for _, c := range counts {
+				sceneCount += c
+			}
+
+			ret = append(ret, &StudioNetwork{
+				Studio:        n.Studio,
+				MemberStudios: n.Members,
+				SceneCount:    sceneCount,
+			})
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
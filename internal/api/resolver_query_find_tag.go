@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/tag"
 )
 
 func (r *queryResolver) FindTag(ctx context.Context, id string) (ret *models.Tag, err error) {
@@ -53,3 +54,53 @@ func (r *queryResolver) AllTags(ctx context.Context) (ret []*models.Tag, err err
 
 	return ret, nil
 }
+
+func (r *queryResolver) FindTagHierarchyCycles(ctx context.Context) ([]*TagHierarchyCycle, error) {
+	ret := []*TagHierarchyCycle{}
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Tag
+		relationships, err := qb.AllRelationships(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, ids := range tag.FindHierarchyCycles(relationships) {
+			tags, err := qb.FindMany(ctx, ids)
+			if err != nil {
+				return err
+			}
+
+			ret = append(ret, &TagHierarchyCycle{
+				Tags: tags,
+			})
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) FindTagOverlapClusters(ctx context.Context) ([]*TagOverlapCluster, error) {
+	ret := []*TagOverlapCluster{}
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		clusters, err := tag.FindOverlapClusters(ctx, r.repository.Tag)
+		if err != nil {
+			return err
+		}
+
+		for _, tags := range clusters {
+			ret = append(ret, &TagOverlapCluster{
+				Tags: tags,
+			})
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -9,3 +9,7 @@ import (
 func (r *queryResolver) SystemStatus(ctx context.Context) (*manager.SystemStatus, error) {
 	return manager.GetInstance().GetSystemStatus(), nil
 }
+
+func (r *queryResolver) FindUnlinkedGeneratedFiles(ctx context.Context) ([]*manager.UnlinkedGeneratedFiles, error) {
+	return manager.GetInstance().FindUnlinkedGeneratedFiles(ctx, false)
+}
@@ -3,12 +3,15 @@ package api
 import (
 	"context"
 	"errors"
+	"path/filepath"
 	"strconv"
 
 	"github.com/stashapp/stash/internal/api/urlbuilders"
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
 )
 
 func (r *queryResolver) SceneStreams(ctx context.Context, id *string) ([]*manager.SceneStreamEndpoint, error) {
@@ -37,3 +40,36 @@ func (r *queryResolver) SceneStreams(ctx context.Context, id *string) ([]*manage
 
 	return manager.GetSceneStreamPaths(scene, builder.GetStreamURL(), config.GetInstance().GetMaxStreamingTranscodeSize())
 }
+
+func (r *queryResolver) SceneDirectoryTree(ctx context.Context, path *string, depth *int) ([]*models.SceneDirectory, error) {
+	var paths []string
+	if path != nil && *path != "" {
+		paths = []string{filepath.Clean(*path)}
+	} else {
+		for _, s := range config.GetInstance().GetStashPaths() {
+			paths = append(paths, s.Path)
+		}
+	}
+
+	maxDepth := -1
+	if depth != nil {
+		maxDepth = *depth
+	}
+
+	var folders []*file.Folder
+	var sceneIDs map[file.FolderID][]int
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		folders, err = r.repository.Folder.FindAllInPaths(ctx, paths, -1, 0)
+		if err != nil {
+			return err
+		}
+
+		sceneIDs, err = r.repository.Scene.FindSceneIDsByFolder(ctx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return scene.DirectoryTree(folders, sceneIDs, maxDepth), nil
+}
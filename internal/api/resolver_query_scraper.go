@@ -48,6 +48,10 @@ func (r *queryResolver) ListScrapers(ctx context.Context, types []scraper.Scrape
 	return r.scraperCache().ListScrapers(types), nil
 }
 
+func (r *queryResolver) ValidateScraper(ctx context.Context, scraperID string, checkURLs *bool) (*scraper.ScraperValidationResult, error) {
+	return r.scraperCache().ValidateScraper(ctx, scraperID, checkURLs != nil && *checkURLs)
+}
+
 func (r *queryResolver) ListPerformerScrapers(ctx context.Context) ([]*scraper.Scraper, error) {
 	return r.scraperCache().ListScrapers([]scraper.ScrapeContentType{scraper.ScrapeContentTypePerformer}), nil
 }
@@ -227,6 +231,15 @@ func (r *queryResolver) ScrapeMovieURL(ctx context.Context, url string) (*models
 	return marshalScrapedMovie(content)
 }
 
+func (r *queryResolver) ScrapeStudioURL(ctx context.Context, url string) (*models.ScrapedStudio, error) {
+	content, err := r.scraperCache().ScrapeURL(ctx, url, scraper.ScrapeContentTypeStudio)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalScrapedStudio(content)
+}
+
 func (r *queryResolver) getStashBoxClient(index int) (*stashbox.Client, error) {
 	boxes := config.GetInstance().GetStashBoxes()
 
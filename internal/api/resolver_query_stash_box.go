@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) FindStaleSceneStashIDs(ctx context.Context) ([]*StaleSceneStashID, error) {
+	results := manager.GetInstance().StashBoxCheckResults.StaleSceneStashIDs()
+
+	// scenes are loaded individually since they may have been deleted since
+	// the check
+	scenes := make(map[int]*models.Scene)
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		for _, res := range results {
+			if _, done := scenes[res.SceneID]; done {
+				continue
+			}
+
+			s, err := r.repository.Scene.Find(ctx, res.SceneID)
+			if err != nil {
+				return err
+			}
+			scenes[res.SceneID] = s
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	ret := []*StaleSceneStashID{}
+	for _, res := range results {
+		s := scenes[res.SceneID]
+		if s == nil {
+			continue
+		}
+
+		stale := &StaleSceneStashID{
+			Scene:   s,
+			StashID: res.StashID,
+			Status:  StashIDStatusNotFound,
+		}
+		if res.RedirectStashID != nil {
+			stale.Status = StashIDStatusRedirected
+			stale.RedirectStashID = res.RedirectStashID
+		}
+
+		ret = append(ret, stale)
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) FindSceneStudioConflicts(ctx context.Context) ([]*SceneStudioConflict, error) {
+	results := manager.GetInstance().StashBoxCheckResults.SceneStudioConflicts()
+
+	// objects are loaded individually since they may have been deleted since
+	// the check
+	scenes := make(map[int]*models.Scene)
+	studios := make(map[int]*models.Studio)
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		for _, res := range results {
+			if _, done := scenes[res.SceneID]; !done {
+				s, err := r.repository.Scene.Find(ctx, res.SceneID)
+				if err != nil {
+					return err
+				}
+				scenes[res.SceneID] = s
+			}
+
+			if _, done := studios[res.StudioID]; !done {
+				s, err := r.repository.Studio.Find(ctx, res.StudioID)
+				if err != nil {
+					return err
+				}
+				studios[res.StudioID] = s
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	ret := []*SceneStudioConflict{}
+	for _, res := range results {
+		s := scenes[res.SceneID]
+		studio := studios[res.StudioID]
+		if s == nil || studio == nil {
+			continue
+		}
+
+		ret = append(ret, &SceneStudioConflict{
+			Scene:          s,
+			StashID:        res.StashID,
+			Studio:         studio,
+			StashBoxStudio: res.StashBoxStudio,
+		})
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) FindPerformerStashBoxDiffs(ctx context.Context) ([]*PerformerStashBoxDiff, error) {
+	results := manager.GetInstance().StashBoxCheckResults.PerformerStashBoxDiffs()
+
+	// performers are loaded individually since they may have been deleted
+	// since the check
+	performers := make(map[int]*models.Performer)
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		for _, res := range results {
+			if _, done := performers[res.PerformerID]; done {
+				continue
+			}
+
+			p, err := r.repository.Performer.Find(ctx, res.PerformerID)
+			if err != nil {
+				return err
+			}
+			performers[res.PerformerID] = p
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	ret := []*PerformerStashBoxDiff{}
+	for _, res := range results {
+		p := performers[res.PerformerID]
+		if p == nil {
+			continue
+		}
+
+		ret = append(ret, &PerformerStashBoxDiff{
+			Performer: p,
+			StashID:   res.StashID,
+			Diffs:     res.Diffs,
+		})
+	}
+
+	return ret, nil
+}
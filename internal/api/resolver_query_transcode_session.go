@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) TranscodeSessions(ctx context.Context) (*TranscodeSessionStats, error) {
+	sessions := manager.GetInstance().TranscodeSessions
+	active := sessions.Active()
+	history := sessions.History()
+
+	// many sessions are of the same scene, particularly for HLS streams
+	scenes := make(map[int]*models.Scene)
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		for _, l := range [][]manager.TranscodeSession{active, history} {
+			for _, s := range l {
+				if _, found := scenes[s.SceneID]; found {
+					continue
+				}
+
+				scene, err := r.repository.Scene.Find(ctx, s.SceneID)
+				if err != nil {
+					return err
+				}
+				scenes[s.SceneID] = scene
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	ret := &TranscodeSessionStats{
+		Active:  make([]*TranscodeSession, len(active)),
+		History: make([]*TranscodeSession, len(history)),
+	}
+	for i, s := range active {
+		ret.Active[i] = transcodeSessionToModel(s, scenes[s.SceneID])
+	}
+	for i, s := range history {
+		ret.History[i] = transcodeSessionToModel(s, scenes[s.SceneID])
+	}
+
+	return ret, nil
+}
+
+func transcodeSessionToModel(s manager.TranscodeSession, scene *models.Scene) *TranscodeSession {
+	ret := &TranscodeSession{
+		ID:               strconv.Itoa(s.ID),
+		Scene:            scene,
+		Path:             s.Path,
+		Format:           s.Format,
+		MaxTranscodeSize: s.MaxTranscodeSize,
+		StartTime:        s.StartTime,
+		StartedAt:        s.StartedAt,
+		EndedAt:          s.EndedAt,
+		Duration:         s.Duration().Seconds(),
+	}
+
+	if s.Client != "" {
+		ret.Client = &s.Client
+	}
+
+	if s.CPUTime != nil {
+		cpuTime := s.CPUTime.Seconds()
+		ret.CPUTime = &cpuTime
+	}
+
+	return ret
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"io"
+	"net"
 	"net/http"
 	"strconv"
 	"strings"
@@ -26,6 +28,7 @@ type SceneFinder interface {
 	manager.SceneCoverGetter
 
 	scene.IDFinder
+	scene.Queryer
 	FindByChecksum(ctx context.Context, checksum string) ([]*models.Scene, error)
 	FindByOSHash(ctx context.Context, oshash string) ([]*models.Scene, error)
 }
@@ -51,6 +54,9 @@ type sceneRoutes struct {
 func (rs sceneRoutes) Routes() chi.Router {
 	r := chi.NewRouter()
 
+	r.Get("/download", rs.Download)
+	r.Post("/download", rs.Download)
+
 	r.Route("/{sceneId}", func(r chi.Router) {
 		r.Use(rs.SceneCtx)
 
@@ -68,6 +74,7 @@ func (rs sceneRoutes) Routes() chi.Router {
 		r.Get("/vtt/chapter", rs.ChapterVtt)
 		r.Get("/funscript", rs.Funscript)
 		r.Get("/interactive_heatmap", rs.InteractiveHeatmap)
+		r.Get("/contact_sheet", rs.ContactSheet)
 		r.Get("/caption", rs.CaptionLang)
 
 		r.Get("/scene_marker/{sceneMarkerId}/stream", rs.SceneMarkerStream)
@@ -139,7 +146,8 @@ func (rs sceneRoutes) StreamHLS(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", ffmpeg.MimeHLS)
 	var str strings.Builder
 
-	ffmpeg.WriteHLSPlaylist(pf.Duration, r.URL.String(), &str)
+	segmentLength := float64(config.GetInstance().GetHLSSegmentDuration())
+	ffmpeg.WriteHLSPlaylist(pf.Duration, segmentLength, r.URL.String(), &str)
 
 	requestByteRange := createByteRange(r.Header.Get("Range"))
 	if requestByteRange.RawString != "" {
@@ -155,6 +163,34 @@ func (rs sceneRoutes) StreamHLS(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// requestClientIP returns the IP address of the client making the request.
+// X-Forwarded-For is only used if the request was made by a local proxy.
+func requestClientIP(r *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	// presence of scope ID in IPv6 addresses prevents parsing. Remove if present
+	if i := strings.Index(host, "%"); i != -1 {
+		host = host[:i]
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return nil
+	}
+
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" && (ip.IsPrivate() || ip.IsLoopback()) {
+		client := strings.TrimSpace(strings.Split(forwarded, ",")[0])
+		if forwardedIP := net.ParseIP(client); forwardedIP != nil {
+			return forwardedIP
+		}
+	}
+
+	return ip
+}
+
 func (rs sceneRoutes) StreamTS(w http.ResponseWriter, r *http.Request) {
 	rs.streamTranscode(w, r, ffmpeg.StreamFormatHLS)
 }
@@ -201,6 +237,47 @@ func (rs sceneRoutes) streamTranscode(w http.ResponseWriter, r *http.Request, st
 		options.MaxTranscodeSize = models.StreamingResolutionEnum(requestedSize).GetMaxResolution()
 	}
 
+	// cap the transcode size by the client network, if configured
+	clientIP := requestClientIP(r)
+	if n := config.MatchStreamingTranscodeSizeNetwork(config.GetInstance().GetStreamingTranscodeSizeNetworks(), clientIP); n != nil {
+		maxSize := n.MaxSize.GetMaxResolution()
+		if maxSize != 0 && (options.MaxTranscodeSize == 0 || options.MaxTranscodeSize > maxSize) {
+			logger.Debugf("[stream] capping transcode size of %s to %s for client %s in network %s", f.Path, n.MaxSize, clientIP, n.Network)
+			options.MaxTranscodeSize = maxSize
+		}
+	}
+
+	var segmentWriter *manager.HLSSegmentWriter
+	if streamFormat.Name == ffmpeg.StreamFormatHLS.Name {
+		options.SegmentLength = float64(config.GetInstance().GetHLSSegmentDuration())
+
+		key := manager.HLSSegmentKey{
+			SceneID:          scene.ID,
+			MaxTranscodeSize: options.MaxTranscodeSize,
+			SegmentLength:    options.SegmentLength,
+			StartTime:        ss,
+		}
+		if clientIP != nil {
+			key.Client = clientIP.String()
+		}
+
+		// serve the segment from the cache if it was already transcoded
+		// in this session
+		segmentCache := manager.GetInstance().HLSSegmentCache
+		if p := segmentCache.Get(key); p != "" {
+			logger.Debugf("[stream] serving cached HLS segment %s", p)
+			w.Header().Set("Content-Type", streamFormat.MimeType)
+			http.ServeFile(w, r, p)
+			return
+		}
+
+		var err error
+		segmentWriter, err = segmentCache.Create(key)
+		if err != nil {
+			logger.Warnf("[stream] error caching HLS segment: %v", err)
+		}
+	}
+
 	encoder := manager.GetInstance().FFMPEG
 
 	lm := manager.GetInstance().ReadLockManager
@@ -214,6 +291,9 @@ func (rs sceneRoutes) streamTranscode(w http.ResponseWriter, r *http.Request, st
 	stream, err := encoder.GetTranscodeStream(lockCtx, options)
 
 	if err != nil {
+		if segmentWriter != nil {
+			_ = segmentWriter.Finish(false)
+		}
 		logger.Errorf("[stream] error transcoding video file: %v", err)
 		w.WriteHeader(http.StatusBadRequest)
 		if _, err := w.Write([]byte(err.Error())); err != nil {
@@ -224,8 +304,68 @@ func (rs sceneRoutes) streamTranscode(w http.ResponseWriter, r *http.Request, st
 
 	lockCtx.AttachCommand(stream.Cmd)
 
-	stream.Serve(w, r)
+	session := manager.TranscodeSession{
+		SceneID:          scene.ID,
+		Path:             f.Path,
+		Format:           streamFormat.Name,
+		MaxTranscodeSize: options.MaxTranscodeSize,
+		StartTime:        ss,
+	}
+	if clientIP != nil {
+		session.Client = clientIP.String()
+	}
+
+	sessions := manager.GetInstance().TranscodeSessions
+	sessionID := sessions.Start(session)
+
+	var tee *teeResponseWriter
+	if segmentWriter != nil {
+		tee = &teeResponseWriter{ResponseWriter: w, tee: segmentWriter}
+		stream.Serve(tee, r)
+	} else {
+		stream.Serve(w, r)
+	}
 	w.(http.Flusher).Flush()
+
+	// the process is killed when the request context is closed. Wait for it
+	// to exit to record the resources it used. The lock context owns the
+	// process, since cancelling it also waits for the process to exit.
+	go func() {
+		waitErr := lockCtx.Wait()
+		sessions.End(sessionID, stream.Cmd.ProcessState)
+
+		// only cache segments that were transcoded and served in full
+		if tee != nil {
+			if err := segmentWriter.Finish(waitErr == nil && !tee.failed); err != nil {
+				logger.Warnf("[stream] error caching HLS segment: %v", err)
+			}
+		}
+	}()
+}
+
+// teeResponseWriter writes the response to tee as well as the client. failed
+// is set if writing to either fails, after which nothing more is written to
+// tee.
+type teeResponseWriter struct {
+	http.ResponseWriter
+	tee    io.Writer
+	failed bool
+}
+
+func (w *teeResponseWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	if err != nil {
+		w.failed = true
+		return n, err
+	}
+
+	if !w.failed {
+		if _, err := w.tee.Write(p[:n]); err != nil {
+			w.failed = true
+		}
+	}
+
+	return n, nil
 }
 
 func (rs sceneRoutes) Screenshot(w http.ResponseWriter, r *http.Request) {
@@ -240,7 +380,7 @@ func (rs sceneRoutes) Screenshot(w http.ResponseWriter, r *http.Request) {
 
 func (rs sceneRoutes) Preview(w http.ResponseWriter, r *http.Request) {
 	scene := r.Context().Value(sceneKey).(*models.Scene)
-	filepath := manager.GetInstance().Paths.Scene.GetVideoPreviewPath(scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()))
+	filepath := manager.GetInstance().Paths.ForPath(scene.Path).Scene.GetVideoPreviewPath(scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()))
 	serveFileNoCache(w, r, filepath)
 }
 
@@ -254,7 +394,7 @@ func serveFileNoCache(w http.ResponseWriter, r *http.Request, filepath string) {
 
 func (rs sceneRoutes) Webp(w http.ResponseWriter, r *http.Request) {
 	scene := r.Context().Value(sceneKey).(*models.Scene)
-	filepath := manager.GetInstance().Paths.Scene.GetWebpPreviewPath(scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()))
+	filepath := manager.GetInstance().Paths.ForPath(scene.Path).Scene.GetWebpPreviewPath(scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()))
 	http.ServeFile(w, r, filepath)
 }
 
@@ -341,7 +481,14 @@ func (rs sceneRoutes) Funscript(w http.ResponseWriter, r *http.Request) {
 func (rs sceneRoutes) InteractiveHeatmap(w http.ResponseWriter, r *http.Request) {
 	scene := r.Context().Value(sceneKey).(*models.Scene)
 	w.Header().Set("Content-Type", "image/png")
-	filepath := manager.GetInstance().Paths.Scene.GetInteractiveHeatmapPath(scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()))
+	filepath := manager.GetInstance().Paths.ForPath(scene.Path).Scene.GetInteractiveHeatmapPath(scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()))
+	http.ServeFile(w, r, filepath)
+}
+
+func (rs sceneRoutes) ContactSheet(w http.ResponseWriter, r *http.Request) {
+	scene := r.Context().Value(sceneKey).(*models.Scene)
+	w.Header().Set("Content-Type", "image/jpeg")
+	filepath := manager.GetInstance().Paths.ForPath(scene.Path).Scene.GetContactSheetPath(scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()))
 	http.ServeFile(w, r, filepath)
 }
 
@@ -408,14 +555,14 @@ func (rs sceneRoutes) CaptionLang(w http.ResponseWriter, r *http.Request) {
 func (rs sceneRoutes) VttThumbs(w http.ResponseWriter, r *http.Request) {
 	scene := r.Context().Value(sceneKey).(*models.Scene)
 	w.Header().Set("Content-Type", "text/vtt")
-	filepath := manager.GetInstance().Paths.Scene.GetSpriteVttFilePath(scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()))
+	filepath := manager.GetInstance().Paths.ForPath(scene.Path).Scene.GetSpriteVttFilePath(scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()))
 	http.ServeFile(w, r, filepath)
 }
 
 func (rs sceneRoutes) VttSprite(w http.ResponseWriter, r *http.Request) {
 	scene := r.Context().Value(sceneKey).(*models.Scene)
 	w.Header().Set("Content-Type", "image/jpeg")
-	filepath := manager.GetInstance().Paths.Scene.GetSpriteImageFilePath(scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()))
+	filepath := manager.GetInstance().Paths.ForPath(scene.Path).Scene.GetSpriteImageFilePath(scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()))
 	http.ServeFile(w, r, filepath)
 }
 
@@ -442,7 +589,7 @@ func (rs sceneRoutes) SceneMarkerStream(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	filepath := manager.GetInstance().Paths.SceneMarkers.GetVideoPreviewPath(scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()), int(sceneMarker.Seconds))
+	filepath := manager.GetInstance().Paths.ForPath(scene.Path).SceneMarkers.GetVideoPreviewPath(scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()), int(sceneMarker.Seconds))
 	http.ServeFile(w, r, filepath)
 }
 
@@ -469,7 +616,7 @@ func (rs sceneRoutes) SceneMarkerPreview(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	filepath := manager.GetInstance().Paths.SceneMarkers.GetWebpPreviewPath(scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()), int(sceneMarker.Seconds))
+	filepath := manager.GetInstance().Paths.ForPath(scene.Path).SceneMarkers.GetWebpPreviewPath(scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()), int(sceneMarker.Seconds))
 
 	// If the image doesn't exist, send the placeholder
 	exists, _ := fsutil.FileExists(filepath)
@@ -506,7 +653,7 @@ func (rs sceneRoutes) SceneMarkerScreenshot(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	filepath := manager.GetInstance().Paths.SceneMarkers.GetScreenshotPath(scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()), int(sceneMarker.Seconds))
+	filepath := manager.GetInstance().Paths.ForPath(scene.Path).SceneMarkers.GetScreenshotPath(scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()), int(sceneMarker.Seconds))
 
 	// If the image doesn't exist, send the placeholder
 	exists, _ := fsutil.FileExists(filepath)
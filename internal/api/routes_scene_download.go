@@ -0,0 +1,283 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/internal/api/urlbuilders"
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+const (
+	sceneDownloadFormatZip  = "zip"
+	sceneDownloadFormatM3U  = "m3u"
+	sceneDownloadFormatM3U8 = "m3u8"
+
+	sceneDownloadManifestName = "manifest.json"
+)
+
+type sceneDownloadEntry struct {
+	scene *models.Scene
+	file  *file.VideoFile
+}
+
+// sceneDownloadManifestEntry describes a scene file in a downloaded zip.
+type sceneDownloadManifestEntry struct {
+	SceneID      int     `json:"scene_id"`
+	Title        string  `json:"title"`
+	Path         string  `json:"path"`
+	OriginalPath string  `json:"original_path"`
+	Size         int64   `json:"size"`
+	Duration     float64 `json:"duration"`
+}
+
+// Download returns the primary files of the requested scenes. Scenes are
+// selected with the ids parameter, a comma-separated list of scene IDs, or
+// the filter parameter, a JSON-encoded SceneFilterType. The format parameter
+// is either zip (the default), which streams a zip of the files with a
+// manifest, or m3u/m3u8, which returns a playlist of stream URLs.
+func (rs sceneRoutes) Download(w http.ResponseWriter, r *http.Request) {
+	format := strings.ToLower(r.FormValue("format"))
+	if format == "" {
+		format = sceneDownloadFormatZip
+	}
+
+	switch format {
+	case sceneDownloadFormatZip, sceneDownloadFormatM3U, sceneDownloadFormatM3U8:
+	default:
+		http.Error(w, fmt.Sprintf("unsupported format %q", format), http.StatusBadRequest)
+		return
+	}
+
+	ids, sceneFilter, err := parseSceneDownloadSelection(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	entries, err := rs.findSceneDownloadEntries(r.Context(), ids, sceneFilter)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			logger.Errorf("error finding scenes to download: %v", err)
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	if format == sceneDownloadFormatZip {
+		rs.downloadZip(w, entries)
+	} else {
+		rs.downloadPlaylist(w, r, format, entries)
+	}
+}
+
+func parseSceneDownloadSelection(r *http.Request) ([]int, *models.SceneFilterType, error) {
+	if idsParam := r.FormValue("ids"); idsParam != "" {
+		var ids []int
+		for _, v := range strings.Split(idsParam, ",") {
+			id, err := strconv.Atoi(strings.TrimSpace(v))
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid scene id %q", v)
+			}
+			ids = append(ids, id)
+		}
+
+		return ids, nil, nil
+	}
+
+	if filterParam := r.FormValue("filter"); filterParam != "" {
+		var sceneFilter models.SceneFilterType
+		if err := json.Unmarshal([]byte(filterParam), &sceneFilter); err != nil {
+			return nil, nil, fmt.Errorf("invalid scene filter: %w", err)
+		}
+
+		return nil, &sceneFilter, nil
+	}
+
+	return nil, nil, errors.New("either ids or filter must be provided")
+}
+
+// findSceneDownloadEntries returns the scenes selected by ids or sceneFilter
+// with their primary files. Scenes without a primary file are skipped.
+func (rs sceneRoutes) findSceneDownloadEntries(ctx context.Context, ids []int, sceneFilter *models.SceneFilterType) ([]sceneDownloadEntry, error) {
+	var ret []sceneDownloadEntry
+
+	add := func(ctx context.Context, s *models.Scene) error {
+		if err := s.LoadPrimaryFile(ctx, rs.fileFinder); err != nil {
+			return err
+		}
+
+		if f := s.Files.Primary(); f != nil {
+			ret = append(ret, sceneDownloadEntry{
+				scene: s,
+				file:  f,
+			})
+		}
+
+		return nil
+	}
+
+	if err := txn.WithReadTxn(ctx, rs.txnManager, func(ctx context.Context) error {
+		if sceneFilter == nil {
+			scenes, err := rs.sceneFinder.FindMany(ctx, ids)
+			if err != nil {
+				return err
+			}
+
+			for _, s := range scenes {
+				if err := add(ctx, s); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+
+		return scene.BatchProcess(ctx, rs.sceneFinder, sceneFilter, nil, func(s *models.Scene) error {
+			return add(ctx, s)
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// downloadZip streams a zip of the scene files to w. The files are stored
+// without compression and copied directly to the response.
+func (rs sceneRoutes) downloadZip(w http.ResponseWriter, entries []sceneDownloadEntry) {
+	var totalSize int64
+	for _, e := range entries {
+		totalSize += e.file.Size
+	}
+
+	if maxSize := config.GetInstance().GetBulkDownloadMaxSize(); maxSize > 0 && totalSize > int64(maxSize)*1024*1024 {
+		msg := fmt.Sprintf("total size of %d MiB exceeds the maximum bulk download size of %d MiB", totalSize/(1024*1024), maxSize)
+		http.Error(w, msg, http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	manifest := make([]sceneDownloadManifestEntry, len(entries))
+	names := map[string]bool{
+		sceneDownloadManifestName: true,
+	}
+	for i, e := range entries {
+		name := e.file.Basename
+		if names[name] {
+			name = fmt.Sprintf("%d_%s", e.scene.ID, name)
+		}
+		names[name] = true
+
+		manifest[i] = sceneDownloadManifestEntry{
+			SceneID:      e.scene.ID,
+			Title:        e.scene.GetTitle(),
+			Path:         name,
+			OriginalPath: e.file.Path,
+			Size:         e.file.Size,
+			Duration:     e.file.Duration,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="scenes.zip"`)
+
+	zw := zip.NewWriter(w)
+
+	if err := writeSceneDownloadManifest(zw, manifest); err != nil {
+		logger.Errorf("error writing bulk download manifest: %v", err)
+		return
+	}
+
+	for i, e := range entries {
+		if err := writeSceneDownloadFile(zw, manifest[i].Path, e.file); err != nil {
+			// the response has already started, so the zip is left truncated
+			logger.Errorf("error writing %s to bulk download: %v", e.file.Path, err)
+			return
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		logger.Errorf("error finishing bulk download: %v", err)
+	}
+}
+
+func writeSceneDownloadManifest(zw *zip.Writer, manifest []sceneDownloadManifestEntry) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fw, err := zw.Create(sceneDownloadManifestName)
+	if err != nil {
+		return err
+	}
+
+	_, err = fw.Write(data)
+	return err
+}
+
+func writeSceneDownloadFile(zw *zip.Writer, name string, f *file.VideoFile) error {
+	rc, err := f.Open(&file.OsFS{})
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	// video files do not compress well, so store them as-is
+	fw, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Store,
+		Modified: f.ModTime,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(fw, rc)
+	return err
+}
+
+// downloadPlaylist writes an extended m3u playlist of the stream URLs of the
+// scenes. The URLs include the API key, if set, so that they can be played
+// outside of the browser.
+func (rs sceneRoutes) downloadPlaylist(w http.ResponseWriter, r *http.Request, format string, entries []sceneDownloadEntry) {
+	baseURL, _ := r.Context().Value(BaseURLCtxKey).(string)
+	apiKey := config.GetInstance().GetAPIKey()
+
+	var buf bytes.Buffer
+	buf.WriteString("#EXTM3U\n")
+	for _, e := range entries {
+		builder := urlbuilders.NewSceneURLBuilder(baseURL, e.scene.ID)
+		builder.APIKey = apiKey
+
+		// titles must be on a single line
+		title := strings.Join(strings.Fields(e.scene.GetTitle()), " ")
+
+		fmt.Fprintf(&buf, "#EXTINF:%d,%s\n%s\n", int(e.file.Duration), title, builder.GetStreamURL().String())
+	}
+
+	contentType := "audio/x-mpegurl"
+	if format == sceneDownloadFormatM3U8 {
+		contentType = "application/vnd.apple.mpegurl; charset=utf-8"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="scenes.%s"`, format))
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		logger.Warnf("error writing playlist: %v", err)
+	}
+}
@@ -0,0 +1,296 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// downloadFileFinder finds the files of downloaded scenes by id.
+type downloadFileFinder map[file.ID]*file.VideoFile
+
+func (f downloadFileFinder) Find(ctx context.Context, ids ...file.ID) ([]file.File, error) {
+	var ret []file.File
+	for _, id := range ids {
+		if vf, ok := f[id]; ok {
+			ret = append(ret, vf)
+		}
+	}
+	return ret, nil
+}
+
+type sceneDownloadTest struct {
+	routes  sceneRoutes
+	sceneRW *mocks.SceneReaderWriter
+	scenes  []*models.Scene
+	files   downloadFileFinder
+}
+
+// newSceneDownloadTest returns scene routes with a scene for each of the
+// provided file paths. The files are created in a temporary directory with
+// their path as the contents.
+func newSceneDownloadTest(t *testing.T, paths ...string) *sceneDownloadTest {
+	dir := t.TempDir()
+
+	ret := &sceneDownloadTest{
+		sceneRW: &mocks.SceneReaderWriter{},
+		files:   downloadFileFinder{},
+	}
+
+	for i, p := range paths {
+		path := filepath.Join(dir, p)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(p), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		id := i + 1
+		fileID := file.ID(id)
+		ret.files[fileID] = &file.VideoFile{
+			BaseFile: &file.BaseFile{
+				ID:       fileID,
+				Path:     path,
+				Basename: filepath.Base(path),
+				Size:     int64(len(p)),
+			},
+			Duration: float64(id * 60),
+		}
+		ret.scenes = append(ret.scenes, &models.Scene{
+			ID:            id,
+			Title:         "scene " + p,
+			PrimaryFileID: &fileID,
+		})
+	}
+
+	ret.routes = sceneRoutes{
+		txnManager:  &mocks.TxnManager{},
+		sceneFinder: ret.sceneRW,
+		fileFinder:  ret.files,
+	}
+
+	return ret
+}
+
+func (d *sceneDownloadTest) download(query url.Values) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, "/download?"+query.Encode(), nil)
+	r = r.WithContext(context.WithValue(r.Context(), BaseURLCtxKey, "http://stash"))
+	w := httptest.NewRecorder()
+	d.routes.Download(w, r)
+	return w
+}
+
+// readSceneDownloadZip returns the manifest and the contents of the other
+// files of a downloaded zip.
+func readSceneDownloadZip(t *testing.T, body []byte) ([]sceneDownloadManifestEntry, map[string]string) {
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		t.Fatalf("reading zip: %v", err)
+	}
+
+	var manifest []sceneDownloadManifestEntry
+	contents := make(map[string]string)
+	for _, zf := range zr.File {
+		rc, err := zf.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if zf.Name == sceneDownloadManifestName {
+			if err := json.Unmarshal(data, &manifest); err != nil {
+				t.Fatalf("reading manifest: %v", err)
+			}
+			continue
+		}
+
+		contents[zf.Name] = string(data)
+	}
+
+	return manifest, contents
+}
+
+// setTestConfig sets the config value for the duration of the test.
+func setTestConfig(t *testing.T, key string, value interface{}) {
+	c := config.GetInstance()
+	c.Set(key, value)
+	t.Cleanup(func() {
+		c.Set(key, nil)
+	})
+}
+
+func TestSceneDownload_badRequest(t *testing.T) {
+	tests := []struct {
+		name  string
+		query url.Values
+	}{
+		{"unsupported format", url.Values{"ids": {"1"}, "format": {"tar"}}},
+		{"no selection", url.Values{}},
+		{"invalid id", url.Values{"ids": {"1,a"}}},
+		{"invalid filter", url.Values{"filter": {"{"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newSceneDownloadTest(t)
+
+			w := d.download(tt.query)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			d.sceneRW.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSceneDownload_zip(t *testing.T) {
+	d := newSceneDownloadTest(t, "a/video.mp4", "b/video.mp4", "c/manifest.json", "other.mp4")
+
+	// ids are trimmed, and scenes are returned in the order found
+	d.sceneRW.On("FindMany", mock.Anything, []int{1, 2, 3, 4}).Return(d.scenes, nil).Once()
+
+	w := d.download(url.Values{"ids": {"1, 2,3,4"}, "format": {"ZIP"}})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="scenes.zip"`, w.Header().Get("Content-Disposition"))
+	d.sceneRW.AssertExpectations(t)
+
+	manifest, contents := readSceneDownloadZip(t, w.Body.Bytes())
+
+	// duplicate basenames and the manifest name are prefixed with the scene id
+	wantNames := []string{"video.mp4", "2_video.mp4", "3_manifest.json", "other.mp4"}
+	wantContents := map[string]string{
+		"video.mp4":       "a/video.mp4",
+		"2_video.mp4":     "b/video.mp4",
+		"3_manifest.json": "c/manifest.json",
+		"other.mp4":       "other.mp4",
+	}
+
+	var wantManifest []sceneDownloadManifestEntry
+	for i, s := range d.scenes {
+		f := d.files[*s.PrimaryFileID]
+		wantManifest = append(wantManifest, sceneDownloadManifestEntry{
+			SceneID:      s.ID,
+			Title:        s.Title,
+			Path:         wantNames[i],
+			OriginalPath: f.Path,
+			Size:         f.Size,
+			Duration:     f.Duration,
+		})
+	}
+
+	assert.Equal(t, wantManifest, manifest)
+	assert.Equal(t, wantContents, contents)
+}
+
+func TestSceneDownload_filter(t *testing.T) {
+	d := newSceneDownloadTest(t, "a.mp4", "b.mp4")
+
+	// scenes without a primary file are skipped
+	d.scenes[1].PrimaryFileID = nil
+
+	d.sceneRW.On("Query", mock.Anything, mock.MatchedBy(func(o models.SceneQueryOptions) bool {
+		return o.SceneFilter != nil && o.SceneFilter.Title != nil && o.SceneFilter.Title.Value == "a"
+	})).Return(mocks.SceneQueryResult(d.scenes, len(d.scenes)), nil).Once()
+
+	w := d.download(url.Values{"filter": {`{"title":{"value":"a","modifier":"EQUALS"}}`}})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	d.sceneRW.AssertExpectations(t)
+
+	manifest, contents := readSceneDownloadZip(t, w.Body.Bytes())
+
+	if assert.Len(t, manifest, 1) {
+		assert.Equal(t, 1, manifest[0].SceneID)
+	}
+	assert.Equal(t, map[string]string{"a.mp4": "a.mp4"}, contents)
+}
+
+func TestSceneDownload_maxSize(t *testing.T) {
+	const mib = 1024 * 1024
+
+	tests := []struct {
+		name     string
+		maxSize  int
+		fileSize int64
+		want     int
+	}{
+		{"unlimited", 0, 2 * mib, http.StatusOK},
+		{"within limit", 2, 2 * mib, http.StatusOK},
+		{"exceeds limit", 1, 2 * mib, http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setTestConfig(t, config.BulkDownloadMaxSize, tt.maxSize)
+
+			d := newSceneDownloadTest(t, "a.mp4", "b.mp4")
+			for _, f := range d.files {
+				f.Size = tt.fileSize / 2
+			}
+
+			d.sceneRW.On("FindMany", mock.Anything, []int{1, 2}).Return(d.scenes, nil).Once()
+
+			w := d.download(url.Values{"ids": {"1,2"}})
+
+			assert.Equal(t, tt.want, w.Code)
+			if tt.want == http.StatusRequestEntityTooLarge {
+				assert.NotEqual(t, "application/zip", w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestSceneDownload_playlist(t *testing.T) {
+	setTestConfig(t, config.ApiKey, "key")
+
+	tests := []struct {
+		format      string
+		contentType string
+	}{
+		{sceneDownloadFormatM3U, "audio/x-mpegurl"},
+		{sceneDownloadFormatM3U8, "application/vnd.apple.mpegurl; charset=utf-8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			d := newSceneDownloadTest(t, "a.mp4", "b.mp4")
+
+			// titles are written on a single line
+			d.scenes[1].Title = "multi\nline  title"
+
+			d.sceneRW.On("FindMany", mock.Anything, []int{1, 2}).Return(d.scenes, nil).Once()
+
+			w := d.download(url.Values{"ids": {"1,2"}, "format": {tt.format}})
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.contentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, `attachment; filename="scenes.`+tt.format+`"`, w.Header().Get("Content-Disposition"))
+
+			want := "#EXTM3U\n" +
+				"#EXTINF:60,scene a.mp4\nhttp://stash/scene/1/stream?apikey=key\n" +
+				"#EXTINF:120,multi line title\nhttp://stash/scene/2/stream?apikey=key\n"
+			assert.Equal(t, want, w.Body.String())
+		})
+	}
+}
@@ -138,3 +138,18 @@ func marshalScrapedMovie(content scraper.ScrapedContent) (*models.ScrapedMovie,
 
 	return m[0], nil
 }
+
+// marshalScrapedStudio will marshal a single scraped studio. If conversion
+// fails, an error is returned.
+func marshalScrapedStudio(content scraper.ScrapedContent) (*models.ScrapedStudio, error) {
+	switch s := content.(type) {
+	case nil:
+		return nil, nil
+	case *models.ScrapedStudio:
+		return s, nil
+	case models.ScrapedStudio:
+		return &s, nil
+	}
+
+	return nil, fmt.Errorf("%w: cannot turn ScrapedContent into ScrapedStudio", models.ErrConversion)
+}
@@ -16,6 +16,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	gqlHandler "github.com/99designs/gqlgen/graphql/handler"
 	gqlExtension "github.com/99designs/gqlgen/graphql/handler/extension"
 	gqlLru "github.com/99designs/gqlgen/graphql/handler/lru"
@@ -25,6 +26,7 @@ import (
 	"github.com/go-chi/chi/middleware"
 	"github.com/gorilla/websocket"
 	"github.com/vearutop/statigz"
+	"github.com/vektah/gqlparser/v2/ast"
 
 	"github.com/go-chi/httplog"
 	"github.com/rs/cors"
@@ -64,7 +66,7 @@ func Start() error {
 		r.Use(httplog.RequestLogger(httpLogger))
 	}
 	r.Use(SecurityHeadersMiddleware)
-	r.Use(middleware.DefaultCompress)
+	r.Use(compressMiddleware(c))
 	r.Use(middleware.StripSlashes)
 	r.Use(cors.AllowAll().Handler)
 	r.Use(BaseURLMiddleware)
@@ -97,6 +99,7 @@ func Start() error {
 		imageService:   imageService,
 		galleryService: galleryService,
 		hookExecutor:   pluginCache,
+		fieldCache:     newFieldCache(c),
 	}
 
 	gqlSrv := gqlHandler.New(NewExecutableSchema(Config{Resolvers: resolver}))
@@ -117,6 +120,16 @@ func Start() error {
 	})
 
 	gqlSrv.SetQueryCache(gqlLru.New(1000))
+	gqlSrv.AroundResponses(func(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+		ret := next(ctx)
+
+		// mutations may change cached field values
+		if op := graphql.GetOperationContext(ctx).Operation; op != nil && op.Operation == ast.Mutation {
+			resolver.fieldCache.clear()
+		}
+
+		return ret
+	})
 	gqlSrv.Use(gqlExtension.Introspection{})
 
 	gqlHandlerFunc := func(w http.ResponseWriter, r *http.Request) {
@@ -456,6 +469,42 @@ var (
 	BaseURLCtxKey = &contextKey{"BaseURL"}
 )
 
+// compressMiddleware gzip compresses text responses, such as GraphQL
+// responses, when enabled in the configuration and accepted by the client.
+// Media streams and images are not compressed, since their content types are
+// not compressible.
+func compressMiddleware(c *config.Instance) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		compressed := middleware.DefaultCompress(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !c.IsHTTPCompression() || !acceptsGzip(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// only gzip is supported, so don't let the compressor negotiate
+			// any other encoding
+			r = r.Clone(r.Context())
+			r.Header.Set("Accept-Encoding", "gzip")
+			compressed.ServeHTTP(w, r)
+		})
+	}
+}
+
+// acceptsGzip returns true if the Accept-Encoding header of the request
+// includes gzip.
+func acceptsGzip(r *http.Request) bool {
+	for _, v := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		encoding, _, _ := strings.Cut(v, ";")
+		if strings.EqualFold(strings.TrimSpace(encoding), "gzip") {
+			return true
+		}
+	}
+
+	return false
+}
+
 func SecurityHeadersMiddleware(next http.Handler) http.Handler {
 	fn := func(w http.ResponseWriter, r *http.Request) {
 		c := config.GetInstance()
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompressMiddleware(t *testing.T) {
+	handler := compressMiddleware(config.GetInstance())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", r.URL.Query().Get("type"))
+		fmt.Fprint(w, "response")
+	}))
+
+	tests := []struct {
+		name           string
+		disabled       bool
+		acceptEncoding string
+		contentType    string
+		want           string
+	}{
+		{"gzip", false, "gzip, deflate, br", "application/json", "gzip"},
+		{"gzip with quality", false, "br;q=1.0, GZIP;q=0.5", "application/json", "gzip"},
+		{"deflate only", false, "deflate", "application/json", ""},
+		{"brotli only", false, "br", "application/json", ""},
+		{"none", false, "", "application/json", ""},
+		{"media", false, "gzip", "video/mp4", ""},
+		{"disabled", true, "gzip", "application/json", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.disabled {
+				setTestConfig(t, config.HTTPCompression, false)
+			}
+
+			r := httptest.NewRequest(http.MethodGet, "/?type="+tt.contentType, nil)
+			r.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.want, w.Header().Get("Content-Encoding"))
+			if tt.want == "" {
+				assert.Equal(t, "response", w.Body.String())
+			}
+		})
+	}
+}
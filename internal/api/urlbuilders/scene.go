@@ -79,6 +79,10 @@ func (b SceneURLBuilder) GetCaptionURL() string {
 	return b.BaseURL + "/scene/" + b.SceneID + "/caption"
 }
 
+func (b SceneURLBuilder) GetContactSheetURL() string {
+	return b.BaseURL + "/scene/" + b.SceneID + "/contact_sheet"
+}
+
 func (b SceneURLBuilder) GetInteractiveHeatmapURL() string {
 	return b.BaseURL + "/scene/" + b.SceneID + "/interactive_heatmap"
 }
@@ -38,6 +38,18 @@ func getGalleryFileTagger(s *models.Gallery, cache *match.Cache) tagger {
 	}
 }
 
+// PrefetchGallery queries the performers, studio and tags matching the gallery's
+// path ahead of tagging, storing the results in the cache. Nil readers are
+// skipped. The studio is not queried if the gallery already has a studio.
+func PrefetchGallery(ctx context.Context, s *models.Gallery, performerReader match.PerformerAutoTagQueryer, studioReader match.StudioAutoTagQueryer, tagReader match.TagAutoTagQueryer, cache *match.Cache) error {
+	if s.StudioID != nil {
+		studioReader = nil
+	}
+
+	t := getGalleryFileTagger(s, cache)
+	return t.prefetch(ctx, performerReader, studioReader, tagReader)
+}
+
 // GalleryPerformers tags the provided gallery with performers whose name matches the gallery's path.
 func GalleryPerformers(ctx context.Context, s *models.Gallery, rw GalleryPerformerUpdater, performerReader match.PerformerAutoTagQueryer, cache *match.Cache) error {
 	t := getGalleryFileTagger(s, cache)
@@ -189,6 +189,7 @@ func TestGalleryTags(t *testing.T) {
 		mockTagReader.On("Query", testCtx, mock.Anything, mock.Anything).Return(nil, 0, nil)
 		mockTagReader.On("QueryForAutoTag", testCtx, mock.Anything).Return([]*models.Tag{&tag, &reversedTag}, nil).Once()
 		mockTagReader.On("GetAliases", testCtx, mock.Anything).Return([]string{}, nil).Maybe()
+		mockTagReader.On("GetAutoTagKeywords", testCtx, mock.Anything).Return([]string{}, nil).Maybe()
 
 		doTest(mockTagReader, mockGalleryReader, test)
 	}
@@ -206,6 +207,7 @@ func TestGalleryTags(t *testing.T) {
 			tagName,
 		}, nil).Once()
 		mockTagReader.On("GetAliases", testCtx, reversedTagID).Return([]string{}, nil).Once()
+		mockTagReader.On("GetAutoTagKeywords", testCtx, mock.Anything).Return([]string{}, nil).Maybe()
 
 		doTest(mockTagReader, mockGalleryReader, test)
 	}
@@ -29,6 +29,18 @@ func getImageFileTagger(s *models.Image, cache *match.Cache) tagger {
 	}
 }
 
+// PrefetchImage queries the performers, studio and tags matching the image's
+// path ahead of tagging, storing the results in the cache. Nil readers are
+// skipped. The studio is not queried if the image already has a studio.
+func PrefetchImage(ctx context.Context, s *models.Image, performerReader match.PerformerAutoTagQueryer, studioReader match.StudioAutoTagQueryer, tagReader match.TagAutoTagQueryer, cache *match.Cache) error {
+	if s.StudioID != nil {
+		studioReader = nil
+	}
+
+	t := getImageFileTagger(s, cache)
+	return t.prefetch(ctx, performerReader, studioReader, tagReader)
+}
+
 // ImagePerformers tags the provided image with performers whose name matches the image's path.
 func ImagePerformers(ctx context.Context, s *models.Image, rw ImagePerformerUpdater, performerReader match.PerformerAutoTagQueryer, cache *match.Cache) error {
 	t := getImageFileTagger(s, cache)
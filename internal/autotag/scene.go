@@ -29,6 +29,18 @@ func getSceneFileTagger(s *models.Scene, cache *match.Cache) tagger {
 	}
 }
 
+// PrefetchScene queries the performers, studio and tags matching the scene's
+// path ahead of tagging, storing the results in the cache. Nil readers are
+// skipped. The studio is not queried if the scene already has a studio.
+func PrefetchScene(ctx context.Context, s *models.Scene, performerReader match.PerformerAutoTagQueryer, studioReader match.StudioAutoTagQueryer, tagReader match.TagAutoTagQueryer, cache *match.Cache) error {
+	if s.StudioID != nil {
+		studioReader = nil
+	}
+
+	t := getSceneFileTagger(s, cache)
+	return t.prefetch(ctx, performerReader, studioReader, tagReader)
+}
+
 // ScenePerformers tags the provided scene with performers whose name matches the scene's path.
 func ScenePerformers(ctx context.Context, s *models.Scene, rw ScenePerformerUpdater, performerReader match.PerformerAutoTagQueryer, cache *match.Cache) error {
 	t := getSceneFileTagger(s, cache)
@@ -54,6 +54,16 @@ func (t *tagger) addLog(otherType, otherName string) {
 	logger.Infof("Added %s '%s' to %s '%s'", otherType, otherName, t.Type, t.Name)
 }
 
+// prefetch stores the performers, studio and tags matching the path in the
+// cache. Nil readers are skipped.
+func (t *tagger) prefetch(ctx context.Context, performerReader match.PerformerAutoTagQueryer, studioReader match.StudioAutoTagQueryer, tagReader match.TagAutoTagQueryer) error {
+	if t.cache == nil {
+		return nil
+	}
+
+	return t.cache.PrefetchPath(ctx, t.Path, t.trimExt, performerReader, studioReader, tagReader)
+}
+
 func (t *tagger) tagPerformers(ctx context.Context, performerReader match.PerformerAutoTagQueryer, addFunc addLinkFunc) error {
 	others, err := match.PathToPerformers(ctx, t.Path, performerReader, t.cache, t.trimExt)
 	if err != nil {
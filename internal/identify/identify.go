@@ -37,9 +37,32 @@ type SceneIdentifier struct {
 	Sources                     []ScraperSource
 	ScreenshotSetter            scene.ScreenshotSetter
 	SceneUpdatePostHookExecutor SceneUpdatePostHookExecutor
+
+	// AutoOrganize sets identified scenes as organized if all of the
+	// AutoOrganizeRequiredFields have a value after the scene is updated.
+	// Scenes are never set as not organized.
+	AutoOrganize               bool
+	AutoOrganizeRequiredFields []string
+
+	// SkipIdentified skips scenes which already have a stash id from one of
+	// the sources, or a value for all of SkipIdentifiedRequiredFields.
+	SkipIdentified               bool
+	SkipIdentifiedRequiredFields []string
 }
 
 func (t *SceneIdentifier) Identify(ctx context.Context, txnManager txn.Manager, scene *models.Scene) error {
+	if t.SkipIdentified {
+		identified, err := t.isIdentified(ctx, scene)
+		if err != nil {
+			return err
+		}
+
+		if identified {
+			logger.Debugf("Skipping already identified scene %s", scene.Path)
+			return nil
+		}
+	}
+
 	result, err := t.scrapeScene(ctx, scene)
 	if err != nil {
 		return err
@@ -187,9 +210,101 @@ func (t *SceneIdentifier) getSceneUpdater(ctx context.Context, s *models.Scene,
 		}
 	}
 
+	if t.AutoOrganize && !s.Organized && hasFields(s, ret.Partial, t.AutoOrganizeRequiredFields) {
+		logger.Infof("Setting %s as organized since all required fields are set", s.Path)
+		ret.Partial.Organized = models.NewOptionalBool(true)
+	}
+
 	return ret, nil
 }
 
+// isIdentified returns true if the scene has a stash id from one of the
+// sources, or a value for all of SkipIdentifiedRequiredFields.
+func (t *SceneIdentifier) isIdentified(ctx context.Context, s *models.Scene) (bool, error) {
+	if err := s.LoadStashIDs(ctx, t.SceneReaderUpdater); err != nil {
+		return false, err
+	}
+
+	for _, source := range t.Sources {
+		if source.RemoteSite == "" {
+			continue
+		}
+
+		for _, id := range s.StashIDs.List() {
+			if id.Endpoint == source.RemoteSite {
+				return true, nil
+			}
+		}
+	}
+
+	if len(t.SkipIdentifiedRequiredFields) == 0 {
+		return false, nil
+	}
+
+	if err := s.LoadPerformerIDs(ctx, t.SceneReaderUpdater); err != nil {
+		return false, err
+	}
+	if err := s.LoadTagIDs(ctx, t.SceneReaderUpdater); err != nil {
+		return false, err
+	}
+
+	return hasFields(s, models.ScenePartial{}, t.SkipIdentifiedRequiredFields), nil
+}
+
+// hasFields returns true if all of the fields have a value after applying
+// partial to the scene. The performer, tag and stash id relationships of
+// the scene must be loaded.
+func hasFields(s *models.Scene, partial models.ScenePartial, fields []string) bool {
+	hasString := func(v models.OptionalString, existing string) bool {
+		if v.Set {
+			return v.Value != ""
+		}
+		return existing != ""
+	}
+	hasIDs := func(v *models.UpdateIDs, existing []int) bool {
+		if v != nil {
+			return len(v.IDs) > 0
+		}
+		return len(existing) > 0
+	}
+
+	for _, f := range fields {
+		var has bool
+		switch f {
+		case "title":
+			has = hasString(partial.Title, s.Title)
+		case "code":
+			has = hasString(partial.Code, s.Code)
+		case "details":
+			has = hasString(partial.Details, s.Details)
+		case "director":
+			has = hasString(partial.Director, s.Director)
+		case "url":
+			has = hasString(partial.URL, s.URL)
+		case "date":
+			has = (partial.Date.Set && !partial.Date.Null) || s.Date != nil
+		case "studio":
+			has = (partial.StudioID.Set && !partial.StudioID.Null) || s.StudioID != nil
+		case "performers":
+			has = hasIDs(partial.PerformerIDs, s.PerformerIDs.List())
+		case "tags":
+			has = hasIDs(partial.TagIDs, s.TagIDs.List())
+		case "stash_ids":
+			if partial.StashIDs != nil {
+				has = len(partial.StashIDs.StashIDs) > 0
+			} else {
+				has = len(s.StashIDs.List()) > 0
+			}
+		}
+
+		if !has {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (t *SceneIdentifier) modifyScene(ctx context.Context, txnManager txn.Manager, s *models.Scene, result *scrapeResult) error {
 	var updater *scene.UpdateSet
 	if err := txn.WithTxn(ctx, txnManager, func(ctx context.Context) error {
@@ -179,6 +179,81 @@ func TestSceneIdentifier_modifyScene(t *testing.T) {
 	}
 }
 
+func TestSceneIdentifier_isIdentified(t *testing.T) {
+	const endpoint = "endpoint"
+
+	scene := func(title string, stashIDs []models.StashID) *models.Scene {
+		return &models.Scene{
+			Title:        title,
+			PerformerIDs: models.NewRelatedIDs([]int{}),
+			TagIDs:       models.NewRelatedIDs([]int{}),
+			StashIDs:     models.NewRelatedStashIDs(stashIDs),
+		}
+	}
+
+	sources := []ScraperSource{
+		{Name: "scraper"},
+		{Name: "stash-box", RemoteSite: endpoint},
+	}
+
+	tests := []struct {
+		name           string
+		scene          *models.Scene
+		requiredFields []string
+		want           bool
+	}{
+		{
+			"no stash ids",
+			scene("title", []models.StashID{}),
+			nil,
+			false,
+		},
+		{
+			"stash id from source",
+			scene("", []models.StashID{{Endpoint: endpoint, StashID: "id"}}),
+			nil,
+			true,
+		},
+		{
+			"stash id from other endpoint",
+			scene("", []models.StashID{{Endpoint: "other", StashID: "id"}}),
+			nil,
+			false,
+		},
+		{
+			"has required fields",
+			scene("title", []models.StashID{}),
+			[]string{"title"},
+			true,
+		},
+		{
+			"missing required fields",
+			scene("title", []models.StashID{}),
+			[]string{"title", "performers"},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identifier := SceneIdentifier{
+				Sources:                      sources,
+				SkipIdentified:               true,
+				SkipIdentifiedRequiredFields: tt.requiredFields,
+			}
+
+			got, err := identifier.isIdentified(testCtx, tt.scene)
+			if err != nil {
+				t.Errorf("SceneIdentifier.isIdentified() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("SceneIdentifier.isIdentified() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_getFieldOptions(t *testing.T) {
 	const (
 		inFirst  = "inFirst"
@@ -511,3 +586,77 @@ func Test_shouldSetSingleValueField(t *testing.T) {
 		})
 	}
 }
+
+func Test_hasFields(t *testing.T) {
+	studioID := 1
+	scene := func(title string, studioID *int, performerIDs []int) *models.Scene {
+		return &models.Scene{
+			Title:        title,
+			StudioID:     studioID,
+			PerformerIDs: models.NewRelatedIDs(performerIDs),
+			TagIDs:       models.NewRelatedIDs([]int{}),
+			StashIDs:     models.NewRelatedStashIDs([]models.StashID{}),
+		}
+	}
+	fields := []string{"title", "studio", "performers"}
+
+	tests := []struct {
+		name    string
+		scene   *models.Scene
+		partial models.ScenePartial
+		fields  []string
+		want    bool
+	}{
+		{
+			"no fields",
+			scene("", nil, []int{}),
+			models.ScenePartial{},
+			nil,
+			true,
+		},
+		{
+			"existing values",
+			scene("title", &studioID, []int{1}),
+			models.ScenePartial{},
+			fields,
+			true,
+		},
+		{
+			"missing existing value",
+			scene("title", nil, []int{1}),
+			models.ScenePartial{},
+			fields,
+			false,
+		},
+		{
+			"updated values",
+			scene("", nil, []int{}),
+			models.ScenePartial{
+				Title:    models.NewOptionalString("title"),
+				StudioID: models.NewOptionalInt(studioID),
+				PerformerIDs: &models.UpdateIDs{
+					IDs:  []int{1},
+					Mode: models.RelationshipUpdateModeSet,
+				},
+			},
+			fields,
+			true,
+		},
+		{
+			"updated empty value",
+			scene("title", &studioID, []int{1}),
+			models.ScenePartial{
+				Title: models.NewOptionalString(""),
+			},
+			fields,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasFields(tt.scene, tt.partial, tt.fields); got != tt.want {
+				t.Errorf("hasFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -23,6 +23,12 @@ type Options struct {
 	SceneIDs []string `json:"sceneIDs"`
 	// paths of scenes to identify - ignored if scene ids are set
 	Paths []string `json:"paths"`
+	// skip scenes that already have a stash id from one of the stash-box
+	// sources, or a value for all of SkipIdentifiedRequiredFields
+	SkipIdentified *bool `json:"skipIdentified"`
+	// fields that identify scenes as already identified when they all have
+	// a value. If empty, only stash ids are checked.
+	SkipIdentifiedRequiredFields []string `json:"skipIdentifiedRequiredFields"`
 }
 
 type MetadataOptions struct {
@@ -35,6 +41,21 @@ type MetadataOptions struct {
 	IncludeMalePerformers *bool `json:"includeMalePerformers"`
 }
 
+// AutoOrganizeFields are the fields that may be required to have a value
+// for identified scenes to be set as organized.
+var AutoOrganizeFields = []string{
+	"title",
+	"code",
+	"details",
+	"director",
+	"url",
+	"date",
+	"studio",
+	"performers",
+	"tags",
+	"stash_ids",
+}
+
 type FieldOptions struct {
 	Field    string        `json:"field"`
 	Strategy FieldStrategy `json:"strategy"`
@@ -2,6 +2,7 @@ package log
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"sync"
@@ -39,9 +40,10 @@ func NewLogger() *Logger {
 	return ret
 }
 
-// Init initialises the logger based on a logging configuration
-func (log *Logger) Init(logFile string, logOut bool, logLevel string) {
-	var file *os.File
+// Init initialises the logger based on a logging configuration. The log
+// file is rotated if rotation is enabled in the provided options.
+func (log *Logger) Init(logFile string, logOut bool, logLevel string, rotate RotateOptions) {
+	var file io.Writer
 	customFormatter := new(logrus.TextFormatter)
 	customFormatter.TimestampFormat = "2006-01-02 15:04:05"
 	customFormatter.ForceColors = true
@@ -58,9 +60,14 @@ func (log *Logger) Init(logFile string, logOut bool, logLevel string) {
 
 	if logFile != "" {
 		var err error
-		file, err = os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if rotate.enabled() {
+			file, err = newRotatingFile(logFile, rotate)
+		} else {
+			file, err = os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		}
 
 		if err != nil {
+			file = nil
 			fmt.Printf("Could not open '%s' for log output due to error: %s\n", logFile, err.Error())
 		}
 	}
@@ -0,0 +1,266 @@
+package log
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	rotateTimeFormat = "20060102-150405.000"
+
+	compressedExt = ".gz"
+	partialExt    = ".tmp"
+)
+
+// RotateOptions configures the rotation of the log file.
+type RotateOptions struct {
+	// MaxSize is the size in bytes after which the log file is rotated.
+	// 0 disables size-based rotation.
+	MaxSize int64
+	// Interval is the time after which the log file is rotated.
+	// 0 disables time-based rotation.
+	Interval time.Duration
+	// MaxBackups is the number of rotated log files to retain.
+	// 0 retains all rotated files.
+	MaxBackups int
+}
+
+func (o RotateOptions) enabled() bool {
+	return o.MaxSize > 0 || o.Interval > 0
+}
+
+// rotatingFile is a log file writer that rotates the file based on its
+// size or age. Rotated files are compressed with gzip.
+type rotatingFile struct {
+	path    string
+	options RotateOptions
+
+	mutex    sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+
+	// compressMutex serialises the compression and pruning of backups,
+	// which is performed without holding mutex.
+	compressMutex sync.Mutex
+
+	now func() time.Time
+}
+
+func newRotatingFile(path string, options RotateOptions) (*rotatingFile, error) {
+	ret := &rotatingFile{
+		path:    path,
+		options: options,
+		now:     time.Now,
+	}
+
+	if err := ret.open(); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = f.now()
+	return nil
+}
+
+func (f *rotatingFile) shouldRotate(n int) bool {
+	if f.size == 0 {
+		return false
+	}
+
+	if f.options.MaxSize > 0 && f.size+int64(n) > f.options.MaxSize {
+		return true
+	}
+
+	return f.options.Interval > 0 && f.now().Sub(f.openedAt) >= f.options.Interval
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	n, rotated, err := f.write(p)
+
+	// compress after releasing the lock, so that writes are not blocked
+	if rotated {
+		f.compressBackups()
+	}
+
+	return n, err
+}
+
+// write writes p to the log file, rotating it first if required. Returns
+// true if the file was rotated.
+func (f *rotatingFile) write(p []byte) (n int, rotated bool, err error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.shouldRotate(len(p)) {
+		if err := f.rotate(); err != nil {
+			// can't use the logger here. Continue writing to the current file
+			fmt.Fprintf(os.Stderr, "Could not rotate log file '%s': %v\n", f.path, err)
+		} else {
+			rotated = true
+		}
+	}
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, rotated, err
+		}
+	}
+
+	n, err = f.file.Write(p)
+	f.size += int64(n)
+	return n, rotated, err
+}
+
+// rotate moves the current log file to an uncompressed backup and opens a
+// new log file. The backup is compressed by compressBackups.
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	f.file = nil
+
+	backup := f.path + "." + f.now().Format(rotateTimeFormat)
+	if err := os.Rename(f.path, backup); err != nil {
+		return err
+	}
+
+	return f.open()
+}
+
+// compressBackups compresses the uncompressed backups, including those left
+// by a previous failure, removes partially compressed files and removes the
+// backups exceeding the maximum.
+func (f *rotatingFile) compressBackups() {
+	f.compressMutex.Lock()
+	defer f.compressMutex.Unlock()
+
+	matches, err := filepath.Glob(f.path + ".*")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not find log file backups of '%s': %v\n", f.path, err)
+		return
+	}
+
+	for _, m := range matches {
+		ts := strings.TrimPrefix(m, f.path+".")
+		switch {
+		case strings.HasSuffix(ts, compressedExt+partialExt):
+			if _, err := time.Parse(rotateTimeFormat, strings.TrimSuffix(ts, compressedExt+partialExt)); err == nil {
+				os.Remove(m)
+			}
+		case !strings.HasSuffix(ts, compressedExt):
+			if _, err := time.Parse(rotateTimeFormat, ts); err != nil {
+				// not a backup
+				continue
+			}
+
+			if err := compressFile(m); err != nil {
+				fmt.Fprintf(os.Stderr, "Could not compress log file backup '%s': %v\n", m, err)
+			}
+		}
+	}
+
+	if err := f.prune(); err != nil {
+		fmt.Fprintf(os.Stderr, "Could not remove old log file backups of '%s': %v\n", f.path, err)
+	}
+}
+
+func (f *rotatingFile) backups() ([]string, error) {
+	ret, err := filepath.Glob(f.path + ".*" + compressedExt)
+	if err != nil {
+		return nil, err
+	}
+
+	// timestamps sort in chronological order
+	sort.Strings(ret)
+	return ret, nil
+}
+
+// prune removes the oldest backups exceeding MaxBackups.
+func (f *rotatingFile) prune() error {
+	if f.options.MaxBackups <= 0 {
+		return nil
+	}
+
+	backups, err := f.backups()
+	if err != nil {
+		return err
+	}
+
+	for len(backups) > f.options.MaxBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+
+	return nil
+}
+
+// compressFile compresses the file at path to path.gz and removes the
+// original file. The compressed file is written to a temporary file first,
+// so that a partially compressed file is never treated as a backup.
+func compressFile(path string) (err error) {
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	dest := path + compressedExt
+	tmp := dest + partialExt
+	out, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			out.Close()
+			os.Remove(tmp)
+		}
+	}()
+
+	w := gzip.NewWriter(out)
+	if _, err = io.Copy(w, in); err != nil {
+		return err
+	}
+
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	if err = out.Close(); err != nil {
+		return err
+	}
+
+	if err = os.Rename(tmp, dest); err != nil {
+		return err
+	}
+
+	in.Close()
+	return os.Remove(path)
+}
@@ -0,0 +1,170 @@
+package log
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func readGzip(t *testing.T, path string) string {
+	t.Helper()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(data)
+}
+
+func TestRotatingFileSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stash.log")
+
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	f, err := newRotatingFile(path, RotateOptions{
+		MaxSize:    10,
+		MaxBackups: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+	defer f.file.Close()
+
+	for _, line := range []string{"line 1\n", "line 2\n", "line 3\n", "line 4\n"} {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "line 4\n", string(data))
+
+	backups, err := f.backups()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the backup containing line 1 is removed
+	if assert.Len(t, backups, 2) {
+		assert.Equal(t, "line 2\n", readGzip(t, backups[0]))
+		assert.Equal(t, "line 3\n", readGzip(t, backups[1]))
+	}
+}
+
+func TestRotatingFileInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stash.log")
+
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	f, err := newRotatingFile(path, RotateOptions{
+		Interval: time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.now = func() time.Time { return now }
+	f.openedAt = now
+	defer f.file.Close()
+
+	if _, err := f.Write([]byte("first\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	now = now.Add(30 * time.Minute)
+	if _, err := f.Write([]byte("second\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	now = now.Add(30 * time.Minute)
+	if _, err := f.Write([]byte("third\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "third\n", string(data))
+
+	backups, err := f.backups()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, backups, 1) {
+		assert.Equal(t, "first\nsecond\n", readGzip(t, backups[0]))
+	}
+}
+
+func TestRotatingFileCompressBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "stash.log")
+
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	write := func(name string, data string) string {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	// left by a failed compression of an earlier rotation
+	failed := write("stash.log."+now.Add(-time.Hour).Format(rotateTimeFormat), "failed\n")
+	partial := write("stash.log."+now.Add(-time.Hour).Format(rotateTimeFormat)+compressedExt+partialExt, "partial")
+	// not a backup
+	other := write("stash.log.old", "other\n")
+
+	f, err := newRotatingFile(path, RotateOptions{
+		MaxSize: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.now = func() time.Time { return now }
+	defer f.file.Close()
+
+	for _, line := range []string{"line 1\n", "line 2\n"} {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	backups, err := f.backups()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, backups, 2) {
+		assert.Equal(t, failed+compressedExt, backups[0])
+		assert.Equal(t, "failed\n", readGzip(t, backups[0]))
+		assert.Equal(t, "line 1\n", readGzip(t, backups[1]))
+	}
+
+	assert.NoFileExists(t, failed)
+	assert.NoFileExists(t, partial)
+	assert.FileExists(t, other)
+}
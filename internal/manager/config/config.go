@@ -49,6 +49,107 @@ const (
 	GalleryExtensions          = "gallery_extensions"
 	CreateGalleriesFromFolders = "create_galleries_from_folders"
 
+	// GalleryCoverMinResolution is the config key for the minimum
+	// resolution of images that are automatically selected as gallery
+	// covers.
+	GalleryCoverMinResolution = "gallery_cover_min_resolution"
+
+	// GalleryImageNaturalSort is the config key for sorting gallery images
+	// by path in natural order, so that numbers in filenames are sorted
+	// numerically.
+	GalleryImageNaturalSort = "gallery_image_natural_sort"
+
+	// SyncLinkedDatesOnScan is the config key for setting the date of a
+	// gallery or its associated scene from the other during scanning.
+	SyncLinkedDatesOnScan = "sync_linked_dates_on_scan"
+
+	// LinkFolderGalleriesOnScan is the config key for linking scenes to
+	// the galleries in the same folder during scanning.
+	LinkFolderGalleriesOnScan = "link_folder_galleries_on_scan"
+
+	// LinkFolderGalleriesMatchStem is the config key for only linking
+	// scenes and galleries in the same folder if their names match.
+	LinkFolderGalleriesMatchStem = "link_folder_galleries_match_stem"
+
+	// PrimaryFilePolicy is the config key for the policy used to select
+	// the primary file of scenes with multiple files.
+	PrimaryFilePolicy = "primary_file_policy"
+
+	// PrimaryFilePathPriority is the config key for the list of paths used
+	// by the PATH_PRIORITY primary file policy.
+	PrimaryFilePathPriority = "primary_file_path_priority"
+
+	// PrimaryFileExtensionPriority is the config key for the list of file
+	// extensions used to choose between files that are equal under the
+	// primary file policy.
+	PrimaryFileExtensionPriority = "primary_file_extension_priority"
+
+	// IdentifyAutoOrganize is the config key for setting identified scenes
+	// as organized once all of the IdentifyAutoOrganizeRequiredFields have
+	// a value.
+	IdentifyAutoOrganize = "identify_auto_organize"
+
+	// IdentifyAutoOrganizeRequiredFields is the config key for the scene
+	// fields required to have a value for IdentifyAutoOrganize.
+	IdentifyAutoOrganizeRequiredFields = "identify_auto_organize_required_fields"
+
+	// NestedZipMaxDepth is the config key for the maximum depth of zip
+	// files inside zip files whose contents are scanned.
+	NestedZipMaxDepth = "nested_zip_max_depth"
+	// ZipMaxEntries is the config key for the maximum number of entries
+	// scanned in a zip file, including nested zip files.
+	ZipMaxEntries = "zip_max_entries"
+
+	// StudioFromMetadataTag is the config key for the name of the container
+	// metadata tag used to set the studio of scenes during scan.
+	StudioFromMetadataTag = "studio_from_metadata_tag"
+
+	// StudioFromMetadataTagPattern is the config key for the regular
+	// expression used to extract the studio name from the metadata tag value.
+	StudioFromMetadataTagPattern = "studio_from_metadata_tag_pattern"
+
+	// ScanProtectedFields is the config key for the list of scene fields
+	// that are never set when scanning, even if they are empty.
+	ScanProtectedFields = "scan_protected_fields"
+
+	// ScanPathTagRules is the config key for the rules mapping the
+	// directory names of scanned files to tags.
+	ScanPathTagRules = "scan_path_tag_rules"
+
+	// RegenerateChangedFiles is the config key used to determine if the
+	// generated files of scenes are regenerated when the contents of their
+	// primary file change.
+	RegenerateChangedFiles = "regenerate_changed_files"
+
+	// DuplicatePhashDistance is the config key for the maximum phash
+	// distance between scenes in the stored duplicate groups.
+	DuplicatePhashDistance = "duplicate_phash_distance"
+
+	// DuplicateDurationTolerance is the config key for the maximum
+	// difference in seconds between the durations of scenes in the stored
+	// duplicate groups.
+	DuplicateDurationTolerance = "duplicate_duration_tolerance"
+
+	// ResolutionLabelBreakpoints is the config key for the minimum size of
+	// the shorter side of HD, full HD, 4K and 8K media.
+	ResolutionLabelBreakpoints = "resolution_label_breakpoints"
+
+	// SceneDateFloorYear is the config key for the earliest plausible year
+	// of scene dates.
+	SceneDateFloorYear = "scene_date_floor_year"
+
+	// SceneDateModTimeTolerance is the config key for the maximum number of
+	// days between a scene date and the modification time of its file.
+	SceneDateModTimeTolerance = "scene_date_mod_time_tolerance"
+
+	// BulkDownloadMaxSize is the config key for the maximum total size in
+	// MiB of scene files downloaded together as a zip.
+	BulkDownloadMaxSize = "bulk_download_max_size"
+
+	// GenerateLoudness is the config key used to determine if the loudness
+	// of scene audio is measured during generate.
+	GenerateLoudness = "generate_loudness"
+
 	// CalculateMD5 is the config key used to determine if MD5 should be calculated
 	// for video files.
 	CalculateMD5 = "calculate_md5"
@@ -60,9 +161,34 @@ const (
 	MaxTranscodeSize          = "max_transcode_size"
 	MaxStreamingTranscodeSize = "max_streaming_transcode_size"
 
+	// StreamingTranscodeSizeNetworks is the config key for the maximum
+	// streaming transcode resolution by client network.
+	StreamingTranscodeSizeNetworks = "streaming_transcode_size_networks"
+
+	// HLSSegmentDuration is the config key for the duration in seconds of
+	// the segments of HLS streams.
+	HLSSegmentDuration        = "hls_segment_duration"
+	hlsSegmentDurationDefault = 10
+
 	ParallelTasks        = "parallel_tasks"
 	parallelTasksDefault = 1
 
+	// AutoTagParallelTasks is the config key for the number of workers
+	// used to match files when auto-tagging. 0 means auto-detect.
+	AutoTagParallelTasks        = "autotag_parallel_tasks"
+	autoTagParallelTasksDefault = 1
+
+	// HashWorkers is the config key for the maximum number of files that
+	// are hashed concurrently during scan. 0 means the number of parallel
+	// scan tasks is used.
+	HashWorkers        = "hash_workers"
+	hashWorkersDefault = 0
+
+	// GalleryParallelTasks is the config key for the number of gallery zip
+	// files that are scanned concurrently, separately from other files. 0
+	// means zip files share the scan workers with other files.
+	GalleryParallelTasks = "gallery_parallel_tasks"
+
 	PreviewPreset = "preview_preset"
 
 	PreviewAudio        = "preview_audio"
@@ -80,9 +206,27 @@ const (
 	PreviewExcludeEnd        = "preview_exclude_end"
 	previewExcludeEndDefault = "0"
 
+	// CoverAvoidBlankFrames is the config key used to determine if
+	// generated scene covers should avoid black or uniform frames.
+	CoverAvoidBlankFrames        = "cover_avoid_blank_frames"
+	coverAvoidBlankFramesDefault = false
+
 	WriteImageThumbnails        = "write_image_thumbnails"
 	writeImageThumbnailsDefault = true
 
+	// MaxImageDimension is the config key for the maximum width or height
+	// of images that are decoded for thumbnails. 0 means no limit.
+	MaxImageDimension = "max_image_dimension"
+
+	// ResolverCacheFields is the config key for the GraphQL fields whose
+	// values are cached in memory, in the form Type.field.
+	ResolverCacheFields = "resolver_cache_fields"
+
+	// ResolverCacheTTL is the config key for the number of seconds that
+	// cached GraphQL field values are kept.
+	ResolverCacheTTL        = "resolver_cache_ttl"
+	resolverCacheTTLDefault = 30
+
 	Host        = "host"
 	hostDefault = "0.0.0.0"
 
@@ -91,6 +235,11 @@ const (
 
 	ExternalHost = "external_host"
 
+	// HTTPCompression is the config key for gzip compressing text responses,
+	// such as GraphQL responses, for clients that support it.
+	HTTPCompression        = "http_compression"
+	httpCompressionDefault = true
+
 	// key used to sign JWT tokens
 	JWTSignKey = "jwt_secret_key"
 
@@ -104,9 +253,22 @@ const (
 	ScraperCDPPath            = "scraper_cdp_path"
 	ScraperExcludeTagPatterns = "scraper_exclude_tag_patterns"
 
+	// ScraperDetailsTemplate is the config key for the Go template used to
+	// generate scraped scene details. Empty to disable.
+	ScraperDetailsTemplate      = "scraper_details_template"
+	ScraperDetailsTemplateForce = "scraper_details_template_force"
+
+	// ScraperSanitizeOutput is the config key used to determine if html is
+	// stripped and whitespace normalized in scraped titles and details.
+	ScraperSanitizeOutput = "scraper_sanitize_output"
+
 	// stash-box options
 	StashBoxes = "stash_boxes"
 
+	// StashBoxSubmitOrganizedOnly is the config key used to determine if
+	// only the fingerprints of organized scenes are submitted to stash-box.
+	StashBoxSubmitOrganizedOnly = "stash_box_submit_organized_only"
+
 	PythonPath = "python_path"
 
 	// plugin options
@@ -126,6 +288,10 @@ const (
 	// Interface options
 	MenuItems = "menu_items"
 
+	// SceneTitleFallback is the config key for the ordered list of formats
+	// used to display scenes without a title.
+	SceneTitleFallback = "scene_title_fallback"
+
 	SoundOnPreview = "sound_on_preview"
 
 	WallShowTitle        = "wall_show_title"
@@ -138,6 +304,8 @@ const (
 	autostartVideoOnPlaySelectedDefault = true
 	ContinuePlaylistDefault             = "continue_playlist_default"
 	ShowStudioAsText                    = "show_studio_as_text"
+	StudioImageInheritance              = "studio_image_inheritance"
+	PerformerImageSceneFallback         = "performer_image_scene_fallback"
 	CSSEnabled                          = "cssEnabled"
 	JavascriptEnabled                   = "javascriptEnabled"
 	CustomLocalesEnabled                = "customLocalesEnabled"
@@ -192,6 +360,17 @@ const (
 	LogAccess        = "logAccess"
 	defaultLogAccess = true
 
+	// LogMaxSize is the config key for the size in megabytes after which the
+	// log file is rotated.
+	LogMaxSize = "logMaxSize"
+	// LogRotateInterval is the config key for the number of hours after
+	// which the log file is rotated.
+	LogRotateInterval = "logRotateInterval"
+	// LogMaxBackups is the config key for the number of rotated log files
+	// to retain.
+	LogMaxBackups        = "logMaxBackups"
+	defaultLogMaxBackups = 5
+
 	// Default settings
 	DefaultScanSettings     = "defaults.scan_task"
 	DefaultIdentifySettings = "defaults.identify_task"
@@ -220,6 +399,10 @@ var (
 	defaultImageExtensions   = []string{"png", "jpg", "jpeg", "gif", "webp"}
 	defaultGalleryExtensions = []string{"zip", "cbz"}
 	defaultMenuItems         = []string{"scenes", "images", "movies", "markers", "galleries", "performers", "studios", "tags"}
+
+	defaultSceneTitleFallback = []string{"{basename}"}
+
+	defaultIdentifyAutoOrganizeRequiredFields = []string{"title", "date", "studio", "performers"}
 )
 
 type MissingConfigError struct {
@@ -481,6 +664,8 @@ type StashConfig struct {
 	Path         string `json:"path"`
 	ExcludeVideo bool   `json:"excludeVideo"`
 	ExcludeImage bool   `json:"excludeImage"`
+	// GeneratedPath overrides the generated path for files in Path if set
+	GeneratedPath string `json:"generatedPath"`
 }
 
 // Stash configuration details
@@ -488,6 +673,8 @@ type StashConfigInput struct {
 	Path         string `json:"path"`
 	ExcludeVideo bool   `json:"excludeVideo"`
 	ExcludeImage bool   `json:"excludeImage"`
+	// GeneratedPath overrides the generated path for files in Path if set
+	GeneratedPath string `json:"generatedPath"`
 }
 
 // GetStathPaths returns the configured stash library paths.
@@ -599,6 +786,213 @@ func (i *Instance) GetCreateGalleriesFromFolders() bool {
 	return i.getBool(CreateGalleriesFromFolders)
 }
 
+// GetGalleryImageNaturalSort returns true if gallery images are sorted by
+// path in natural order, and the contents of zip files are scanned in
+// natural order. Defaults to true.
+func (i *Instance) GetGalleryImageNaturalSort() bool {
+	return i.getBoolDefault(GalleryImageNaturalSort, true)
+}
+
+// GetSyncLinkedDatesOnScan returns true if the date of a gallery or a scene
+// associated with it during scanning is set from the other when only one of
+// them has a date.
+func (i *Instance) GetSyncLinkedDatesOnScan() bool {
+	return i.getBool(SyncLinkedDatesOnScan)
+}
+
+// GetLinkFolderGalleriesOnScan returns true if scenes are linked to the
+// galleries in the same folder during scanning.
+func (i *Instance) GetLinkFolderGalleriesOnScan() bool {
+	return i.getBool(LinkFolderGalleriesOnScan)
+}
+
+// GetLinkFolderGalleriesMatchStem returns true if scenes are only linked to
+// galleries in the same folder when their names, without extension, match.
+func (i *Instance) GetLinkFolderGalleriesMatchStem() bool {
+	return i.getBool(LinkFolderGalleriesMatchStem)
+}
+
+// GetGalleryCoverMinResolution returns the minimum resolution of images
+// that are automatically selected as gallery covers. Returns nil if any
+// image may be selected.
+func (i *Instance) GetGalleryCoverMinResolution() *models.ResolutionEnum {
+	ret := models.ResolutionEnum(i.getString(GalleryCoverMinResolution))
+	if !ret.IsValid() {
+		return nil
+	}
+
+	return &ret
+}
+
+// GetPrimaryFilePolicy returns the policy used to select the primary file
+// of scenes with multiple files. Defaults to keeping the existing primary
+// file.
+func (i *Instance) GetPrimaryFilePolicy() models.PrimaryFilePolicy {
+	ret := models.PrimaryFilePolicy(i.getString(PrimaryFilePolicy))
+	if !ret.IsValid() {
+		return models.PrimaryFilePolicyExisting
+	}
+
+	return ret
+}
+
+// GetPrimaryFilePathPriority returns the paths used by the PATH_PRIORITY
+// primary file policy, in order of priority.
+func (i *Instance) GetPrimaryFilePathPriority() []string {
+	return i.getStringSlice(PrimaryFilePathPriority)
+}
+
+// GetPrimaryFileExtensionPriority returns the file extensions used to
+// choose between files that are equal under the primary file policy, in
+// order of priority.
+func (i *Instance) GetPrimaryFileExtensionPriority() []string {
+	return i.getStringSlice(PrimaryFileExtensionPriority)
+}
+
+// GetIdentifyAutoOrganize returns true if the identify task should set
+// scenes as organized once all of the required fields have a value. This is
+// opt-in and defaults to false.
+func (i *Instance) GetIdentifyAutoOrganize() bool {
+	return i.getBool(IdentifyAutoOrganize)
+}
+
+// GetIdentifyAutoOrganizeRequiredFields returns the scene fields that must
+// have a value for the identify task to set a scene as organized.
+func (i *Instance) GetIdentifyAutoOrganizeRequiredFields() []string {
+	i.RLock()
+	defer i.RUnlock()
+	v := i.viper(IdentifyAutoOrganizeRequiredFields)
+	if v.IsSet(IdentifyAutoOrganizeRequiredFields) {
+		return v.GetStringSlice(IdentifyAutoOrganizeRequiredFields)
+	}
+	return defaultIdentifyAutoOrganizeRequiredFields
+}
+
+// GetNestedZipMaxDepth returns the maximum depth of zip files inside zip
+// files whose contents are scanned. Returns 0 if the contents of nested zip
+// files should not be scanned.
+func (i *Instance) GetNestedZipMaxDepth() int {
+	return i.getInt(NestedZipMaxDepth)
+}
+
+// GetZipMaxEntries returns the maximum number of entries scanned in a zip
+// file, including the entries of nested zip files. Returns 0 if there is no
+// limit.
+func (i *Instance) GetZipMaxEntries() int {
+	return i.getInt(ZipMaxEntries)
+}
+
+// GetStudioFromMetadataTag returns the name of the container metadata tag
+// used to set the studio of scenes without a studio during scan. Returns an
+// empty string if disabled.
+func (i *Instance) GetStudioFromMetadataTag() string {
+	return i.getString(StudioFromMetadataTag)
+}
+
+// GetStudioFromMetadataTagPattern returns the regular expression used to
+// extract the studio name from the metadata tag value. If the expression
+// has a capturing group, then the first group is used, otherwise the whole
+// match is used. An empty string uses the whole value.
+func (i *Instance) GetStudioFromMetadataTagPattern() string {
+	return i.getString(StudioFromMetadataTagPattern)
+}
+
+// GetScanProtectedFields returns the scene fields that are never set when
+// scanning, even if they are empty. Scanning never overwrites fields that
+// are already set.
+func (i *Instance) GetScanProtectedFields() []string {
+	return i.getStringSlice(ScanProtectedFields)
+}
+
+// IsRegenerateChangedFiles returns true if the generated files of scenes
+// should be deleted and regenerated when scanning finds that the contents
+// of their primary file have changed. If false, the generated files are
+// kept.
+func (i *Instance) IsRegenerateChangedFiles() bool {
+	return i.getBool(RegenerateChangedFiles)
+}
+
+// GetDuplicatePhashDistance returns the maximum phash distance between
+// scenes when rebuilding the stored duplicate groups.
+func (i *Instance) GetDuplicatePhashDistance() int {
+	return i.getInt(DuplicatePhashDistance)
+}
+
+// GetDuplicateDurationTolerance returns the maximum difference in seconds
+// between the durations of scenes when rebuilding the stored duplicate
+// groups. Durations are not compared if this is not positive.
+func (i *Instance) GetDuplicateDurationTolerance() float64 {
+	return i.getFloat64(DuplicateDurationTolerance)
+}
+
+// GetSceneDateFloorYear returns the earliest plausible year of scene dates.
+// Scene dates are not checked against a floor year if this is not positive.
+func (i *Instance) GetSceneDateFloorYear() int {
+	return i.getInt(SceneDateFloorYear)
+}
+
+// GetSceneDateModTimeTolerance returns the maximum number of days between a
+// scene date and the modification time of its primary file. Not checked if
+// this is not positive.
+func (i *Instance) GetSceneDateModTimeTolerance() int {
+	return i.getInt(SceneDateModTimeTolerance)
+}
+
+// GetBulkDownloadMaxSize returns the maximum total size in MiB of scene
+// files downloaded together as a zip. The size is unlimited if this is not
+// positive.
+func (i *Instance) GetBulkDownloadMaxSize() int {
+	return i.getInt(BulkDownloadMaxSize)
+}
+
+// IsGenerateLoudness returns true if the integrated loudness of scene files
+// is measured during generate. This requires an extra ffmpeg pass over the
+// audio of each file.
+func (i *Instance) IsGenerateLoudness() bool {
+	return i.getBool(GenerateLoudness)
+}
+
+// GetResolutionLabelBreakpoints returns the minimum size of the shorter side
+// of HD, full HD, 4K and 8K media. Returns the default breakpoints if not set
+// or invalid.
+func (i *Instance) GetResolutionLabelBreakpoints() []int {
+	var ret []int
+	if err := i.unmarshalKey(ResolutionLabelBreakpoints, &ret); err != nil || models.ValidateResolutionLabelBreakpoints(ret) != nil {
+		return models.DefaultResolutionLabelBreakpoints
+	}
+
+	return ret
+}
+
+// ScanPathTagRule maps a directory name in the path of scanned files to a
+// tag.
+type ScanPathTagRule struct {
+	// Component is the directory name to match, ignoring case.
+	Component string `json:"component"`
+	// Tag is the name of the tag to add. Uses Component if empty.
+	Tag string `json:"tag"`
+}
+
+// TagName returns the name of the tag added by the rule.
+func (r ScanPathTagRule) TagName() string {
+	if r.Tag == "" {
+		return r.Component
+	}
+	return r.Tag
+}
+
+// GetScanPathTagRules returns the rules mapping directory names in the path
+// of scanned files to tags. Tags are added to scenes when a file is first
+// associated with them.
+func (i *Instance) GetScanPathTagRules() []*ScanPathTagRule {
+	var ret []*ScanPathTagRule
+	if err := i.unmarshalKey(ScanPathTagRules, &ret); err != nil {
+		logger.Warnf("error in unmarshalkey: %v", err)
+	}
+
+	return ret
+}
+
 func (i *Instance) GetLanguage() string {
 	ret := i.getString(Language)
 
@@ -653,6 +1047,26 @@ func (i *Instance) GetScraperExcludeTagPatterns() []string {
 	return i.getStringSlice(ScraperExcludeTagPatterns)
 }
 
+// GetScraperDetailsTemplate returns the Go template used to generate the
+// details of scraped scenes. Returns an empty string if not set.
+func (i *Instance) GetScraperDetailsTemplate() string {
+	return i.getString(ScraperDetailsTemplate)
+}
+
+// IsScraperDetailsTemplateForced returns true if the details template should
+// replace existing and scraped details, rather than only being used when the
+// scene has no details and none are scraped.
+func (i *Instance) IsScraperDetailsTemplateForced() bool {
+	return i.getBool(ScraperDetailsTemplateForce)
+}
+
+// IsScraperSanitizeOutput returns true if html tags should be stripped,
+// entities decoded and whitespace normalized in scraped titles and details.
+// Scrapers may override this in their configuration.
+func (i *Instance) IsScraperSanitizeOutput() bool {
+	return i.getBool(ScraperSanitizeOutput)
+}
+
 func (i *Instance) GetStashBoxes() []*models.StashBox {
 	var boxes []*models.StashBox
 	if err := i.unmarshalKey(StashBoxes, &boxes); err != nil {
@@ -662,6 +1076,12 @@ func (i *Instance) GetStashBoxes() []*models.StashBox {
 	return boxes
 }
 
+// IsStashBoxSubmitOrganizedOnly returns true if the fingerprints of scenes
+// that are not organized should not be submitted to stash-box.
+func (i *Instance) IsStashBoxSubmitOrganizedOnly() bool {
+	return i.getBool(StashBoxSubmitOrganizedOnly)
+}
+
 func (i *Instance) GetDefaultPluginsPath() string {
 	// default to the same directory as the config file
 	fn := filepath.Join(i.GetConfigPath(), "plugins")
@@ -703,6 +1123,13 @@ func (i *Instance) GetExternalHost() string {
 	return i.getString(ExternalHost)
 }
 
+// IsHTTPCompression returns true if text responses, such as GraphQL
+// responses, should be gzip compressed for clients that support it. Media
+// streams and images are never compressed. Defaults to true.
+func (i *Instance) IsHTTPCompression() bool {
+	return i.getBoolDefault(HTTPCompression, httpCompressionDefault)
+}
+
 // GetPreviewSegmentDuration returns the duration of a single segment in a
 // scene preview file, in seconds.
 func (i *Instance) GetPreviewSegmentDuration() float64 {
@@ -723,10 +1150,43 @@ func (i *Instance) GetParallelTasksWithAutoDetection() int {
 	return parallelTasks
 }
 
+// GetHashWorkers returns the maximum number of files that are hashed
+// concurrently during scan. Returns 0 if the number of parallel scan tasks
+// is used.
+func (i *Instance) GetHashWorkers() int {
+	return i.getInt(HashWorkers)
+}
+
+// GetGalleryParallelTasks returns the number of gallery zip files that are
+// scanned concurrently, separately from other files. Returns 0 if zip files
+// share the scan workers with other files.
+func (i *Instance) GetGalleryParallelTasks() int {
+	return i.getInt(GalleryParallelTasks)
+}
+
+func (i *Instance) GetAutoTagParallelTasks() int {
+	return i.getInt(AutoTagParallelTasks)
+}
+
+func (i *Instance) GetAutoTagParallelTasksWithAutoDetection() int {
+	parallelTasks := i.getInt(AutoTagParallelTasks)
+	if parallelTasks <= 0 {
+		parallelTasks = (runtime.NumCPU() / 4) + 1
+	}
+	return parallelTasks
+}
+
 func (i *Instance) GetPreviewAudio() bool {
 	return i.getBool(PreviewAudio)
 }
 
+// IsCoverAvoidBlankFrames returns true if generated scene covers should be
+// taken from the most detailed of several sampled frames, rather than a
+// fixed position in the video.
+func (i *Instance) IsCoverAvoidBlankFrames() bool {
+	return i.getBool(CoverAvoidBlankFrames)
+}
+
 // GetPreviewSegments returns the amount of segments in a scene preview file.
 func (i *Instance) GetPreviewSegments() int {
 	return i.getInt(PreviewSegments)
@@ -786,12 +1246,37 @@ func (i *Instance) GetMaxStreamingTranscodeSize() models.StreamingResolutionEnum
 	return models.StreamingResolutionEnum(ret)
 }
 
+// GetHLSSegmentDuration returns the duration in seconds of the segments of
+// HLS streams.
+func (i *Instance) GetHLSSegmentDuration() int {
+	return i.getInt(HLSSegmentDuration)
+}
+
 // IsWriteImageThumbnails returns true if image thumbnails should be written
 // to disk after generating on the fly.
 func (i *Instance) IsWriteImageThumbnails() bool {
 	return i.getBool(WriteImageThumbnails)
 }
 
+// GetMaxImageDimension returns the maximum width or height of images that
+// thumbnails are generated for. Larger images are scanned with basic
+// metadata only. Returns 0 if there is no limit.
+func (i *Instance) GetMaxImageDimension() int {
+	return i.getInt(MaxImageDimension)
+}
+
+// GetResolverCacheFields returns the GraphQL fields that should be cached,
+// in the form Type.field. For example: Tag.scene_count.
+func (i *Instance) GetResolverCacheFields() []string {
+	return i.getStringSlice(ResolverCacheFields)
+}
+
+// GetResolverCacheTTL returns the number of seconds that cached GraphQL
+// field values are kept for.
+func (i *Instance) GetResolverCacheTTL() int {
+	return i.getInt(ResolverCacheTTL)
+}
+
 func (i *Instance) GetAPIKey() string {
 	return i.getString(ApiKey)
 }
@@ -907,6 +1392,19 @@ func (i *Instance) GetMenuItems() []string {
 	return defaultMenuItems
 }
 
+// GetSceneTitleFallback returns the ordered list of formats used to display
+// scenes without a title. The first format for which all tokens have a value
+// is used.
+func (i *Instance) GetSceneTitleFallback() []string {
+	i.RLock()
+	defer i.RUnlock()
+	v := i.viper(SceneTitleFallback)
+	if v.IsSet(SceneTitleFallback) {
+		return v.GetStringSlice(SceneTitleFallback)
+	}
+	return defaultSceneTitleFallback
+}
+
 func (i *Instance) GetSoundOnPreview() bool {
 	return i.getBool(SoundOnPreview)
 }
@@ -964,6 +1462,19 @@ func (i *Instance) GetShowStudioAsText() bool {
 	return i.getBool(ShowStudioAsText)
 }
 
+// GetStudioImageInheritance returns true if studios without an image should
+// use the image of their nearest ancestor studio with an image.
+func (i *Instance) GetStudioImageInheritance() bool {
+	return i.getBool(StudioImageInheritance)
+}
+
+// GetPerformerImageSceneFallback returns true if performers without an image
+// should be displayed with the cover of their highest rated scene, or their
+// most recent scene if none are rated.
+func (i *Instance) GetPerformerImageSceneFallback() bool {
+	return i.getBool(PerformerImageSceneFallback)
+}
+
 func (i *Instance) getSlideshowDelay() int {
 	// assume have lock
 
@@ -1330,6 +1841,24 @@ func (i *Instance) GetLogAccess() bool {
 	return i.getBoolDefault(LogAccess, defaultLogAccess)
 }
 
+// GetLogMaxSize returns the size in megabytes after which the log file is
+// rotated. 0 disables size-based rotation.
+func (i *Instance) GetLogMaxSize() int {
+	return i.getInt(LogMaxSize)
+}
+
+// GetLogRotateInterval returns the number of hours after which the log file
+// is rotated. 0 disables time-based rotation.
+func (i *Instance) GetLogRotateInterval() int {
+	return i.getInt(LogRotateInterval)
+}
+
+// GetLogMaxBackups returns the number of rotated log files to retain.
+// 0 retains all rotated files. Defaults to 5.
+func (i *Instance) GetLogMaxBackups() int {
+	return i.getInt(LogMaxBackups)
+}
+
 // Max allowed graphql upload size in megabytes
 func (i *Instance) GetMaxUploadSize() int64 {
 	i.RLock()
@@ -1391,12 +1920,18 @@ func (i *Instance) setDefaultValues(write bool) error {
 	i.main.SetDefault(Port, portDefault)
 
 	i.main.SetDefault(ParallelTasks, parallelTasksDefault)
+	i.main.SetDefault(AutoTagParallelTasks, autoTagParallelTasksDefault)
+	i.main.SetDefault(HashWorkers, hashWorkersDefault)
+	i.main.SetDefault(HLSSegmentDuration, hlsSegmentDurationDefault)
+	i.main.SetDefault(ResolverCacheTTL, resolverCacheTTLDefault)
+	i.main.SetDefault(LogMaxBackups, defaultLogMaxBackups)
 	i.main.SetDefault(PreviewSegmentDuration, previewSegmentDurationDefault)
 	i.main.SetDefault(PreviewSegments, previewSegmentsDefault)
 	i.main.SetDefault(PreviewExcludeStart, previewExcludeStartDefault)
 	i.main.SetDefault(PreviewExcludeEnd, previewExcludeEndDefault)
 	i.main.SetDefault(PreviewAudio, previewAudioDefault)
 	i.main.SetDefault(SoundOnPreview, false)
+	i.main.SetDefault(CoverAvoidBlankFrames, coverAvoidBlankFramesDefault)
 
 	i.main.SetDefault(ThemeColor, DefaultThemeColor)
 
@@ -30,6 +30,7 @@ func TestConcurrentConfigAccess(t *testing.T) {
 				i.GetStashPaths()
 				_ = i.ValidateStashBoxes(nil)
 				_ = i.Validate()
+				_ = i.GetRedactedSettings()
 				_ = i.ActivatePublicAccessTripwire("")
 				i.Set(Cache, i.GetCachePath())
 				i.Set(Generated, i.GetGeneratedPath())
@@ -44,12 +45,40 @@ func TestConcurrentConfigAccess(t *testing.T) {
 				i.Set(ImageExtensions, i.GetImageExtensions())
 				i.Set(GalleryExtensions, i.GetGalleryExtensions())
 				i.Set(CreateGalleriesFromFolders, i.GetCreateGalleriesFromFolders())
+				i.GetGalleryCoverMinResolution()
+				i.Set(GalleryImageNaturalSort, i.GetGalleryImageNaturalSort())
+				i.Set(SyncLinkedDatesOnScan, i.GetSyncLinkedDatesOnScan())
+				i.Set(LinkFolderGalleriesOnScan, i.GetLinkFolderGalleriesOnScan())
+				i.Set(LinkFolderGalleriesMatchStem, i.GetLinkFolderGalleriesMatchStem())
+				i.Set(PrimaryFilePolicy, i.GetPrimaryFilePolicy())
+				i.Set(PrimaryFilePathPriority, i.GetPrimaryFilePathPriority())
+				i.Set(PrimaryFileExtensionPriority, i.GetPrimaryFileExtensionPriority())
+				i.Set(IdentifyAutoOrganize, i.GetIdentifyAutoOrganize())
+				i.Set(IdentifyAutoOrganizeRequiredFields, i.GetIdentifyAutoOrganizeRequiredFields())
+				i.Set(NestedZipMaxDepth, i.GetNestedZipMaxDepth())
+				i.Set(ZipMaxEntries, i.GetZipMaxEntries())
+				i.Set(StudioFromMetadataTag, i.GetStudioFromMetadataTag())
+				i.Set(StudioFromMetadataTagPattern, i.GetStudioFromMetadataTagPattern())
+				i.Set(ScanProtectedFields, i.GetScanProtectedFields())
+				i.Set(ScanPathTagRules, i.GetScanPathTagRules())
+				i.Set(RegenerateChangedFiles, i.IsRegenerateChangedFiles())
+				i.Set(DuplicatePhashDistance, i.GetDuplicatePhashDistance())
+				i.Set(DuplicateDurationTolerance, i.GetDuplicateDurationTolerance())
+				i.Set(ResolutionLabelBreakpoints, i.GetResolutionLabelBreakpoints())
+				i.Set(SceneDateFloorYear, i.GetSceneDateFloorYear())
+				i.Set(SceneDateModTimeTolerance, i.GetSceneDateModTimeTolerance())
+				i.Set(BulkDownloadMaxSize, i.GetBulkDownloadMaxSize())
+				i.Set(GenerateLoudness, i.IsGenerateLoudness())
+				i.Set(StashBoxSubmitOrganizedOnly, i.IsStashBoxSubmitOrganizedOnly())
 				i.Set(Language, i.GetLanguage())
 				i.Set(VideoFileNamingAlgorithm, i.GetVideoFileNamingAlgorithm())
 				i.Set(ScrapersPath, i.GetScrapersPath())
 				i.Set(ScraperUserAgent, i.GetScraperUserAgent())
 				i.Set(ScraperCDPPath, i.GetScraperCDPPath())
 				i.Set(ScraperCertCheck, i.GetScraperCertCheck())
+				i.Set(ScraperDetailsTemplate, i.GetScraperDetailsTemplate())
+				i.Set(ScraperDetailsTemplateForce, i.IsScraperDetailsTemplateForced())
+				i.Set(ScraperSanitizeOutput, i.IsScraperSanitizeOutput())
 				i.Set(ScraperExcludeTagPatterns, i.GetScraperExcludeTagPatterns())
 				i.Set(StashBoxes, i.GetStashBoxes())
 				i.GetDefaultPluginsPath()
@@ -57,16 +86,26 @@ func TestConcurrentConfigAccess(t *testing.T) {
 				i.Set(Host, i.GetHost())
 				i.Set(Port, i.GetPort())
 				i.Set(ExternalHost, i.GetExternalHost())
+				i.Set(HTTPCompression, i.IsHTTPCompression())
 				i.Set(PreviewSegmentDuration, i.GetPreviewSegmentDuration())
 				i.Set(ParallelTasks, i.GetParallelTasks())
 				i.Set(ParallelTasks, i.GetParallelTasksWithAutoDetection())
+				i.Set(AutoTagParallelTasks, i.GetAutoTagParallelTasksWithAutoDetection())
+				i.Set(HashWorkers, i.GetHashWorkers())
+				i.Set(GalleryParallelTasks, i.GetGalleryParallelTasks())
 				i.Set(PreviewAudio, i.GetPreviewAudio())
 				i.Set(PreviewSegments, i.GetPreviewSegments())
 				i.Set(PreviewExcludeStart, i.GetPreviewExcludeStart())
 				i.Set(PreviewExcludeEnd, i.GetPreviewExcludeEnd())
 				i.Set(PreviewPreset, i.GetPreviewPreset())
+				i.Set(CoverAvoidBlankFrames, i.IsCoverAvoidBlankFrames())
+				i.Set(MaxImageDimension, i.GetMaxImageDimension())
+				i.Set(ResolverCacheFields, i.GetResolverCacheFields())
+				i.Set(ResolverCacheTTL, i.GetResolverCacheTTL())
 				i.Set(MaxTranscodeSize, i.GetMaxTranscodeSize())
 				i.Set(MaxStreamingTranscodeSize, i.GetMaxStreamingTranscodeSize())
+				i.Set(HLSSegmentDuration, i.GetHLSSegmentDuration())
+				i.Set(StreamingTranscodeSizeNetworks, i.GetStreamingTranscodeSizeNetworks())
 				i.Set(ApiKey, i.GetAPIKey())
 				i.Set(Username, i.GetUsername())
 				i.Set(Password, i.GetPasswordHash())
@@ -75,6 +114,7 @@ func TestConcurrentConfigAccess(t *testing.T) {
 				i.Set(CustomServedFolders, i.GetCustomServedFolders())
 				i.Set(CustomUILocation, i.GetCustomUILocation())
 				i.Set(MenuItems, i.GetMenuItems())
+				i.Set(SceneTitleFallback, i.GetSceneTitleFallback())
 				i.Set(SoundOnPreview, i.GetSoundOnPreview())
 				i.Set(WallShowTitle, i.GetWallShowTitle())
 				i.Set(CustomPerformerImageLocation, i.GetCustomPerformerImageLocation())
@@ -82,6 +122,8 @@ func TestConcurrentConfigAccess(t *testing.T) {
 				i.Set(MaximumLoopDuration, i.GetMaximumLoopDuration())
 				i.Set(AutostartVideo, i.GetAutostartVideo())
 				i.Set(ShowStudioAsText, i.GetShowStudioAsText())
+				i.Set(StudioImageInheritance, i.GetStudioImageInheritance())
+				i.Set(PerformerImageSceneFallback, i.GetPerformerImageSceneFallback())
 				i.Set(legacyImageLightboxSlideshowDelay, *i.GetImageLightboxOptions().SlideshowDelay)
 				i.Set(ImageLightboxSlideshowDelay, *i.GetImageLightboxOptions().SlideshowDelay)
 				i.GetCSSPath()
@@ -101,6 +143,9 @@ func TestConcurrentConfigAccess(t *testing.T) {
 				i.Set(LogOut, i.GetLogOut())
 				i.Set(LogLevel, i.GetLogLevel())
 				i.Set(LogAccess, i.GetLogAccess())
+				i.Set(LogMaxSize, i.GetLogMaxSize())
+				i.Set(LogRotateInterval, i.GetLogRotateInterval())
+				i.Set(LogMaxBackups, i.GetLogMaxBackups())
 				i.Set(MaxUploadSize, i.GetMaxUploadSize())
 				i.Set(FunscriptOffset, i.GetFunscriptOffset())
 				i.Set(DefaultIdentifySettings, i.GetDefaultIdentifySettings())
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+const redactedValue = "********"
+
+// sensitiveKeys are the keys of configuration values that must not be
+// exposed. Keys are matched at any level of the configuration.
+var sensitiveKeys = map[string]bool{
+	ApiKey:          true,
+	"apikey":        true,
+	Password:        true,
+	JWTSignKey:      true,
+	SessionStoreKey: true,
+	HandyKey:        true,
+}
+
+// GetRedactedSettings returns the effective configuration, including
+// default and overridden values, with sensitive values redacted. Sensitive
+// values that are empty are returned as is, so that it can be seen whether
+// they are set.
+func (i *Instance) GetRedactedSettings() map[string]interface{} {
+	i.RLock()
+	settings := i.main.AllSettings()
+	for k, v := range i.overrides.AllSettings() {
+		settings[k] = v
+	}
+	i.RUnlock()
+
+	ret, _ := redactValue(settings).(map[string]interface{})
+	return ret
+}
+
+// redactValue returns a copy of v with the values of sensitive keys
+// redacted. Maps are converted to map[string]interface{}, and structs are
+// converted using their JSON representation.
+func redactValue(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case nil, string, bool, int, int64, float64:
+		return vv
+	case map[string]interface{}:
+		ret := make(map[string]interface{}, len(vv))
+		for k, value := range vv {
+			ret[k] = redactEntry(k, value)
+		}
+		return ret
+	case map[interface{}]interface{}:
+		ret := make(map[string]interface{}, len(vv))
+		for k, value := range vv {
+			key := fmt.Sprint(k)
+			ret[key] = redactEntry(key, value)
+		}
+		return ret
+	case []interface{}:
+		ret := make([]interface{}, len(vv))
+		for i, value := range vv {
+			ret[i] = redactValue(value)
+		}
+		return ret
+	}
+
+	switch reflect.Indirect(reflect.ValueOf(v)).Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Map:
+		// convert to generic values so that nested fields can be redacted
+		data, err := json.Marshal(v)
+		if err != nil {
+			return redactedValue
+		}
+
+		var generic interface{}
+		if err := json.Unmarshal(data, &generic); err != nil {
+			return redactedValue
+		}
+
+		return redactValue(generic)
+	}
+
+	return v
+}
+
+func redactEntry(key string, value interface{}) interface{} {
+	if !sensitiveKeys[key] {
+		return redactValue(value)
+	}
+
+	if value == nil || reflect.ValueOf(value).IsZero() {
+		return value
+	}
+
+	return redactedValue
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactValue(t *testing.T) {
+	settings := map[string]interface{}{
+		Host:     "0.0.0.0",
+		Port:     9999,
+		ApiKey:   "secret",
+		Password: "",
+		HandyKey: "secret",
+		StashBoxes: []interface{}{
+			map[interface{}]interface{}{
+				"endpoint": "https://stashdb.org/graphql",
+				"apikey":   "secret",
+			},
+		},
+		"stash_box_structs": []*StashBoxInput{
+			{Endpoint: "https://stashdb.org/graphql", APIKey: "secret", Name: "stashdb"},
+		},
+		"nested": map[string]interface{}{
+			JWTSignKey: "secret",
+		},
+	}
+
+	want := map[string]interface{}{
+		Host:     "0.0.0.0",
+		Port:     9999,
+		ApiKey:   redactedValue,
+		Password: "",
+		HandyKey: redactedValue,
+		StashBoxes: []interface{}{
+			map[string]interface{}{
+				"endpoint": "https://stashdb.org/graphql",
+				"apikey":   redactedValue,
+			},
+		},
+		"stash_box_structs": []interface{}{
+			map[string]interface{}{
+				"endpoint": "https://stashdb.org/graphql",
+				"api_key":  redactedValue,
+				"name":     "stashdb",
+			},
+		},
+		"nested": map[string]interface{}{
+			JWTSignKey: redactedValue,
+		},
+	}
+
+	assert.Equal(t, want, redactValue(settings))
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// StreamingTranscodeSizeNetwork maps a client network to the maximum
+// resolution of streaming transcodes for clients in that network.
+type StreamingTranscodeSizeNetwork struct {
+	// Network is a CIDR network or a single IP address.
+	Network string                         `json:"network"`
+	MaxSize models.StreamingResolutionEnum `json:"maxSize"`
+}
+
+func (n StreamingTranscodeSizeNetwork) parseNetwork() (*net.IPNet, error) {
+	if !strings.Contains(n.Network, "/") {
+		ip := net.ParseIP(n.Network)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid network %q", n.Network)
+		}
+
+		bits := 8 * net.IPv6len
+		if ip.To4() != nil {
+			ip = ip.To4()
+			bits = 8 * net.IPv4len
+		}
+
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+
+	_, ret, err := net.ParseCIDR(n.Network)
+	if err != nil {
+		return nil, fmt.Errorf("invalid network %q: %w", n.Network, err)
+	}
+
+	return ret, nil
+}
+
+// ValidateStreamingTranscodeSizeNetworks returns an error if any of the
+// provided networks or resolutions are invalid.
+func ValidateStreamingTranscodeSizeNetworks(networks []*StreamingTranscodeSizeNetwork) error {
+	for _, n := range networks {
+		if _, err := n.parseNetwork(); err != nil {
+			return err
+		}
+
+		if !n.MaxSize.IsValid() {
+			return fmt.Errorf("invalid max size %q for network %q", n.MaxSize, n.Network)
+		}
+	}
+
+	return nil
+}
+
+// MatchStreamingTranscodeSizeNetwork returns the first of the provided
+// networks that contains ip. Returns nil if none match. Invalid networks are
+// ignored.
+func MatchStreamingTranscodeSizeNetwork(networks []*StreamingTranscodeSizeNetwork, ip net.IP) *StreamingTranscodeSizeNetwork {
+	if ip == nil {
+		return nil
+	}
+
+	for _, n := range networks {
+		ipNet, err := n.parseNetwork()
+		if err != nil {
+			continue
+		}
+
+		if ipNet.Contains(ip) {
+			return n
+		}
+	}
+
+	return nil
+}
+
+// GetStreamingTranscodeSizeNetworks returns the configured maximum streaming
+// transcode resolutions by client network.
+func (i *Instance) GetStreamingTranscodeSizeNetworks() []*StreamingTranscodeSizeNetwork {
+	var ret []*StreamingTranscodeSizeNetwork
+	if err := i.unmarshalKey(StreamingTranscodeSizeNetworks, &ret); err != nil {
+		logger.Warnf("error in unmarshalkey: %v", err)
+	}
+
+	return ret
+}
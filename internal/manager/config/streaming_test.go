@@ -0,0 +1,60 @@
+package config
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestMatchStreamingTranscodeSizeNetwork(t *testing.T) {
+	lan := &StreamingTranscodeSizeNetwork{Network: "192.168.0.0/16", MaxSize: models.StreamingResolutionEnumOriginal}
+	host := &StreamingTranscodeSizeNetwork{Network: "10.0.0.5", MaxSize: models.StreamingResolutionEnumFullHd}
+	v6 := &StreamingTranscodeSizeNetwork{Network: "fd00::/8", MaxSize: models.StreamingResolutionEnumStandard}
+	all := &StreamingTranscodeSizeNetwork{Network: "0.0.0.0/0", MaxSize: models.StreamingResolutionEnumStandardHd}
+	invalid := &StreamingTranscodeSizeNetwork{Network: "invalid", MaxSize: models.StreamingResolutionEnumLow}
+
+	networks := []*StreamingTranscodeSizeNetwork{invalid, lan, host, v6, all}
+
+	tests := []struct {
+		ip   string
+		want *StreamingTranscodeSizeNetwork
+	}{
+		{"192.168.1.10", lan},
+		{"10.0.0.5", host},
+		{"10.0.0.6", all},
+		{"fd12::1", v6},
+		{"2001:db8::1", nil},
+		{"8.8.8.8", all},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			if got := MatchStreamingTranscodeSizeNetwork(networks, net.ParseIP(tt.ip)); got != tt.want {
+				t.Errorf("MatchStreamingTranscodeSizeNetwork() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateStreamingTranscodeSizeNetworks(t *testing.T) {
+	tests := []struct {
+		name    string
+		network StreamingTranscodeSizeNetwork
+		wantErr bool
+	}{
+		{"cidr", StreamingTranscodeSizeNetwork{Network: "192.168.0.0/16", MaxSize: models.StreamingResolutionEnumStandardHd}, false},
+		{"ip", StreamingTranscodeSizeNetwork{Network: "::1", MaxSize: models.StreamingResolutionEnumOriginal}, false},
+		{"invalid network", StreamingTranscodeSizeNetwork{Network: "192.168.0.0/33", MaxSize: models.StreamingResolutionEnumOriginal}, true},
+		{"invalid size", StreamingTranscodeSizeNetwork{Network: "192.168.0.0/16", MaxSize: "HUGE"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateStreamingTranscodeSizeNetworks([]*StreamingTranscodeSizeNetwork{&tt.network})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateStreamingTranscodeSizeNetworks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+// UnlinkedGeneratedFiles are generated files that exist for a scene, but are
+// named using a hash other than the one used by the scene for generated
+// files. The scene does not use these files until they are relinked.
+type UnlinkedGeneratedFiles struct {
+	// Hash encoded in the paths of the generated files
+	Hash string `json:"hash"`
+	// Hash used by the scene for generated files
+	SceneHash string        `json:"scene_hash"`
+	Scene     *models.Scene `json:"scene"`
+	Files     []string      `json:"files"`
+	Relinked  bool          `json:"relinked"`
+}
+
+// FindUnlinkedGeneratedFiles cross-references the generated files on disk
+// against the scene file fingerprints, and returns the generated files that
+// belong to a scene but are not named with the scene's hash. If relink is
+// true, then these files are renamed to use the scene's hash, unless the
+// scene already has generated files, or the files match multiple scenes.
+func (s *Manager) FindUnlinkedGeneratedFiles(ctx context.Context, relink bool) ([]*UnlinkedGeneratedFiles, error) {
+	generated, err := scene.GeneratedFilesByHash(s.Paths)
+	if err != nil {
+		return nil, fmt.Errorf("reading generated files: %w", err)
+	}
+
+	hashes := make([]string, 0, len(generated))
+	for h := range generated {
+		hashes = append(hashes, h)
+	}
+	sort.Strings(hashes)
+
+	fileNamingAlgo := s.Config.GetVideoFileNamingAlgorithm()
+
+	var ret []*UnlinkedGeneratedFiles
+	matches := make(map[string]int)
+	if err := txn.WithReadTxn(ctx, s.Repository, func(ctx context.Context) error {
+		qb := s.Repository.Scene
+		for _, h := range hashes {
+			scenes, err := qb.FindByFingerprints(ctx, []file.Fingerprint{
+				{
+					Type:        file.FingerprintTypeOshash,
+					Fingerprint: h,
+				},
+				{
+					Type:        file.FingerprintTypeMD5,
+					Fingerprint: h,
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, ss := range scenes {
+				sceneHash := ss.GetHash(fileNamingAlgo)
+				if sceneHash == "" || sceneHash == h {
+					continue
+				}
+
+				ret = append(ret, &UnlinkedGeneratedFiles{
+					Hash:      h,
+					SceneHash: sceneHash,
+					Scene:     ss,
+					Files:     generated[h],
+				})
+				matches[h]++
+				matches[sceneHash]++
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("finding scenes: %w", err)
+	}
+
+	if !relink {
+		return ret, nil
+	}
+
+	for _, u := range ret {
+		if matches[u.Hash] > 1 || matches[u.SceneHash] > 1 {
+			logger.Warnf("Not relinking generated files with hash %s: matches multiple scenes", u.Hash)
+			continue
+		}
+
+		if len(generated[u.SceneHash]) > 0 {
+			logger.Warnf("Not relinking generated files with hash %s: scene %s already has generated files", u.Hash, u.Scene.DisplayName())
+			continue
+		}
+
+		scene.MigrateHash(s.Paths, u.Hash, u.SceneHash)
+		u.Relinked = true
+	}
+
+	return ret, nil
+}
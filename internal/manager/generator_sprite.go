@@ -77,7 +77,7 @@ func NewSpriteGenerator(videoFile ffmpeg.VideoFile, videoChecksum string, imageO
 		g: &generate.Generator{
 			Encoder:     instance.FFMPEG,
 			LockManager: instance.ReadLockManager,
-			ScenePaths:  instance.Paths.Scene,
+			ScenePaths:  instance.Paths.ForPath(videoFile.Path).Scene,
 		},
 	}, nil
 }
@@ -0,0 +1,177 @@
+package manager
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// hlsSegmentCacheTTL is the time after which the cached segments of a
+// playback session that has not been accessed are removed.
+const hlsSegmentCacheTTL = 10 * time.Minute
+
+// HLSSegmentKey identifies a transcoded HLS segment.
+type HLSSegmentKey struct {
+	SceneID int
+	// Client is the address of the client playing the scene.
+	Client           string
+	MaxTranscodeSize int
+	// SegmentLength is the length of the segment in seconds.
+	SegmentLength float64
+	// StartTime is the position in the file where the segment starts, in
+	// seconds.
+	StartTime float64
+}
+
+// session returns the name of the playback session of the segment. Segments
+// of a scene played by the same client with the same transcode settings are
+// in the same session.
+func (k HLSSegmentKey) session() string {
+	client := strings.NewReplacer(":", "-", ".", "-").Replace(k.Client)
+	return fmt.Sprintf("%d_%s_%d_%g", k.SceneID, client, k.MaxTranscodeSize, k.SegmentLength)
+}
+
+func (k HLSSegmentKey) filename() string {
+	return fmt.Sprintf("%f.ts", k.StartTime)
+}
+
+// HLSSegmentCache caches transcoded HLS segments on disk for each playback
+// session, so that seeking back to a segment does not transcode it again.
+// The segments of sessions which have not been accessed within the TTL are
+// removed. It is safe for concurrent use.
+type HLSSegmentCache struct {
+	mutex sync.Mutex
+	dir   string
+	ttl   time.Duration
+	// lastAccess is the last access time of each session
+	lastAccess map[string]time.Time
+}
+
+func NewHLSSegmentCache() *HLSSegmentCache {
+	return &HLSSegmentCache{
+		ttl:        hlsSegmentCacheTTL,
+		lastAccess: make(map[string]time.Time),
+	}
+}
+
+// SetDir sets the directory that segments are cached in, removing any
+// existing segments. Caching is disabled if dir is empty.
+func (c *HLSSegmentCache) SetDir(dir string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if dir == c.dir {
+		return
+	}
+
+	c.removeAll()
+	c.dir = dir
+
+	// remove segments left by a previous run
+	c.removeAll()
+}
+
+func (c *HLSSegmentCache) removeAll() {
+	if c.dir == "" {
+		return
+	}
+
+	if err := os.RemoveAll(c.dir); err != nil {
+		logger.Warnf("error removing HLS segment cache %s: %v", c.dir, err)
+	}
+	c.lastAccess = make(map[string]time.Time)
+}
+
+// expire removes the segments of sessions that have not been accessed within
+// the TTL. Assumes the mutex is held.
+func (c *HLSSegmentCache) expire(now time.Time) {
+	for session, t := range c.lastAccess {
+		if now.Sub(t) <= c.ttl {
+			continue
+		}
+
+		logger.Debugf("removing expired HLS segment cache session %s", session)
+		if err := os.RemoveAll(filepath.Join(c.dir, session)); err != nil {
+			logger.Warnf("error removing HLS segment cache session %s: %v", session, err)
+		}
+		delete(c.lastAccess, session)
+	}
+}
+
+// Get returns the path of the cached segment. Returns an empty string if the
+// segment is not cached.
+func (c *HLSSegmentCache) Get(key HLSSegmentKey) string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	c.expire(now)
+
+	session := key.session()
+	if _, found := c.lastAccess[session]; !found {
+		return ""
+	}
+	c.lastAccess[session] = now
+
+	p := filepath.Join(c.dir, session, key.filename())
+	if exists, _ := fsutil.FileExists(p); !exists {
+		return ""
+	}
+
+	return p
+}
+
+// Create returns a writer for the segment. Returns nil if caching is
+// disabled.
+func (c *HLSSegmentCache) Create(key HLSSegmentKey) (*HLSSegmentWriter, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.dir == "" {
+		return nil, nil
+	}
+
+	now := time.Now()
+	c.expire(now)
+
+	session := key.session()
+	sessionDir := filepath.Join(c.dir, session)
+	if err := fsutil.EnsureDirAll(sessionDir); err != nil {
+		return nil, err
+	}
+	c.lastAccess[session] = now
+
+	f, err := os.CreateTemp(sessionDir, key.filename()+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+
+	return &HLSSegmentWriter{
+		File: f,
+		path: filepath.Join(sessionDir, key.filename()),
+	}, nil
+}
+
+// HLSSegmentWriter writes a segment to the cache.
+type HLSSegmentWriter struct {
+	*os.File
+	path string
+}
+
+// Finish closes the writer. The segment is added to the cache if complete
+// is true, and discarded otherwise.
+func (w *HLSSegmentWriter) Finish(complete bool) error {
+	tmpPath := w.Name()
+	if err := w.Close(); err != nil || !complete {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	return os.Rename(tmpPath, w.path)
+}
@@ -0,0 +1,64 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHLSSegmentCache(t *testing.T) {
+	c := NewHLSSegmentCache()
+	c.SetDir(filepath.Join(t.TempDir(), "hls"))
+
+	key := HLSSegmentKey{
+		SceneID:       1,
+		Client:        "::1",
+		SegmentLength: 10,
+		StartTime:     20,
+	}
+
+	assert.Empty(t, c.Get(key))
+
+	// discarded segments are not cached
+	w, err := c.Create(key)
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, _ = w.WriteString("partial")
+	assert.Nil(t, w.Finish(false))
+	assert.Empty(t, c.Get(key))
+
+	w, err = c.Create(key)
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, _ = w.WriteString("segment")
+	assert.Nil(t, w.Finish(true))
+
+	p := c.Get(key)
+	if assert.NotEmpty(t, p) {
+		data, _ := os.ReadFile(p)
+		assert.Equal(t, "segment", string(data))
+	}
+
+	otherKey := key
+	otherKey.StartTime = 30
+	assert.Empty(t, c.Get(otherKey))
+
+	// expired sessions are removed
+	c.lastAccess[key.session()] = time.Now().Add(-2 * hlsSegmentCacheTTL)
+	assert.Empty(t, c.Get(key))
+	_, err = os.Stat(p)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestHLSSegmentCache_disabled(t *testing.T) {
+	c := NewHLSSegmentCache()
+
+	w, err := c.Create(HLSSegmentKey{SceneID: 1})
+	assert.Nil(t, err)
+	assert.Nil(t, w)
+}
@@ -75,6 +75,12 @@ func (i *fileFolderImporter) fileJSONToFile(ctx context.Context, fileJSON jsonsc
 			BitRate:          ff.BitRate,
 			Interactive:      ff.Interactive,
 			InteractiveSpeed: ff.InteractiveSpeed,
+
+			InteractiveAverageSpeed: ff.InteractiveAverageSpeed,
+			InteractiveRange:        ff.InteractiveRange,
+			InteractiveDensity:      ff.InteractiveDensity,
+
+			Loudness: ff.Loudness,
 		}, nil
 	case *jsonschema.ImageFile:
 		baseFile, err := i.baseFileJSONToBaseFile(ctx, ff.BaseFile)
@@ -107,12 +107,17 @@ type Manager struct {
 	Logger *log.Logger
 
 	Paths *paths.Paths
+	// pathsMutex serialises updates to Paths
+	pathsMutex sync.Mutex
 
 	FFMPEG  ffmpeg.FFMpeg
 	FFProbe ffmpeg.FFProbe
 
 	ReadLockManager *fsutil.ReadLockManager
 
+	TranscodeSessions *TranscodeSessionTracker
+	HLSSegmentCache   *HLSSegmentCache
+
 	SessionStore *session.Store
 
 	JobManager *job.Manager
@@ -122,6 +127,9 @@ type Manager struct {
 
 	DownloadStore *DownloadStore
 
+	StashBoxCheckResults *StashBoxCheckResults
+	OshashCollisions     *OshashCollisionResults
+
 	DLNAService *dlna.Service
 
 	Database   *sqlite.Database
@@ -173,11 +181,15 @@ func initialize() error {
 	emptyPaths := paths.Paths{}
 
 	instance = &Manager{
-		Config:          cfg,
-		Logger:          l,
-		ReadLockManager: fsutil.NewReadLockManager(),
-		DownloadStore:   NewDownloadStore(),
-		PluginCache:     plugin.NewCache(cfg),
+		Config:               cfg,
+		Logger:               l,
+		ReadLockManager:      fsutil.NewReadLockManager(),
+		TranscodeSessions:    NewTranscodeSessionTracker(),
+		HLSSegmentCache:      NewHLSSegmentCache(),
+		DownloadStore:        NewDownloadStore(),
+		StashBoxCheckResults: NewStashBoxCheckResults(),
+		OshashCollisions:     NewOshashCollisionResults(),
+		PluginCache:          plugin.NewCache(cfg),
 
 		Database:   db,
 		Repository: sqliteRepository(db),
@@ -294,10 +306,12 @@ func (g *coverGenerator) GenerateCover(ctx context.Context, scene *models.Scene,
 	gg := generate.Generator{
 		Encoder:     instance.FFMPEG,
 		LockManager: instance.ReadLockManager,
-		ScenePaths:  instance.Paths.Scene,
+		ScenePaths:  instance.Paths.ForPath(f.Path).Scene,
 	}
 
-	return gg.Screenshot(ctx, f.Path, scene.GetHash(instance.Config.GetVideoFileNamingAlgorithm()), f.Width, f.Duration, generate.ScreenshotOptions{})
+	return gg.Screenshot(ctx, f.Path, scene.GetHash(instance.Config.GetVideoFileNamingAlgorithm()), f.Width, f.Duration, generate.ScreenshotOptions{
+		AvoidBlankFrames: instance.Config.IsCoverAvoidBlankFrames(),
+	})
 }
 
 func makeScanner(db *sqlite.Database, pluginCache *plugin.Cache) *file.Scanner {
@@ -316,8 +330,10 @@ func makeScanner(db *sqlite.Database, pluginCache *plugin.Cache) *file.Scanner {
 				Filter: file.FilterFunc(videoFileFilter),
 			},
 			&file.FilteredDecorator{
-				Decorator: &file_image.Decorator{},
-				Filter:    file.FilterFunc(imageFileFilter),
+				Decorator: &file_image.Decorator{
+					Config: instance.Config,
+				},
+				Filter: file.FilterFunc(imageFileFilter),
 			},
 		},
 		FingerprintCalculator: &fingerprintCalculator{instance.Config},
@@ -437,7 +453,11 @@ func initFFMPEG(ctx context.Context) error {
 func initLog() *log.Logger {
 	config := config.GetInstance()
 	l := log.NewLogger()
-	l.Init(config.GetLogFile(), config.GetLogOut(), config.GetLogLevel())
+	l.Init(config.GetLogFile(), config.GetLogOut(), config.GetLogLevel(), log.RotateOptions{
+		MaxSize:    int64(config.GetLogMaxSize()) * 1024 * 1024,
+		Interval:   time.Duration(config.GetLogRotateInterval()) * time.Hour,
+		MaxBackups: config.GetLogMaxBackups(),
+	})
 	logger.Logger = l
 
 	return l
@@ -525,30 +545,63 @@ func (s *Manager) initScraperCache() *scraper.Cache {
 }
 
 func (s *Manager) RefreshConfig() {
-	*s.Paths = paths.NewPaths(s.Config.GetGeneratedPath())
 	config := s.Config
-	if config.Validate() == nil {
-		if err := fsutil.EnsureDir(s.Paths.Generated.Screenshots); err != nil {
-			logger.Warnf("could not create directory for Screenshots: %v", err)
-		}
-		if err := fsutil.EnsureDir(s.Paths.Generated.Vtt); err != nil {
-			logger.Warnf("could not create directory for VTT: %v", err)
-		}
-		if err := fsutil.EnsureDir(s.Paths.Generated.Markers); err != nil {
-			logger.Warnf("could not create directory for Markers: %v", err)
-		}
-		if err := fsutil.EnsureDir(s.Paths.Generated.Transcodes); err != nil {
-			logger.Warnf("could not create directory for Transcodes: %v", err)
-		}
-		if err := fsutil.EnsureDir(s.Paths.Generated.Downloads); err != nil {
-			logger.Warnf("could not create directory for Downloads: %v", err)
+
+	// build the paths with all overrides before replacing the shared paths,
+	// so that the overrides are never modified while in use
+	newPaths := paths.NewPaths(config.GetGeneratedPath())
+	for _, stash := range config.GetStashPaths() {
+		if stash.GeneratedPath != "" {
+			newPaths.AddGeneratedOverride(stash.Path, stash.GeneratedPath)
 		}
-		if err := fsutil.EnsureDir(s.Paths.Generated.InteractiveHeatmap); err != nil {
-			logger.Warnf("could not create directory for Interactive Heatmaps: %v", err)
+	}
+
+	s.pathsMutex.Lock()
+	*s.Paths = newPaths
+	s.pathsMutex.Unlock()
+
+	s.Database.Image.SetNaturalPathSort(config.GetGalleryImageNaturalSort())
+
+	resolutionLabelBreakpoints := config.GetResolutionLabelBreakpoints()
+	s.Database.Scene.SetResolutionLabelBreakpoints(resolutionLabelBreakpoints)
+	s.Database.Image.SetResolutionLabelBreakpoints(resolutionLabelBreakpoints)
+
+	hlsSegmentCacheDir := ""
+	if cachePath := config.GetCachePath(); cachePath != "" {
+		hlsSegmentCacheDir = filepath.Join(cachePath, "hls")
+	}
+	s.HLSSegmentCache.SetDir(hlsSegmentCacheDir)
+	if config.Validate() == nil {
+		for _, p := range s.Paths.All() {
+			ensureGeneratedDirs(p)
 		}
 	}
 }
 
+func ensureGeneratedDirs(p *paths.Paths) {
+	if err := fsutil.EnsureDir(p.Generated.Screenshots); err != nil {
+		logger.Warnf("could not create directory for Screenshots: %v", err)
+	}
+	if err := fsutil.EnsureDir(p.Generated.Vtt); err != nil {
+		logger.Warnf("could not create directory for VTT: %v", err)
+	}
+	if err := fsutil.EnsureDir(p.Generated.Markers); err != nil {
+		logger.Warnf("could not create directory for Markers: %v", err)
+	}
+	if err := fsutil.EnsureDir(p.Generated.Transcodes); err != nil {
+		logger.Warnf("could not create directory for Transcodes: %v", err)
+	}
+	if err := fsutil.EnsureDir(p.Generated.Downloads); err != nil {
+		logger.Warnf("could not create directory for Downloads: %v", err)
+	}
+	if err := fsutil.EnsureDir(p.Generated.InteractiveHeatmap); err != nil {
+		logger.Warnf("could not create directory for Interactive Heatmaps: %v", err)
+	}
+	if err := p.Generated.EnsureTmpDir(); err != nil {
+		logger.Warnf("could not create temporary directory: %v", err)
+	}
+}
+
 // RefreshScraperCache refreshes the scraper cache. Call this when scraper
 // configuration changes.
 func (s *Manager) RefreshScraperCache() {
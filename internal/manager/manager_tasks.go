@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"strconv"
 	"sync"
 	"time"
@@ -84,6 +85,7 @@ func (s *Manager) Scan(ctx context.Context, input ScanMetadataInput) (int, error
 		scanner:       s.Scanner,
 		input:         input,
 		subscriptions: s.scanSubs,
+		collisions:    s.OshashCollisions,
 	}
 
 	return s.JobManager.Add(ctx, "Scanning...", &scanJob), nil
@@ -148,8 +150,13 @@ func (s *Manager) Generate(ctx context.Context, input GenerateMetadataInput) (in
 	if err := s.validateFFMPEG(); err != nil {
 		return 0, err
 	}
-	if err := instance.Paths.Generated.EnsureTmpDir(); err != nil {
-		logger.Warnf("could not generate temporary directory: %v", err)
+	if err := input.ContactSheetOptions.validate(); err != nil {
+		return 0, err
+	}
+	for _, p := range instance.Paths.All() {
+		if err := p.Generated.EnsureTmpDir(); err != nil {
+			logger.Warnf("could not generate temporary directory: %v", err)
+		}
 	}
 
 	j := &GenerateJob{
@@ -170,8 +177,10 @@ func (s *Manager) GenerateScreenshot(ctx context.Context, sceneId string, at flo
 
 // generate default screenshot if at is nil
 func (s *Manager) generateScreenshot(ctx context.Context, sceneId string, at *float64) int {
-	if err := instance.Paths.Generated.EnsureTmpDir(); err != nil {
-		logger.Warnf("failure generating screenshot: %v", err)
+	for _, p := range instance.Paths.All() {
+		if err := p.Generated.EnsureTmpDir(); err != nil {
+			logger.Warnf("failure generating screenshot: %v", err)
+		}
 	}
 
 	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) {
@@ -218,12 +227,56 @@ type AutoTagMetadataInput struct {
 	Studios []string `json:"studios"`
 	// IDs of tags to tag files with, or "*" for all
 	Tags []string `json:"tags"`
+	// Order to process files in when tagging files. Defaults to database order
+	Order *AutoTagOrder `json:"order"`
+}
+
+type AutoTagOrder string
+
+const (
+	AutoTagOrderPath        AutoTagOrder = "PATH"
+	AutoTagOrderNewestFirst AutoTagOrder = "NEWEST_FIRST"
+)
+
+var AllAutoTagOrder = []AutoTagOrder{
+	AutoTagOrderPath,
+	AutoTagOrderNewestFirst,
+}
+
+func (e AutoTagOrder) IsValid() bool {
+	switch e {
+	case AutoTagOrderPath, AutoTagOrderNewestFirst:
+		return true
+	}
+	return false
+}
+
+func (e AutoTagOrder) String() string {
+	return string(e)
+}
+
+func (e *AutoTagOrder) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AutoTagOrder(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AutoTagOrder", str)
+	}
+	return nil
+}
+
+func (e AutoTagOrder) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
 func (s *Manager) AutoTag(ctx context.Context, input AutoTagMetadataInput) int {
 	j := autoTagJob{
 		txnManager: s.Repository,
 		input:      input,
+		workers:    s.Config.GetAutoTagParallelTasksWithAutoDetection(),
 	}
 
 	return s.JobManager.Add(ctx, "Auto-tagging...", &j)
@@ -296,6 +349,87 @@ func (s *Manager) MigrateHash(ctx context.Context) int {
 	return s.JobManager.Add(ctx, "Migrating scene hashes...", j)
 }
 
+// RebuildSceneDuplicateGroups starts a job which replaces the stored groups
+// of duplicate scenes, using the configured phash distance and duration
+// tolerance. Returns the job ID.
+func (s *Manager) RebuildSceneDuplicateGroups(ctx context.Context) int {
+	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) {
+		distance := s.Config.GetDuplicatePhashDistance()
+		durationTolerance := s.Config.GetDuplicateDurationTolerance()
+		logger.Infof("Finding duplicate scenes with phash distance %d", distance)
+
+		var groups [][]int
+		if err := s.Repository.WithReadTxn(ctx, func(ctx context.Context) error {
+			var err error
+			groups, err = s.Repository.Scene.FindDuplicateIDs(ctx, distance, durationTolerance)
+			return err
+		}); err != nil {
+			logger.Errorf("Error finding duplicate scenes: %v", err)
+			return
+		}
+
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return
+		}
+
+		if err := s.Repository.WithTxn(ctx, func(ctx context.Context) error {
+			return s.Repository.Scene.SetDuplicateGroups(ctx, groups)
+		}); err != nil {
+			logger.Errorf("Error storing duplicate scene groups: %v", err)
+			return
+		}
+
+		logger.Infof("Stored %d groups of duplicate scenes", len(groups))
+	})
+
+	return s.JobManager.Add(ctx, "Finding duplicate scenes...", j)
+}
+
+// RepairScenesSharingFiles starts a job which detaches each file linked to
+// more than one scene from all but the most complete of the scenes. Returns
+// the job ID.
+func (s *Manager) RepairScenesSharingFiles(ctx context.Context) int {
+	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) {
+		var shared []*models.SceneSharedFile
+		if err := s.Repository.WithReadTxn(ctx, func(ctx context.Context) error {
+			var err error
+			shared, err = s.Repository.Scene.FindSharedFiles(ctx)
+			return err
+		}); err != nil {
+			logger.Errorf("Error finding files shared by scenes: %v", err)
+			return
+		}
+
+		progress.SetTotal(len(shared))
+
+		repaired := 0
+		for _, sf := range shared {
+			if job.IsCancelled(ctx) {
+				logger.Info("Stopping due to user request")
+				break
+			}
+
+			progress.ExecuteTask(fmt.Sprintf("Repairing file %d", sf.FileID), func() {
+				if err := s.Repository.WithTxn(ctx, func(ctx context.Context) error {
+					return s.SceneService.RepairSharedFile(ctx, sf)
+				}); err != nil {
+					logger.Errorf("Error repairing file %d: %v", sf.FileID, err)
+					return
+				}
+
+				repaired++
+			})
+
+			progress.Increment()
+		}
+
+		logger.Infof("Repaired %d files shared by scenes", repaired)
+	})
+
+	return s.JobManager.Add(ctx, "Repairing scenes sharing files...", j)
+}
+
 // If neither performer_ids nor performer_names are set, tag all performers
 type StashBoxBatchPerformerTagInput struct {
 	// Stash endpoint to use for the performer tagging
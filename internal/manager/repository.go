@@ -5,6 +5,7 @@ import (
 
 	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/gallery"
+	"github.com/stashapp/stash/pkg/hash/audiofingerprint"
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
@@ -31,6 +32,14 @@ type SceneReaderWriter interface {
 	models.SceneReaderWriter
 	scene.CreatorUpdater
 	GetManyFileIDs(ctx context.Context, ids []int) ([][]file.ID, error)
+	FindSceneIDsByFolder(ctx context.Context) (map[file.FolderID][]int, error)
+	AddStubFingerprints(ctx context.Context, sceneID int, fp []file.Fingerprint) error
+	AllAudioFingerprints(ctx context.Context) ([]audiofingerprint.Fingerprint, error)
+	FindDuplicateIDs(ctx context.Context, distance int, durationTolerance float64) ([][]int, error)
+	SetDuplicateGroups(ctx context.Context, groups [][]int) error
+	FindDuplicateGroups(ctx context.Context) ([][]*models.Scene, error)
+	FindUnlinkedPerformerCandidates(ctx context.Context) (map[int][]int, error)
+	QueryMarkerCoverage(ctx context.Context, options models.SceneMarkerCoverageOptions) ([]*models.SceneMarkerCoverage, int, error)
 }
 
 type FileReaderWriter interface {
@@ -39,6 +48,7 @@ type FileReaderWriter interface {
 	Query(ctx context.Context, options models.FileQueryOptions) (*models.FileQueryResult, error)
 	GetCaptions(ctx context.Context, fileID file.ID) ([]*models.VideoCaption, error)
 	IsPrimary(ctx context.Context, fileID file.ID) (bool, error)
+	FindUnlinkedVideoFiles(ctx context.Context) ([]*file.VideoFile, error)
 }
 
 type FolderReaderWriter interface {
@@ -99,6 +109,8 @@ type SceneService interface {
 	Create(ctx context.Context, input *models.Scene, fileIDs []file.ID, coverImage []byte) (*models.Scene, error)
 	AssignFile(ctx context.Context, sceneID int, fileID file.ID) error
 	Merge(ctx context.Context, sourceIDs []int, destinationID int, values models.ScenePartial) error
+	MergeWithRules(ctx context.Context, ids []int, rules []*models.SceneMergeRule) error
+	RepairSharedFile(ctx context.Context, sf *models.SceneSharedFile) error
 	Destroy(ctx context.Context, scene *models.Scene, fileDeleter *scene.FileDeleter, deleteGenerated, deleteFile bool) error
 }
 
@@ -110,6 +122,7 @@ type ImageService interface {
 type GalleryService interface {
 	AddImages(ctx context.Context, g *models.Gallery, toAdd ...int) error
 	RemoveImages(ctx context.Context, g *models.Gallery, toRemove ...int) error
+	Split(ctx context.Context, g *models.Gallery, imageGroups [][]int) ([]*models.Gallery, error)
 
 	Destroy(ctx context.Context, i *models.Gallery, fileDeleter *image.FileDeleter, deleteGenerated, deleteFile bool) ([]*models.Image, error)
 
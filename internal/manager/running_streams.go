@@ -77,7 +77,7 @@ func KillRunningStreams(scene *models.Scene, fileNamingAlgo models.HashAlgorithm
 		return
 	}
 
-	transcodePath := GetInstance().Paths.Scene.GetTranscodePath(sceneHash)
+	transcodePath := GetInstance().Paths.ForPath(scene.Path).Scene.GetTranscodePath(sceneHash)
 	instance.ReadLockManager.Cancel(transcodePath)
 }
 
@@ -93,7 +93,7 @@ type SceneServer struct {
 func (s *SceneServer) StreamSceneDirect(scene *models.Scene, w http.ResponseWriter, r *http.Request) {
 	fileNamingAlgo := config.GetInstance().GetVideoFileNamingAlgorithm()
 
-	filepath := GetInstance().Paths.Scene.GetStreamPath(scene.Path, scene.GetHash(fileNamingAlgo))
+	filepath := GetInstance().Paths.ForPath(scene.Path).Scene.GetStreamPath(scene.Path, scene.GetHash(fileNamingAlgo))
 	streamRequestCtx := NewStreamRequestContext(w, r)
 
 	// #2579 - hijacking and closing the connection here causes video playback to fail in Safari
@@ -107,7 +107,7 @@ func (s *SceneServer) ServeScreenshot(scene *models.Scene, w http.ResponseWriter
 	const defaultSceneImage = "scene/scene.svg"
 
 	if scene.Path != "" {
-		filepath := GetInstance().Paths.Scene.GetScreenshotPath(scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()))
+		filepath := GetInstance().Paths.ForPath(scene.Path).Scene.GetScreenshotPath(scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()))
 
 		// fall back to the scene image blob if the file isn't present
 		screenshotExists, _ := fsutil.FileExists(filepath)
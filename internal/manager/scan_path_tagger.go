@@ -0,0 +1,113 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/tag"
+)
+
+type pathTaggerReaderWriter interface {
+	tag.Queryer
+	Create(ctx context.Context, newTag models.Tag) (*models.Tag, error)
+}
+
+// pathTagger determines tags using the configured rules for the directory
+// names in the path of files. Missing tags are created.
+type pathTagger struct {
+	rules        []*config.ScanPathTagRule
+	readerWriter pathTaggerReaderWriter
+}
+
+// newPathTagger returns a path tagger using the configured rules. Returns
+// nil if no rules are configured.
+func newPathTagger(c *config.Instance, rw pathTaggerReaderWriter) scene.FilePathTagger {
+	rules := c.GetScanPathTagRules()
+	if len(rules) == 0 {
+		return nil
+	}
+
+	return &pathTagger{
+		rules:        rules,
+		readerWriter: rw,
+	}
+}
+
+func (t *pathTagger) PathTags(ctx context.Context, f file.File) ([]int, error) {
+	var ret []int
+	for _, name := range t.match(f.Base().Path) {
+		id, err := t.getOrCreate(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, id)
+	}
+
+	return ret, nil
+}
+
+// match returns the names of the tags of the rules matching the directory
+// names in the path.
+func (t *pathTagger) match(path string) []string {
+	dirs := strings.FieldsFunc(filepath.Dir(path), func(r rune) bool {
+		return r == '/' || r == filepath.Separator
+	})
+
+	var ret []string
+	seen := make(map[string]bool)
+	for _, r := range t.rules {
+		if r.Component == "" {
+			continue
+		}
+
+		for _, d := range dirs {
+			if !strings.EqualFold(d, r.Component) {
+				continue
+			}
+
+			name := r.TagName()
+			if !seen[strings.ToLower(name)] {
+				seen[strings.ToLower(name)] = true
+				ret = append(ret, name)
+			}
+			break
+		}
+	}
+
+	return ret
+}
+
+func (t *pathTagger) getOrCreate(ctx context.Context, name string) (int, error) {
+	existing, err := tag.ByName(ctx, t.readerWriter, name)
+	if err != nil {
+		return 0, err
+	}
+
+	if existing == nil {
+		existing, err = tag.ByAlias(ctx, t.readerWriter, name)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if existing != nil {
+		return existing.ID, nil
+	}
+
+	logger.Infof("Creating tag %q from path rule", name)
+
+	created, err := t.readerWriter.Create(ctx, *models.NewTag(name))
+	if err != nil {
+		return 0, fmt.Errorf("creating tag %q: %w", name, err)
+	}
+
+	return created.ID, nil
+}
@@ -0,0 +1,42 @@
+package manager
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stashapp/stash/internal/manager/config"
+)
+
+func TestPathTaggerMatch(t *testing.T) {
+	tagger := &pathTagger{
+		rules: []*config.ScanPathTagRule{
+			{Component: "4K"},
+			{Component: "uhd", Tag: "4K"},
+			{Component: "Category", Tag: "Category Tag"},
+			{Component: ""},
+		},
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{"none", "/stash/videos/file.mp4", nil},
+		{"component", "/stash/4K/file.mp4", []string{"4K"}},
+		{"case insensitive", "/stash/4k/file.mp4", []string{"4K"}},
+		{"mapped tag", "/stash/category/file.mp4", []string{"Category Tag"}},
+		{"duplicate tag", "/stash/UHD/4K/file.mp4", []string{"4K"}},
+		{"multiple", "/stash/Category/4K/file.mp4", []string{"4K", "Category Tag"}},
+		{"file name", "/stash/videos/4K", nil},
+		{"partial", "/stash/4K videos/file.mp4", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tagger.match(tt.path); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("match(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/studio"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+type studioSceneUpdater interface {
+	Find(ctx context.Context, id int) (*models.Scene, error)
+	UpdatePartial(ctx context.Context, id int, updatedScene models.ScenePartial) (*models.Scene, error)
+}
+
+// metadataStudioMatcher matches studios using a container metadata tag of
+// video files. The files are probed in tasks of the scan task queue, outside
+// of the scan transaction.
+type metadataStudioMatcher struct {
+	ffprobe     ffmpeg.FFProbe
+	txnManager  txn.Manager
+	queryer     studio.Queryer
+	scenes      studioSceneUpdater
+	pluginCache *plugin.Cache
+	taskQueue   *job.TaskQueue
+	progress    *job.Progress
+	tag         string
+	pattern     *regexp.Regexp
+}
+
+// newMetadataStudioMatcher returns a studio matcher using the configured
+// metadata tag. Returns nil if not configured.
+func newMetadataStudioMatcher(c *config.Instance, ffprobe ffmpeg.FFProbe, repo Repository, pluginCache *plugin.Cache, taskQueue *job.TaskQueue, progress *job.Progress) scene.FileStudioMatcher {
+	tag := c.GetStudioFromMetadataTag()
+	if tag == "" {
+		return nil
+	}
+
+	ret := &metadataStudioMatcher{
+		ffprobe:     ffprobe,
+		txnManager:  repo,
+		queryer:     repo.Studio,
+		scenes:      repo.Scene,
+		pluginCache: pluginCache,
+		taskQueue:   taskQueue,
+		progress:    progress,
+		tag:         strings.ToLower(tag),
+	}
+
+	if p := c.GetStudioFromMetadataTagPattern(); p != "" {
+		var err error
+		ret.pattern, err = regexp.Compile(p)
+		if err != nil {
+			logger.Warnf("Not setting studios from metadata: invalid pattern %q: %v", p, err)
+			return nil
+		}
+	}
+
+	return ret
+}
+
+func (m *metadataStudioMatcher) MatchStudio(ctx context.Context, sceneIDs []int, f *file.VideoFile) error {
+	// ffprobe cannot read files inside zip files
+	if f.ZipFileID != nil {
+		return nil
+	}
+
+	m.progress.AddTotal(1)
+	m.taskQueue.Add(fmt.Sprintf("Matching studio for %s", f.Path), func(ctx context.Context) {
+		defer m.progress.Increment()
+
+		if err := m.matchStudio(ctx, sceneIDs, f); err != nil && ctx.Err() == nil {
+			// just log if matching fails. We can try again on rescan
+			logger.Warnf("Error matching studio for %s: %v", f.Path, err)
+		}
+	})
+
+	return nil
+}
+
+// matchStudio reads the metadata of the file, and then sets the studio of
+// the scenes in a transaction.
+func (m *metadataStudioMatcher) matchStudio(ctx context.Context, sceneIDs []int, f *file.VideoFile) error {
+	tags, err := m.ffprobe.FormatTags(f.Path)
+	if err != nil {
+		return err
+	}
+
+	name := m.normalize(tags[m.tag])
+	if name == "" {
+		return nil
+	}
+
+	return txn.WithTxn(ctx, m.txnManager, func(ctx context.Context) error {
+		return m.setStudio(ctx, sceneIDs, name, f)
+	})
+}
+
+// setStudio sets the studio of the scenes that still have no studio to the
+// studio with the provided name or alias.
+func (m *metadataStudioMatcher) setStudio(ctx context.Context, sceneIDs []int, name string, f *file.VideoFile) error {
+	s, err := studio.ByName(ctx, m.queryer, name)
+	if err != nil {
+		return err
+	}
+
+	if s == nil {
+		s, err = studio.ByAlias(ctx, m.queryer, name)
+		if err != nil {
+			return err
+		}
+	}
+
+	if s == nil {
+		logger.Debugf("No studio found matching %q from metadata of %s", name, f.Path)
+		return nil
+	}
+
+	for _, id := range sceneIDs {
+		// the studio may have been set since the scan
+		existing, err := m.scenes.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if existing == nil || existing.StudioID != nil {
+			continue
+		}
+
+		logger.Infof("Setting studio of scene %s from file metadata", existing.DisplayName())
+
+		partial := models.NewScenePartial()
+		partial.StudioID = models.NewOptionalInt(s.ID)
+
+		if _, err := m.scenes.UpdatePartial(ctx, id, partial); err != nil {
+			return fmt.Errorf("updating scene studio: %w", err)
+		}
+
+		m.pluginCache.RegisterPostHooks(ctx, id, plugin.SceneUpdatePost, nil, nil)
+	}
+
+	return nil
+}
+
+// normalize extracts the studio name from the tag value using the
+// configured pattern.
+func (m *metadataStudioMatcher) normalize(v string) string {
+	if m.pattern != nil {
+		match := m.pattern.FindStringSubmatch(v)
+		switch {
+		case match == nil:
+			return ""
+		case len(match) > 1:
+			v = match[1]
+		default:
+			v = match[0]
+		}
+	}
+
+	return strings.Join(strings.Fields(v), " ")
+}
@@ -0,0 +1,94 @@
+package manager
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/txn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMetadataStudioMatcherNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		value   string
+		want    string
+	}{
+		{"no pattern", "", "  Studio   Name ", "Studio Name"},
+		{"whole match", `[A-Za-z ]+`, "Studio Name - 2020", "Studio Name"},
+		{"capturing group", `^Studio: (.*)$`, "Studio: Studio Name", "Studio Name"},
+		{"no match", `^Studio: (.*)$`, "Other", ""},
+		{"empty", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &metadataStudioMatcher{}
+			if tt.pattern != "" {
+				m.pattern = regexp.MustCompile(tt.pattern)
+			}
+
+			if got := m.normalize(tt.value); got != tt.want {
+				t.Errorf("normalize(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMetadataStudioMatcherSetStudio(t *testing.T) {
+	const (
+		studioID       = 1
+		noStudioID     = 10
+		hasStudioID    = 11
+		missingSceneID = 12
+		otherStudioID  = 2
+	)
+
+	ctx := context.Background()
+	f := &file.VideoFile{BaseFile: &file.BaseFile{Path: "scene.mp4"}}
+
+	studioRW := &mocks.StudioReaderWriter{}
+	studioRW.On("Query", mock.Anything, mock.MatchedBy(func(f *models.StudioFilterType) bool {
+		return f.Name != nil && f.Name.Value == "Studio"
+	}), mock.Anything).Return([]*models.Studio{{ID: studioID}}, 1, nil)
+	studioRW.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(nil, 0, nil)
+
+	otherStudio := otherStudioID
+	sceneRW := &mocks.SceneReaderWriter{}
+	sceneRW.On("Find", mock.Anything, noStudioID).Return(&models.Scene{ID: noStudioID}, nil)
+	sceneRW.On("Find", mock.Anything, hasStudioID).Return(&models.Scene{ID: hasStudioID, StudioID: &otherStudio}, nil)
+	sceneRW.On("Find", mock.Anything, missingSceneID).Return(nil, nil)
+	sceneRW.On("UpdatePartial", mock.Anything, noStudioID, mock.MatchedBy(func(p models.ScenePartial) bool {
+		return p.StudioID.Set && p.StudioID.Value == studioID
+	})).Return(&models.Scene{ID: noStudioID}, nil).Once()
+
+	m := &metadataStudioMatcher{
+		queryer:     studioRW,
+		scenes:      sceneRW,
+		pluginCache: &plugin.Cache{},
+	}
+
+	withTxn := func(fn txn.TxnFunc) error {
+		return txn.WithTxn(ctx, &mocks.TxnManager{}, fn)
+	}
+
+	// scenes which had a studio set since the scan are not changed
+	assert.NoError(t, withTxn(func(ctx context.Context) error {
+		return m.setStudio(ctx, []int{noStudioID, hasStudioID, missingSceneID}, "Studio", f)
+	}))
+
+	// unknown studios are ignored
+	assert.NoError(t, withTxn(func(ctx context.Context) error {
+		return m.setStudio(ctx, []int{noStudioID}, "Unknown", f)
+	}))
+
+	sceneRW.AssertExpectations(t)
+	sceneRW.AssertNumberOfCalls(t, "UpdatePartial", 1)
+}
@@ -57,6 +57,11 @@ type SceneStreamEndpoint struct {
 	URL      string  `json:"url"`
 	MimeType *string `json:"mime_type"`
 	Label    *string `json:"label"`
+
+	// IntroEnd and OutroStart are the skippable ranges of the scene, so
+	// that clients can offer to skip them.
+	IntroEnd   *float64 `json:"intro_end"`
+	OutroStart *float64 `json:"outro_start"`
 }
 
 func makeStreamEndpoint(streamURL *url.URL, streamingResolution models.StreamingResolutionEnum, mimeType, label string) *SceneStreamEndpoint {
@@ -191,6 +196,11 @@ func GetSceneStreamPaths(scene *models.Scene, directStreamURL *url.URL, maxStrea
 	}
 	ret = append(ret, &hls)
 
+	for _, e := range ret {
+		e.IntroEnd = scene.IntroEnd
+		e.OutroStart = scene.OutroStart
+	}
+
 	return ret, nil
 }
 
@@ -207,7 +217,7 @@ func HasTranscode(scene *models.Scene, fileNamingAlgo models.HashAlgorithm) bool
 		return false
 	}
 
-	transcodePath := instance.Paths.Scene.GetTranscodePath(sceneHash)
+	transcodePath := instance.Paths.ForPath(scene.Path).Scene.GetTranscodePath(sceneHash)
 	ret, _ := fsutil.FileExists(transcodePath)
 	return ret
 }
@@ -21,6 +21,7 @@ import (
 type autoTagJob struct {
 	txnManager Repository
 	input      AutoTagMetadataInput
+	workers    int
 
 	cache match.Cache
 }
@@ -58,6 +59,8 @@ func (j *autoTagJob) autoTagFiles(ctx context.Context, progress *job.Progress, p
 		progress:   progress,
 		txnManager: j.txnManager,
 		cache:      &j.cache,
+		order:      j.input.Order,
+		workers:    j.workers,
 	}
 
 	t.process(ctx)
@@ -335,6 +338,12 @@ func (j *autoTagJob) autoTagTags(ctx context.Context, progress *job.Progress, pa
 						return fmt.Errorf("getting tag aliases: %w", err)
 					}
 
+					keywords, err := r.Tag.GetAutoTagKeywords(ctx, tag.ID)
+					if err != nil {
+						return fmt.Errorf("getting tag auto-tag keywords: %w", err)
+					}
+					aliases = append(aliases, keywords...)
+
 					if err := tagger.TagScenes(ctx, tag, paths, aliases, r.Scene); err != nil {
 						return fmt.Errorf("processing scenes: %w", err)
 					}
@@ -370,6 +379,94 @@ type autoTagFilesTask struct {
 	progress   *job.Progress
 	txnManager Repository
 	cache      *match.Cache
+
+	order *AutoTagOrder
+	// number of workers used to match files concurrently
+	workers int
+}
+
+func (t *autoTagFilesTask) makeFindFilter(batchSize int) *models.FindFilterType {
+	ret := models.BatchFindFilter(batchSize)
+
+	if t.order != nil {
+		var sort string
+		direction := models.SortDirectionEnumAsc
+		switch *t.order {
+		case AutoTagOrderPath:
+			sort = "path"
+		case AutoTagOrderNewestFirst:
+			sort = "created_at"
+			direction = models.SortDirectionEnumDesc
+		}
+
+		ret.Sort = &sort
+		ret.Direction = &direction
+	}
+
+	return ret
+}
+
+// processBatch tags the n objects of a batch. If more than one worker is
+// configured, the matching performers, studios and tags are first queried
+// concurrently using prefetch, each within a read transaction. The objects
+// are then tagged in batch order using tag, so that database writes are
+// serialized and the results are the same regardless of the number of
+// workers.
+func (t *autoTagFilesTask) processBatch(ctx context.Context, n int, prefetch func(ctx context.Context, i int) error, tag func(ctx context.Context, i int)) {
+	if t.workers > 1 {
+		queue := make(chan int)
+		var wg sync.WaitGroup
+		for w := 0; w < t.workers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range queue {
+					if err := t.txnManager.WithReadTxn(ctx, func(ctx context.Context) error {
+						return prefetch(ctx, i)
+					}); err != nil {
+						// matches are queried again when tagging
+						logger.Warnf("error querying autotag matches: %v", err)
+					}
+				}
+			}()
+		}
+
+		for i := 0; i < n && !job.IsCancelled(ctx); i++ {
+			queue <- i
+		}
+		close(queue)
+		wg.Wait()
+	}
+
+	for i := 0; i < n; i++ {
+		if job.IsCancelled(ctx) {
+			return
+		}
+
+		tag(ctx, i)
+		t.progress.Increment()
+	}
+}
+
+func (t *autoTagFilesTask) performerReader(r Repository) match.PerformerAutoTagQueryer {
+	if !t.performers {
+		return nil
+	}
+	return r.Performer
+}
+
+func (t *autoTagFilesTask) studioReader(r Repository) match.StudioAutoTagQueryer {
+	if !t.studios {
+		return nil
+	}
+	return r.Studio
+}
+
+func (t *autoTagFilesTask) tagReader(r Repository) match.TagAutoTagQueryer {
+	if !t.tags {
+		return nil
+	}
+	return r.Tag
 }
 
 func (t *autoTagFilesTask) makeSceneFilter() *models.SceneFilterType {
@@ -495,7 +592,7 @@ func (t *autoTagFilesTask) processScenes(ctx context.Context, r Repository) erro
 
 	batchSize := 1000
 
-	findFilter := models.BatchFindFilter(batchSize)
+	findFilter := t.makeFindFilter(batchSize)
 	sceneFilter := t.makeSceneFilter()
 
 	more := true
@@ -509,14 +606,16 @@ func (t *autoTagFilesTask) processScenes(ctx context.Context, r Repository) erro
 			return fmt.Errorf("querying scenes: %w", err)
 		}
 
-		for _, ss := range scenes {
-			if job.IsCancelled(ctx) {
+		t.processBatch(ctx, len(scenes), func(ctx context.Context, i int) error {
+			ss := scenes[i]
+			if ss.Path == "" {
 				return nil
 			}
-
+			return autotag.PrefetchScene(ctx, ss, t.performerReader(r), t.studioReader(r), t.tagReader(r), t.cache)
+		}, func(ctx context.Context, i int) {
 			tt := autoTagSceneTask{
 				txnManager: t.txnManager,
-				scene:      ss,
+				scene:      scenes[i],
 				performers: t.performers,
 				studios:    t.studios,
 				tags:       t.tags,
@@ -527,8 +626,10 @@ func (t *autoTagFilesTask) processScenes(ctx context.Context, r Repository) erro
 			wg.Add(1)
 			go tt.Start(ctx, &wg)
 			wg.Wait()
+		})
 
-			t.progress.Increment()
+		if job.IsCancelled(ctx) {
+			return nil
 		}
 
 		if len(scenes) != batchSize {
@@ -552,7 +653,7 @@ func (t *autoTagFilesTask) processImages(ctx context.Context, r Repository) erro
 
 	batchSize := 1000
 
-	findFilter := models.BatchFindFilter(batchSize)
+	findFilter := t.makeFindFilter(batchSize)
 	imageFilter := t.makeImageFilter()
 
 	more := true
@@ -566,14 +667,12 @@ func (t *autoTagFilesTask) processImages(ctx context.Context, r Repository) erro
 			return fmt.Errorf("querying images: %w", err)
 		}
 
-		for _, ss := range images {
-			if job.IsCancelled(ctx) {
-				return nil
-			}
-
+		t.processBatch(ctx, len(images), func(ctx context.Context, i int) error {
+			return autotag.PrefetchImage(ctx, images[i], t.performerReader(r), t.studioReader(r), t.tagReader(r), t.cache)
+		}, func(ctx context.Context, i int) {
 			tt := autoTagImageTask{
 				txnManager: t.txnManager,
-				image:      ss,
+				image:      images[i],
 				performers: t.performers,
 				studios:    t.studios,
 				tags:       t.tags,
@@ -584,8 +683,10 @@ func (t *autoTagFilesTask) processImages(ctx context.Context, r Repository) erro
 			wg.Add(1)
 			go tt.Start(ctx, &wg)
 			wg.Wait()
+		})
 
-			t.progress.Increment()
+		if job.IsCancelled(ctx) {
+			return nil
 		}
 
 		if len(images) != batchSize {
@@ -609,7 +710,7 @@ func (t *autoTagFilesTask) processGalleries(ctx context.Context, r Repository) e
 
 	batchSize := 1000
 
-	findFilter := models.BatchFindFilter(batchSize)
+	findFilter := t.makeFindFilter(batchSize)
 	galleryFilter := t.makeGalleryFilter()
 
 	more := true
@@ -623,14 +724,12 @@ func (t *autoTagFilesTask) processGalleries(ctx context.Context, r Repository) e
 			return fmt.Errorf("querying galleries: %w", err)
 		}
 
-		for _, ss := range galleries {
-			if job.IsCancelled(ctx) {
-				return nil
-			}
-
+		t.processBatch(ctx, len(galleries), func(ctx context.Context, i int) error {
+			return autotag.PrefetchGallery(ctx, galleries[i], t.performerReader(r), t.studioReader(r), t.tagReader(r), t.cache)
+		}, func(ctx context.Context, i int) {
 			tt := autoTagGalleryTask{
 				txnManager: t.txnManager,
-				gallery:    ss,
+				gallery:    galleries[i],
 				performers: t.performers,
 				studios:    t.studios,
 				tags:       t.tags,
@@ -641,8 +740,10 @@ func (t *autoTagFilesTask) processGalleries(ctx context.Context, r Repository) e
 			wg.Add(1)
 			go tt.Start(ctx, &wg)
 			wg.Wait()
+		})
 
-			t.progress.Increment()
+		if job.IsCancelled(ctx) {
+			return nil
 		}
 
 		if len(galleries) != batchSize {
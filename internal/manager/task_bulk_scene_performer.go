@@ -0,0 +1,143 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/sliceutil/intslice"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+type bulkScenePerformerJob struct {
+	txnManager  Repository
+	sceneFilter *models.SceneFilterType
+	performerID int
+	// mode is either RelationshipUpdateModeAdd or RelationshipUpdateModeRemove
+	mode models.RelationshipUpdateMode
+}
+
+func (j *bulkScenePerformerJob) Execute(ctx context.Context, progress *job.Progress) {
+	var sceneIDs []int
+	if err := txn.WithReadTxn(ctx, j.txnManager, func(ctx context.Context) error {
+		all := -1
+		result, err := j.txnManager.Scene.Query(ctx, scene.QueryOptions(j.sceneFilter, &models.FindFilterType{
+			PerPage: &all,
+		}, false))
+		if err != nil {
+			return err
+		}
+
+		sceneIDs = result.IDs
+		return nil
+	}); err != nil {
+		logger.Errorf("Error finding scenes: %v", err)
+		return
+	}
+
+	logger.Infof("Updating performers of %d scenes", len(sceneIDs))
+
+	progress.SetTotal(len(sceneIDs))
+
+	updated := 0
+	for _, id := range sceneIDs {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return
+		}
+
+		var changed bool
+		if err := txn.WithTxn(ctx, j.txnManager, func(ctx context.Context) error {
+			var err error
+			changed, err = j.updateScene(ctx, id)
+			return err
+		}); err != nil {
+			logger.Errorf("Error updating scene %d: %v", id, err)
+		} else if changed {
+			updated++
+		}
+
+		progress.Increment()
+	}
+
+	logger.Infof("Updated performers of %d scenes", updated)
+}
+
+// updateScene adds or removes the performer from the scene. Returns false
+// if the scene did not need to be changed.
+func (j *bulkScenePerformerJob) updateScene(ctx context.Context, sceneID int) (bool, error) {
+	qb := j.txnManager.Scene
+
+	performerIDs, err := qb.GetPerformerIDs(ctx, sceneID)
+	if err != nil {
+		return false, err
+	}
+
+	if intslice.IntInclude(performerIDs, j.performerID) == (j.mode == models.RelationshipUpdateModeAdd) {
+		return false, nil
+	}
+
+	partial := models.NewScenePartial()
+	partial.PerformerIDs = &models.UpdateIDs{
+		IDs:  []int{j.performerID},
+		Mode: j.mode,
+	}
+
+	if _, err := qb.UpdatePartial(ctx, sceneID, partial); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// BulkAddPerformerToScenes starts a job which adds the performer to all
+// scenes matching the provided filter. Existing performers of the scenes
+// are retained. Returns the job ID.
+func (s *Manager) BulkAddPerformerToScenes(ctx context.Context, sceneFilter *models.SceneFilterType, performerID string) (int, error) {
+	return s.bulkScenePerformer(ctx, sceneFilter, performerID, models.RelationshipUpdateModeAdd, "Adding performer to scenes...")
+}
+
+// BulkRemovePerformerFromScenes starts a job which removes the performer
+// from all scenes matching the provided filter. Returns the job ID.
+func (s *Manager) BulkRemovePerformerFromScenes(ctx context.Context, sceneFilter *models.SceneFilterType, performerID string) (int, error) {
+	return s.bulkScenePerformer(ctx, sceneFilter, performerID, models.RelationshipUpdateModeRemove, "Removing performer from scenes...")
+}
+
+func (s *Manager) bulkScenePerformer(ctx context.Context, sceneFilter *models.SceneFilterType, performerID string, mode models.RelationshipUpdateMode, description string) (int, error) {
+	id, err := strconv.Atoi(performerID)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid performer id: %v", ErrInput, err)
+	}
+
+	if err := s.Repository.WithReadTxn(ctx, func(ctx context.Context) error {
+		p, err := s.Repository.Performer.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if p == nil {
+			return fmt.Errorf("%w: performer with id %d not found", ErrInput, id)
+		}
+
+		return nil
+	}); err != nil {
+		if errors.Is(err, ErrInput) {
+			return 0, err
+		}
+		return 0, fmt.Errorf("finding performer: %w", err)
+	}
+
+	j := &bulkScenePerformerJob{
+		txnManager:  s.Repository,
+		sceneFilter: sceneFilter,
+		performerID: id,
+		mode:        mode,
+	}
+
+	return s.JobManager.Add(ctx, description, j), nil
+}
@@ -0,0 +1,90 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// bulkSceneRW forwards the methods used by the bulk scene jobs to a mock.
+// Other methods panic.
+type bulkSceneRW struct {
+	SceneReaderWriter
+	mock *mocks.SceneReaderWriter
+}
+
+func (s *bulkSceneRW) Find(ctx context.Context, id int) (*models.Scene, error) {
+	return s.mock.Find(ctx, id)
+}
+
+func (s *bulkSceneRW) FindMany(ctx context.Context, ids []int) ([]*models.Scene, error) {
+	return s.mock.FindMany(ctx, ids)
+}
+
+func (s *bulkSceneRW) Query(ctx context.Context, options models.SceneQueryOptions) (*models.SceneQueryResult, error) {
+	return s.mock.Query(ctx, options)
+}
+
+func (s *bulkSceneRW) GetFiles(ctx context.Context, relatedID int) ([]*file.VideoFile, error) {
+	return s.mock.GetFiles(ctx, relatedID)
+}
+
+func (s *bulkSceneRW) GetPerformerIDs(ctx context.Context, relatedID int) ([]int, error) {
+	return s.mock.GetPerformerIDs(ctx, relatedID)
+}
+
+func (s *bulkSceneRW) UpdatePartial(ctx context.Context, id int, partial models.ScenePartial) (*models.Scene, error) {
+	return s.mock.UpdatePartial(ctx, id, partial)
+}
+
+func TestBulkScenePerformerJobUpdateScene(t *testing.T) {
+	const (
+		performerID      = 1
+		otherPerformerID = 2
+		sceneID          = 10
+	)
+
+	tests := []struct {
+		name         string
+		mode         models.RelationshipUpdateMode
+		performerIDs []int
+		want         bool
+	}{
+		{"add missing", models.RelationshipUpdateModeAdd, []int{otherPerformerID}, true},
+		{"add existing", models.RelationshipUpdateModeAdd, []int{otherPerformerID, performerID}, false},
+		{"remove existing", models.RelationshipUpdateModeRemove, []int{performerID}, true},
+		{"remove missing", models.RelationshipUpdateModeRemove, []int{otherPerformerID}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sceneRW := &mocks.SceneReaderWriter{}
+			sceneRW.On("GetPerformerIDs", mock.Anything, sceneID).Return(tt.performerIDs, nil).Once()
+			if tt.want {
+				// only the performer is added or removed, so other performers are kept
+				sceneRW.On("UpdatePartial", mock.Anything, sceneID, mock.MatchedBy(func(p models.ScenePartial) bool {
+					return p.PerformerIDs != nil && p.PerformerIDs.Mode == tt.mode && assert.ObjectsAreEqual([]int{performerID}, p.PerformerIDs.IDs)
+				})).Return(&models.Scene{ID: sceneID}, nil).Once()
+			}
+
+			j := &bulkScenePerformerJob{
+				txnManager: Repository{
+					Scene: &bulkSceneRW{mock: sceneRW},
+				},
+				performerID: performerID,
+				mode:        tt.mode,
+			}
+
+			got, err := j.updateScene(context.Background(), sceneID)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			sceneRW.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+type bulkSceneStudioJob struct {
+	txnManager  Repository
+	pathPattern string
+	studioID    int
+	// onlyMissing is true if only scenes without a studio are updated
+	onlyMissing bool
+}
+
+func (j *bulkSceneStudioJob) Execute(ctx context.Context, progress *job.Progress) {
+	var sceneIDs []int
+	if err := txn.WithReadTxn(ctx, j.txnManager, func(ctx context.Context) error {
+		all := -1
+		result, err := j.txnManager.Scene.Query(ctx, scene.QueryOptions(&models.SceneFilterType{
+			Path: &models.StringCriterionInput{
+				Value:    j.pathPattern,
+				Modifier: models.CriterionModifierMatchesRegex,
+			},
+		}, &models.FindFilterType{
+			PerPage: &all,
+		}, false))
+		if err != nil {
+			return err
+		}
+
+		sceneIDs = result.IDs
+		return nil
+	}); err != nil {
+		logger.Errorf("Error finding scenes: %v", err)
+		return
+	}
+
+	logger.Infof("Setting studio of %d scenes matching %q", len(sceneIDs), j.pathPattern)
+
+	progress.SetTotal(len(sceneIDs))
+
+	updated := 0
+	for _, id := range sceneIDs {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return
+		}
+
+		var changed bool
+		if err := txn.WithTxn(ctx, j.txnManager, func(ctx context.Context) error {
+			var err error
+			changed, err = j.updateScene(ctx, id)
+			return err
+		}); err != nil {
+			logger.Errorf("Error updating scene %d: %v", id, err)
+		} else if changed {
+			updated++
+		}
+
+		progress.Increment()
+	}
+
+	logger.Infof("Set studio of %d scenes", updated)
+}
+
+// updateScene sets the studio of the scene. Returns false if the scene did
+// not need to be changed.
+func (j *bulkSceneStudioJob) updateScene(ctx context.Context, sceneID int) (bool, error) {
+	qb := j.txnManager.Scene
+
+	s, err := qb.Find(ctx, sceneID)
+	if err != nil {
+		return false, err
+	}
+
+	if s == nil || s.StudioID != nil && (j.onlyMissing || *s.StudioID == j.studioID) {
+		return false, nil
+	}
+
+	partial := models.NewScenePartial()
+	partial.StudioID = models.NewOptionalInt(j.studioID)
+
+	if _, err := qb.UpdatePartial(ctx, sceneID, partial); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// BulkSetStudioByPath starts a job which sets the studio of all scenes with
+// a file path matching the provided regular expression. If onlyMissing is
+// true, then only scenes without a studio are updated. Returns the job ID.
+func (s *Manager) BulkSetStudioByPath(ctx context.Context, pathPattern string, studioID string, onlyMissing bool) (int, error) {
+	if pathPattern == "" {
+		return 0, fmt.Errorf("%w: path pattern must not be empty", ErrInput)
+	}
+
+	if _, err := regexp.Compile(pathPattern); err != nil {
+		return 0, fmt.Errorf("%w: invalid path pattern: %v", ErrInput, err)
+	}
+
+	id, err := strconv.Atoi(studioID)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid studio id: %v", ErrInput, err)
+	}
+
+	if err := s.Repository.WithReadTxn(ctx, func(ctx context.Context) error {
+		studio, err := s.Repository.Studio.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if studio == nil {
+			return fmt.Errorf("%w: studio with id %d not found", ErrInput, id)
+		}
+
+		return nil
+	}); err != nil {
+		if errors.Is(err, ErrInput) {
+			return 0, err
+		}
+		return 0, fmt.Errorf("finding studio: %w", err)
+	}
+
+	j := &bulkSceneStudioJob{
+		txnManager:  s.Repository,
+		pathPattern: pathPattern,
+		studioID:    id,
+		onlyMissing: onlyMissing,
+	}
+
+	return s.JobManager.Add(ctx, "Setting studio of scenes...", j), nil
+}
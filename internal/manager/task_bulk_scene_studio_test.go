@@ -0,0 +1,83 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBulkSceneStudioJobUpdateScene(t *testing.T) {
+	const (
+		studioID      = 1
+		otherStudioID = 2
+		sceneID       = 10
+	)
+
+	studio := studioID
+	otherStudio := otherStudioID
+
+	tests := []struct {
+		name        string
+		scene       *models.Scene
+		onlyMissing bool
+		want        bool
+	}{
+		{"no studio", &models.Scene{ID: sceneID}, false, true},
+		{"other studio", &models.Scene{ID: sceneID, StudioID: &otherStudio}, false, true},
+		{"same studio", &models.Scene{ID: sceneID, StudioID: &studio}, false, false},
+		{"no studio only missing", &models.Scene{ID: sceneID}, true, true},
+		{"other studio only missing", &models.Scene{ID: sceneID, StudioID: &otherStudio}, true, false},
+		{"missing scene", nil, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sceneRW := &mocks.SceneReaderWriter{}
+			sceneRW.On("Find", mock.Anything, sceneID).Return(tt.scene, nil).Once()
+			if tt.want {
+				sceneRW.On("UpdatePartial", mock.Anything, sceneID, mock.MatchedBy(func(p models.ScenePartial) bool {
+					return p.StudioID.Set && p.StudioID.Value == studioID
+				})).Return(&models.Scene{ID: sceneID}, nil).Once()
+			}
+
+			j := &bulkSceneStudioJob{
+				txnManager: Repository{
+					Scene: &bulkSceneRW{mock: sceneRW},
+				},
+				studioID:    studioID,
+				onlyMissing: tt.onlyMissing,
+			}
+
+			got, err := j.updateScene(context.Background(), sceneID)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			sceneRW.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBulkSetStudioByPathInvalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		pathPattern string
+		studioID    string
+	}{
+		{"empty pattern", "", "1"},
+		{"invalid pattern", "[", "1"},
+		{"invalid studio id", "^/videos/", "a"},
+	}
+
+	m := &Manager{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := m.BulkSetStudioByPath(context.Background(), tt.pathPattern, tt.studioID, false)
+			assert.True(t, errors.Is(err, ErrInput), "expected ErrInput, got %v", err)
+		})
+	}
+}
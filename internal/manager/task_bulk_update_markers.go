@@ -0,0 +1,120 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil/intslice"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+type bulkUpdateMarkersJob struct {
+	txnManager   Repository
+	markerFilter *models.SceneMarkerFilterType
+	primaryTagID int
+}
+
+func (j *bulkUpdateMarkersJob) Execute(ctx context.Context, progress *job.Progress) {
+	var markers []*models.SceneMarker
+	if err := txn.WithReadTxn(ctx, j.txnManager, func(ctx context.Context) error {
+		all := -1
+		var err error
+		markers, _, err = j.txnManager.SceneMarker.Query(ctx, j.markerFilter, &models.FindFilterType{
+			PerPage: &all,
+		})
+		return err
+	}); err != nil {
+		logger.Errorf("Error finding scene markers: %v", err)
+		return
+	}
+
+	logger.Infof("Setting primary tag of %d scene markers", len(markers))
+
+	progress.SetTotal(len(markers))
+
+	updated := 0
+	for _, m := range markers {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return
+		}
+
+		if m.PrimaryTagID != j.primaryTagID {
+			if err := txn.WithTxn(ctx, j.txnManager, func(ctx context.Context) error {
+				return j.updateMarker(ctx, *m)
+			}); err != nil {
+				logger.Errorf("Error updating scene marker %d: %v", m.ID, err)
+			} else {
+				updated++
+			}
+		}
+
+		progress.Increment()
+	}
+
+	logger.Infof("Updated primary tag of %d scene markers", updated)
+}
+
+func (j *bulkUpdateMarkersJob) updateMarker(ctx context.Context, m models.SceneMarker) error {
+	qb := j.txnManager.SceneMarker
+
+	m.PrimaryTagID = j.primaryTagID
+	m.UpdatedAt = models.SQLiteTimestamp{Timestamp: time.Now()}
+
+	if _, err := qb.Update(ctx, m); err != nil {
+		return err
+	}
+
+	// the primary tag should not also be a secondary tag
+	tagIDs, err := qb.GetTagIDs(ctx, m.ID)
+	if err != nil {
+		return err
+	}
+
+	if intslice.IntInclude(tagIDs, j.primaryTagID) {
+		return qb.UpdateTags(ctx, m.ID, intslice.IntExclude(tagIDs, []int{j.primaryTagID}))
+	}
+
+	return nil
+}
+
+// BulkUpdateMarkers starts a job which sets the primary tag of all scene
+// markers matching the provided filter. Returns the job ID.
+func (s *Manager) BulkUpdateMarkers(ctx context.Context, markerFilter *models.SceneMarkerFilterType, primaryTagID string) (int, error) {
+	tagID, err := strconv.Atoi(primaryTagID)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid primary tag id: %v", ErrInput, err)
+	}
+
+	if err := s.Repository.WithReadTxn(ctx, func(ctx context.Context) error {
+		t, err := s.Repository.Tag.Find(ctx, tagID)
+		if err != nil {
+			return err
+		}
+
+		if t == nil {
+			return fmt.Errorf("%w: tag with id %d not found", ErrInput, tagID)
+		}
+
+		return nil
+	}); err != nil {
+		if errors.Is(err, ErrInput) {
+			return 0, err
+		}
+		return 0, fmt.Errorf("finding tag: %w", err)
+	}
+
+	j := &bulkUpdateMarkersJob{
+		txnManager:   s.Repository,
+		markerFilter: markerFilter,
+		primaryTagID: tagID,
+	}
+
+	return s.JobManager.Add(ctx, "Updating scene markers...", j), nil
+}
@@ -51,12 +51,32 @@ func (j *cleanJob) Execute(ctx context.Context, progress *job.Progress) {
 	}
 
 	j.cleanEmptyGalleries(ctx)
+	j.detectSharedSceneFiles(ctx)
 
 	j.scanSubs.notify()
 	elapsed := time.Since(start)
 	logger.Info(fmt.Sprintf("Finished Cleaning (%s)", elapsed))
 }
 
+// detectSharedSceneFiles warns about files that are linked to more than one
+// scene. These are not repaired by the clean, since the repair detaches files
+// from scenes.
+func (j *cleanJob) detectSharedSceneFiles(ctx context.Context) {
+	var shared []*models.SceneSharedFile
+	if err := txn.WithReadTxn(ctx, j.txnManager, func(ctx context.Context) error {
+		var err error
+		shared, err = j.txnManager.Scene.FindSharedFiles(ctx)
+		return err
+	}); err != nil {
+		logger.Errorf("Error finding files shared by scenes: %v", err)
+		return
+	}
+
+	if len(shared) > 0 {
+		logger.Warnf("Found %d files linked to more than one scene. Run the repair scenes sharing files task to fix", len(shared))
+	}
+}
+
 func (j *cleanJob) cleanEmptyGalleries(ctx context.Context) {
 	const batchSize = 1000
 	var toClean []int
@@ -284,6 +304,7 @@ func (h *cleanHandler) handleRelatedScenes(ctx context.Context, fileDeleter *fil
 	}
 
 	fileNamingAlgo := mgr.Config.GetVideoFileNamingAlgorithm()
+	sceneFileSelector := scene.NewPrimaryFileSelector(mgr.Config)
 
 	sceneFileDeleter := &scene.FileDeleter{
 		Deleter:        fileDeleter,
@@ -313,16 +334,16 @@ func (h *cleanHandler) handleRelatedScenes(ctx context.Context, fileDeleter *fil
 			}, nil)
 		} else {
 			// set the primary file to a remaining file
-			var newPrimaryID file.ID
+			var remaining []*file.VideoFile
 			for _, f := range scene.Files.List() {
 				if f.ID != fileID {
-					newPrimaryID = f.ID
-					break
+					remaining = append(remaining, f)
 				}
 			}
 
+			newPrimary := sceneFileSelector.Select(remaining, scene.PrimaryFileID)
 			if _, err := mgr.Repository.Scene.UpdatePartial(ctx, scene.ID, models.ScenePartial{
-				PrimaryFileID: &newPrimaryID,
+				PrimaryFileID: &newPrimary.ID,
 			}); err != nil {
 				return err
 			}
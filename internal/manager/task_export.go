@@ -441,6 +441,12 @@ func fileToJSON(f file.File) jsonschema.DirEntry {
 			BitRate:          ff.BitRate,
 			Interactive:      ff.Interactive,
 			InteractiveSpeed: ff.InteractiveSpeed,
+
+			InteractiveAverageSpeed: ff.InteractiveAverageSpeed,
+			InteractiveRange:        ff.InteractiveRange,
+			InteractiveDensity:      ff.InteractiveDensity,
+
+			Loudness: ff.Loudness,
 		}
 	case *file.ImageFile:
 		base.Type = jsonschema.DirEntryTypeImage
@@ -533,6 +539,8 @@ func exportScene(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *models
 		newSceneJSON.ResumeTime = s.ResumeTime
 		newSceneJSON.PlayCount = s.PlayCount
 		newSceneJSON.PlayDuration = s.PlayDuration
+		newSceneJSON.IntroEnd = s.IntroEnd
+		newSceneJSON.OutroStart = s.OutroStart
 
 		performers, err := performerReader.FindBySceneID(ctx, s.ID)
 		if err != nil {
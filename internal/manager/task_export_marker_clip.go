@@ -0,0 +1,202 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene/generate"
+)
+
+// markerClip describes a clip to be cut from a scene file.
+type markerClip struct {
+	input    string
+	basename string
+	options  generate.MarkerClipOptions
+}
+
+// markerClipDuration returns the length of a clip starting at the marker.
+// If duration is provided, then it is used, limited to the end of the file.
+// Otherwise the clip ends at the next marker, or at the end of the file if
+// there is no later marker.
+func markerClipDuration(marker *models.SceneMarker, markers []*models.SceneMarker, fileDuration float64, duration *float64) (float64, error) {
+	if marker.Seconds >= fileDuration {
+		return 0, fmt.Errorf("%w: marker at %v is beyond the end of the file", ErrInput, marker.Seconds)
+	}
+
+	end := fileDuration
+	if duration != nil {
+		if *duration <= 0 {
+			return 0, fmt.Errorf("%w: duration must be greater than zero", ErrInput)
+		}
+
+		if marker.Seconds+*duration < end {
+			end = marker.Seconds + *duration
+		}
+	} else {
+		for _, m := range markers {
+			if m.Seconds > marker.Seconds && m.Seconds < end {
+				end = m.Seconds
+			}
+		}
+	}
+
+	return end - marker.Seconds, nil
+}
+
+func (s *Manager) getMarkerClip(ctx context.Context, markerID int, duration *float64) (*markerClip, error) {
+	var ret *markerClip
+	if err := s.Repository.WithReadTxn(ctx, func(ctx context.Context) error {
+		marker, err := s.Repository.SceneMarker.Find(ctx, markerID)
+		if err != nil {
+			return err
+		}
+
+		if marker == nil {
+			return fmt.Errorf("%w: scene marker with id %d not found", ErrInput, markerID)
+		}
+
+		sceneID := int(marker.SceneID.Int64)
+		scene, err := s.Repository.Scene.Find(ctx, sceneID)
+		if err != nil {
+			return err
+		}
+
+		if scene == nil {
+			return fmt.Errorf("scene with id %d not found", sceneID)
+		}
+
+		if err := scene.LoadPrimaryFile(ctx, s.Repository.File); err != nil {
+			return err
+		}
+
+		f := scene.Files.Primary()
+		if f == nil {
+			return fmt.Errorf("%w: scene with id %d has no files", ErrInput, sceneID)
+		}
+
+		markers, err := s.Repository.SceneMarker.FindBySceneID(ctx, sceneID)
+		if err != nil {
+			return err
+		}
+
+		d, err := markerClipDuration(marker, markers, f.Duration, duration)
+		if err != nil {
+			return err
+		}
+
+		ret = &markerClip{
+			input:    f.Path,
+			basename: markerClipBasename(f, marker),
+			options: generate.MarkerClipOptions{
+				Seconds:  marker.Seconds,
+				Duration: d,
+			},
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// markerClipBasename returns the filename of a clip of the marker. The clip
+// uses the same extension as the file, since its streams are copied.
+func markerClipBasename(f *file.VideoFile, marker *models.SceneMarker) string {
+	ext := filepath.Ext(f.Basename)
+	name := strings.TrimSuffix(f.Basename, ext)
+	if marker.Title != "" {
+		name += " - " + marker.Title
+	}
+
+	return fsutil.SanitiseBasename(fmt.Sprintf("%s - %d", name, int(marker.Seconds))) + ext
+}
+
+func (s *Manager) markerClipGenerator() *generate.Generator {
+	return &generate.Generator{
+		Encoder:     s.FFMPEG,
+		LockManager: s.ReadLockManager,
+		MarkerPaths: s.Paths.SceneMarkers,
+	}
+}
+
+// ExportMarkerClip starts a job which cuts a clip starting at the scene
+// marker and saves it to outputDir. If duration is nil, then the clip ends
+// at the next marker, or at the end of the scene. Returns the job ID.
+func (s *Manager) ExportMarkerClip(ctx context.Context, markerID int, duration *float64, outputDir string) (int, error) {
+	if exists, err := fsutil.DirExists(outputDir); !exists {
+		return 0, fmt.Errorf("%w: output directory %q does not exist: %v", ErrInput, outputDir, err)
+	}
+
+	clip, err := s.getMarkerClip(ctx, markerID, duration)
+	if err != nil {
+		if errors.Is(err, ErrInput) {
+			return 0, err
+		}
+		return 0, fmt.Errorf("finding scene marker: %w", err)
+	}
+
+	output := filepath.Join(outputDir, clip.basename)
+	if exists, _ := fsutil.FileExists(output); exists {
+		return 0, fmt.Errorf("%w: %q already exists", ErrInput, output)
+	}
+
+	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) {
+		options := clip.options
+		options.Progress = progress.SetPercent
+
+		if err := s.markerClipGenerator().MarkerClip(ctx, clip.input, output, options); err != nil {
+			logger.Errorf("Error exporting marker clip: %v", err)
+			return
+		}
+
+		logger.Infof("Exported marker clip to %s", output)
+	})
+
+	return s.JobManager.Add(ctx, "Exporting marker clip...", j), nil
+}
+
+// DownloadMarkerClip cuts a clip starting at the scene marker into the
+// downloads directory and registers it for download. If duration is nil,
+// then the clip ends at the next marker, or at the end of the scene.
+// Returns the download hash and the filename of the clip.
+func (s *Manager) DownloadMarkerClip(ctx context.Context, markerID int, duration *float64) (string, string, error) {
+	clip, err := s.getMarkerClip(ctx, markerID, duration)
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := fsutil.EnsureDir(s.Paths.Generated.Downloads); err != nil {
+		return "", "", fmt.Errorf("could not create downloads directory %v: %w", s.Paths.Generated.Downloads, err)
+	}
+
+	f, err := os.CreateTemp(s.Paths.Generated.Downloads, "clip*"+filepath.Ext(clip.basename))
+	if err != nil {
+		return "", "", err
+	}
+	output := f.Name()
+	f.Close()
+
+	if err := s.markerClipGenerator().MarkerClip(ctx, clip.input, output, clip.options); err != nil {
+		_ = os.Remove(output)
+		return "", "", err
+	}
+
+	hash, err := s.DownloadStore.RegisterFile(output, "", false)
+	if err != nil {
+		_ = os.Remove(output)
+		return "", "", fmt.Errorf("error registering file for download: %w", err)
+	}
+
+	return hash, clip.basename, nil
+}
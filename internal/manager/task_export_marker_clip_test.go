@@ -0,0 +1,60 @@
+package manager
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkerClipDuration(t *testing.T) {
+	floatPtr := func(v float64) *float64 { return &v }
+
+	marker := &models.SceneMarker{ID: 2, Seconds: 30}
+	markers := []*models.SceneMarker{
+		{ID: 1, Seconds: 10},
+		marker,
+		{ID: 3, Seconds: 75},
+		{ID: 4, Seconds: 50},
+	}
+
+	tests := []struct {
+		name         string
+		marker       *models.SceneMarker
+		fileDuration float64
+		duration     *float64
+		want         float64
+		wantErr      bool
+	}{
+		{"next marker", marker, 100, nil, 20, false},
+		{"last marker", markers[2], 100, nil, 25, false},
+		{"duration", marker, 100, floatPtr(15), 15, false},
+		{"duration past end", marker, 40, floatPtr(15), 10, false},
+		{"zero duration", marker, 100, floatPtr(0), 0, true},
+		{"marker past end", marker, 20, nil, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := markerClipDuration(tt.marker, markers, tt.fileDuration, tt.duration)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrInput))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMarkerClipBasename(t *testing.T) {
+	f := &file.VideoFile{
+		BaseFile: &file.BaseFile{Basename: "scene.mkv"},
+	}
+
+	assert.Equal(t, "scene-intro-90.mkv", markerClipBasename(f, &models.SceneMarker{Title: "intro", Seconds: 90.5}))
+	assert.Equal(t, "scene-12.mkv", markerClipBasename(f, &models.SceneMarker{Seconds: 12}))
+}
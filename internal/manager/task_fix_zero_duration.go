@@ -0,0 +1,154 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+type zeroDurationSceneFinder interface {
+	scene.Queryer
+	models.VideoFileLoader
+}
+
+type fixZeroDurationJob struct {
+	txnManager  txn.Manager
+	sceneFinder zeroDurationSceneFinder
+	fileUpdater file.Updater
+	probe       func(path string) (*ffmpeg.VideoFile, error)
+}
+
+// zeroDurationFilter returns a filter for scenes with a file with a duration
+// of less than a second. This includes files without a duration, which are
+// stored with a negative duration. Files are checked individually as the
+// filter rounds durations down.
+func zeroDurationFilter() *models.SceneFilterType {
+	return &models.SceneFilterType{
+		Duration: &models.IntCriterionInput{
+			Value:    1,
+			Modifier: models.CriterionModifierLessThan,
+		},
+	}
+}
+
+func (j *fixZeroDurationJob) Execute(ctx context.Context, progress *job.Progress) {
+	var files []*file.VideoFile
+	if err := txn.WithReadTxn(ctx, j.txnManager, func(ctx context.Context) error {
+		var err error
+		files, err = j.findFiles(ctx)
+		return err
+	}); err != nil {
+		logger.Errorf("Error finding scenes: %v", err)
+		return
+	}
+
+	logger.Infof("Probing %d files with zero duration", len(files))
+
+	progress.SetTotal(len(files))
+
+	fixed, failed := j.fixFiles(ctx, progress, files)
+
+	if job.IsCancelled(ctx) {
+		logger.Info("Stopping due to user request")
+	}
+
+	logger.Infof("Fixed duration of %d files, %d failed", fixed, failed)
+}
+
+// findFiles returns the files with zero or unknown duration of the scenes
+// matching zeroDurationFilter. Files shared by several scenes are only
+// returned once.
+func (j *fixZeroDurationJob) findFiles(ctx context.Context) ([]*file.VideoFile, error) {
+	all := -1
+	scenes, err := scene.Query(ctx, j.sceneFinder, zeroDurationFilter(), &models.FindFilterType{
+		PerPage: &all,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*file.VideoFile
+	seen := make(map[file.ID]bool)
+	for _, s := range scenes {
+		if err := s.LoadFiles(ctx, j.sceneFinder); err != nil {
+			return nil, err
+		}
+
+		for _, f := range s.Files.List() {
+			if f.Duration <= 0 && !seen[f.ID] {
+				seen[f.ID] = true
+				ret = append(ret, f)
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+// fixFiles probes each file again, and returns the number of files that
+// were fixed and that failed.
+func (j *fixZeroDurationJob) fixFiles(ctx context.Context, progress file.ProgressReporter, files []*file.VideoFile) (fixed int, failed int) {
+	for _, f := range files {
+		if job.IsCancelled(ctx) {
+			return
+		}
+
+		progress.ExecuteTask(fmt.Sprintf("Probing %s", f.Path), func() {
+			if err := j.fixFile(ctx, f); err != nil {
+				logger.Errorf("Error fixing duration of %s: %v", f.Path, err)
+				failed++
+			} else {
+				fixed++
+			}
+		})
+
+		progress.Increment()
+	}
+
+	return
+}
+
+// fixFile probes f again and updates the probed fields if a duration is
+// found.
+func (j *fixZeroDurationJob) fixFile(ctx context.Context, f *file.VideoFile) error {
+	probe, err := j.probe(f.Path)
+	if err != nil {
+		return fmt.Errorf("running ffprobe: %w", err)
+	}
+
+	if probe.FileDuration <= 0 {
+		return fmt.Errorf("ffprobe returned zero duration")
+	}
+
+	f.Duration = probe.FileDuration
+	f.VideoCodec = probe.VideoCodec
+	f.AudioCodec = probe.AudioCodec
+	f.Width = probe.Width
+	f.Height = probe.Height
+	f.FrameRate = probe.FrameRate
+	f.BitRate = probe.Bitrate
+
+	return txn.WithTxn(ctx, j.txnManager, func(ctx context.Context) error {
+		return j.fileUpdater.Update(ctx, f)
+	})
+}
+
+// FixZeroDurationScenes starts a job which probes the files of scenes with
+// zero duration again and updates them. Returns the job ID.
+func (s *Manager) FixZeroDurationScenes(ctx context.Context) int {
+	j := &fixZeroDurationJob{
+		txnManager:  s.Repository,
+		sceneFinder: s.Repository.Scene,
+		fileUpdater: s.Repository.File,
+		probe:       s.FFProbe.NewVideoFile,
+	}
+
+	return s.JobManager.Add(ctx, "Fixing scenes with zero duration...", j)
+}
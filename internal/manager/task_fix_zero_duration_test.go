@@ -0,0 +1,117 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testProgress struct{}
+
+func (testProgress) AddTotal(total int)                        {}
+func (testProgress) Increment()                                {}
+func (testProgress) Definite()                                 {}
+func (testProgress) ExecuteTask(description string, fn func()) { fn() }
+
+type testFileUpdater struct {
+	updated []file.ID
+	err     map[file.ID]error
+}
+
+func (u *testFileUpdater) Update(ctx context.Context, f file.File) error {
+	id := f.Base().ID
+	if err := u.err[id]; err != nil {
+		return err
+	}
+	u.updated = append(u.updated, id)
+	return nil
+}
+
+func makeZeroDurationFile(id file.ID, path string, duration float64) *file.VideoFile {
+	return &file.VideoFile{
+		BaseFile: &file.BaseFile{ID: id, Path: path},
+		Duration: duration,
+	}
+}
+
+func TestFixZeroDurationJobFindFiles(t *testing.T) {
+	const (
+		sceneID      = 1
+		otherSceneID = 2
+	)
+
+	unknown := makeZeroDurationFile(1, "unknown.mp4", -1)
+	zero := makeZeroDurationFile(2, "zero.mp4", 0)
+	short := makeZeroDurationFile(3, "short.mp4", 0.5)
+	long := makeZeroDurationFile(4, "long.mp4", 10)
+
+	sceneRW := &mocks.SceneReaderWriter{}
+	result := models.NewSceneQueryResult(sceneRW)
+	result.IDs = []int{sceneID, otherSceneID}
+	sceneRW.On("Query", mock.Anything, mock.MatchedBy(func(o models.SceneQueryOptions) bool {
+		d := o.SceneFilter.Duration
+		// files without a duration are stored with a negative duration
+		return d != nil && d.Modifier == models.CriterionModifierLessThan && d.Value == 1
+	})).Return(result, nil).Once()
+
+	sceneRW.On("FindMany", mock.Anything, []int{sceneID, otherSceneID}).Return([]*models.Scene{
+		{ID: sceneID},
+		{ID: otherSceneID},
+	}, nil)
+	sceneRW.On("GetFiles", mock.Anything, sceneID).Return([]*file.VideoFile{unknown, zero, short, long}, nil)
+	sceneRW.On("GetFiles", mock.Anything, otherSceneID).Return([]*file.VideoFile{zero}, nil)
+
+	j := &fixZeroDurationJob{
+		sceneFinder: sceneRW,
+	}
+
+	got, err := j.findFiles(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []*file.VideoFile{unknown, zero}, got)
+
+	sceneRW.AssertExpectations(t)
+}
+
+func TestFixZeroDurationJobFixFiles(t *testing.T) {
+	fixable := makeZeroDurationFile(1, "fixable.mp4", -1)
+	stillZero := makeZeroDurationFile(2, "zero.mp4", 0)
+	probeFail := makeZeroDurationFile(3, "fail.mp4", 0)
+	updateFail := makeZeroDurationFile(4, "updatefail.mp4", 0)
+
+	probed := map[string]*ffmpeg.VideoFile{
+		fixable.Path:    {FileDuration: 12.5, Width: 640, Height: 480},
+		stillZero.Path:  {},
+		updateFail.Path: {FileDuration: 5},
+	}
+
+	updater := &testFileUpdater{
+		err: map[file.ID]error{updateFail.ID: errors.New("update error")},
+	}
+
+	j := &fixZeroDurationJob{
+		txnManager:  &mocks.TxnManager{},
+		fileUpdater: updater,
+		probe: func(path string) (*ffmpeg.VideoFile, error) {
+			if ret, ok := probed[path]; ok {
+				return ret, nil
+			}
+			return nil, errors.New("probe error")
+		},
+	}
+
+	fixed, failed := j.fixFiles(context.Background(), testProgress{}, []*file.VideoFile{fixable, stillZero, probeFail, updateFail})
+	assert.Equal(t, 1, fixed)
+	assert.Equal(t, 3, failed)
+
+	assert.Equal(t, 12.5, fixable.Duration)
+	assert.Equal(t, 640, fixable.Width)
+
+	assert.Equal(t, []file.ID{fixable.ID}, updater.updated)
+}
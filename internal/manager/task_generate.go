@@ -7,6 +7,7 @@ import (
 
 	"github.com/remeh/sizedwaitgroup"
 	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/hash/audiofingerprint"
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
@@ -28,11 +29,18 @@ type GenerateMetadataInput struct {
 	// Generate transcodes even if not required
 	ForceTranscodes           *bool `json:"forceTranscodes"`
 	Phashes                   *bool `json:"phashes"`
+	AudioFingerprints         *bool `json:"audioFingerprints"`
 	InteractiveHeatmapsSpeeds *bool `json:"interactiveHeatmapsSpeeds"`
+	// Generate contact sheet images of scenes
+	ContactSheets       *bool                             `json:"contactSheets"`
+	ContactSheetOptions *GenerateContactSheetOptionsInput `json:"contactSheetOptions"`
 	// scene ids to generate for
 	SceneIDs []string `json:"sceneIDs"`
 	// marker ids to generate for
 	MarkerIDs []string `json:"markerIDs"`
+	// only generate for scenes matching this filter. Ignored if scene or
+	// marker ids are set
+	SceneFilter *models.SceneFilterType `json:"sceneFilter"`
 	// overwrite existing media
 	Overwrite *bool `json:"overwrite"`
 }
@@ -50,14 +58,40 @@ type GeneratePreviewOptionsInput struct {
 	PreviewPreset *models.PreviewPreset `json:"previewPreset"`
 }
 
+type GenerateContactSheetOptionsInput struct {
+	// Number of rows of images in a contact sheet
+	Rows *int `json:"rows"`
+	// Number of columns of images in a contact sheet
+	Columns *int `json:"columns"`
+}
+
+const maxContactSheetSize = 10
+
+func (o *GenerateContactSheetOptionsInput) validate() error {
+	if o == nil {
+		return nil
+	}
+
+	if o.Rows != nil && (*o.Rows < 1 || *o.Rows > maxContactSheetSize) {
+		return fmt.Errorf("%w: contact sheet rows must be between 1 and %d", ErrInput, maxContactSheetSize)
+	}
+	if o.Columns != nil && (*o.Columns < 1 || *o.Columns > maxContactSheetSize) {
+		return fmt.Errorf("%w: contact sheet columns must be between 1 and %d", ErrInput, maxContactSheetSize)
+	}
+
+	return nil
+}
+
 const generateQueueSize = 200000
 
 type GenerateJob struct {
 	txnManager Repository
 	input      GenerateMetadataInput
 
-	overwrite      bool
-	fileNamingAlgo models.HashAlgorithm
+	overwrite         bool
+	fileNamingAlgo    models.HashAlgorithm
+	audioFingerprints bool
+	loudness          bool
 }
 
 type totalsGenerate struct {
@@ -67,7 +101,10 @@ type totalsGenerate struct {
 	markers                  int64
 	transcodes               int64
 	phashes                  int64
+	audioFingerprints        int64
+	loudness                 int64
 	interactiveHeatmapSpeeds int64
+	contactSheets            int64
 
 	tasks int
 }
@@ -82,7 +119,18 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) {
 	}
 	j.fileNamingAlgo = config.GetInstance().GetVideoFileNamingAlgorithm()
 
+	if utils.IsTrue(j.input.AudioFingerprints) {
+		// audio fingerprints require the optional fpcalc executable
+		if audiofingerprint.GetFpcalcPath() == "" {
+			logger.Warnf("Not generating audio fingerprints: %v", audiofingerprint.ErrUnavailable)
+		} else {
+			j.audioFingerprints = true
+		}
+	}
+
 	config := config.GetInstance()
+	// loudness is gated by config as it requires an extra ffmpeg pass
+	j.loudness = config.IsGenerateLoudness()
 	parallelTasks := config.GetParallelTasksWithAutoDetection()
 
 	logger.Infof("Generate started with %d parallel tasks", parallelTasks)
@@ -142,7 +190,7 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) {
 			return
 		}
 
-		logger.Infof("Generating %d sprites %d previews %d image previews %d markers %d transcodes %d phashes %d heatmaps & speeds", totals.sprites, totals.previews, totals.imagePreviews, totals.markers, totals.transcodes, totals.phashes, totals.interactiveHeatmapSpeeds)
+		logger.Infof("Generating %d sprites %d previews %d image previews %d markers %d transcodes %d phashes %d audio fingerprints %d loudness %d heatmaps & speeds %d contact sheets", totals.sprites, totals.previews, totals.imagePreviews, totals.markers, totals.transcodes, totals.phashes, totals.audioFingerprints, totals.loudness, totals.interactiveHeatmapSpeeds, totals.contactSheets)
 
 		progress.SetTotal(int(totals.tasks))
 	}()
@@ -151,13 +199,17 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) {
 
 	// Start measuring how long the generate has taken. (consider moving this up)
 	start := time.Now()
-	if err = instance.Paths.Generated.EnsureTmpDir(); err != nil {
-		logger.Warnf("could not create temporary directory: %v", err)
+	for _, p := range instance.Paths.All() {
+		if err = p.Generated.EnsureTmpDir(); err != nil {
+			logger.Warnf("could not create temporary directory: %v", err)
+		}
 	}
 
 	defer func() {
-		if err := instance.Paths.Generated.EmptyTmpDir(); err != nil {
-			logger.Warnf("failure emptying temporary directory: %v", err)
+		for _, p := range instance.Paths.All() {
+			if err := p.Generated.EmptyTmpDir(); err != nil {
+				logger.Warnf("failure emptying temporary directory: %v", err)
+			}
 		}
 	}()
 
@@ -200,7 +252,7 @@ func (j *GenerateJob) queueTasks(ctx context.Context, g *generate.Generator, que
 			return totals
 		}
 
-		scenes, err := scene.Query(ctx, j.txnManager.Scene, nil, findFilter)
+		scenes, err := scene.Query(ctx, j.txnManager.Scene, j.input.SceneFilter, findFilter)
 		if err != nil {
 			logger.Errorf("Error encountered queuing files to scan: %s", err.Error())
 			return totals
@@ -265,6 +317,8 @@ func getGeneratePreviewOptions(optionsInput GeneratePreviewOptionsInput) generat
 }
 
 func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator, scene *models.Scene, queue chan<- Task, totals *totalsGenerate) {
+	g = generatorForPath(g, scene.Path)
+
 	if utils.IsTrue(j.input.Sprites) {
 		task := &GenerateSpriteTask{
 			Scene:               *scene,
@@ -370,6 +424,42 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 		}
 	}
 
+	if j.audioFingerprints {
+		// generate for all files in scene
+		for _, f := range scene.Files.List() {
+			task := &GenerateAudioFingerprintTask{
+				File:        f,
+				txnManager:  j.txnManager,
+				fileUpdater: j.txnManager.File,
+				Overwrite:   j.overwrite,
+			}
+
+			if task.shouldGenerate() {
+				totals.audioFingerprints++
+				totals.tasks++
+				queue <- task
+			}
+		}
+	}
+
+	if j.loudness {
+		// generate for all files in scene
+		for _, f := range scene.Files.List() {
+			task := &GenerateLoudnessTask{
+				File:        f,
+				txnManager:  j.txnManager,
+				fileUpdater: j.txnManager.File,
+				Overwrite:   j.overwrite,
+			}
+
+			if task.shouldGenerate() {
+				totals.loudness++
+				totals.tasks++
+				queue <- task
+			}
+		}
+	}
+
 	if utils.IsTrue(j.input.InteractiveHeatmapsSpeeds) {
 		task := &GenerateInteractiveHeatmapSpeedTask{
 			Scene:               *scene,
@@ -384,6 +474,32 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 			queue <- task
 		}
 	}
+
+	if utils.IsTrue(j.input.ContactSheets) {
+		task := &GenerateContactSheetTask{
+			Scene:               *scene,
+			Rows:                contactSheetRowsDefault,
+			Columns:             contactSheetColumnsDefault,
+			Overwrite:           j.overwrite,
+			fileNamingAlgorithm: j.fileNamingAlgo,
+			generator:           g,
+		}
+
+		if options := j.input.ContactSheetOptions; options != nil {
+			if options.Rows != nil {
+				task.Rows = *options.Rows
+			}
+			if options.Columns != nil {
+				task.Columns = *options.Columns
+			}
+		}
+
+		if j.overwrite || task.required() {
+			totals.contactSheets++
+			totals.tasks++
+			queue <- task
+		}
+	}
 }
 
 func (j *GenerateJob) queueMarkerJob(g *generate.Generator, marker *models.SceneMarker, queue chan<- Task, totals *totalsGenerate) {
@@ -398,3 +514,14 @@ func (j *GenerateJob) queueMarkerJob(g *generate.Generator, marker *models.Scene
 	totals.tasks++
 	queue <- task
 }
+
+// generatorForPath returns a copy of g which uses the generated paths for the
+// file with the provided path.
+func generatorForPath(g *generate.Generator, path string) *generate.Generator {
+	p := instance.Paths.ForPath(path)
+
+	ret := *g
+	ret.MarkerPaths = p.SceneMarkers
+	ret.ScenePaths = p.Scene
+	return &ret
+}
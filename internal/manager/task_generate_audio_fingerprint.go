@@ -0,0 +1,49 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/hash/audiofingerprint"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+type GenerateAudioFingerprintTask struct {
+	File        *file.VideoFile
+	Overwrite   bool
+	txnManager  txn.Manager
+	fileUpdater file.Updater
+}
+
+func (t *GenerateAudioFingerprintTask) GetDescription() string {
+	return fmt.Sprintf("Generating audio fingerprint for %s", t.File.Path)
+}
+
+func (t *GenerateAudioFingerprintTask) Start(ctx context.Context) {
+	if !t.shouldGenerate() {
+		return
+	}
+
+	fp, err := audiofingerprint.Generate(ctx, t.File)
+	if err != nil {
+		logger.Errorf("error generating audio fingerprint for %s: %v", t.File.Path, err)
+		return
+	}
+
+	if err := txn.WithTxn(ctx, t.txnManager, func(ctx context.Context) error {
+		t.File.Fingerprints = t.File.Fingerprints.AppendUnique(file.Fingerprint{
+			Type:        file.FingerprintTypeAudio,
+			Fingerprint: fp,
+		})
+
+		return t.fileUpdater.Update(ctx, t.File)
+	}); err != nil && ctx.Err() == nil {
+		logger.Errorf("Error setting audio fingerprint: %v", err)
+	}
+}
+
+func (t *GenerateAudioFingerprintTask) shouldGenerate() bool {
+	return t.Overwrite || t.File.Fingerprints.Get(file.FingerprintTypeAudio) == nil
+}
@@ -0,0 +1,98 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"image"
+
+	"github.com/disintegration/imaging"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene/generate"
+)
+
+const (
+	contactSheetRowsDefault    = 4
+	contactSheetColumnsDefault = 4
+)
+
+type GenerateContactSheetTask struct {
+	Scene               models.Scene
+	Rows                int
+	Columns             int
+	Overwrite           bool
+	fileNamingAlgorithm models.HashAlgorithm
+
+	generator *generate.Generator
+}
+
+func (t *GenerateContactSheetTask) GetDescription() string {
+	return fmt.Sprintf("Generating contact sheet for %s", t.Scene.Path)
+}
+
+func (t *GenerateContactSheetTask) Start(ctx context.Context) {
+	if !t.Overwrite && !t.required() {
+		return
+	}
+
+	sceneHash := t.Scene.GetHash(t.fileNamingAlgorithm)
+	if sceneHash == "" {
+		return
+	}
+
+	videoFile, err := instance.FFProbe.NewVideoFile(t.Scene.Path)
+	if err != nil {
+		logger.Errorf("error reading video file: %s", err.Error())
+		return
+	}
+
+	duration := videoFile.VideoStreamDuration
+	if duration <= 0 {
+		logger.Errorf("video %s: invalid duration (%.3f), skipping contact sheet creation", t.Scene.Path, duration)
+		return
+	}
+
+	logger.Infof("[generator] generating contact sheet for %s", t.Scene.Path)
+
+	// take each image from the middle of its part of the video, to avoid
+	// the blank frames at the start
+	count := t.Rows * t.Columns
+	stepSize := duration / float64(count)
+
+	var images []image.Image
+	var times []float64
+	for i := 0; i < count; i++ {
+		time := (float64(i) + 0.5) * stepSize
+
+		img, err := t.generator.ContactSheetScreenshot(ctx, t.Scene.Path, time)
+		if err != nil {
+			logger.Errorf("error generating contact sheet for %s: %v", t.Scene.Path, err)
+			logErrorOutput(err)
+			return
+		}
+
+		images = append(images, img)
+		times = append(times, time)
+	}
+
+	output := instance.Paths.ForPath(t.Scene.Path).Scene.GetContactSheetPath(sceneHash)
+	if err := imaging.Save(t.generator.CombineContactSheetImages(images, times, t.Columns), output); err != nil {
+		logger.Errorf("error saving contact sheet for %s: %v", t.Scene.Path, err)
+	}
+}
+
+// required returns true if the contact sheet needs to be generated
+func (t *GenerateContactSheetTask) required() bool {
+	if t.Scene.Path == "" {
+		return false
+	}
+
+	sceneHash := t.Scene.GetHash(t.fileNamingAlgorithm)
+	if sceneHash == "" {
+		return false
+	}
+
+	exists, _ := fsutil.FileExists(instance.Paths.ForPath(t.Scene.Path).Scene.GetContactSheetPath(sceneHash))
+	return !exists
+}
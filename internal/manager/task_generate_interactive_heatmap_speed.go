@@ -3,6 +3,7 @@ package manager
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/stashapp/stash/pkg/file/video"
 	"github.com/stashapp/stash/pkg/fsutil"
@@ -28,7 +29,7 @@ func (t *GenerateInteractiveHeatmapSpeedTask) Start(ctx context.Context) {
 
 	videoChecksum := t.Scene.GetHash(t.fileNamingAlgorithm)
 	funscriptPath := video.GetFunscriptPath(t.Scene.Path)
-	heatmapPath := instance.Paths.Scene.GetInteractiveHeatmapPath(videoChecksum)
+	heatmapPath := instance.Paths.ForPath(t.Scene.Path).Scene.GetInteractiveHeatmapPath(videoChecksum)
 
 	generator := NewInteractiveHeatmapSpeedGenerator(funscriptPath, heatmapPath, t.Scene.Files.Primary().Duration)
 
@@ -41,9 +42,18 @@ func (t *GenerateInteractiveHeatmapSpeedTask) Start(ctx context.Context) {
 
 	median := generator.InteractiveSpeed
 
+	stats, err := readFunscriptStats(funscriptPath)
+	if err != nil {
+		logger.Errorf("error reading funscript stats: %s", err.Error())
+		return
+	}
+
 	if err := t.TxnManager.WithTxn(ctx, func(ctx context.Context) error {
 		primaryFile := t.Scene.Files.Primary()
 		primaryFile.InteractiveSpeed = &median
+		primaryFile.InteractiveAverageSpeed = &stats.AverageSpeed
+		primaryFile.InteractiveRange = &stats.Range
+		primaryFile.InteractiveDensity = &stats.Density
 		qb := t.TxnManager.File
 		return qb.Update(ctx, primaryFile)
 	}); err != nil && ctx.Err() == nil {
@@ -57,7 +67,17 @@ func (t *GenerateInteractiveHeatmapSpeedTask) shouldGenerate() bool {
 		return false
 	}
 	sceneHash := t.Scene.GetHash(t.fileNamingAlgorithm)
-	return !t.doesHeatmapExist(sceneHash) || primaryFile.InteractiveSpeed == nil || t.Overwrite
+	return !t.doesHeatmapExist(sceneHash) || primaryFile.InteractiveSpeed == nil || primaryFile.InteractiveAverageSpeed == nil || t.Overwrite
+}
+
+func readFunscriptStats(path string) (*video.FunscriptStats, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return video.ReadFunscriptStats(f)
 }
 
 func (t *GenerateInteractiveHeatmapSpeedTask) doesHeatmapExist(sceneChecksum string) bool {
@@ -65,6 +85,6 @@ func (t *GenerateInteractiveHeatmapSpeedTask) doesHeatmapExist(sceneChecksum str
 		return false
 	}
 
-	imageExists, _ := fsutil.FileExists(instance.Paths.Scene.GetInteractiveHeatmapPath(sceneChecksum))
+	imageExists, _ := fsutil.FileExists(instance.Paths.ForPath(t.Scene.Path).Scene.GetInteractiveHeatmapPath(sceneChecksum))
 	return imageExists
 }
@@ -0,0 +1,55 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+type GenerateLoudnessTask struct {
+	File        *file.VideoFile
+	Overwrite   bool
+	txnManager  txn.Manager
+	fileUpdater file.Updater
+}
+
+func (t *GenerateLoudnessTask) GetDescription() string {
+	return fmt.Sprintf("Measuring loudness of %s", t.File.Path)
+}
+
+func (t *GenerateLoudnessTask) Start(ctx context.Context) {
+	if !t.shouldGenerate() {
+		return
+	}
+
+	loudness, err := instance.FFMPEG.CalculateLoudness(ctx, t.File.Path)
+	if err != nil {
+		if errors.Is(err, ffmpeg.ErrNoLoudness) {
+			logger.Debugf("no loudness measured for %s", t.File.Path)
+		} else if ctx.Err() == nil {
+			logger.Errorf("error measuring loudness of %s: %v", t.File.Path, err)
+		}
+		return
+	}
+
+	if err := txn.WithTxn(ctx, t.txnManager, func(ctx context.Context) error {
+		t.File.Loudness = &loudness
+		return t.fileUpdater.Update(ctx, t.File)
+	}); err != nil && ctx.Err() == nil {
+		logger.Errorf("Error setting loudness: %v", err)
+	}
+}
+
+func (t *GenerateLoudnessTask) shouldGenerate() bool {
+	// files without audio cannot be measured
+	if t.File.AudioCodec == "" {
+		return false
+	}
+
+	return t.Overwrite || t.File.Loudness == nil
+}
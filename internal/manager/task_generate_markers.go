@@ -91,7 +91,7 @@ func (t *GenerateMarkersTask) generateSceneMarkers(ctx context.Context) {
 	sceneHash := t.Scene.GetHash(t.fileNamingAlgorithm)
 
 	// Make the folder for the scenes markers
-	markersFolder := filepath.Join(instance.Paths.Generated.Markers, sceneHash)
+	markersFolder := filepath.Join(instance.Paths.ForPath(t.Scene.Path).Generated.Markers, sceneHash)
 	if err := fsutil.EnsureDir(markersFolder); err != nil {
 		logger.Warnf("could not create the markers folder (%v): %v", markersFolder, err)
 	}
@@ -108,7 +108,7 @@ func (t *GenerateMarkersTask) generateMarker(videoFile *file.VideoFile, scene *m
 	sceneHash := t.Scene.GetHash(t.fileNamingAlgorithm)
 	seconds := int(sceneMarker.Seconds)
 
-	g := t.generator
+	g := generatorForPath(t.generator, scene.Path)
 
 	if err := g.MarkerPreviewVideo(context.TODO(), videoFile.Path, sceneHash, seconds, instance.Config.GetPreviewAudio()); err != nil {
 		logger.Errorf("[generator] failed to generate marker video: %v", err)
@@ -171,7 +171,7 @@ func (t *GenerateMarkersTask) videoExists(sceneChecksum string, seconds int) boo
 		return false
 	}
 
-	videoPath := instance.Paths.SceneMarkers.GetVideoPreviewPath(sceneChecksum, seconds)
+	videoPath := instance.Paths.ForPath(t.Scene.Path).SceneMarkers.GetVideoPreviewPath(sceneChecksum, seconds)
 	videoExists, _ := fsutil.FileExists(videoPath)
 
 	return videoExists
@@ -182,7 +182,7 @@ func (t *GenerateMarkersTask) imageExists(sceneChecksum string, seconds int) boo
 		return false
 	}
 
-	imagePath := instance.Paths.SceneMarkers.GetWebpPreviewPath(sceneChecksum, seconds)
+	imagePath := instance.Paths.ForPath(t.Scene.Path).SceneMarkers.GetWebpPreviewPath(sceneChecksum, seconds)
 	imageExists, _ := fsutil.FileExists(imagePath)
 
 	return imageExists
@@ -193,7 +193,7 @@ func (t *GenerateMarkersTask) screenshotExists(sceneChecksum string, seconds int
 		return false
 	}
 
-	screenshotPath := instance.Paths.SceneMarkers.GetScreenshotPath(sceneChecksum, seconds)
+	screenshotPath := instance.Paths.ForPath(t.Scene.Path).SceneMarkers.GetScreenshotPath(sceneChecksum, seconds)
 	screenshotExists, _ := fsutil.FileExists(screenshotPath)
 
 	return screenshotExists
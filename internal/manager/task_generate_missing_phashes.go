@@ -0,0 +1,113 @@
+package manager
+
+import (
+	"context"
+
+	"github.com/remeh/sizedwaitgroup"
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+type generateMissingPhashesJob struct {
+	txnManager  Repository
+	sceneFilter *models.SceneFilterType
+}
+
+// missingPhashFilter returns a filter for scenes matching sceneFilter that
+// have a file without a phash.
+func missingPhashFilter(sceneFilter *models.SceneFilterType) *models.SceneFilterType {
+	isMissing := "phash"
+	return &models.SceneFilterType{
+		IsMissing: &isMissing,
+		And:       sceneFilter,
+	}
+}
+
+func (j *generateMissingPhashesJob) Execute(ctx context.Context, progress *job.Progress) {
+	var tasks []*GeneratePhashTask
+	if err := txn.WithReadTxn(ctx, j.txnManager, func(ctx context.Context) error {
+		var err error
+		tasks, err = j.findTasks(ctx)
+		return err
+	}); err != nil {
+		logger.Errorf("Error finding scenes: %v", err)
+		return
+	}
+
+	logger.Infof("Generating %d missing phashes", len(tasks))
+
+	progress.SetTotal(len(tasks))
+
+	wg := sizedwaitgroup.New(config.GetInstance().GetParallelTasksWithAutoDetection())
+	for _, t := range tasks {
+		if job.IsCancelled(ctx) {
+			break
+		}
+
+		wg.Add()
+		task := t
+		go progress.ExecuteTask(task.GetDescription(), func() {
+			task.Start(ctx)
+			wg.Done()
+			progress.Increment()
+		})
+	}
+
+	wg.Wait()
+
+	if job.IsCancelled(ctx) {
+		logger.Info("Stopping due to user request")
+		return
+	}
+
+	logger.Info("Finished generating missing phashes")
+}
+
+// findTasks returns the tasks generating the phashes of the files without a
+// phash of the scenes matching the filter.
+func (j *generateMissingPhashesJob) findTasks(ctx context.Context) ([]*GeneratePhashTask, error) {
+	all := -1
+	scenes, err := scene.Query(ctx, j.txnManager.Scene, missingPhashFilter(j.sceneFilter), &models.FindFilterType{
+		PerPage: &all,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*GeneratePhashTask
+	for _, s := range scenes {
+		if err := s.LoadFiles(ctx, j.txnManager.Scene); err != nil {
+			return nil, err
+		}
+
+		for _, f := range s.Files.List() {
+			task := &GeneratePhashTask{
+				File:        f,
+				txnManager:  j.txnManager,
+				fileUpdater: j.txnManager.File,
+			}
+
+			if task.shouldGenerate() {
+				ret = append(ret, task)
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+// GenerateMissingPhashes starts a job which generates phashes for the files
+// of scenes matching the provided filter that do not have one. Existing
+// phashes are not regenerated. Returns the job ID.
+func (s *Manager) GenerateMissingPhashes(ctx context.Context, sceneFilter *models.SceneFilterType) int {
+	j := &generateMissingPhashesJob{
+		txnManager:  s.Repository,
+		sceneFilter: sceneFilter,
+	}
+
+	return s.JobManager.Add(ctx, "Generating missing phashes...", j)
+}
@@ -0,0 +1,55 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGenerateMissingPhashesJobFindTasks(t *testing.T) {
+	const sceneID = 1
+
+	newFile := func(id file.ID, fps ...file.Fingerprint) *file.VideoFile {
+		return &file.VideoFile{BaseFile: &file.BaseFile{ID: id, Fingerprints: fps}}
+	}
+
+	missing := newFile(1, file.Fingerprint{Type: file.FingerprintTypeOshash, Fingerprint: "oshash"})
+	existing := newFile(2, file.Fingerprint{Type: file.FingerprintTypePhash, Fingerprint: int64(1)})
+
+	organized := true
+	sceneFilter := &models.SceneFilterType{Organized: &organized}
+
+	sceneRW := &mocks.SceneReaderWriter{}
+	result := models.NewSceneQueryResult(sceneRW)
+	result.IDs = []int{sceneID}
+	sceneRW.On("Query", mock.Anything, mock.MatchedBy(func(o models.SceneQueryOptions) bool {
+		f := o.SceneFilter
+		// the provided filter is combined with the missing phash filter
+		return f.IsMissing != nil && *f.IsMissing == "phash" && f.And == sceneFilter
+	})).Return(result, nil).Once()
+	sceneRW.On("FindMany", mock.Anything, []int{sceneID}).Return([]*models.Scene{{ID: sceneID}}, nil).Once()
+	sceneRW.On("GetFiles", mock.Anything, sceneID).Return([]*file.VideoFile{missing, existing}, nil).Once()
+
+	j := &generateMissingPhashesJob{
+		txnManager: Repository{
+			Scene: &bulkSceneRW{mock: sceneRW},
+		},
+		sceneFilter: sceneFilter,
+	}
+
+	got, err := j.findTasks(context.Background())
+	assert.NoError(t, err)
+
+	// existing phashes are not regenerated
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, missing, got[0].File)
+		assert.False(t, got[0].Overwrite)
+	}
+
+	sceneRW.AssertExpectations(t)
+}
@@ -98,7 +98,7 @@ func (t *GeneratePreviewTask) doesVideoPreviewExist() bool {
 	}
 
 	if t.videoPreviewExists == nil {
-		videoExists, _ := fsutil.FileExists(instance.Paths.Scene.GetVideoPreviewPath(sceneChecksum))
+		videoExists, _ := fsutil.FileExists(instance.Paths.ForPath(t.Scene.Path).Scene.GetVideoPreviewPath(sceneChecksum))
 		t.videoPreviewExists = &videoExists
 	}
 
@@ -112,7 +112,7 @@ func (t *GeneratePreviewTask) doesImagePreviewExist() bool {
 	}
 
 	if t.imagePreviewExists == nil {
-		imageExists, _ := fsutil.FileExists(instance.Paths.Scene.GetWebpPreviewPath(sceneChecksum))
+		imageExists, _ := fsutil.FileExists(instance.Paths.ForPath(t.Scene.Path).Scene.GetWebpPreviewPath(sceneChecksum))
 		t.imagePreviewExists = &imageExists
 	}
 
@@ -27,15 +27,9 @@ func (t *GenerateScreenshotTask) Start(ctx context.Context) {
 		return
 	}
 
-	var at float64
-	if t.ScreenshotAt == nil {
-		at = float64(videoFile.Duration) * 0.2
-	} else {
-		at = *t.ScreenshotAt
-	}
-
 	checksum := t.Scene.GetHash(t.fileNamingAlgorithm)
-	normalPath := instance.Paths.Scene.GetScreenshotPath(checksum)
+	paths := instance.Paths.ForPath(scenePath)
+	normalPath := paths.Scene.GetScreenshotPath(checksum)
 
 	// we'll generate the screenshot, grab the generated data and set it
 	// in the database. We'll use SetSceneScreenshot to set the data
@@ -46,12 +40,13 @@ func (t *GenerateScreenshotTask) Start(ctx context.Context) {
 	g := generate.Generator{
 		Encoder:     instance.FFMPEG,
 		LockManager: instance.ReadLockManager,
-		ScenePaths:  instance.Paths.Scene,
+		ScenePaths:  paths.Scene,
 		Overwrite:   true,
 	}
 
 	if err := g.Screenshot(context.TODO(), videoFile.Path, checksum, videoFile.Width, videoFile.Duration, generate.ScreenshotOptions{
-		At: &at,
+		At:               t.ScreenshotAt,
+		AvoidBlankFrames: instance.Config.IsCoverAvoidBlankFrames(),
 	}); err != nil {
 		logger.Errorf("Error generating screenshot: %v", err)
 		logErrorOutput(err)
@@ -75,7 +70,7 @@ func (t *GenerateScreenshotTask) Start(ctx context.Context) {
 		qb := t.txnManager.Scene
 		updatedScene := models.NewScenePartial()
 
-		if err := scene.SetScreenshot(instance.Paths, checksum, coverImageData); err != nil {
+		if err := scene.SetScreenshot(paths, checksum, coverImageData); err != nil {
 			return fmt.Errorf("error writing screenshot: %v", err)
 		}
 
@@ -32,8 +32,9 @@ func (t *GenerateSpriteTask) Start(ctx context.Context) {
 	}
 
 	sceneHash := t.Scene.GetHash(t.fileNamingAlgorithm)
-	imagePath := instance.Paths.Scene.GetSpriteImageFilePath(sceneHash)
-	vttPath := instance.Paths.Scene.GetSpriteVttFilePath(sceneHash)
+	scenePaths := instance.Paths.ForPath(t.Scene.Path).Scene
+	imagePath := scenePaths.GetSpriteImageFilePath(sceneHash)
+	vttPath := scenePaths.GetSpriteVttFilePath(sceneHash)
 	generator, err := NewSpriteGenerator(*videoFile, sceneHash, imagePath, vttPath, 9, 9)
 
 	if err != nil {
@@ -63,7 +64,8 @@ func (t *GenerateSpriteTask) doesSpriteExist(sceneChecksum string) bool {
 		return false
 	}
 
-	imageExists, _ := fsutil.FileExists(instance.Paths.Scene.GetSpriteImageFilePath(sceneChecksum))
-	vttExists, _ := fsutil.FileExists(instance.Paths.Scene.GetSpriteVttFilePath(sceneChecksum))
+	scenePaths := instance.Paths.ForPath(t.Scene.Path).Scene
+	imageExists, _ := fsutil.FileExists(scenePaths.GetSpriteImageFilePath(sceneChecksum))
+	vttExists, _ := fsutil.FileExists(scenePaths.GetSpriteVttFilePath(sceneChecksum))
 	return imageExists && vttExists
 }
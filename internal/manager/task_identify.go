@@ -145,6 +145,12 @@ func (j *IdentifyJob) identifyScene(ctx context.Context, s *models.Scene, source
 				FileNamingAlgorithm: instance.Config.GetVideoFileNamingAlgorithm(),
 			},
 			SceneUpdatePostHookExecutor: j.postHookExecutor,
+
+			AutoOrganize:               instance.Config.GetIdentifyAutoOrganize(),
+			AutoOrganizeRequiredFields: instance.Config.GetIdentifyAutoOrganizeRequiredFields(),
+
+			SkipIdentified:               j.input.SkipIdentified != nil && *j.input.SkipIdentified,
+			SkipIdentifiedRequiredFields: j.input.SkipIdentifiedRequiredFields,
 		}
 
 		taskError = task.Identify(ctx, instance.Repository, s)
@@ -29,5 +29,5 @@ func (t *MigrateHashTask) Start() {
 		newHash = oshash
 	}
 
-	scene.MigrateHash(instance.Paths, oldHash, newHash)
+	scene.MigrateHash(instance.Paths.ForPath(t.Scene.Path), oldHash, newHash)
 }
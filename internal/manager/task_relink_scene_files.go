@@ -0,0 +1,101 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+type unlinkedVideoFileFinder interface {
+	FindUnlinkedVideoFiles(ctx context.Context) ([]*file.VideoFile, error)
+}
+
+type sceneFileRelinker interface {
+	Relink(ctx context.Context, f *file.VideoFile) ([]*models.Scene, error)
+}
+
+type relinkSceneFilesJob struct {
+	txnManager txn.Manager
+	fileFinder unlinkedVideoFileFinder
+}
+
+func (j *relinkSceneFilesJob) Execute(ctx context.Context, progress *job.Progress) {
+	var files []*file.VideoFile
+	if err := txn.WithReadTxn(ctx, j.txnManager, func(ctx context.Context) error {
+		var err error
+		files, err = j.fileFinder.FindUnlinkedVideoFiles(ctx)
+		return err
+	}); err != nil {
+		logger.Errorf("Error finding unlinked video files: %v", err)
+		return
+	}
+
+	logger.Infof("Relinking %d unlinked video files", len(files))
+
+	progress.SetTotal(len(files))
+
+	// studios are matched in tasks of the queue
+	const taskQueueSize = 200000
+	taskQueue := job.NewTaskQueue(ctx, progress, taskQueueSize, instance.Config.GetParallelTasksWithAutoDetection())
+
+	relinked, unmatched := j.relinkFiles(ctx, progress, getSceneRelinkHandler(taskQueue, progress), files)
+
+	taskQueue.Close()
+
+	if job.IsCancelled(ctx) {
+		logger.Info("Stopping due to user request")
+	}
+
+	logger.Infof("Relinked %d video files, %d did not match any scene", relinked, unmatched)
+}
+
+// relinkFiles relinks each file in its own transaction, and returns the
+// number of files that were relinked and that did not match any scene.
+func (j *relinkSceneFilesJob) relinkFiles(ctx context.Context, progress file.ProgressReporter, relinker sceneFileRelinker, files []*file.VideoFile) (relinked int, unmatched int) {
+	for _, f := range files {
+		if job.IsCancelled(ctx) {
+			return
+		}
+
+		progress.ExecuteTask(fmt.Sprintf("Relinking %s", f.Path), func() {
+			var scenes []*models.Scene
+			if err := txn.WithTxn(ctx, j.txnManager, func(ctx context.Context) error {
+				var err error
+				scenes, err = relinker.Relink(ctx, f)
+				return err
+			}); err != nil {
+				logger.Errorf("Error relinking %s: %v", f.Path, err)
+				return
+			}
+
+			if len(scenes) == 0 {
+				logger.Debugf("%s did not match any scene", f.Path)
+				unmatched++
+				return
+			}
+
+			relinked++
+		})
+
+		progress.Increment()
+	}
+
+	return
+}
+
+// RelinkSceneFiles starts a job which adds video files which are not linked
+// to any scene to the scenes matching their fingerprints, in the same way as
+// scanning. Returns the job ID.
+func (s *Manager) RelinkSceneFiles(ctx context.Context) int {
+	j := &relinkSceneFilesJob{
+		txnManager: s.Repository,
+		fileFinder: s.Repository.File,
+	}
+
+	return s.JobManager.Add(ctx, "Relinking scene files...", j)
+}
@@ -0,0 +1,54 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+type testRelinker struct {
+	scenes map[string][]*models.Scene
+	err    map[string]error
+}
+
+func (r *testRelinker) Relink(ctx context.Context, f *file.VideoFile) ([]*models.Scene, error) {
+	return r.scenes[f.Path], r.err[f.Path]
+}
+
+func TestRelinkSceneFilesJobRelinkFiles(t *testing.T) {
+	newFile := func(id file.ID, path string) *file.VideoFile {
+		return &file.VideoFile{BaseFile: &file.BaseFile{ID: id, Path: path}}
+	}
+
+	relinker := &testRelinker{
+		scenes: map[string][]*models.Scene{
+			"single.mp4":   {{ID: 1}},
+			"multiple.mp4": {{ID: 2}, {ID: 3}},
+		},
+		err: map[string]error{
+			"error.mp4": errors.New("relink error"),
+		},
+	}
+
+	files := []*file.VideoFile{
+		newFile(1, "single.mp4"),
+		newFile(2, "multiple.mp4"),
+		newFile(3, "unmatched.mp4"),
+		newFile(4, "error.mp4"),
+	}
+
+	j := &relinkSceneFilesJob{
+		txnManager: &mocks.TxnManager{},
+	}
+
+	relinked, unmatched := j.relinkFiles(context.Background(), testProgress{}, relinker, files)
+
+	// files which fail to relink are neither relinked nor unmatched
+	assert.Equal(t, 2, relinked)
+	assert.Equal(t, 1, unmatched)
+}
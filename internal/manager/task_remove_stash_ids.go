@@ -0,0 +1,217 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+type StashIDObjectType string
+
+const (
+	StashIDObjectTypeScene     StashIDObjectType = "SCENE"
+	StashIDObjectTypePerformer StashIDObjectType = "PERFORMER"
+	StashIDObjectTypeStudio    StashIDObjectType = "STUDIO"
+)
+
+var AllStashIDObjectType = []StashIDObjectType{
+	StashIDObjectTypeScene,
+	StashIDObjectTypePerformer,
+	StashIDObjectTypeStudio,
+}
+
+func (e StashIDObjectType) IsValid() bool {
+	switch e {
+	case StashIDObjectTypeScene, StashIDObjectTypePerformer, StashIDObjectTypeStudio:
+		return true
+	}
+	return false
+}
+
+func (e StashIDObjectType) String() string {
+	return string(e)
+}
+
+func (e *StashIDObjectType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = StashIDObjectType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid StashIDObjectType", str)
+	}
+	return nil
+}
+
+func (e StashIDObjectType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type RemoveStashIDsInput struct {
+	// Type of the objects to remove the stash ids from
+	Type StashIDObjectType `json:"type"`
+	// IDs of the objects to remove the stash ids from
+	Ids []string `json:"ids"`
+	// Endpoint of the stash ids to remove
+	Endpoint string `json:"endpoint"`
+}
+
+type removeStashIDsJob struct {
+	txnManager Repository
+	input      RemoveStashIDsInput
+	ids        []int
+}
+
+func (j *removeStashIDsJob) Execute(ctx context.Context, progress *job.Progress) {
+	logger.Infof("Removing %s stash IDs for endpoint %s", j.input.Type, j.input.Endpoint)
+
+	progress.SetTotal(len(j.ids))
+
+	updated := 0
+	removed := 0
+	for _, id := range j.ids {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return
+		}
+
+		var n int
+		if err := txn.WithTxn(ctx, j.txnManager, func(ctx context.Context) error {
+			var err error
+			n, err = j.removeFrom(ctx, id)
+			return err
+		}); err != nil {
+			logger.Errorf("Error removing stash IDs from %s %d: %v", j.input.Type, id, err)
+		}
+
+		if n > 0 {
+			updated++
+			removed += n
+		}
+
+		progress.Increment()
+	}
+
+	logger.Infof("Removed %d stash IDs from %d of %d objects", removed, updated, len(j.ids))
+}
+
+// removeFrom removes the stash ids matching the job endpoint from the object
+// with the provided id. Returns the number of stash ids removed.
+func (j *removeStashIDsJob) removeFrom(ctx context.Context, id int) (int, error) {
+	r := j.txnManager
+
+	switch j.input.Type {
+	case StashIDObjectTypeScene:
+		existing, err := r.Scene.GetStashIDs(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+
+		toRemove := stashIDsForEndpoint(existing, j.input.Endpoint)
+		if len(toRemove) == 0 {
+			return 0, nil
+		}
+
+		partial := models.NewScenePartial()
+		partial.StashIDs = &models.UpdateStashIDs{
+			StashIDs: toRemove,
+			Mode:     models.RelationshipUpdateModeRemove,
+		}
+		if _, err := r.Scene.UpdatePartial(ctx, id, partial); err != nil {
+			return 0, err
+		}
+
+		return len(toRemove), nil
+	case StashIDObjectTypePerformer:
+		existing, err := r.Performer.GetStashIDs(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+
+		toRemove := stashIDsForEndpoint(existing, j.input.Endpoint)
+		if len(toRemove) == 0 {
+			return 0, nil
+		}
+
+		partial := models.NewPerformerPartial()
+		partial.StashIDs = &models.UpdateStashIDs{
+			StashIDs: toRemove,
+			Mode:     models.RelationshipUpdateModeRemove,
+		}
+		if _, err := r.Performer.UpdatePartial(ctx, id, partial); err != nil {
+			return 0, err
+		}
+
+		return len(toRemove), nil
+	case StashIDObjectTypeStudio:
+		existing, err := r.Studio.GetStashIDs(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+
+		var toKeep []models.StashID
+		for _, v := range existing {
+			if v.Endpoint != j.input.Endpoint {
+				toKeep = append(toKeep, v)
+			}
+		}
+
+		n := len(existing) - len(toKeep)
+		if n == 0 {
+			return 0, nil
+		}
+
+		if err := r.Studio.UpdateStashIDs(ctx, id, toKeep); err != nil {
+			return 0, err
+		}
+
+		return n, nil
+	}
+
+	return 0, fmt.Errorf("unsupported object type %s", j.input.Type)
+}
+
+func stashIDsForEndpoint(stashIDs []models.StashID, endpoint string) []models.StashID {
+	var ret []models.StashID
+	for _, v := range stashIDs {
+		if v.Endpoint == endpoint {
+			ret = append(ret, v)
+		}
+	}
+
+	return ret
+}
+
+// RemoveStashIDs starts a job which removes all stash ids for the input
+// endpoint from the input objects. Returns the job ID.
+func (s *Manager) RemoveStashIDs(ctx context.Context, input RemoveStashIDsInput) (int, error) {
+	if !input.Type.IsValid() {
+		return 0, fmt.Errorf("%w: invalid type %s", ErrInput, input.Type)
+	}
+
+	if input.Endpoint == "" {
+		return 0, fmt.Errorf("%w: endpoint must be set", ErrInput)
+	}
+
+	ids, err := stringslice.StringSliceToIntSlice(input.Ids)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid ids: %v", ErrInput, err)
+	}
+
+	j := &removeStashIDsJob{
+		txnManager: s.Repository,
+		input:      input,
+		ids:        ids,
+	}
+
+	return s.JobManager.Add(ctx, "Removing stash IDs...", j), nil
+}
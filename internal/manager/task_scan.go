@@ -7,6 +7,7 @@ import (
 	"io/fs"
 	"path/filepath"
 	"regexp"
+	"sync"
 	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler/lru"
@@ -19,8 +20,10 @@ import (
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/paths"
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/scene/generate"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 	"github.com/stashapp/stash/pkg/txn"
 )
 
@@ -32,6 +35,50 @@ type ScanJob struct {
 	scanner       scanner
 	input         ScanMetadataInput
 	subscriptions *subscriptionManager
+	collisions    *OshashCollisionResults
+}
+
+// OshashCollisionResults holds the suspected oshash collisions found by the
+// last scan.
+type OshashCollisionResults struct {
+	mutex   sync.Mutex
+	results []*file.OshashCollision
+}
+
+func NewOshashCollisionResults() *OshashCollisionResults {
+	return &OshashCollisionResults{}
+}
+
+// Results returns the collisions found by the last scan, in the order they
+// were found.
+func (r *OshashCollisionResults) Results() []*file.OshashCollision {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.results
+}
+
+func (r *OshashCollisionResults) reset() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.results = nil
+}
+
+// ReportOshashCollision stores the collision. Collisions between the same
+// files are only stored once.
+func (r *OshashCollisionResults) ReportOshashCollision(ctx context.Context, c file.OshashCollision) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, existing := range r.results {
+		if existing.Path == c.Path && existing.OtherPath == c.OtherPath {
+			if existing.SceneID == nil {
+				existing.SceneID = c.SceneID
+			}
+			return
+		}
+	}
+
+	r.results = append(r.results, &c)
 }
 
 func (j *ScanJob) Execute(ctx context.Context, progress *job.Progress) {
@@ -58,14 +105,22 @@ func (j *ScanJob) Execute(ctx context.Context, progress *job.Progress) {
 		minModTime = *j.input.Filter.MinModTime
 	}
 
-	j.scanner.Scan(ctx, getScanHandlers(j.input, taskQueue, progress), file.ScanOptions{
-		Paths:             paths,
-		ScanFilters:       []file.PathFilter{newScanFilter(instance.Config, minModTime)},
-		ZipFileExtensions: instance.Config.GetGalleryExtensions(),
-		ParallelTasks:     instance.Config.GetParallelTasksWithAutoDetection(),
+	j.collisions.reset()
+
+	j.scanner.Scan(ctx, getScanHandlers(j.input, taskQueue, progress, j.collisions), file.ScanOptions{
+		Paths:                 paths,
+		ScanFilters:           []file.PathFilter{newScanFilter(instance.Config, minModTime)},
+		ZipFileExtensions:     instance.Config.GetGalleryExtensions(),
+		MaxZipDepth:           instance.Config.GetNestedZipMaxDepth(),
+		MaxZipEntries:         instance.Config.GetZipMaxEntries(),
+		NaturalSortZipEntries: instance.Config.GetGalleryImageNaturalSort(),
+		ParallelTasks:         instance.Config.GetParallelTasksWithAutoDetection(),
+		HashWorkers:           instance.Config.GetHashWorkers(),
+		ZipParallelTasks:      instance.Config.GetGalleryParallelTasks(),
 		HandlerRequiredFilters: []file.Filter{
 			newHandlerRequiredFilter(instance.Config),
 		},
+		CollisionReporter: j.collisions,
 	}, progress)
 
 	taskQueue.Close()
@@ -196,7 +251,7 @@ func (f *handlerRequiredFilter) Accept(ctx context.Context, ff file.File) bool {
 	if isVideoFile {
 		// check if the screenshot file exists
 		hash := scene.GetHash(ff, f.videoFileNamingAlgorithm)
-		ssPath := instance.Paths.Scene.GetScreenshotPath(hash)
+		ssPath := instance.Paths.ForPath(ff.Base().Path).Scene.GetScreenshotPath(hash)
 		if exists, _ := fsutil.FileExists(ssPath); !exists {
 			// if not, check if the file is a primary file for a scene
 			scenes, err := f.SceneFinder.FindByPrimaryFileID(ctx, ff.Base().ID)
@@ -316,9 +371,77 @@ func (c *scanConfig) IsGenerateThumbnails() bool {
 	return c.isGenerateThumbnails
 }
 
-func getScanHandlers(options ScanMetadataInput, taskQueue *job.TaskQueue, progress *job.Progress) []file.Handler {
+// isSceneDateProtected returns true if the date of scenes is never set when
+// scanning.
+func isSceneDateProtected() bool {
+	return stringslice.StrInclude(instance.Config.GetScanProtectedFields(), scene.ScanFieldDate)
+}
+
+// newFolderLinker returns the linker of scenes and images to folder
+// galleries. Returns nil if linking is disabled.
+func newFolderLinker() *gallery.FolderLinker {
+	if !instance.Config.GetLinkFolderGalleriesOnScan() {
+		return nil
+	}
+
+	return &gallery.FolderLinker{
+		SceneFinderUpdater: instance.Database.Scene,
+		GalleryFinder:      instance.Database.Gallery,
+		MatchStem:          instance.Config.GetLinkFolderGalleriesMatchStem(),
+		SyncLinkedDates:    instance.Config.GetSyncLinkedDatesOnScan(),
+		GalleryUpdater:     instance.Database.Gallery,
+		ProtectSceneDate:   isSceneDateProtected(),
+	}
+}
+
+// getSceneRelinkHandler returns a scene scan handler with the scan
+// configuration, used to relink unlinked video files to scenes. Generation is
+// not performed when relinking.
+func getSceneRelinkHandler(taskQueue *job.TaskQueue, progress *job.Progress) *scene.ScanHandler {
+	// the interface field must remain nil if linking is disabled
+	var sceneGalleryLinker scene.FolderGalleryLinker
+	if folderLinker := newFolderLinker(); folderLinker != nil {
+		sceneGalleryLinker = folderLinker
+	}
+
+	return &scene.ScanHandler{
+		CreatorUpdater:      instance.Database.Scene,
+		PluginCache:         instance.PluginCache,
+		StudioMatcher:       newMetadataStudioMatcher(instance.Config, instance.FFProbe, instance.Repository, instance.PluginCache, taskQueue, progress),
+		PathTagger:          newPathTagger(instance.Config, instance.Repository.Tag),
+		GalleryLinker:       sceneGalleryLinker,
+		CollisionReporter:   instance.OshashCollisions,
+		ProtectedFields:     instance.Config.GetScanProtectedFields(),
+		PrimaryFileSelector: scene.NewPrimaryFileSelector(instance.Config),
+	}
+}
+
+func getScanHandlers(options ScanMetadataInput, taskQueue *job.TaskQueue, progress *job.Progress, collisions file.CollisionReporter) []file.Handler {
 	db := instance.Database
 	pluginCache := instance.PluginCache
+	protectedFields := instance.Config.GetScanProtectedFields()
+
+	sceneGen := &sceneGenerators{
+		input:     options,
+		taskQueue: taskQueue,
+		progress:  progress,
+	}
+
+	var sceneRegenerator scene.ScanRegenerator
+	if instance.Config.IsRegenerateChangedFiles() {
+		sceneRegenerator = sceneGen
+	}
+
+	// the interface fields must remain nil if linking is disabled
+	var (
+		folderLinker       *gallery.FolderLinker
+		imageGalleryLinker image.FolderGalleryLinker
+		sceneGalleryLinker scene.FolderGalleryLinker
+	)
+	if folderLinker = newFolderLinker(); folderLinker != nil {
+		imageGalleryLinker = folderLinker
+		sceneGalleryLinker = folderLinker
+	}
 
 	return []file.Handler{
 		&file.FilteredHandler{
@@ -330,8 +453,9 @@ func getScanHandlers(options ScanMetadataInput, taskQueue *job.TaskQueue, progre
 				ScanConfig: &scanConfig{
 					isGenerateThumbnails: options.ScanGenerateThumbnails,
 				},
-				PluginCache: pluginCache,
-				Paths:       instance.Paths,
+				GalleryLinker: imageGalleryLinker,
+				PluginCache:   pluginCache,
+				Paths:         instance.Paths,
 			},
 		},
 		&file.FilteredHandler{
@@ -341,20 +465,26 @@ func getScanHandlers(options ScanMetadataInput, taskQueue *job.TaskQueue, progre
 				SceneFinderUpdater: db.Scene,
 				ImageFinderUpdater: db.Image,
 				PluginCache:        pluginCache,
+				SyncLinkedDates:    instance.Config.GetSyncLinkedDatesOnScan(),
+				ProtectSceneDate:   isSceneDateProtected(),
+				FolderLinker:       folderLinker,
 			},
 		},
 		&file.FilteredHandler{
 			Filter: file.FilterFunc(videoFileFilter),
 			Handler: &scene.ScanHandler{
-				CreatorUpdater: db.Scene,
-				PluginCache:    pluginCache,
-				CaptionUpdater: db.File,
-				CoverGenerator: &coverGenerator{},
-				ScanGenerator: &sceneGenerators{
-					input:     options,
-					taskQueue: taskQueue,
-					progress:  progress,
-				},
+				CreatorUpdater:      db.Scene,
+				PluginCache:         pluginCache,
+				CaptionUpdater:      db.File,
+				StudioMatcher:       newMetadataStudioMatcher(instance.Config, instance.FFProbe, instance.Repository, pluginCache, taskQueue, progress),
+				PathTagger:          newPathTagger(instance.Config, instance.Repository.Tag),
+				GalleryLinker:       sceneGalleryLinker,
+				CollisionReporter:   collisions,
+				ProtectedFields:     protectedFields,
+				PrimaryFileSelector: scene.NewPrimaryFileSelector(instance.Config),
+				CoverGenerator:      &coverGenerator{},
+				ScanGenerator:       sceneGen,
+				Regenerator:         sceneRegenerator,
 				FileNamingAlgorithm: instance.Config.GetVideoFileNamingAlgorithm(),
 				Paths:               instance.Paths,
 			},
@@ -365,7 +495,7 @@ func getScanHandlers(options ScanMetadataInput, taskQueue *job.TaskQueue, progre
 type imageThumbnailGenerator struct{}
 
 func (g *imageThumbnailGenerator) GenerateThumbnail(ctx context.Context, i *models.Image, f *file.ImageFile) error {
-	thumbPath := GetInstance().Paths.Generated.GetThumbnailPath(i.Checksum, models.DefaultGthumbWidth)
+	thumbPath := GetInstance().Paths.ForPath(f.Path).Generated.GetThumbnailPath(i.Checksum, models.DefaultGthumbWidth)
 	exists, _ := fsutil.FileExists(thumbPath)
 	if exists {
 		return nil
@@ -447,8 +577,8 @@ func (g *sceneGenerators) Generate(ctx context.Context, s *models.Scene, f *file
 			g := &generate.Generator{
 				Encoder:     instance.FFMPEG,
 				LockManager: instance.ReadLockManager,
-				MarkerPaths: instance.Paths.SceneMarkers,
-				ScenePaths:  instance.Paths.Scene,
+				MarkerPaths: instance.Paths.ForPath(path).SceneMarkers,
+				ScenePaths:  instance.Paths.ForPath(path).Scene,
 				Overwrite:   overwrite,
 			}
 
@@ -467,3 +597,198 @@ func (g *sceneGenerators) Generate(ctx context.Context, s *models.Scene, f *file
 
 	return nil
 }
+
+// staleGeneratedFiles are the generated files of a scene which are named
+// using a hash which no longer matches the contents of its primary file.
+type staleGeneratedFiles struct {
+	// files and dirs are deleted before regeneration
+	files []string
+	dirs  []string
+
+	// artifacts which must be regenerated by Regenerate
+	sprite             bool
+	preview            bool
+	imagePreview       bool
+	transcode          bool
+	contactSheet       bool
+	markers            bool
+	markerImagePreview bool
+	markerScreenshot   bool
+	heatmap            bool
+}
+
+// findStaleGeneratedFiles returns the existing generated files named using
+// staleHash. Sprites and previews are only flagged for regeneration if the
+// scan does not already generate them. The screenshot is regenerated by the
+// cover generator of the scan handler, and the legacy thumbnail is no longer
+// generated.
+func findStaleGeneratedFiles(p *paths.Paths, staleHash string, input ScanMetadataInput) staleGeneratedFiles {
+	var ret staleGeneratedFiles
+
+	exists := func(path string) bool {
+		found, _ := fsutil.FileExists(path)
+		if found {
+			ret.files = append(ret.files, path)
+		}
+		return found
+	}
+
+	scenePaths := p.Scene
+
+	exists(scenePaths.GetThumbnailScreenshotPath(staleHash))
+	exists(scenePaths.GetScreenshotPath(staleHash))
+
+	hasSprite := exists(scenePaths.GetSpriteImageFilePath(staleHash))
+	hasVtt := exists(scenePaths.GetSpriteVttFilePath(staleHash))
+	ret.sprite = (hasSprite || hasVtt) && !input.ScanGenerateSprites
+
+	hasPreview := exists(scenePaths.GetVideoPreviewPath(staleHash))
+	hasImagePreview := exists(scenePaths.GetWebpPreviewPath(staleHash))
+	ret.preview = (hasPreview || hasImagePreview) && !input.ScanGeneratePreviews
+	ret.imagePreview = hasImagePreview
+
+	ret.transcode = exists(scenePaths.GetTranscodePath(staleHash))
+	ret.contactSheet = exists(scenePaths.GetContactSheetPath(staleHash))
+	ret.heatmap = exists(scenePaths.GetInteractiveHeatmapPath(staleHash))
+
+	markersDir := filepath.Join(p.Generated.Markers, staleHash)
+	if found, _ := fsutil.DirExists(markersDir); found {
+		ret.dirs = append(ret.dirs, markersDir)
+		ret.markers = true
+
+		webps, _ := filepath.Glob(filepath.Join(markersDir, "*.webp"))
+		ret.markerImagePreview = len(webps) > 0
+		screenshots, _ := filepath.Glob(filepath.Join(markersDir, "*.jpg"))
+		ret.markerScreenshot = len(screenshots) > 0
+	}
+
+	return ret
+}
+
+// Regenerate deletes the stale generated files of the scene, and queues
+// regeneration of those not already generated by the scan options. The
+// cover is regenerated by the scan handler.
+func (g *sceneGenerators) Regenerate(ctx context.Context, s *models.Scene, f *file.VideoFile, staleHash string) error {
+	const overwrite = true
+
+	progress := g.progress
+	path := f.Path
+	config := instance.Config
+	fileNamingAlgorithm := config.GetVideoFileNamingAlgorithm()
+	paths := instance.Paths.ForPath(path)
+
+	stale := findStaleGeneratedFiles(paths, staleHash, g.input)
+
+	deleter := file.NewDeleter()
+	if err := deleter.Files(stale.files); err != nil {
+		deleter.Rollback()
+		return fmt.Errorf("deleting stale generated files: %w", err)
+	}
+	if err := deleter.Dirs(stale.dirs); err != nil {
+		deleter.Rollback()
+		return fmt.Errorf("deleting stale generated files: %w", err)
+	}
+	deleter.Commit()
+
+	newGenerator := func() *generate.Generator {
+		return &generate.Generator{
+			Encoder:     instance.FFMPEG,
+			LockManager: instance.ReadLockManager,
+			MarkerPaths: paths.SceneMarkers,
+			ScenePaths:  paths.Scene,
+			Overwrite:   overwrite,
+		}
+	}
+
+	if stale.sprite {
+		progress.AddTotal(1)
+		g.taskQueue.Add(fmt.Sprintf("Regenerating sprites for %s", path), func(ctx context.Context) {
+			taskSprite := GenerateSpriteTask{
+				Scene:               *s,
+				Overwrite:           overwrite,
+				fileNamingAlgorithm: fileNamingAlgorithm,
+			}
+			taskSprite.Start(ctx)
+			progress.Increment()
+		})
+	}
+
+	if stale.preview {
+		progress.AddTotal(1)
+		g.taskQueue.Add(fmt.Sprintf("Regenerating preview for %s", path), func(ctx context.Context) {
+			taskPreview := GeneratePreviewTask{
+				Scene:               *s,
+				ImagePreview:        stale.imagePreview,
+				Options:             getGeneratePreviewOptions(GeneratePreviewOptionsInput{}),
+				Overwrite:           overwrite,
+				fileNamingAlgorithm: fileNamingAlgorithm,
+				generator:           newGenerator(),
+			}
+			taskPreview.Start(ctx)
+			progress.Increment()
+		})
+	}
+
+	if stale.transcode {
+		progress.AddTotal(1)
+		g.taskQueue.Add(fmt.Sprintf("Regenerating transcode for %s", path), func(ctx context.Context) {
+			taskTranscode := GenerateTranscodeTask{
+				Scene:               *s,
+				Overwrite:           overwrite,
+				fileNamingAlgorithm: fileNamingAlgorithm,
+				g:                   newGenerator(),
+			}
+			taskTranscode.Start(ctx)
+			progress.Increment()
+		})
+	}
+
+	if stale.contactSheet {
+		progress.AddTotal(1)
+		g.taskQueue.Add(fmt.Sprintf("Regenerating contact sheet for %s", path), func(ctx context.Context) {
+			taskContactSheet := GenerateContactSheetTask{
+				Scene:               *s,
+				Rows:                contactSheetRowsDefault,
+				Columns:             contactSheetColumnsDefault,
+				Overwrite:           overwrite,
+				fileNamingAlgorithm: fileNamingAlgorithm,
+				generator:           newGenerator(),
+			}
+			taskContactSheet.Start(ctx)
+			progress.Increment()
+		})
+	}
+
+	if stale.markers {
+		progress.AddTotal(1)
+		g.taskQueue.Add(fmt.Sprintf("Regenerating markers for %s", path), func(ctx context.Context) {
+			taskMarkers := GenerateMarkersTask{
+				TxnManager:          instance.Repository,
+				Scene:               s,
+				Overwrite:           overwrite,
+				fileNamingAlgorithm: fileNamingAlgorithm,
+				ImagePreview:        stale.markerImagePreview,
+				Screenshot:          stale.markerScreenshot,
+				generator:           newGenerator(),
+			}
+			taskMarkers.Start(ctx)
+			progress.Increment()
+		})
+	}
+
+	if stale.heatmap {
+		progress.AddTotal(1)
+		g.taskQueue.Add(fmt.Sprintf("Regenerating heatmap and speed for %s", path), func(ctx context.Context) {
+			taskHeatmap := GenerateInteractiveHeatmapSpeedTask{
+				Scene:               *s,
+				Overwrite:           overwrite,
+				fileNamingAlgorithm: fileNamingAlgorithm,
+				TxnManager:          instance.Repository,
+			}
+			taskHeatmap.Start(ctx)
+			progress.Increment()
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models/paths"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindStaleGeneratedFiles(t *testing.T) {
+	const (
+		staleHash = "stale"
+		otherHash = "other"
+	)
+
+	p := paths.NewPaths(t.TempDir())
+
+	touch := func(path string) string {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	screenshot := touch(p.Scene.GetScreenshotPath(staleHash))
+	sprite := touch(p.Scene.GetSpriteImageFilePath(staleHash))
+	vtt := touch(p.Scene.GetSpriteVttFilePath(staleHash))
+	imagePreview := touch(p.Scene.GetWebpPreviewPath(staleHash))
+	heatmap := touch(p.Scene.GetInteractiveHeatmapPath(staleHash))
+	touch(p.SceneMarkers.GetWebpPreviewPath(staleHash, 10))
+
+	// files of other scenes must not be touched
+	touch(p.Scene.GetTranscodePath(otherHash))
+	touch(p.Scene.GetContactSheetPath(otherHash))
+
+	markersDir := filepath.Join(p.Generated.Markers, staleHash)
+
+	t.Run("no scan generation", func(t *testing.T) {
+		got := findStaleGeneratedFiles(&p, staleHash, ScanMetadataInput{})
+
+		assert.ElementsMatch(t, []string{screenshot, sprite, vtt, imagePreview, heatmap}, got.files)
+		assert.Equal(t, []string{markersDir}, got.dirs)
+
+		assert.Equal(t, staleGeneratedFiles{
+			files:              got.files,
+			dirs:               got.dirs,
+			sprite:             true,
+			preview:            true,
+			imagePreview:       true,
+			markers:            true,
+			markerImagePreview: true,
+			heatmap:            true,
+		}, got)
+	})
+
+	t.Run("generated by scan", func(t *testing.T) {
+		got := findStaleGeneratedFiles(&p, staleHash, ScanMetadataInput{
+			ScanMetadataOptions: config.ScanMetadataOptions{
+				ScanGenerateSprites:  true,
+				ScanGeneratePreviews: true,
+			},
+		})
+
+		// files are still deleted, but regenerated by the scan generators
+		assert.ElementsMatch(t, []string{screenshot, sprite, vtt, imagePreview, heatmap}, got.files)
+		assert.False(t, got.sprite)
+		assert.False(t, got.preview)
+		assert.True(t, got.markers)
+		assert.True(t, got.heatmap)
+	})
+
+	t.Run("nothing generated", func(t *testing.T) {
+		got := findStaleGeneratedFiles(&p, "missing", ScanMetadataInput{})
+		assert.Equal(t, staleGeneratedFiles{}, got)
+	})
+}
+
+func TestOshashCollisionResults(t *testing.T) {
+	ctx := context.Background()
+	sceneID := 1
+
+	r := NewOshashCollisionResults()
+	r.ReportOshashCollision(ctx, file.OshashCollision{Path: "a", OtherPath: "b"})
+	r.ReportOshashCollision(ctx, file.OshashCollision{Path: "a", OtherPath: "c"})
+	// the same pair reported when associating with a scene sets the scene
+	r.ReportOshashCollision(ctx, file.OshashCollision{Path: "a", OtherPath: "b", SceneID: &sceneID})
+
+	assert.Equal(t, []*file.OshashCollision{
+		{Path: "a", OtherPath: "b", SceneID: &sceneID},
+		{Path: "a", OtherPath: "c"},
+	}, r.Results())
+
+	r.reset()
+	assert.Empty(t, r.Results())
+}
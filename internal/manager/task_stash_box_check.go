@@ -0,0 +1,497 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/match"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/scraper/stashbox"
+)
+
+// stashBoxCheckBatchSize is the number of stash ids passed to the stash-box
+// client at a time. Progress is updated and cancellation is checked between
+// batches.
+const stashBoxCheckBatchSize = 50
+
+// StaleSceneStashID is a scene stash id which no longer resolves on a
+// stash-box instance.
+type StaleSceneStashID struct {
+	SceneID int
+	StashID string
+	// RedirectStashID is the id that the stash id resolves to. It is nil if
+	// the stash id was not found.
+	RedirectStashID *string
+}
+
+// SceneStudioConflict is a scene where the assigned studio differs from the
+// studio of the scene on a stash-box instance.
+type SceneStudioConflict struct {
+	SceneID  int
+	StashID  string
+	StudioID int
+	// StashBoxStudio is the studio of the scene on the stash-box instance.
+	// StoredID is set if it matches a local studio.
+	StashBoxStudio *models.ScrapedStudio
+}
+
+// PerformerStashBoxDiff is a performer where local fields differ from the
+// values of the performer on a stash-box instance.
+type PerformerStashBoxDiff struct {
+	PerformerID int
+	StashID     string
+	Diffs       []*PerformerFieldDiff
+}
+
+// PerformerFieldDiff is a field of a performer which differs from the value
+// on a stash-box instance.
+type PerformerFieldDiff struct {
+	Field         string  `json:"field"`
+	LocalValue    *string `json:"local_value"`
+	StashBoxValue string  `json:"stash_box_value"`
+}
+
+// StashBoxCheckResults holds the results of the last run of each of the
+// stash-box check jobs.
+type StashBoxCheckResults struct {
+	mutex              sync.Mutex
+	staleSceneStashIDs []*StaleSceneStashID
+	studioConflicts    []*SceneStudioConflict
+	performerDiffs     []*PerformerStashBoxDiff
+}
+
+func NewStashBoxCheckResults() *StashBoxCheckResults {
+	return &StashBoxCheckResults{}
+}
+
+// StaleSceneStashIDs returns the results of the last CheckSceneStashIDs job.
+func (r *StashBoxCheckResults) StaleSceneStashIDs() []*StaleSceneStashID {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.staleSceneStashIDs
+}
+
+func (r *StashBoxCheckResults) setStaleSceneStashIDs(v []*StaleSceneStashID) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.staleSceneStashIDs = v
+}
+
+// SceneStudioConflicts returns the results of the last
+// CheckSceneStudioConflicts job.
+func (r *StashBoxCheckResults) SceneStudioConflicts() []*SceneStudioConflict {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.studioConflicts
+}
+
+func (r *StashBoxCheckResults) setSceneStudioConflicts(v []*SceneStudioConflict) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.studioConflicts = v
+}
+
+// PerformerStashBoxDiffs returns the results of the last
+// CheckPerformerStashBoxDiffs job.
+func (r *StashBoxCheckResults) PerformerStashBoxDiffs() []*PerformerStashBoxDiff {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.performerDiffs
+}
+
+func (r *StashBoxCheckResults) setPerformerStashBoxDiffs(v []*PerformerStashBoxDiff) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.performerDiffs = v
+}
+
+func (s *Manager) newStashBoxClient(box *models.StashBox) *stashbox.Client {
+	return stashbox.NewClient(*box, s.Repository, stashbox.Repository{
+		Scene:     s.Repository.Scene,
+		Performer: s.Repository.Performer,
+		Tag:       s.Repository.Tag,
+		Studio:    s.Repository.Studio,
+	})
+}
+
+// sceneStashID is a stash id of a scene for a stash-box endpoint.
+type sceneStashID struct {
+	scene   *models.Scene
+	stashID string
+}
+
+// findSceneStashIDs returns the stash ids for the endpoint of the scenes
+// matching the filter. If filter is nil, then all scenes are considered.
+// Scenes with more than one stash id for the endpoint are returned once for
+// each stash id.
+func (s *Manager) findSceneStashIDs(ctx context.Context, endpoint string, filter *models.SceneFilterType) ([]sceneStashID, error) {
+	sceneFilter := &models.SceneFilterType{}
+	if filter != nil {
+		f := *filter
+		sceneFilter = &f
+	}
+	sceneFilter.StashIDEndpoint = &models.StashIDCriterionInput{
+		Endpoint: &endpoint,
+		Modifier: models.CriterionModifierNotNull,
+	}
+
+	var ret []sceneStashID
+	if err := s.Repository.WithReadTxn(ctx, func(ctx context.Context) error {
+		all := -1
+		scenes, err := scene.Query(ctx, s.Repository.Scene, sceneFilter, &models.FindFilterType{
+			PerPage: &all,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, sc := range scenes {
+			if err := sc.LoadStashIDs(ctx, s.Repository.Scene); err != nil {
+				return err
+			}
+
+			for _, sid := range sc.StashIDs.List() {
+				if sid.Endpoint == endpoint {
+					ret = append(ret, sceneStashID{
+						scene:   sc,
+						stashID: sid.StashID,
+					})
+				}
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// stashBoxCheckBatched calls fn for consecutive ranges of at most
+// stashBoxCheckBatchSize of the n stash ids, updating the progress after
+// each range. It returns false if the job was cancelled.
+func stashBoxCheckBatched(ctx context.Context, progress *job.Progress, n int, fn func(start, end int) error) (bool, error) {
+	progress.SetTotal(n)
+
+	for start := 0; start < n; start += stashBoxCheckBatchSize {
+		if job.IsCancelled(ctx) {
+			return false, nil
+		}
+
+		end := start + stashBoxCheckBatchSize
+		if end > n {
+			end = n
+		}
+
+		if err := fn(start, end); err != nil {
+			return false, err
+		}
+
+		progress.AddProcessed(end - start)
+	}
+
+	return true, nil
+}
+
+// CheckSceneStashIDs starts a job which checks the stash ids for the
+// stash-box instance of the scenes matching the filter, and stores those
+// that no longer resolve. Returns the job ID.
+func (s *Manager) CheckSceneStashIDs(ctx context.Context, box *models.StashBox, filter *models.SceneFilterType) int {
+	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) {
+		logger.Infof("Checking scene stash ids for %s", box.Endpoint)
+
+		sceneStashIDs, err := s.findSceneStashIDs(ctx, box.Endpoint, filter)
+		if err != nil {
+			logger.Errorf("Error finding scene stash ids: %v", err)
+			return
+		}
+
+		client := s.newStashBoxClient(box)
+		ret := []*StaleSceneStashID{}
+
+		completed, err := stashBoxCheckBatched(ctx, progress, len(sceneStashIDs), func(start, end int) error {
+			stashIDs := make([]string, end-start)
+			for i := range stashIDs {
+				stashIDs[i] = sceneStashIDs[start+i].stashID
+			}
+
+			results, err := client.CheckSceneStashIDs(ctx, stashIDs)
+			if err != nil {
+				return err
+			}
+
+			for i, res := range results {
+				if res.Found && res.RedirectID == nil {
+					continue
+				}
+
+				ret = append(ret, &StaleSceneStashID{
+					SceneID:         sceneStashIDs[start+i].scene.ID,
+					StashID:         res.StashID,
+					RedirectStashID: res.RedirectID,
+				})
+			}
+
+			return nil
+		})
+		if err != nil {
+			logger.Errorf("Error checking scene stash ids: %v", err)
+			return
+		}
+
+		if !completed {
+			logger.Info("Stopping due to user request")
+			return
+		}
+
+		s.StashBoxCheckResults.setStaleSceneStashIDs(ret)
+		logger.Infof("Found %d stale scene stash ids", len(ret))
+	})
+
+	return s.JobManager.Add(ctx, fmt.Sprintf("Checking scene stash ids for %s...", box.Endpoint), j)
+}
+
+// CheckSceneStudioConflicts starts a job which finds the scenes matching the
+// filter where the assigned studio differs from the studio of the scene on
+// the stash-box instance, and stores them. Returns the job ID.
+func (s *Manager) CheckSceneStudioConflicts(ctx context.Context, box *models.StashBox, filter *models.SceneFilterType) int {
+	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) {
+		logger.Infof("Finding scene studio conflicts for %s", box.Endpoint)
+
+		found, err := s.findSceneStashIDs(ctx, box.Endpoint, filter)
+		if err != nil {
+			logger.Errorf("Error finding scene stash ids: %v", err)
+			return
+		}
+
+		// only scenes with a studio can conflict
+		var sceneStashIDs []sceneStashID
+		for _, sid := range found {
+			if sid.scene.StudioID != nil {
+				sceneStashIDs = append(sceneStashIDs, sid)
+			}
+		}
+
+		client := s.newStashBoxClient(box)
+		ret := []*SceneStudioConflict{}
+
+		// matches by remote studio id, since many scenes share the same
+		// studio
+		matched := make(map[string]*string)
+
+		completed, err := stashBoxCheckBatched(ctx, progress, len(sceneStashIDs), func(start, end int) error {
+			stashIDs := make([]string, end-start)
+			for i := range stashIDs {
+				stashIDs[i] = sceneStashIDs[start+i].stashID
+			}
+
+			studios, err := client.FindSceneStudios(ctx, stashIDs)
+			if err != nil {
+				return err
+			}
+
+			return s.Repository.WithReadTxn(ctx, func(ctx context.Context) error {
+				for i, remote := range studios {
+					if remote == nil {
+						continue
+					}
+
+					storedID, done := matched[*remote.RemoteSiteID]
+					if !done {
+						if err := match.ScrapedStudio(ctx, s.Repository.Studio, remote, &box.Endpoint); err != nil {
+							return err
+						}
+						storedID = remote.StoredID
+						matched[*remote.RemoteSiteID] = storedID
+					}
+					remote.StoredID = storedID
+
+					sc := sceneStashIDs[start+i].scene
+					if storedID != nil && *storedID == strconv.Itoa(*sc.StudioID) {
+						continue
+					}
+
+					ret = append(ret, &SceneStudioConflict{
+						SceneID:        sc.ID,
+						StashID:        sceneStashIDs[start+i].stashID,
+						StudioID:       *sc.StudioID,
+						StashBoxStudio: remote,
+					})
+				}
+
+				return nil
+			})
+		})
+		if err != nil {
+			logger.Errorf("Error finding scene studio conflicts: %v", err)
+			return
+		}
+
+		if !completed {
+			logger.Info("Stopping due to user request")
+			return
+		}
+
+		s.StashBoxCheckResults.setSceneStudioConflicts(ret)
+		logger.Infof("Found %d scene studio conflicts", len(ret))
+	})
+
+	return s.JobManager.Add(ctx, fmt.Sprintf("Finding scene studio conflicts for %s...", box.Endpoint), j)
+}
+
+// CheckPerformerStashBoxDiffs starts a job which finds the performers
+// matching the filter where local fields differ from the values of the
+// performer on the stash-box instance, and stores them. Returns the job ID.
+func (s *Manager) CheckPerformerStashBoxDiffs(ctx context.Context, box *models.StashBox, filter *models.PerformerFilterType) int {
+	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) {
+		logger.Infof("Finding performer differences for %s", box.Endpoint)
+
+		performerFilter := &models.PerformerFilterType{}
+		if filter != nil {
+			f := *filter
+			performerFilter = &f
+		}
+		performerFilter.StashIDEndpoint = &models.StashIDCriterionInput{
+			Endpoint: &box.Endpoint,
+			Modifier: models.CriterionModifierNotNull,
+		}
+
+		var performers []*models.Performer
+		var stashIDs []string
+		if err := s.Repository.WithReadTxn(ctx, func(ctx context.Context) error {
+			all := -1
+			found, _, err := s.Repository.Performer.Query(ctx, performerFilter, &models.FindFilterType{
+				PerPage: &all,
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, p := range found {
+				if err := p.LoadStashIDs(ctx, s.Repository.Performer); err != nil {
+					return err
+				}
+
+				for _, sid := range p.StashIDs.List() {
+					if sid.Endpoint == box.Endpoint {
+						performers = append(performers, p)
+						stashIDs = append(stashIDs, sid.StashID)
+					}
+				}
+			}
+
+			return nil
+		}); err != nil {
+			logger.Errorf("Error finding performer stash ids: %v", err)
+			return
+		}
+
+		client := s.newStashBoxClient(box)
+		ret := []*PerformerStashBoxDiff{}
+
+		completed, err := stashBoxCheckBatched(ctx, progress, len(stashIDs), func(start, end int) error {
+			remote, err := client.FindPerformersByIDs(ctx, stashIDs[start:end])
+			if err != nil {
+				return err
+			}
+
+			for i, rp := range remote {
+				if rp == nil {
+					continue
+				}
+
+				diffs := performerStashBoxDiffs(performers[start+i], rp)
+				if len(diffs) == 0 {
+					continue
+				}
+
+				ret = append(ret, &PerformerStashBoxDiff{
+					PerformerID: performers[start+i].ID,
+					StashID:     stashIDs[start+i],
+					Diffs:       diffs,
+				})
+			}
+
+			return nil
+		})
+		if err != nil {
+			logger.Errorf("Error finding performer differences: %v", err)
+			return
+		}
+
+		if !completed {
+			logger.Info("Stopping due to user request")
+			return
+		}
+
+		s.StashBoxCheckResults.setPerformerStashBoxDiffs(ret)
+		logger.Infof("Found %d performers with differences", len(ret))
+	})
+
+	return s.JobManager.Add(ctx, fmt.Sprintf("Finding performer differences for %s...", box.Endpoint), j)
+}
+
+// performerStashBoxDiffs returns the fields of p that differ from the
+// performer on the stash-box instance. Fields without a value on the
+// stash-box instance are ignored, and values are compared
+// case-insensitively.
+func performerStashBoxDiffs(p *models.Performer, remote *models.ScrapedPerformer) []*PerformerFieldDiff {
+	var birthdate, height string
+	if p.Birthdate != nil {
+		birthdate = p.Birthdate.String()
+	}
+	if p.Height != nil {
+		height = strconv.Itoa(*p.Height)
+	}
+
+	fields := []struct {
+		name   string
+		local  string
+		remote *string
+	}{
+		{"name", p.Name, remote.Name},
+		{"disambiguation", p.Disambiguation, remote.Disambiguation},
+		{"gender", p.Gender.String(), remote.Gender},
+		{"birthdate", birthdate, remote.Birthdate},
+		{"country", p.Country, remote.Country},
+		{"ethnicity", p.Ethnicity, remote.Ethnicity},
+		{"eye_color", p.EyeColor, remote.EyeColor},
+		{"hair_color", p.HairColor, remote.HairColor},
+		{"height", height, remote.Height},
+		{"measurements", p.Measurements, remote.Measurements},
+		{"fake_tits", p.FakeTits, remote.FakeTits},
+		{"career_length", p.CareerLength, remote.CareerLength},
+		{"tattoos", p.Tattoos, remote.Tattoos},
+		{"piercings", p.Piercings, remote.Piercings},
+	}
+
+	var ret []*PerformerFieldDiff
+	for _, f := range fields {
+		if f.remote == nil || strings.TrimSpace(*f.remote) == "" {
+			continue
+		}
+
+		if strings.EqualFold(strings.TrimSpace(f.local), strings.TrimSpace(*f.remote)) {
+			continue
+		}
+
+		diff := &PerformerFieldDiff{
+			Field:         f.name,
+			StashBoxValue: *f.remote,
+		}
+		if f.local != "" {
+			local := f.local
+			diff.LocalValue = &local
+		}
+		ret = append(ret, diff)
+	}
+
+	return ret
+}
@@ -0,0 +1,130 @@
+package manager
+
+import (
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// transcodeSessionHistorySize is the number of ended transcode sessions
+// that are kept.
+const transcodeSessionHistorySize = 100
+
+// TranscodeSession describes a live transcode of a scene file.
+type TranscodeSession struct {
+	ID      int
+	SceneID int
+	Path    string
+	// Format is the name of the stream format.
+	Format string
+	// MaxTranscodeSize is the maximum dimension of the transcoded video.
+	// 0 if the original size is used.
+	MaxTranscodeSize int
+	// StartTime is the position in the file where the transcode started,
+	// in seconds.
+	StartTime float64
+	Client    string
+
+	StartedAt time.Time
+	// EndedAt is nil while the session is active.
+	EndedAt *time.Time
+	// CPUTime is the user and system CPU time used by the transcoding
+	// process. Nil while the session is active, or if it is unknown.
+	CPUTime *time.Duration
+}
+
+// Duration returns the time that the session has been running for, or the
+// total running time of an ended session.
+func (s TranscodeSession) Duration() time.Duration {
+	if s.EndedAt != nil {
+		return s.EndedAt.Sub(s.StartedAt)
+	}
+
+	return time.Since(s.StartedAt)
+}
+
+// TranscodeSessionTracker records active transcode sessions and a history
+// of recently ended sessions. It is safe for concurrent use.
+type TranscodeSessionTracker struct {
+	mutex   sync.Mutex
+	lastID  int
+	active  map[int]*TranscodeSession
+	history []TranscodeSession
+}
+
+func NewTranscodeSessionTracker() *TranscodeSessionTracker {
+	return &TranscodeSessionTracker{
+		active: make(map[int]*TranscodeSession),
+	}
+}
+
+// Start records the start of a session and returns its id. The ID and
+// StartedAt fields of s are set by the tracker.
+func (t *TranscodeSessionTracker) Start(s TranscodeSession) int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.lastID++
+	s.ID = t.lastID
+	s.StartedAt = time.Now()
+	t.active[s.ID] = &s
+
+	return s.ID
+}
+
+// End records the end of the session with the provided id. state is the
+// state of the exited transcoding process, and may be nil if it is unknown.
+func (t *TranscodeSessionTracker) End(id int, state *os.ProcessState) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	s, found := t.active[id]
+	if !found {
+		return
+	}
+
+	delete(t.active, id)
+
+	now := time.Now()
+	s.EndedAt = &now
+	if state != nil {
+		cpuTime := state.UserTime() + state.SystemTime()
+		s.CPUTime = &cpuTime
+	}
+
+	t.history = append(t.history, *s)
+	if len(t.history) > transcodeSessionHistorySize {
+		t.history = t.history[len(t.history)-transcodeSessionHistorySize:]
+	}
+}
+
+// Active returns the active sessions, in the order they were started.
+func (t *TranscodeSessionTracker) Active() []TranscodeSession {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	ret := make([]TranscodeSession, 0, len(t.active))
+	for _, s := range t.active {
+		ret = append(ret, *s)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].ID < ret[j].ID
+	})
+
+	return ret
+}
+
+// History returns the most recently ended sessions, most recent first.
+func (t *TranscodeSessionTracker) History() []TranscodeSession {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	ret := make([]TranscodeSession, len(t.history))
+	for i, s := range t.history {
+		ret[len(ret)-1-i] = s
+	}
+
+	return ret
+}
@@ -0,0 +1,57 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranscodeSessionTracker(t *testing.T) {
+	tracker := NewTranscodeSessionTracker()
+
+	first := tracker.Start(TranscodeSession{SceneID: 1, Format: "hls"})
+	second := tracker.Start(TranscodeSession{SceneID: 2, Format: "h264"})
+	third := tracker.Start(TranscodeSession{SceneID: 1, Format: "vp9"})
+
+	active := tracker.Active()
+	if assert.Len(t, active, 3) {
+		assert.Equal(t, []int{first, second, third}, []int{active[0].ID, active[1].ID, active[2].ID})
+		assert.Nil(t, active[0].EndedAt)
+		assert.False(t, active[0].StartedAt.IsZero())
+	}
+	assert.Empty(t, tracker.History())
+
+	tracker.End(second, nil)
+	tracker.End(first, nil)
+	// ending an unknown or ended session does nothing
+	tracker.End(first, nil)
+	tracker.End(0, nil)
+
+	active = tracker.Active()
+	if assert.Len(t, active, 1) {
+		assert.Equal(t, third, active[0].ID)
+	}
+
+	history := tracker.History()
+	if assert.Len(t, history, 2) {
+		assert.Equal(t, first, history[0].ID)
+		assert.Equal(t, second, history[1].ID)
+		assert.NotNil(t, history[0].EndedAt)
+		assert.Nil(t, history[0].CPUTime)
+	}
+}
+
+func TestTranscodeSessionTracker_historySize(t *testing.T) {
+	tracker := NewTranscodeSessionTracker()
+
+	var last int
+	for i := 0; i < transcodeSessionHistorySize+10; i++ {
+		last = tracker.Start(TranscodeSession{SceneID: i})
+		tracker.End(last, nil)
+	}
+
+	history := tracker.History()
+	assert.Len(t, history, transcodeSessionHistorySize)
+	assert.Equal(t, last, history[0].ID)
+	assert.Equal(t, last-transcodeSessionHistorySize+1, history[len(history)-1].ID)
+}
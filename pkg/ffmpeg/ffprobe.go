@@ -93,6 +93,33 @@ func (f *FFProbe) NewVideoFile(videoPath string) (*VideoFile, error) {
 	return parse(videoPath, probeJSON)
 }
 
+// FormatTags runs ffprobe on the given path and returns the container
+// metadata tags. Tag names are returned in lower case.
+func (f *FFProbe) FormatTags(path string) (map[string]string, error) {
+	args := []string{"-v", "quiet", "-print_format", "json", "-show_format", "-show_error", path}
+	out, err := exec.Command(string(*f), args...).Output()
+
+	if err != nil {
+		return nil, fmt.Errorf("FFProbe encountered an error with <%s>.\nError JSON:\n%s\nError: %s", path, string(out), err.Error())
+	}
+
+	var probeJSON struct {
+		Format struct {
+			Tags map[string]string `json:"tags"`
+		} `json:"format"`
+	}
+	if err := json.Unmarshal(out, &probeJSON); err != nil {
+		return nil, fmt.Errorf("error unmarshalling format tags for <%s>: %s", path, err.Error())
+	}
+
+	ret := make(map[string]string, len(probeJSON.Format.Tags))
+	for k, v := range probeJSON.Format.Tags {
+		ret[strings.ToLower(k)] = v
+	}
+
+	return ret, nil
+}
+
 // GetReadFrameCount counts the actual frames of the video file.
 // Used when the frame count is missing or incorrect.
 func (f *FFProbe) GetReadFrameCount(path string) (int64, error) {
@@ -3,18 +3,25 @@ package ffmpeg
 import (
 	"fmt"
 	"io"
+	"math"
 	"strings"
 )
 
-const hlsSegmentLength = 10.0
+// DefaultHLSSegmentLength is the default length of HLS segments in seconds.
+const DefaultHLSSegmentLength = 10.0
 
 // WriteHLSPlaylist writes a HLS playlist to w using baseUrl as the base URL for TS streams.
-func WriteHLSPlaylist(duration float64, baseUrl string, w io.Writer) {
+// segmentLength is the length of each segment in seconds.
+func WriteHLSPlaylist(duration float64, segmentLength float64, baseUrl string, w io.Writer) {
+	if segmentLength <= 0 {
+		segmentLength = DefaultHLSSegmentLength
+	}
+
 	fmt.Fprint(w, "#EXTM3U\n")
 	fmt.Fprint(w, "#EXT-X-VERSION:3\n")
 	fmt.Fprint(w, "#EXT-X-MEDIA-SEQUENCE:0\n")
 	fmt.Fprint(w, "#EXT-X-ALLOW-CACHE:YES\n")
-	fmt.Fprintf(w, "#EXT-X-TARGETDURATION:%d\n", int(hlsSegmentLength))
+	fmt.Fprintf(w, "#EXT-X-TARGETDURATION:%d\n", int(math.Ceil(segmentLength)))
 	fmt.Fprint(w, "#EXT-X-PLAYLIST-TYPE:VOD\n")
 
 	leftover := duration
@@ -24,7 +31,7 @@ func WriteHLSPlaylist(duration float64, baseUrl string, w io.Writer) {
 	tsURL := baseUrl[0:i] + ".ts"
 
 	for leftover > 0 {
-		thisLength := hlsSegmentLength
+		thisLength := segmentLength
 		if leftover < thisLength {
 			thisLength = leftover
 		}
@@ -0,0 +1,29 @@
+package ffmpeg
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteHLSPlaylist(t *testing.T) {
+	var b strings.Builder
+	WriteHLSPlaylist(12.5, 5, "http://localhost/scene/1/stream.m3u8", &b)
+
+	want := `#EXTM3U
+#EXT-X-VERSION:3
+#EXT-X-MEDIA-SEQUENCE:0
+#EXT-X-ALLOW-CACHE:YES
+#EXT-X-TARGETDURATION:5
+#EXT-X-PLAYLIST-TYPE:VOD
+#EXTINF: 5.000000,
+http://localhost/scene/1/stream.ts?start=0.000000
+#EXTINF: 5.000000,
+http://localhost/scene/1/stream.ts?start=5.000000
+#EXTINF: 2.500000,
+http://localhost/scene/1/stream.ts?start=10.000000
+#EXT-X-ENDLIST
+`
+	assert.Equal(t, want, b.String())
+}
@@ -0,0 +1,65 @@
+package ffmpeg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrNoLoudness is returned when the loudness of a file could not be
+// measured, for example because it has no audio stream.
+var ErrNoLoudness = errors.New("loudness could not be measured")
+
+// CalculateLoudness measures the integrated loudness of the audio of the
+// file at path, in LUFS, using the analysis pass of the loudnorm filter.
+// This decodes the whole audio stream, so it takes a while for long files.
+func (f FFMpeg) CalculateLoudness(ctx context.Context, path string) (float64, error) {
+	var args Args
+	args = append(args, "-hide_banner", "-nostats")
+	args = args.Input(path).
+		SkipVideo()
+	args = append(args, "-af", "loudnorm=print_format=json")
+	args = args.Format(FormatNull).
+		NullOutput()
+
+	command := f.Command(ctx, args)
+	var stdErrBuffer bytes.Buffer
+	command.Stderr = &stdErrBuffer // loudnorm writes its summary to stderr
+	if err := command.Run(); err != nil {
+		return 0, fmt.Errorf("running ffmpeg: %w", err)
+	}
+
+	return parseLoudness(stdErrBuffer.String())
+}
+
+type loudnormOutput struct {
+	InputI string `json:"input_i"`
+}
+
+// parseLoudness returns the integrated loudness from the JSON summary that
+// loudnorm writes at the end of the ffmpeg output.
+func parseLoudness(output string) (float64, error) {
+	start := strings.LastIndex(output, "{")
+	end := strings.LastIndex(output, "}")
+	if start == -1 || end < start {
+		return 0, ErrNoLoudness
+	}
+
+	var summary loudnormOutput
+	if err := json.Unmarshal([]byte(output[start:end+1]), &summary); err != nil {
+		return 0, fmt.Errorf("parsing loudnorm output: %w", err)
+	}
+
+	ret, err := strconv.ParseFloat(summary.InputI, 64)
+	// silent audio is reported as -inf
+	if err != nil || math.IsInf(ret, 0) || math.IsNaN(ret) {
+		return 0, ErrNoLoudness
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,69 @@
+package ffmpeg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLoudness(t *testing.T) {
+	const summary = `[Parsed_loudnorm_0 @ 0x5581c3a1c2c0] 
+{
+	"input_i" : "-23.54",
+	"input_tp" : "-4.10",
+	"input_lra" : "7.30",
+	"input_thresh" : "-34.02",
+	"output_i" : "-24.38",
+	"output_tp" : "-2.00",
+	"output_lra" : "6.00",
+	"output_thresh" : "-34.87",
+	"normalization_type" : "dynamic",
+	"target_offset" : "0.38"
+}
+`
+
+	tests := []struct {
+		name    string
+		output  string
+		want    float64
+		wantErr bool
+	}{
+		{
+			"valid",
+			"Input #0, mov,mp4,m4a,3gp,3g2,mj2, from 'test.mp4':\n" + summary,
+			-23.54,
+			false,
+		},
+		{
+			"silent",
+			`{ "input_i" : "-inf" }`,
+			0,
+			true,
+		},
+		{
+			"no summary",
+			"Output file #0 does not contain any stream",
+			0,
+			true,
+		},
+		{
+			"invalid json",
+			`{ "input_i" : }`,
+			0,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLoudness(tt.output)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package ffmpeg
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Progress adds the flags to write machine readable progress information
+// to standard output, and returns the result. The output can be parsed
+// using ParseProgress.
+func (a Args) Progress() Args {
+	return append(a, "-progress", "pipe:1", "-nostats")
+}
+
+// ParseProgress reads the progress information written by ffmpeg when run
+// with the Progress flags. fn is called with the current position in the
+// output, in seconds, each time it is reported. Returns when r is exhausted
+// or ffmpeg reports that it has finished.
+func ParseProgress(r io.Reader, fn func(seconds float64)) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		key, value, found := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !found {
+			continue
+		}
+
+		switch key {
+		// out_time_ms is actually in microseconds
+		case "out_time_us", "out_time_ms":
+			us, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || us < 0 {
+				continue
+			}
+			fn(float64(us) / 1e6)
+		case "progress":
+			if value == "end" {
+				return nil
+			}
+		}
+	}
+
+	return scanner.Err()
+}
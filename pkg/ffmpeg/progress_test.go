@@ -0,0 +1,29 @@
+package ffmpeg
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProgress(t *testing.T) {
+	const output = `frame=10
+out_time_us=500000
+out_time_ms=500000
+out_time=00:00:00.500000
+progress=continue
+out_time_us=N/A
+out_time_us=1250000
+progress=end
+out_time_us=9000000
+`
+
+	var got []float64
+	err := ParseProgress(strings.NewReader(output), func(seconds float64) {
+		got = append(got, seconds)
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{0.5, 0.5, 1.25}, got)
+}
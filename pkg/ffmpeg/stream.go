@@ -44,6 +44,8 @@ func (s *Stream) Serve(w http.ResponseWriter, r *http.Request) {
 
 // StreamFormat represents a transcode stream format.
 type StreamFormat struct {
+	// Name identifies the format in logs and statistics.
+	Name      string
 	MimeType  string
 	codec     VideoCodec
 	format    Format
@@ -53,6 +55,7 @@ type StreamFormat struct {
 
 var (
 	StreamFormatHLS = StreamFormat{
+		Name:     "hls",
 		codec:    VideoCodecLibX264,
 		format:   FormatMpegTS,
 		MimeType: MimeMpegts,
@@ -66,6 +69,7 @@ var (
 	}
 
 	StreamFormatH264 = StreamFormat{
+		Name:     "h264",
 		codec:    VideoCodecLibX264,
 		format:   FormatMP4,
 		MimeType: MimeMp4,
@@ -78,6 +82,7 @@ var (
 	}
 
 	StreamFormatVP9 = StreamFormat{
+		Name:     "vp9",
 		codec:    VideoCodecVP9,
 		format:   FormatWebm,
 		MimeType: MimeWebm,
@@ -92,6 +97,7 @@ var (
 	}
 
 	StreamFormatVP8 = StreamFormat{
+		Name:     "vp8",
 		codec:    VideoCodecVPX,
 		format:   FormatWebm,
 		MimeType: MimeWebm,
@@ -105,6 +111,7 @@ var (
 	}
 
 	StreamFormatHEVC = StreamFormat{
+		Name:     "hevc",
 		codec:    VideoCodecLibX265,
 		format:   FormatMP4,
 		MimeType: MimeMp4,
@@ -118,6 +125,7 @@ var (
 	// it is very common in MKVs to have just the audio codec unsupported
 	// copy the video stream, transcode the audio and serve as Matroska
 	StreamFormatMKVAudio = StreamFormat{
+		Name:     "mkv-audio",
 		codec:    VideoCodecCopy,
 		format:   FormatMatroska,
 		MimeType: MimeMkv,
@@ -136,6 +144,10 @@ type TranscodeStreamOptions struct {
 	StartTime        float64
 	MaxTranscodeSize int
 
+	// SegmentLength is the length of HLS segments in seconds.
+	// DefaultHLSSegmentLength is used if 0.
+	SegmentLength float64
+
 	// original video dimensions
 	VideoWidth  int
 	VideoHeight int
@@ -157,7 +169,11 @@ func (o TranscodeStreamOptions) getStreamArgs() Args {
 
 	if o.Codec.hls {
 		// we only serve a fixed segment length
-		args = args.Duration(hlsSegmentLength)
+		segmentLength := o.SegmentLength
+		if segmentLength <= 0 {
+			segmentLength = DefaultHLSSegmentLength
+		}
+		args = args.Duration(segmentLength)
 	}
 
 	args = args.Input(o.Input)
@@ -1,9 +1,13 @@
 package file
 
+import "context"
+
 var (
 	FingerprintTypeOshash = "oshash"
 	FingerprintTypeMD5    = "md5"
 	FingerprintTypePhash  = "phash"
+	// FingerprintTypeAudio is a raw chromaprint fingerprint of the audio
+	FingerprintTypeAudio = "audio"
 )
 
 // Fingerprint represents a fingerprint of a file.
@@ -115,6 +119,40 @@ func (f Fingerprints) AppendUnique(o Fingerprint) Fingerprints {
 	return append(f, o)
 }
 
+// IsOshashCollision returns true if the provided files have the same oshash,
+// but are known to have different contents. The contents are compared using
+// the MD5 fingerprint if both files have one, and the file size otherwise.
+func IsOshashCollision(f *BaseFile, other *BaseFile) bool {
+	oshash := f.Fingerprints.For(FingerprintTypeOshash)
+	otherOshash := other.Fingerprints.For(FingerprintTypeOshash)
+	if oshash == nil || otherOshash == nil || oshash.Fingerprint != otherOshash.Fingerprint {
+		return false
+	}
+
+	md5 := f.Fingerprints.For(FingerprintTypeMD5)
+	otherMD5 := other.Fingerprints.For(FingerprintTypeMD5)
+	if md5 != nil && otherMD5 != nil {
+		return md5.Fingerprint != otherMD5.Fingerprint
+	}
+
+	return f.Size != other.Size
+}
+
+// OshashCollision is a pair of files which have the same oshash, but
+// different contents.
+type OshashCollision struct {
+	Path      string
+	OtherPath string
+	// SceneID is the scene of the other file, if the collision was found
+	// when associating the file with an existing scene.
+	SceneID *int
+}
+
+// CollisionReporter is notified of suspected oshash collisions.
+type CollisionReporter interface {
+	ReportOshashCollision(ctx context.Context, c OshashCollision)
+}
+
 // FingerprintCalculator calculates a fingerprint for the provided file.
 type FingerprintCalculator interface {
 	CalculateFingerprints(f *BaseFile, o Opener, useExisting bool) ([]Fingerprint, error)
@@ -155,3 +155,77 @@ func TestFingerprints_ContentsChanged(t *testing.T) {
 		})
 	}
 }
+
+func TestIsOshashCollision(t *testing.T) {
+	makeFile := func(size int64, fp ...Fingerprint) *BaseFile {
+		return &BaseFile{
+			Size:         size,
+			Fingerprints: fp,
+		}
+	}
+
+	oshash := func(v string) Fingerprint {
+		return Fingerprint{Type: FingerprintTypeOshash, Fingerprint: v}
+	}
+	md5 := func(v string) Fingerprint {
+		return Fingerprint{Type: FingerprintTypeMD5, Fingerprint: v}
+	}
+
+	tests := []struct {
+		name  string
+		f     *BaseFile
+		other *BaseFile
+		want  bool
+	}{
+		{
+			"different oshash",
+			makeFile(1, oshash("a")),
+			makeFile(2, oshash("b")),
+			false,
+		},
+		{
+			"same oshash and size",
+			makeFile(1, oshash("a")),
+			makeFile(1, oshash("a")),
+			false,
+		},
+		{
+			"same oshash different size",
+			makeFile(1, oshash("a")),
+			makeFile(2, oshash("a")),
+			true,
+		},
+		{
+			"same oshash and md5",
+			makeFile(1, oshash("a"), md5("x")),
+			makeFile(1, oshash("a"), md5("x")),
+			false,
+		},
+		{
+			"same oshash different md5",
+			makeFile(1, oshash("a"), md5("x")),
+			makeFile(1, oshash("a"), md5("y")),
+			true,
+		},
+		{
+			"md5 missing",
+			makeFile(1, oshash("a"), md5("x")),
+			makeFile(1, oshash("a")),
+			false,
+		},
+		{
+			"oshash missing",
+			makeFile(1, md5("x")),
+			makeFile(2, md5("x")),
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsOshashCollision(tt.f, tt.other); got != tt.want {
+				t.Errorf("IsOshashCollision() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
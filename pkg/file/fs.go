@@ -46,9 +46,14 @@ func (f *OsFS) Open(name string) (fs.ReadDirFile, error) {
 	return os.Open(name)
 }
 
+// OpenZip opens the zip file with the provided name. The zip file may be
+// inside one or more other zip files.
 func (f *OsFS) OpenZip(name string) (*ZipFS, error) {
 	info, err := f.Lstat(name)
 	if err != nil {
+		if isNotExist(err) {
+			return openZipInZip(f, name, err)
+		}
 		return nil, err
 	}
 
@@ -10,11 +10,19 @@ import (
 	_ "image/png"
 
 	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/logger"
 	_ "golang.org/x/image/webp"
 )
 
+type Config interface {
+	// GetMaxImageDimension returns the maximum width or height of images
+	// that are processed beyond their basic metadata. 0 means no limit.
+	GetMaxImageDimension() int
+}
+
 // Decorator adds image specific fields to a File.
 type Decorator struct {
+	Config Config
 }
 
 func (d *Decorator) Decorate(ctx context.Context, fs file.FS, f file.File) (file.File, error) {
@@ -30,12 +38,21 @@ func (d *Decorator) Decorate(ctx context.Context, fs file.FS, f file.File) (file
 		return f, fmt.Errorf("decoding image file %q: %w", base.Path, err)
 	}
 
-	return &file.ImageFile{
+	ret := &file.ImageFile{
 		BaseFile: base,
 		Format:   format,
 		Width:    c.Width,
 		Height:   c.Height,
-	}, nil
+	}
+
+	if d.Config != nil {
+		if maxDimension := d.Config.GetMaxImageDimension(); maxDimension > 0 && (c.Width > maxDimension || c.Height > maxDimension) {
+			logger.Infof("Image %q (%dx%d) exceeds maximum dimension %d. Only basic metadata will be recorded.", base.Path, c.Width, c.Height, maxDimension)
+			ret.Oversized = true
+		}
+	}
+
+	return ret, nil
 }
 
 func (d *Decorator) IsMissingMetadata(ctx context.Context, fs file.FS, f file.File) bool {
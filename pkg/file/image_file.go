@@ -6,4 +6,8 @@ type ImageFile struct {
 	Format string `json:"format"`
 	Width  int    `json:"width"`
 	Height int    `json:"height"`
+	// Oversized is true if the image dimensions exceeded the maximum
+	// dimension when scanned. Thumbnails are not generated for
+	// oversized images.
+	Oversized bool `json:"oversized"`
 }
@@ -9,8 +9,10 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/fvbommel/sortorder"
 	"github.com/remeh/sizedwaitgroup"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/txn"
@@ -23,6 +25,8 @@ const (
 	maxRetries = -1
 )
 
+var errZipEntryLimit = errors.New("zip file entry limit reached")
+
 // Repository provides access to storage methods for files and folders.
 type Repository struct {
 	txn.Manager
@@ -91,6 +95,9 @@ type scanJob struct {
 	zipPathToID    sync.Map
 	count          int
 
+	// hashSlots limits the number of files that are hashed concurrently
+	hashSlots chan struct{}
+
 	txnRetryer txn.Retryer
 }
 
@@ -102,6 +109,21 @@ type ScanOptions struct {
 	// Extension does not include the . character.
 	ZipFileExtensions []string
 
+	// MaxZipDepth is the maximum depth of zip files inside zip files whose
+	// contents are scanned. If 0, then the contents of zip files inside zip
+	// files are not scanned.
+	MaxZipDepth int
+
+	// MaxZipEntries is the maximum number of entries that are scanned in a
+	// zip file, including the entries of zip files inside it. If 0, then
+	// there is no limit.
+	MaxZipEntries int
+
+	// NaturalSortZipEntries is true if the contents of zip files are
+	// scanned in natural order, so that numbers in names are sorted
+	// numerically. Otherwise they are scanned in lexical order.
+	NaturalSortZipEntries bool
+
 	// ScanFilters are used to determine if a file should be scanned.
 	ScanFilters []PathFilter
 
@@ -109,6 +131,21 @@ type ScanOptions struct {
 	HandlerRequiredFilters []Filter
 
 	ParallelTasks int
+
+	// HashWorkers is the maximum number of files that are hashed
+	// concurrently. Files are processed by the larger of ParallelTasks and
+	// HashWorkers goroutines. If less than 1, then ParallelTasks is used.
+	HashWorkers int
+
+	// ZipParallelTasks is the maximum number of zip files that are scanned
+	// concurrently, separately from other files, so that slow zip files do
+	// not block the scanning of other files. If less than 1, then zip files
+	// are scanned by the same goroutines as other files.
+	ZipParallelTasks int
+
+	// CollisionReporter is notified of suspected oshash collisions, which
+	// are not treated as moves. Optional.
+	CollisionReporter CollisionReporter
 }
 
 // Scan starts the scanning process.
@@ -131,6 +168,12 @@ type scanFile struct {
 	*BaseFile
 	fs   FS
 	info fs.FileInfo
+
+	// zipDepth is the number of zip files containing the file
+	zipDepth int
+	// zipEntries counts the entries scanned in the outermost zip file
+	// containing the file
+	zipEntries *int64
 }
 
 func (s *scanJob) withTxn(ctx context.Context, fn func(ctx context.Context) error) error {
@@ -249,12 +292,18 @@ func (s *scanJob) queueFileFunc(ctx context.Context, f FS, zipFile *scanFile) fs
 		}
 
 		if zipFile != nil {
+			if s.options.MaxZipEntries > 0 && atomic.AddInt64(zipFile.zipEntries, 1) > int64(s.options.MaxZipEntries) {
+				return errZipEntryLimit
+			}
+
 			zipFileID, err := s.getZipFileID(ctx, zipFile)
 			if err != nil {
 				return err
 			}
 			ff.ZipFileID = zipFileID
 			ff.ZipFile = zipFile
+			ff.zipDepth = zipFile.zipDepth + 1
+			ff.zipEntries = zipFile.zipEntries
 		}
 
 		if info.IsDir() {
@@ -308,6 +357,10 @@ func (s *scanJob) acceptEntry(ctx context.Context, path string, info fs.FileInfo
 }
 
 func (s *scanJob) scanZipFile(ctx context.Context, f scanFile) error {
+	if f.zipEntries == nil {
+		f.zipEntries = new(int64)
+	}
+
 	zipFS, err := f.fs.OpenZip(f.Path)
 	if err != nil {
 		if errors.Is(err, errNotReaderAt) {
@@ -321,7 +374,12 @@ func (s *scanJob) scanZipFile(ctx context.Context, f scanFile) error {
 
 	defer zipFS.Close()
 
-	return symWalk(zipFS, f.Path, s.queueFileFunc(ctx, zipFS, &f))
+	less := lexicalLess
+	if s.options.NaturalSortZipEntries {
+		less = sortorder.NaturalLess
+	}
+
+	return zipWalk(zipFS, f.Path, less, s.queueFileFunc(ctx, zipFS, &f))
 }
 
 func (s *scanJob) processQueue(ctx context.Context) error {
@@ -330,22 +388,64 @@ func (s *scanJob) processQueue(ctx context.Context) error {
 		parallelTasks = 1
 	}
 
+	hashWorkers := s.options.HashWorkers
+	if hashWorkers < 1 {
+		hashWorkers = parallelTasks
+	}
+	s.hashSlots = make(chan struct{}, hashWorkers)
+
+	// hashing is the slowest part of processing new files, so allow enough
+	// goroutines to keep all hash workers busy. Database writes are still
+	// serialised by the write transaction.
+	if hashWorkers > parallelTasks {
+		parallelTasks = hashWorkers
+	}
+
 	wg := sizedwaitgroup.New(parallelTasks)
 
+	// zip files are processed in their own goroutines, limited by zipSlots,
+	// so that they don't hold up the other files in the queue. The slot is
+	// acquired before starting the goroutine, so that the queue waits for a
+	// free slot instead of starting a goroutine for every zip file.
+	var zipWg sync.WaitGroup
+	var zipSlots chan struct{}
+	if s.options.ZipParallelTasks > 0 {
+		zipSlots = make(chan struct{}, s.options.ZipParallelTasks)
+	}
+
+	process := func(f scanFile) {
+		if zipSlots != nil && s.isZipFile(f.info.Name()) {
+			zipSlots <- struct{}{}
+			zipWg.Add(1)
+			go func() {
+				defer zipWg.Done()
+				defer func() { <-zipSlots }()
+				s.processQueueItem(ctx, f)
+			}()
+			return
+		}
+
+		wg.Add()
+		go func() {
+			defer wg.Done()
+			s.processQueueItem(ctx, f)
+		}()
+	}
+
+	wait := func() {
+		wg.Wait()
+		zipWg.Wait()
+	}
+
 	if err := func() error {
-		defer wg.Wait()
+		defer wait()
 
 		for f := range s.fileQueue {
 			if err := ctx.Err(); err != nil {
 				return err
 			}
 
-			wg.Add()
-			ff := f
-			go func() {
-				defer wg.Done()
-				s.processQueueItem(ctx, ff)
-			}()
+			process(f)
 		}
 
 		return nil
@@ -356,19 +456,14 @@ func (s *scanJob) processQueue(ctx context.Context) error {
 	s.retrying = true
 
 	if err := func() error {
-		defer wg.Wait()
+		defer wait()
 
 		for _, f := range s.retryList {
 			if err := ctx.Err(); err != nil {
 				return err
 			}
 
-			wg.Add()
-			ff := f
-			go func() {
-				defer wg.Done()
-				s.processQueueItem(ctx, ff)
-			}()
+			process(f)
 		}
 
 		return nil
@@ -568,7 +663,7 @@ func (s *scanJob) handleFile(ctx context.Context, f scanFile) error {
 		return err
 	}
 
-	if ff != nil && s.isZipFile(f.info.Name()) {
+	if ff != nil && s.isZipFile(f.info.Name()) && f.zipDepth <= s.options.MaxZipDepth {
 		f.BaseFile = ff.Base()
 
 		// scan zip files with a different context that is not cancellable
@@ -694,6 +789,11 @@ func (s *scanJob) calculateFingerprints(fs FS, f *BaseFile, path string, useExis
 		logger.Infof("Calculating fingerprints for %s ...", path)
 	}
 
+	if s.hashSlots != nil {
+		s.hashSlots <- struct{}{}
+		defer func() { <-s.hashSlots }()
+	}
+
 	// calculate primary fingerprint for the file
 	fp, err := s.FingerprintCalculator.CalculateFingerprints(f, &fsOpener{
 		fs:   fs,
@@ -754,6 +854,17 @@ func (s *scanJob) handleRename(ctx context.Context, f File, fp []Fingerprint) (F
 	var missing []File
 
 	for _, other := range others {
+		if IsOshashCollision(f.Base(), other.Base()) {
+			logger.Warnf("Suspected oshash collision between %s and %s. Not treating as a move.", f.Base().Path, other.Base().Path)
+			if s.options.CollisionReporter != nil {
+				s.options.CollisionReporter.ReportOshashCollision(ctx, OshashCollision{
+					Path:      f.Base().Path,
+					OtherPath: other.Base().Path,
+				})
+			}
+			continue
+		}
+
 		// if file does not exist, then update it to the new path
 		fs, err := s.getFileFS(other.Base())
 		if err != nil {
@@ -0,0 +1,163 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// concurrencyCalculator records the maximum number of concurrent calls to
+// CalculateFingerprints.
+type concurrencyCalculator struct {
+	current int32
+	max     int32
+}
+
+func (c *concurrencyCalculator) CalculateFingerprints(f *BaseFile, o Opener, useExisting bool) ([]Fingerprint, error) {
+	n := atomic.AddInt32(&c.current, 1)
+	defer atomic.AddInt32(&c.current, -1)
+
+	recordMax(&c.max, n)
+
+	time.Sleep(10 * time.Millisecond)
+	return nil, nil
+}
+
+// recordMax sets max to n if n is greater.
+func recordMax(max *int32, n int32) {
+	for {
+		m := atomic.LoadInt32(max)
+		if n <= m || atomic.CompareAndSwapInt32(max, m, n) {
+			return
+		}
+	}
+}
+
+// queueProgress records the maximum number of concurrent tasks, and the
+// maximum number of files taken from the queue which have not started their
+// task, without executing the tasks.
+type queueProgress struct {
+	queue chan scanFile
+	files int32
+
+	started    int32
+	current    int32
+	maxCurrent int32
+	maxWaiting int32
+}
+
+func (p *queueProgress) AddTotal(total int) {}
+func (p *queueProgress) Increment()         {}
+func (p *queueProgress) Definite()          {}
+
+func (p *queueProgress) ExecuteTask(description string, fn func()) {
+	started := atomic.AddInt32(&p.started, 1)
+	n := atomic.AddInt32(&p.current, 1)
+	defer atomic.AddInt32(&p.current, -1)
+
+	recordMax(&p.maxCurrent, n)
+
+	time.Sleep(10 * time.Millisecond)
+
+	taken := p.files - int32(len(p.queue))
+	recordMax(&p.maxWaiting, taken-started)
+}
+
+type testFileInfo struct {
+	fs.FileInfo
+	name string
+}
+
+func (i testFileInfo) Name() string { return i.name }
+func (i testFileInfo) IsDir() bool  { return false }
+
+func TestScanJob_processQueue_zipParallelTasks(t *testing.T) {
+	const (
+		files            = 12
+		zipParallelTasks = 2
+	)
+
+	queue := make(chan scanFile, files)
+	for i := 0; i < files; i++ {
+		name := fmt.Sprintf("%d.zip", i)
+		queue <- scanFile{
+			BaseFile: &BaseFile{Path: name},
+			info:     testFileInfo{name: name},
+		}
+	}
+	close(queue)
+
+	progress := &queueProgress{
+		queue: queue,
+		files: files,
+	}
+	s := &scanJob{
+		Scanner:         &Scanner{},
+		ProgressReports: progress,
+		options: ScanOptions{
+			ZipFileExtensions: []string{"zip"},
+			ParallelTasks:     files,
+			ZipParallelTasks:  zipParallelTasks,
+		},
+		fileQueue: queue,
+	}
+
+	if err := s.processQueue(context.Background()); err != nil {
+		t.Fatalf("processQueue() error = %v", err)
+	}
+
+	if progress.started != files {
+		t.Errorf("processed files = %d, want %d", progress.started, files)
+	}
+	if progress.maxCurrent > zipParallelTasks {
+		t.Errorf("concurrent zip files = %d, want at most %d", progress.maxCurrent, zipParallelTasks)
+	}
+	if progress.maxCurrent < 2 {
+		t.Errorf("concurrent zip files = %d, want zip files scanned concurrently", progress.maxCurrent)
+	}
+
+	// the queue is not drained ahead of the free zip slots. Besides the
+	// goroutines holding a slot which have not started their task yet, at
+	// most one file is held waiting for a slot.
+	if progress.maxWaiting > zipParallelTasks+1 {
+		t.Errorf("files taken from the queue before starting = %d, want at most %d", progress.maxWaiting, zipParallelTasks+1)
+	}
+}
+
+func TestScanJob_calculateFingerprints_hashWorkers(t *testing.T) {
+	const (
+		files       = 12
+		hashWorkers = 3
+	)
+
+	calculator := &concurrencyCalculator{}
+	s := &scanJob{
+		Scanner: &Scanner{
+			FingerprintCalculator: calculator,
+		},
+		hashSlots: make(chan struct{}, hashWorkers),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < files; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.calculateFingerprints(nil, &BaseFile{}, "file", true); err != nil {
+				t.Errorf("calculateFingerprints() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calculator.max > hashWorkers {
+		t.Errorf("concurrent hashes = %d, want at most %d", calculator.max, hashWorkers)
+	}
+	if calculator.max < 2 {
+		t.Errorf("concurrent hashes = %d, want files hashed concurrently", calculator.max)
+	}
+}
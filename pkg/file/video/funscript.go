@@ -1,7 +1,12 @@
 package video
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -12,3 +17,75 @@ func GetFunscriptPath(path string) string {
 	fn := strings.TrimSuffix(path, ext)
 	return fn + ".funscript"
 }
+
+type funscriptAction struct {
+	// At is the time of the action in milliseconds.
+	At int64 `json:"at"`
+	// Pos is the position to move to, in percent.
+	Pos int `json:"pos"`
+}
+
+type funscript struct {
+	Actions []funscriptAction `json:"actions"`
+}
+
+// FunscriptStats summarises the actions of a funscript.
+type FunscriptStats struct {
+	// AverageSpeed is the average speed of the actions in position units per
+	// second, across the time between the first and last action.
+	AverageSpeed int
+	// Range is the difference between the highest and lowest position.
+	Range int
+	// Density is the number of actions per minute, across the time between
+	// the first and last action.
+	Density int
+}
+
+// ReadFunscriptStats reads a funscript and returns the summary of its
+// actions. Actions with negative timestamps are ignored.
+func ReadFunscriptStats(r io.Reader) (*FunscriptStats, error) {
+	var script funscript
+	if err := json.NewDecoder(r).Decode(&script); err != nil {
+		return nil, fmt.Errorf("decoding funscript: %w", err)
+	}
+
+	var actions []funscriptAction
+	for _, a := range script.Actions {
+		if a.At >= 0 {
+			actions = append(actions, a)
+		}
+	}
+
+	if len(actions) == 0 {
+		return nil, fmt.Errorf("no valid actions in funscript")
+	}
+
+	sort.SliceStable(actions, func(i, j int) bool { return actions[i].At < actions[j].At })
+
+	minPos := actions[0].Pos
+	maxPos := actions[0].Pos
+	distance := 0
+	for i := 1; i < len(actions); i++ {
+		pos := actions[i].Pos
+		if pos < minPos {
+			minPos = pos
+		}
+		if pos > maxPos {
+			maxPos = pos
+		}
+
+		distance += int(math.Abs(float64(pos - actions[i-1].Pos)))
+	}
+
+	ret := &FunscriptStats{
+		Range: maxPos - minPos,
+	}
+
+	durationMilli := actions[len(actions)-1].At - actions[0].At
+	if durationMilli > 0 {
+		ret.AverageSpeed = int(math.Round(float64(distance) / float64(durationMilli) * 1000))
+		ret.Density = int(math.Round(float64(len(actions)) / float64(durationMilli) * 60000))
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,66 @@
+package video
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadFunscriptStats(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		want    *FunscriptStats
+		wantErr bool
+	}{
+		{
+			"valid",
+			`{"actions":[{"at":0,"pos":10},{"at":500,"pos":90},{"at":1000,"pos":10}]}`,
+			&FunscriptStats{AverageSpeed: 160, Range: 80, Density: 180},
+			false,
+		},
+		{
+			"unsorted",
+			`{"actions":[{"at":1000,"pos":10},{"at":0,"pos":10},{"at":500,"pos":90}]}`,
+			&FunscriptStats{AverageSpeed: 160, Range: 80, Density: 180},
+			false,
+		},
+		{
+			"negative timestamps",
+			`{"actions":[{"at":-500,"pos":0},{"at":0,"pos":50},{"at":2000,"pos":100}]}`,
+			&FunscriptStats{AverageSpeed: 25, Range: 50, Density: 60},
+			false,
+		},
+		{
+			"single action",
+			`{"actions":[{"at":100,"pos":50}]}`,
+			&FunscriptStats{},
+			false,
+		},
+		{
+			"no actions",
+			`{"actions":[]}`,
+			nil,
+			true,
+		},
+		{
+			"invalid",
+			`{"actions":`,
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadFunscriptStats(strings.NewReader(tt.script))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadFunscriptStats() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/stashapp/stash/pkg/ffmpeg"
 	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/logger"
 )
 
 // Decorator adds video specific fields to a File.
@@ -38,11 +39,12 @@ func (d *Decorator) Decorate(ctx context.Context, fs file.FS, f file.File) (file
 
 	// check if there is a funscript file
 	interactive := false
-	if _, err := fs.Lstat(GetFunscriptPath(base.Path)); err == nil {
+	funscriptPath := GetFunscriptPath(base.Path)
+	if _, err := fs.Lstat(funscriptPath); err == nil {
 		interactive = true
 	}
 
-	return &file.VideoFile{
+	ret := &file.VideoFile{
 		BaseFile:    base,
 		Format:      string(container),
 		VideoCodec:  videoFile.VideoCodec,
@@ -53,7 +55,33 @@ func (d *Decorator) Decorate(ctx context.Context, fs file.FS, f file.File) (file
 		FrameRate:   videoFile.FrameRate,
 		BitRate:     videoFile.Bitrate,
 		Interactive: interactive,
-	}, nil
+	}
+
+	if interactive {
+		if err := setFunscriptStats(fs, funscriptPath, ret); err != nil {
+			logger.Warnf("error reading funscript %q: %v", funscriptPath, err)
+		}
+	}
+
+	return ret, nil
+}
+
+func setFunscriptStats(fs file.FS, path string, f *file.VideoFile) error {
+	r, err := fs.Open(path)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	stats, err := ReadFunscriptStats(r)
+	if err != nil {
+		return err
+	}
+
+	f.InteractiveAverageSpeed = &stats.AverageSpeed
+	f.InteractiveRange = &stats.Range
+	f.InteractiveDensity = &stats.Density
+	return nil
 }
 
 func (d *Decorator) IsMissingMetadata(ctx context.Context, fs file.FS, f file.File) bool {
@@ -14,6 +14,17 @@ type VideoFile struct {
 
 	Interactive      bool `json:"interactive"`
 	InteractiveSpeed *int `json:"interactive_speed"`
+	// InteractiveAverageSpeed is the average speed of the funscript actions,
+	// in position units per second.
+	InteractiveAverageSpeed *int `json:"interactive_average_speed"`
+	// InteractiveRange is the difference between the highest and lowest
+	// position of the funscript actions.
+	InteractiveRange *int `json:"interactive_range"`
+	// InteractiveDensity is the number of funscript actions per minute.
+	InteractiveDensity *int `json:"interactive_density"`
+
+	// Loudness is the integrated loudness of the audio, in LUFS.
+	Loudness *float64 `json:"loudness"`
 }
 
 func (f VideoFile) GetMinResolution() int {
@@ -26,3 +37,15 @@ func (f VideoFile) GetMinResolution() int {
 
 	return h
 }
+
+// BitratePerPixel returns the bitrate of the file divided by the number of
+// pixels per second, which is a measure of how efficiently the file is
+// encoded regardless of its resolution. Returns 0 if not known.
+func (f VideoFile) BitratePerPixel() float64 {
+	pixelsPerSecond := float64(f.Width*f.Height) * f.FrameRate
+	if pixelsPerSecond <= 0 {
+		return 0
+	}
+
+	return float64(f.BitRate) / pixelsPerSecond
+}
@@ -76,7 +76,7 @@ func walkSym(f FS, filename string, linkDirname string, walkFn fs.WalkDirFunc) e
 
 		return walkFn(path, info, err)
 	}
-	return fsWalk(f, filename, symWalkFunc)
+	return fsWalk(f, filename, lexicalLess, symWalkFunc)
 }
 
 // symWalk extends filepath.Walk to also follow symlinks
@@ -93,12 +93,23 @@ func (d *statDirEntry) IsDir() bool                { return d.info.IsDir() }
 func (d *statDirEntry) Type() fs.FileMode          { return d.info.Mode().Type() }
 func (d *statDirEntry) Info() (fs.FileInfo, error) { return d.info, nil }
 
-func fsWalk(f FS, root string, fn fs.WalkDirFunc) error {
+// lexicalLess sorts directory entry names lexically.
+func lexicalLess(a, b string) bool {
+	return a < b
+}
+
+// zipWalk walks the contents of a zip file. Directory entries are walked in
+// the order given by less.
+func zipWalk(f FS, root string, less func(a, b string) bool, fn fs.WalkDirFunc) error {
+	return fsWalk(f, root, less, fn)
+}
+
+func fsWalk(f FS, root string, less func(a, b string) bool, fn fs.WalkDirFunc) error {
 	info, err := f.Lstat(root)
 	if err != nil {
 		err = fn(root, nil, err)
 	} else {
-		err = walkDir(f, root, &statDirEntry{info}, fn)
+		err = walkDir(f, root, &statDirEntry{info}, less, fn)
 	}
 	if errors.Is(err, fs.SkipDir) {
 		return nil
@@ -106,7 +117,7 @@ func fsWalk(f FS, root string, fn fs.WalkDirFunc) error {
 	return err
 }
 
-func walkDir(f FS, path string, d fs.DirEntry, walkDirFn fs.WalkDirFunc) error {
+func walkDir(f FS, path string, d fs.DirEntry, less func(a, b string) bool, walkDirFn fs.WalkDirFunc) error {
 	if err := walkDirFn(path, d, nil); err != nil || !d.IsDir() {
 		if errors.Is(err, fs.SkipDir) && d.IsDir() {
 			// Successfully skipped directory.
@@ -115,7 +126,7 @@ func walkDir(f FS, path string, d fs.DirEntry, walkDirFn fs.WalkDirFunc) error {
 		return err
 	}
 
-	dirs, err := readDir(f, path)
+	dirs, err := readDir(f, path, less)
 	if err != nil {
 		// Second call, to report ReadDir error.
 		err = walkDirFn(path, d, err)
@@ -131,7 +142,7 @@ func walkDir(f FS, path string, d fs.DirEntry, walkDirFn fs.WalkDirFunc) error {
 			continue
 		}
 		path1 := filepath.Join(path, name)
-		if err := walkDir(f, path1, d1, walkDirFn); err != nil {
+		if err := walkDir(f, path1, d1, less, walkDirFn); err != nil {
 			if errors.Is(err, fs.SkipDir) {
 				break
 			}
@@ -142,8 +153,8 @@ func walkDir(f FS, path string, d fs.DirEntry, walkDirFn fs.WalkDirFunc) error {
 }
 
 // readDir reads the directory named by dirname and returns
-// a sorted list of directory entries.
-func readDir(fs FS, dirname string) ([]fs.DirEntry, error) {
+// a list of directory entries sorted by name using less.
+func readDir(fs FS, dirname string, less func(a, b string) bool) ([]fs.DirEntry, error) {
 	f, err := fs.Open(dirname)
 	if err != nil {
 		return nil, err
@@ -153,6 +164,6 @@ func readDir(fs FS, dirname string) ([]fs.DirEntry, error) {
 	if err != nil {
 		return nil, err
 	}
-	sort.Slice(dirs, func(i, j int) bool { return dirs[i].Name() < dirs[j].Name() })
+	sort.Slice(dirs, func(i, j int) bool { return less(dirs[i].Name(), dirs[j].Name()) })
 	return dirs, nil
 }
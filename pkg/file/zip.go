@@ -2,16 +2,23 @@ package file
 
 import (
 	"archive/zip"
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
 	"path/filepath"
+	"syscall"
 )
 
+// maxNestedZipSize is the maximum size of a zip file inside a zip file that
+// can be opened. Nested zip files are read into memory, since the contents
+// of a zip file cannot be read randomly.
+const maxNestedZipSize = 256 << 20
+
 var (
-	errNotReaderAt  = errors.New("not a ReaderAt")
-	errZipFSOpenZip = errors.New("cannot open zip file inside zip file")
+	errNotReaderAt      = errors.New("not a ReaderAt")
+	errNestedZipTooLong = fmt.Errorf("zip file inside zip file is larger than %d bytes", maxNestedZipSize)
 )
 
 // ZipFS is a file system backed by a zip file.
@@ -79,10 +86,104 @@ func (f *ZipFS) Lstat(name string) (fs.FileInfo, error) {
 	return f.Stat(name)
 }
 
+// OpenZip opens the zip file inside this zip file with the provided name.
+// The zip file is read into memory. Closing the returned ZipFS does not
+// close this ZipFS.
 func (f *ZipFS) OpenZip(name string) (*ZipFS, error) {
-	return nil, errZipFSOpenZip
+	info, err := f.Stat(name)
+	if err != nil {
+		if isNotExist(err) {
+			return openZipInZip(f, name, err)
+		}
+		return nil, err
+	}
+
+	if info.Size() > maxNestedZipSize {
+		return nil, errNestedZipTooLong
+	}
+
+	reader, err := f.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	// guard against the size in the header being incorrect
+	data, err := io.ReadAll(io.LimitReader(reader, maxNestedZipSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > maxNestedZipSize {
+		return nil, errNestedZipTooLong
+	}
+
+	zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ZipFS{
+		Reader:        zipReader,
+		zipFileCloser: nopCloser{},
+		zipInfo:       info,
+		zipPath:       name,
+	}, nil
 }
 
+// openZipInZip opens the zip file with the provided name, which does not
+// exist directly in f, but is inside a zip file in f. Returns notExistErr if
+// no zip file containing name is found.
+func openZipInZip(f FS, name string, notExistErr error) (*ZipFS, error) {
+	// find the closest ancestor that exists
+	p := name
+	for {
+		parent := filepath.Dir(p)
+		if parent == p {
+			return nil, notExistErr
+		}
+		p = parent
+
+		info, err := f.Lstat(p)
+		if err == nil {
+			if info.IsDir() {
+				// name is not inside a zip file
+				return nil, notExistErr
+			}
+			break
+		}
+
+		if !isNotExist(err) {
+			return nil, err
+		}
+	}
+
+	outer, err := f.OpenZip(p)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := outer.OpenZip(name)
+	if err != nil {
+		outer.Close()
+		return nil, err
+	}
+
+	// closing the returned zip file closes the containing zip files
+	ret.zipFileCloser = outer
+	return ret, nil
+}
+
+// isNotExist returns true if err indicates that a path does not exist,
+// including when part of the path is a file rather than a directory.
+func isNotExist(err error) bool {
+	return errors.Is(err, fs.ErrNotExist) || errors.Is(err, syscall.ENOTDIR)
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
+
 func (f *ZipFS) IsPathCaseSensitive(path string) (bool, error) {
 	return true, nil
 }
@@ -0,0 +1,131 @@
+package file
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fvbommel/sortorder"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeZip(t *testing.T, files map[string][]byte) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, data := range files {
+		fw, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestOsFS_OpenZipNested(t *testing.T) {
+	innermost := makeZip(t, map[string][]byte{"c.txt": []byte("c")})
+	inner := makeZip(t, map[string][]byte{
+		"b.txt":         []byte("b"),
+		"dir/deep.zip":  innermost,
+		"dir/other.txt": []byte("other"),
+	})
+	outer := makeZip(t, map[string][]byte{
+		"a.txt":     []byte("a"),
+		"inner.zip": inner,
+	})
+
+	dir := t.TempDir()
+	outerPath := filepath.Join(dir, "outer.zip")
+	if err := os.WriteFile(outerPath, outer, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fs := &OsFS{}
+
+	read := func(zipPath string, name string) string {
+		zfs, err := fs.OpenZip(zipPath)
+		if !assert.NoError(t, err) {
+			return ""
+		}
+		defer zfs.Close()
+
+		r, err := zfs.Open(name)
+		if !assert.NoError(t, err) {
+			return ""
+		}
+		defer r.Close()
+
+		data, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		return string(data)
+	}
+
+	innerPath := filepath.Join(outerPath, "inner.zip")
+	deepPath := filepath.Join(innerPath, "dir", "deep.zip")
+
+	assert.Equal(t, "a", read(outerPath, filepath.Join(outerPath, "a.txt")))
+	assert.Equal(t, "b", read(innerPath, filepath.Join(innerPath, "b.txt")))
+	assert.Equal(t, "c", read(deepPath, filepath.Join(deepPath, "c.txt")))
+
+	f := &BaseFile{
+		DirEntry: DirEntry{
+			ZipFile: &BaseFile{Path: deepPath},
+		},
+		Path: filepath.Join(deepPath, "c.txt"),
+	}
+	r, err := f.Open(fs)
+	if assert.NoError(t, err) {
+		data, _ := io.ReadAll(r)
+		assert.Equal(t, "c", string(data))
+		r.Close()
+	}
+
+	_, err = fs.OpenZip(filepath.Join(outerPath, "missing.zip"))
+	assert.True(t, isNotExist(err))
+
+	_, err = fs.OpenZip(filepath.Join(dir, "missing", "outer.zip"))
+	assert.True(t, isNotExist(err))
+}
+
+func TestZipWalk_order(t *testing.T) {
+	data := makeZip(t, map[string][]byte{
+		"img10.jpg": nil,
+		"img2.jpg":  nil,
+		"img1.jpg":  nil,
+	})
+
+	dir := t.TempDir()
+	zipPath := filepath.Join(dir, "gallery.zip")
+	if err := os.WriteFile(zipPath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	walk := func(less func(a, b string) bool) []string {
+		zfs, err := (&OsFS{}).OpenZip(zipPath)
+		if !assert.NoError(t, err) {
+			return nil
+		}
+		defer zfs.Close()
+
+		var ret []string
+		assert.NoError(t, zipWalk(zfs, zipPath, less, func(path string, d fs.DirEntry, err error) error {
+			if err == nil && !d.IsDir() {
+				ret = append(ret, filepath.Base(path))
+			}
+			return err
+		}))
+		return ret
+	}
+
+	assert.Equal(t, []string{"img1.jpg", "img10.jpg", "img2.jpg"}, walk(lexicalLess))
+	assert.Equal(t, []string{"img1.jpg", "img2.jpg", "img10.jpg"}, walk(sortorder.NaturalLess))
+}
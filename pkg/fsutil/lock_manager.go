@@ -15,11 +15,36 @@ type LockContext struct {
 	context.Context
 	cancel context.CancelFunc
 
-	cmd *exec.Cmd
+	cmd      *exec.Cmd
+	waitOnce sync.Once
+	waitErr  error
+	waitDone chan struct{}
 }
 
+// AttachCommand attaches a started command to the context, so that Cancel
+// waits for it to exit. The command must then only be waited on using Wait.
 func (c *LockContext) AttachCommand(cmd *exec.Cmd) {
 	c.cmd = cmd
+	c.waitDone = make(chan struct{})
+}
+
+// Wait waits for the attached command to exit and returns the result of
+// its Wait. It may be called more than once, and concurrently with Cancel.
+// The ProcessState of the command is set once Wait returns.
+func (c *LockContext) Wait() error {
+	if c.cmd == nil {
+		return nil
+	}
+
+	c.waitOnce.Do(func() {
+		go func() {
+			c.waitErr = c.cmd.Wait()
+			close(c.waitDone)
+		}()
+	})
+
+	<-c.waitDone
+	return c.waitErr
 }
 
 func (c *LockContext) Cancel() {
@@ -28,10 +53,10 @@ func (c *LockContext) Cancel() {
 	if c.cmd != nil {
 		// wait for the process to die before returning
 		// don't wait more than a few seconds
-		done := make(chan error)
+		done := make(chan struct{})
 		go func() {
-			err := c.cmd.Wait()
-			done <- err
+			_ = c.Wait()
+			close(done)
 		}()
 
 		select {
@@ -0,0 +1,46 @@
+package fsutil
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"sync"
+	"testing"
+)
+
+func TestLockContext_WaitAndCancel(t *testing.T) {
+	m := NewReadLockManager()
+	lockCtx := m.ReadLock(context.Background(), "file")
+
+	// run the test binary without any tests so that it exits immediately
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("error starting command: %v", err)
+	}
+
+	lockCtx.AttachCommand(cmd)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = lockCtx.Wait()
+		}()
+	}
+
+	lockCtx.Cancel()
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			t.Errorf("LockContext.Wait() error = %v", err)
+		}
+	}
+
+	if cmd.ProcessState == nil {
+		t.Error("ProcessState not set after Wait")
+	}
+}
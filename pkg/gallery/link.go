@@ -0,0 +1,162 @@
+package gallery
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil/intslice"
+)
+
+type FolderLinkSceneFinderUpdater interface {
+	FindByParentFolderID(ctx context.Context, folderID file.FolderID) ([]*models.Scene, error)
+	UpdatePartial(ctx context.Context, id int, updatedScene models.ScenePartial) (*models.Scene, error)
+	models.GalleryIDLoader
+	models.VideoFileLoader
+}
+
+type FolderLinkGalleryFinder interface {
+	FindByFolderID(ctx context.Context, folderID file.FolderID) ([]*models.Gallery, error)
+	FindByParentFolderID(ctx context.Context, folderID file.FolderID) ([]*models.Gallery, error)
+}
+
+// FolderLinker links scenes to the galleries in the same folder as their
+// files. A gallery is in a folder if it is the folder-based gallery of the
+// folder, or if its zip file is in the folder.
+type FolderLinker struct {
+	SceneFinderUpdater FolderLinkSceneFinderUpdater
+	GalleryFinder      FolderLinkGalleryFinder
+
+	// MatchStem restricts the links to scenes and galleries with the same
+	// name, ignoring the extension. The name of a folder-based gallery is
+	// the name of its folder.
+	MatchStem bool
+
+	// SyncLinkedDates is true if the date of a linked gallery or scene is
+	// set from the other when only one of them has a date. GalleryUpdater
+	// is required if set.
+	SyncLinkedDates bool
+	GalleryUpdater  PartialUpdater
+
+	// ProtectSceneDate is true if the date of scenes linked by LinkGallery
+	// is never set from the gallery.
+	ProtectSceneDate bool
+}
+
+// LinkScene links the scene to the galleries in the folder of the file. The
+// date of the scene is never set from the galleries if protectDate is true.
+func (l *FolderLinker) LinkScene(ctx context.Context, s *models.Scene, f file.File, protectDate bool) error {
+	folderID := f.Base().ParentFolderID
+
+	galleries, err := l.GalleryFinder.FindByFolderID(ctx, folderID)
+	if err != nil {
+		return err
+	}
+
+	zipGalleries, err := l.GalleryFinder.FindByParentFolderID(ctx, folderID)
+	if err != nil {
+		return err
+	}
+	galleries = append(galleries, zipGalleries...)
+
+	for _, g := range galleries {
+		if l.MatchStem && galleryStem(g) != fileStem(f.Base().Basename) {
+			continue
+		}
+
+		if err := l.link(ctx, s, g, protectDate); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LinkGallery links the gallery to the scenes with files in the folder.
+func (l *FolderLinker) LinkGallery(ctx context.Context, g *models.Gallery, folderID file.FolderID) error {
+	scenes, err := l.SceneFinderUpdater.FindByParentFolderID(ctx, folderID)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range scenes {
+		if l.MatchStem {
+			matched, err := l.sceneMatchesStem(ctx, s, folderID, galleryStem(g))
+			if err != nil {
+				return err
+			}
+			if !matched {
+				continue
+			}
+		}
+
+		if err := l.link(ctx, s, g, l.ProtectSceneDate); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sceneMatchesStem returns true if the scene has a file in the folder with
+// the provided stem.
+func (l *FolderLinker) sceneMatchesStem(ctx context.Context, s *models.Scene, folderID file.FolderID, stem string) (bool, error) {
+	if err := s.LoadFiles(ctx, l.SceneFinderUpdater); err != nil {
+		return false, err
+	}
+
+	for _, f := range s.Files.List() {
+		if f.ParentFolderID == folderID && fileStem(f.Basename) == stem {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// link adds the gallery to the scene, unless they are already linked.
+func (l *FolderLinker) link(ctx context.Context, s *models.Scene, g *models.Gallery, protectSceneDate bool) error {
+	if err := s.LoadGalleryIDs(ctx, l.SceneFinderUpdater); err != nil {
+		return err
+	}
+
+	if intslice.IntInclude(s.GalleryIDs.List(), g.ID) {
+		return nil
+	}
+
+	logger.Infof("Linking scene %s to gallery %s in the same folder", s.DisplayName(), g.DisplayName())
+
+	partial := models.NewScenePartial()
+	partial.GalleryIDs = &models.UpdateIDs{
+		IDs:  []int{g.ID},
+		Mode: models.RelationshipUpdateModeAdd,
+	}
+
+	if _, err := l.SceneFinderUpdater.UpdatePartial(ctx, s.ID, partial); err != nil {
+		return fmt.Errorf("adding gallery to scene: %w", err)
+	}
+
+	s.GalleryIDs.Add(g.ID)
+
+	if l.SyncLinkedDates {
+		return syncLinkedDate(ctx, l.GalleryUpdater, l.SceneFinderUpdater, g, s, protectSceneDate)
+	}
+
+	return nil
+}
+
+func galleryStem(g *models.Gallery) string {
+	if g.FolderID != nil {
+		return filepath.Base(g.Path)
+	}
+
+	return fileStem(filepath.Base(g.Path))
+}
+
+func fileStem(basename string) string {
+	return strings.TrimSuffix(basename, filepath.Ext(basename))
+}
@@ -0,0 +1,214 @@
+package gallery
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	linkFolderID      file.FolderID = 1
+	linkOtherFolderID file.FolderID = 2
+
+	folderGalleryID = 1
+	zipGalleryID    = 2
+	linkedSceneID   = 1
+	unlinkedSceneID = 2
+)
+
+func linkFolderGalleries() []*models.Gallery {
+	folderID := linkFolderID
+	return []*models.Gallery{
+		{ID: folderGalleryID, FolderID: &folderID, Path: "/shoot/scene"},
+	}
+}
+
+func linkZipGalleries() []*models.Gallery {
+	return []*models.Gallery{
+		{ID: zipGalleryID, Path: "/shoot/scene/photos.zip"},
+	}
+}
+
+func updateGalleryIDsMatcher(galleryID int) interface{} {
+	return mock.MatchedBy(func(p models.ScenePartial) bool {
+		return p.GalleryIDs != nil && assert.ObjectsAreEqual([]int{galleryID}, p.GalleryIDs.IDs)
+	})
+}
+
+func linkVideoFile(folderID file.FolderID, basename string) *file.VideoFile {
+	return &file.VideoFile{
+		BaseFile: &file.BaseFile{
+			ParentFolderID: folderID,
+			Basename:       basename,
+		},
+	}
+}
+
+func TestFolderLinker_LinkScene(t *testing.T) {
+	tests := []struct {
+		name      string
+		matchStem bool
+		galleries []int
+		basename  string
+		want      []int
+	}{
+		{"all", false, nil, "scene.mp4", []int{folderGalleryID, zipGalleryID}},
+		{"already linked", false, []int{folderGalleryID}, "scene.mp4", []int{zipGalleryID}},
+		{"match folder stem", true, nil, "scene.mp4", []int{folderGalleryID}},
+		{"match zip stem", true, nil, "photos.mp4", []int{zipGalleryID}},
+		{"no stem match", true, nil, "other.mp4", nil},
+	}
+
+	ctx := context.Background()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sceneMock := &mocks.SceneReaderWriter{}
+			galleryMock := &mocks.GalleryReaderWriter{}
+
+			galleryMock.On("FindByFolderID", ctx, linkFolderID).Return(linkFolderGalleries(), nil)
+			galleryMock.On("FindByParentFolderID", ctx, linkFolderID).Return(linkZipGalleries(), nil)
+
+			for _, id := range tt.want {
+				sceneMock.On("UpdatePartial", ctx, linkedSceneID, updateGalleryIDsMatcher(id)).Return(nil, nil).Once()
+			}
+
+			l := &FolderLinker{
+				SceneFinderUpdater: sceneMock,
+				GalleryFinder:      galleryMock,
+				MatchStem:          tt.matchStem,
+			}
+
+			s := &models.Scene{
+				ID:         linkedSceneID,
+				GalleryIDs: models.NewRelatedIDs(append([]int{}, tt.galleries...)),
+			}
+
+			if err := l.LinkScene(ctx, s, linkVideoFile(linkFolderID, tt.basename), false); err != nil {
+				t.Errorf("FolderLinker.LinkScene() error = %v", err)
+				return
+			}
+
+			sceneMock.AssertExpectations(t)
+			sceneMock.AssertNumberOfCalls(t, "UpdatePartial", len(tt.want))
+			assert.ElementsMatch(t, append(tt.galleries, tt.want...), s.GalleryIDs.List())
+		})
+	}
+}
+
+func TestFolderLinker_LinkScene_syncLinkedDates(t *testing.T) {
+	ctx := context.Background()
+	date := models.NewDate("2020-01-02")
+
+	tests := []struct {
+		name        string
+		protectDate bool
+		want        *models.Date
+	}{
+		{"unprotected", false, &date},
+		{"protected", true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sceneMock := &mocks.SceneReaderWriter{}
+			galleryMock := &mocks.GalleryReaderWriter{}
+
+			galleries := linkFolderGalleries()
+			galleries[0].Date = &date
+
+			galleryMock.On("FindByFolderID", ctx, linkFolderID).Return(galleries, nil)
+			galleryMock.On("FindByParentFolderID", ctx, linkFolderID).Return(nil, nil)
+			sceneMock.On("UpdatePartial", ctx, linkedSceneID, updateGalleryIDsMatcher(folderGalleryID)).Return(nil, nil).Once()
+			if tt.want != nil {
+				sceneMock.On("UpdatePartial", ctx, linkedSceneID, sceneDateMatcher(*tt.want)).Return(nil, nil).Once()
+			}
+
+			l := &FolderLinker{
+				SceneFinderUpdater: sceneMock,
+				GalleryFinder:      galleryMock,
+				SyncLinkedDates:    true,
+				GalleryUpdater:     galleryMock,
+			}
+
+			s := &models.Scene{
+				ID:         linkedSceneID,
+				GalleryIDs: models.NewRelatedIDs([]int{}),
+			}
+
+			if err := l.LinkScene(ctx, s, linkVideoFile(linkFolderID, "scene.mp4"), tt.protectDate); err != nil {
+				t.Errorf("FolderLinker.LinkScene() error = %v", err)
+				return
+			}
+
+			sceneMock.AssertExpectations(t)
+			assert.Equal(t, tt.want, s.Date)
+		})
+	}
+}
+
+func TestFolderLinker_LinkGallery(t *testing.T) {
+	ctx := context.Background()
+
+	newScenes := func() []*models.Scene {
+		return []*models.Scene{
+			{
+				ID:         linkedSceneID,
+				GalleryIDs: models.NewRelatedIDs([]int{zipGalleryID}),
+				Files: models.NewRelatedVideoFiles([]*file.VideoFile{
+					linkVideoFile(linkFolderID, "photos.mp4"),
+				}),
+			},
+			{
+				ID:         unlinkedSceneID,
+				GalleryIDs: models.NewRelatedIDs([]int{}),
+				Files: models.NewRelatedVideoFiles([]*file.VideoFile{
+					linkVideoFile(linkOtherFolderID, "photos.mp4"),
+					linkVideoFile(linkFolderID, "other.mp4"),
+				}),
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		matchStem bool
+		want      []int
+	}{
+		{"all", false, []int{unlinkedSceneID}},
+		// the unlinked scene only has a matching file in another folder
+		{"match stem", true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sceneMock := &mocks.SceneReaderWriter{}
+			galleryMock := &mocks.GalleryReaderWriter{}
+
+			sceneMock.On("FindByParentFolderID", ctx, linkFolderID).Return(newScenes(), nil)
+			for _, id := range tt.want {
+				sceneMock.On("UpdatePartial", ctx, id, updateGalleryIDsMatcher(zipGalleryID)).Return(nil, nil).Once()
+			}
+
+			l := &FolderLinker{
+				SceneFinderUpdater: sceneMock,
+				GalleryFinder:      galleryMock,
+				MatchStem:          tt.matchStem,
+			}
+
+			g := linkZipGalleries()[0]
+			if err := l.LinkGallery(ctx, g, linkFolderID); err != nil {
+				t.Errorf("FolderLinker.LinkGallery() error = %v", err)
+				return
+			}
+
+			sceneMock.AssertExpectations(t)
+			sceneMock.AssertNumberOfCalls(t, "UpdatePartial", len(tt.want))
+		})
+	}
+}
@@ -24,6 +24,7 @@ type FinderCreatorUpdater interface {
 type SceneFinderUpdater interface {
 	FindByPath(ctx context.Context, p string) ([]*models.Scene, error)
 	Update(ctx context.Context, updatedScene *models.Scene) error
+	UpdatePartial(ctx context.Context, id int, updatedScene models.ScenePartial) (*models.Scene, error)
 	AddGalleryIDs(ctx context.Context, sceneID int, galleryIDs []int) error
 }
 
@@ -37,6 +38,18 @@ type ScanHandler struct {
 	SceneFinderUpdater SceneFinderUpdater
 	ImageFinderUpdater ImageFinderUpdater
 	PluginCache        *plugin.Cache
+
+	// SyncLinkedDates is true if the date of a gallery or a scene associated
+	// with it is set from the other when only one of them has a date.
+	SyncLinkedDates bool
+
+	// ProtectSceneDate is true if the date of scenes is never set from the
+	// gallery. The date of the gallery is still set from the scene.
+	ProtectSceneDate bool
+
+	// FolderLinker is used to link galleries to the scenes in the same
+	// folder as their zip file. Optional.
+	FolderLinker *FolderLinker
 }
 
 func (h *ScanHandler) Handle(ctx context.Context, f file.File, oldFile file.File) error {
@@ -77,6 +90,7 @@ func (h *ScanHandler) Handle(ctx context.Context, f file.File, oldFile file.File
 		// create a new gallery
 		now := time.Now()
 		newGallery := &models.Gallery{
+			Path:      f.Base().Path,
 			CreatedAt: now,
 			UpdatedAt: now,
 		}
@@ -109,6 +123,14 @@ func (h *ScanHandler) Handle(ctx context.Context, f file.File, oldFile file.File
 		return err
 	}
 
+	if h.FolderLinker != nil {
+		for _, g := range existing {
+			if err := h.FolderLinker.LinkGallery(ctx, g, f.Base().ParentFolderID); err != nil {
+				return fmt.Errorf("linking folder scenes: %w", err)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -167,6 +189,45 @@ func (h *ScanHandler) associateScene(ctx context.Context, existing []*models.Gal
 		if err := h.SceneFinderUpdater.AddGalleryIDs(ctx, scene.ID, galleryIDs); err != nil {
 			return err
 		}
+
+		if h.SyncLinkedDates {
+			for _, g := range existing {
+				if err := syncLinkedDate(ctx, h.CreatorUpdater, h.SceneFinderUpdater, g, scene, h.ProtectSceneDate); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// ScenePartialUpdater updates scenes.
+type ScenePartialUpdater interface {
+	UpdatePartial(ctx context.Context, id int, updatedScene models.ScenePartial) (*models.Scene, error)
+}
+
+// syncLinkedDate sets the date of the gallery from the scene, or of the scene
+// from the gallery, if only one of them has a date. The date of the scene is
+// not set if protectSceneDate is true.
+func syncLinkedDate(ctx context.Context, galleryUpdater PartialUpdater, sceneUpdater ScenePartialUpdater, g *models.Gallery, s *models.Scene, protectSceneDate bool) error {
+	switch {
+	case g.Date == nil && s.Date != nil:
+		logger.Infof("Setting date of gallery %s from scene %d", g.GetTitle(), s.ID)
+		partial := models.NewGalleryPartial()
+		partial.Date = models.NewOptionalDate(*s.Date)
+		if _, err := galleryUpdater.UpdatePartial(ctx, g.ID, partial); err != nil {
+			return fmt.Errorf("updating gallery date: %w", err)
+		}
+		g.Date = s.Date
+	case s.Date == nil && g.Date != nil && !protectSceneDate:
+		logger.Infof("Setting date of scene %d from gallery %s", s.ID, g.GetTitle())
+		partial := models.NewScenePartial()
+		partial.Date = models.NewOptionalDate(*g.Date)
+		if _, err := sceneUpdater.UpdatePartial(ctx, s.ID, partial); err != nil {
+			return fmt.Errorf("updating scene date: %w", err)
+		}
+		s.Date = g.Date
 	}
 
 	return nil
@@ -0,0 +1,83 @@
+package gallery
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func sceneDateMatcher(date models.Date) interface{} {
+	return mock.MatchedBy(func(p models.ScenePartial) bool {
+		return p.Date.Set && p.Date.Value.String() == date.String()
+	})
+}
+
+func galleryDateMatcher(date models.Date) interface{} {
+	return mock.MatchedBy(func(p models.GalleryPartial) bool {
+		return p.Date.Set && p.Date.Value.String() == date.String()
+	})
+}
+
+func TestSyncLinkedDate(t *testing.T) {
+	const (
+		galleryID = 1
+		sceneID   = 2
+	)
+
+	sceneDate := models.NewDate("2020-01-02")
+	galleryDate := models.NewDate("2021-03-04")
+
+	tests := []struct {
+		name             string
+		galleryDate      *models.Date
+		sceneDate        *models.Date
+		protectSceneDate bool
+		want             *models.Date
+	}{
+		{"from scene", nil, &sceneDate, false, &sceneDate},
+		{"from gallery", &galleryDate, nil, false, &galleryDate},
+		{"both set", &galleryDate, &sceneDate, false, nil},
+		{"neither set", nil, nil, false, nil},
+		{"from scene protected", nil, &sceneDate, true, &sceneDate},
+		{"from gallery protected", &galleryDate, nil, true, nil},
+	}
+
+	ctx := context.Background()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sceneMock := &mocks.SceneReaderWriter{}
+			galleryMock := &mocks.GalleryReaderWriter{}
+
+			g := &models.Gallery{ID: galleryID, Date: tt.galleryDate}
+			s := &models.Scene{ID: sceneID, Date: tt.sceneDate}
+
+			switch {
+			case tt.want != nil && tt.galleryDate == nil:
+				galleryMock.On("UpdatePartial", ctx, galleryID, galleryDateMatcher(*tt.want)).Return(nil, nil).Once()
+			case tt.want != nil && tt.sceneDate == nil:
+				sceneMock.On("UpdatePartial", ctx, sceneID, sceneDateMatcher(*tt.want)).Return(nil, nil).Once()
+			}
+
+			if err := syncLinkedDate(ctx, galleryMock, sceneMock, g, s, tt.protectSceneDate); err != nil {
+				t.Errorf("syncLinkedDate() error = %v", err)
+				return
+			}
+
+			sceneMock.AssertExpectations(t)
+			galleryMock.AssertExpectations(t)
+
+			if tt.want != nil {
+				assert.Equal(t, tt.want, g.Date)
+				assert.Equal(t, tt.want, s.Date)
+			} else {
+				assert.Equal(t, tt.galleryDate, g.Date)
+				assert.Equal(t, tt.sceneDate, s.Date)
+			}
+		})
+	}
+}
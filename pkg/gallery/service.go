@@ -15,8 +15,12 @@ type FinderByFile interface {
 type Repository interface {
 	models.GalleryFinder
 	FinderByFile
+	Create(ctx context.Context, newGallery *models.Gallery, fileIDs []file.ID) error
 	Destroy(ctx context.Context, id int) error
 	models.FileLoader
+	models.SceneIDLoader
+	models.PerformerIDLoader
+	models.TagIDLoader
 	ImageUpdater
 }
 
@@ -0,0 +1,97 @@
+package gallery
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil/intslice"
+)
+
+// Split moves each group of images from the provided gallery into a new
+// gallery. The new galleries are user-created galleries with the metadata of
+// the source gallery, and are returned in the order of imageGroups. Images
+// that are not in any group remain in the source gallery.
+//
+// The contents of folder and zip-based galleries are determined by scanning,
+// so the images of those galleries are added to the new galleries without
+// being removed from the source gallery.
+//
+// It returns an error if a group is empty, or if an image is not in the
+// source gallery or is in more than one group.
+func (s *Service) Split(ctx context.Context, g *models.Gallery, imageGroups [][]int) ([]*models.Gallery, error) {
+	if len(imageGroups) == 0 {
+		return nil, fmt.Errorf("no image groups provided")
+	}
+
+	existing, err := s.Repository.GetImageIDs(ctx, g.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting images of gallery %q: %w", g.GetTitle(), err)
+	}
+
+	var seen []int
+	for i, group := range imageGroups {
+		if len(group) == 0 {
+			return nil, fmt.Errorf("image group %d is empty", i+1)
+		}
+
+		for _, id := range group {
+			if !intslice.IntInclude(existing, id) {
+				return nil, fmt.Errorf("image %d is not in gallery %q", id, g.GetTitle())
+			}
+			if intslice.IntInclude(seen, id) {
+				return nil, fmt.Errorf("image %d is in more than one group", id)
+			}
+			seen = append(seen, id)
+		}
+	}
+
+	if err := g.LoadSceneIDs(ctx, s.Repository); err != nil {
+		return nil, err
+	}
+	if err := g.LoadPerformerIDs(ctx, s.Repository); err != nil {
+		return nil, err
+	}
+	if err := g.LoadTagIDs(ctx, s.Repository); err != nil {
+		return nil, err
+	}
+
+	ret := make([]*models.Gallery, len(imageGroups))
+	for i, group := range imageGroups {
+		now := time.Now()
+		newGallery := &models.Gallery{
+			Title:        fmt.Sprintf("%s (%d)", g.GetTitle(), i+1),
+			URL:          g.URL,
+			Date:         g.Date,
+			Details:      g.Details,
+			Rating:       g.Rating,
+			Organized:    g.Organized,
+			StudioID:     g.StudioID,
+			SceneIDs:     models.NewRelatedIDs(g.SceneIDs.List()),
+			TagIDs:       models.NewRelatedIDs(g.TagIDs.List()),
+			PerformerIDs: models.NewRelatedIDs(g.PerformerIDs.List()),
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+
+		if err := s.Repository.Create(ctx, newGallery, nil); err != nil {
+			return nil, fmt.Errorf("creating gallery: %w", err)
+		}
+
+		if err := s.Repository.AddImages(ctx, newGallery.ID, group...); err != nil {
+			return nil, fmt.Errorf("adding images to gallery %q: %w", newGallery.Title, err)
+		}
+
+		ret[i] = newGallery
+	}
+
+	// the images of file-based galleries would be added back on the next scan
+	if validateContentChange(g) == nil {
+		if err := s.Repository.RemoveImages(ctx, g.ID, seen...); err != nil {
+			return nil, fmt.Errorf("removing images from gallery %q: %w", g.GetTitle(), err)
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,103 @@
+package gallery
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// splitRepository adds the methods of Repository that are missing from the
+// gallery mock.
+type splitRepository struct {
+	*mocks.GalleryReaderWriter
+}
+
+func (r *splitRepository) FindByFileID(ctx context.Context, fileID file.ID) ([]*models.Gallery, error) {
+	return nil, nil
+}
+
+func (r *splitRepository) GetFiles(ctx context.Context, relatedID int) ([]file.File, error) {
+	return nil, nil
+}
+
+func (r *splitRepository) AddImages(ctx context.Context, galleryID int, imageIDs ...int) error {
+	return r.Called(ctx, galleryID, imageIDs).Error(0)
+}
+
+func (r *splitRepository) RemoveImages(ctx context.Context, galleryID int, imageIDs ...int) error {
+	return r.Called(ctx, galleryID, imageIDs).Error(0)
+}
+
+func TestService_Split(t *testing.T) {
+	const (
+		galleryID = 1
+		newID     = 10
+	)
+
+	folderID := file.FolderID(1)
+	primaryFileID := file.ID(1)
+
+	tests := []struct {
+		name        string
+		gallery     models.Gallery
+		imageGroups [][]int
+		wantRemoved bool
+		wantErr     bool
+	}{
+		{"valid", models.Gallery{ID: galleryID, Title: "title"}, [][]int{{1, 2}, {3}}, true, false},
+		{"folder-based", models.Gallery{ID: galleryID, Title: "title", FolderID: &folderID}, [][]int{{1, 2}, {3}}, false, false},
+		{"zip-based", models.Gallery{ID: galleryID, Title: "title", PrimaryFileID: &primaryFileID}, [][]int{{1, 2}, {3}}, false, false},
+		{"no groups", models.Gallery{ID: galleryID}, nil, false, true},
+		{"empty group", models.Gallery{ID: galleryID}, [][]int{{1}, {}}, false, true},
+		{"image not in gallery", models.Gallery{ID: galleryID}, [][]int{{5}}, false, true},
+		{"image in two groups", models.Gallery{ID: galleryID}, [][]int{{1}, {1, 2}}, false, true},
+	}
+
+	ctx := context.Background()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &splitRepository{&mocks.GalleryReaderWriter{}}
+			repo.On("GetImageIDs", ctx, galleryID).Return([]int{1, 2, 3, 4}, nil)
+			repo.On("GetSceneIDs", ctx, galleryID).Return([]int{}, nil)
+			repo.On("GetPerformerIDs", ctx, galleryID).Return([]int{}, nil)
+			repo.On("GetTagIDs", ctx, galleryID).Return([]int{}, nil)
+			repo.On("Create", ctx, mock.Anything, []file.ID(nil)).Run(func(args mock.Arguments) {
+				args.Get(1).(*models.Gallery).ID = newID
+			}).Return(nil)
+			repo.On("AddImages", ctx, newID, mock.Anything).Return(nil)
+			repo.On("RemoveImages", ctx, galleryID, mock.Anything).Return(nil)
+
+			s := &Service{Repository: repo}
+			g := tt.gallery
+
+			got, err := s.Split(ctx, &g, tt.imageGroups)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Split() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+				repo.AssertNotCalled(t, "RemoveImages", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.Len(t, got, len(tt.imageGroups))
+			assert.Equal(t, "title (1)", got[0].Title)
+			repo.AssertCalled(t, "AddImages", ctx, newID, []int{1, 2})
+			repo.AssertCalled(t, "AddImages", ctx, newID, []int{3})
+
+			if tt.wantRemoved {
+				repo.AssertCalled(t, "RemoveImages", ctx, galleryID, []int{1, 2, 3})
+			} else {
+				repo.AssertNotCalled(t, "RemoveImages", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
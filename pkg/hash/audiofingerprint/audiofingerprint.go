@@ -0,0 +1,216 @@
+// Package audiofingerprint generates and compares chromaprint audio
+// fingerprints using the fpcalc executable.
+package audiofingerprint
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/bits"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	stashExec "github.com/stashapp/stash/pkg/exec"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/sliceutil/intslice"
+)
+
+// fingerprintDuration is the number of seconds of audio that is
+// fingerprinted, from the start of the file.
+const fingerprintDuration = 120
+
+// maxOffset is the maximum number of fingerprint items that fingerprints are
+// shifted by when comparing them. Each item represents roughly 0.12 seconds.
+const maxOffset = 80
+
+// minOverlap is the minimum number of fingerprint items that must overlap
+// for fingerprints to be compared.
+const minOverlap = 50
+
+var ErrUnavailable = errors.New("fpcalc executable not found")
+
+var (
+	fpcalcPath     string
+	fpcalcPathOnce sync.Once
+)
+
+// GetFpcalcPath returns the path to the fpcalc executable. Returns an empty
+// string if it could not be found. The path is only looked up once.
+func GetFpcalcPath() string {
+	fpcalcPathOnce.Do(func() {
+		fpcalcPath, _ = exec.LookPath("fpcalc")
+	})
+	return fpcalcPath
+}
+
+// Generate returns the raw chromaprint fingerprint of the audio of the
+// provided file, as a comma-separated list of integers.
+func Generate(ctx context.Context, videoFile *file.VideoFile) (string, error) {
+	fpcalc := GetFpcalcPath()
+	if fpcalc == "" {
+		return "", ErrUnavailable
+	}
+
+	if videoFile.ZipFileID != nil {
+		return "", errors.New("cannot generate audio fingerprint for file in zip")
+	}
+
+	cmd := stashExec.CommandContext(ctx, fpcalc, "-raw", "-length", strconv.Itoa(fingerprintDuration), videoFile.Path)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("running fpcalc: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	const prefix = "FINGERPRINT="
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		if strings.HasPrefix(line, prefix) {
+			ret := strings.TrimSpace(strings.TrimPrefix(line, prefix))
+			if ret == "" {
+				return "", errors.New("file has no audio")
+			}
+			return ret, nil
+		}
+	}
+
+	return "", errors.New("fingerprint not found in fpcalc output")
+}
+
+// Parse parses a raw fingerprint as returned by Generate.
+func Parse(s string) ([]uint32, error) {
+	fields := strings.Split(s, ",")
+	ret := make([]uint32, len(fields))
+	for i, f := range fields {
+		// fpcalc outputs signed or unsigned values depending on version
+		v, err := strconv.ParseInt(strings.TrimSpace(f), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parsing fingerprint: %w", err)
+		}
+		ret[i] = uint32(v)
+	}
+
+	return ret, nil
+}
+
+// Similarity returns the similarity of the provided fingerprints, between 0
+// and 1, where 1 means identical. The fingerprints are compared at several
+// offsets to allow for differences in the start of the audio, and the best
+// match is returned.
+func Similarity(a, b []uint32) float64 {
+	best := 0.0
+	for offset := -maxOffset; offset <= maxOffset; offset++ {
+		if s := similarityAt(a, b, offset); s > best {
+			best = s
+		}
+	}
+
+	return best
+}
+
+func similarityAt(a, b []uint32, offset int) float64 {
+	if offset < 0 {
+		a, b = b, a
+		offset = -offset
+	}
+
+	if offset >= len(a) {
+		return 0
+	}
+
+	a = a[offset:]
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+
+	if n < minOverlap {
+		return 0
+	}
+
+	errorBits := 0
+	for i := 0; i < n; i++ {
+		errorBits += bits.OnesCount32(a[i] ^ b[i])
+	}
+
+	return 1 - float64(errorBits)/float64(n*32)
+}
+
+// Fingerprint is the audio fingerprint of a scene file.
+type Fingerprint struct {
+	SceneID int
+	// Duration of the file in seconds
+	Duration    float64
+	Fingerprint []uint32
+}
+
+// FindDuplicates returns groups of scene ids whose fingerprints have at
+// least the provided similarity. Only fingerprints of files whose durations
+// differ by at most durationDiff seconds are compared. If durationDiff is
+// negative, all fingerprints are compared.
+func FindDuplicates(fingerprints []Fingerprint, similarity float64, durationDiff float64) [][]int {
+	// union-find over the fingerprint indexes
+	parent := make([]int, len(fingerprints))
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	// sort by duration so that only the files with similar durations
+	// following each file need to be compared
+	byDuration := make([]int, len(fingerprints))
+	for i := range byDuration {
+		byDuration[i] = i
+	}
+	sort.SliceStable(byDuration, func(i, j int) bool {
+		return fingerprints[byDuration[i]].Duration < fingerprints[byDuration[j]].Duration
+	})
+
+	for x, i := range byDuration {
+		for _, j := range byDuration[x+1:] {
+			if durationDiff >= 0 && fingerprints[j].Duration-fingerprints[i].Duration > durationDiff {
+				break
+			}
+
+			if fingerprints[i].SceneID == fingerprints[j].SceneID || find(i) == find(j) {
+				continue
+			}
+
+			if Similarity(fingerprints[i].Fingerprint, fingerprints[j].Fingerprint) >= similarity {
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+
+	groups := make(map[int][]int)
+	var roots []int
+	for i, fp := range fingerprints {
+		root := find(i)
+		if _, found := groups[root]; !found {
+			roots = append(roots, root)
+		}
+		groups[root] = intslice.IntAppendUnique(groups[root], fp.SceneID)
+	}
+
+	var ret [][]int
+	for _, root := range roots {
+		if len(groups[root]) > 1 {
+			ret = append(ret, groups[root])
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,83 @@
+package audiofingerprint
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func randomFingerprint(r *rand.Rand, n int) []uint32 {
+	ret := make([]uint32, n)
+	for i := range ret {
+		ret[i] = r.Uint32()
+	}
+	return ret
+}
+
+func TestParse(t *testing.T) {
+	got, err := Parse("1,-1, 4294967295")
+	assert.Nil(t, err)
+	assert.Equal(t, []uint32{1, 4294967295, 4294967295}, got)
+
+	_, err = Parse("1,a")
+	assert.NotNil(t, err)
+}
+
+func TestSimilarity(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	a := randomFingerprint(r, 500)
+
+	assert.Equal(t, 1.0, Similarity(a, a))
+
+	// shifted audio should still match
+	assert.Equal(t, 1.0, Similarity(a[10:], a))
+	assert.Equal(t, 1.0, Similarity(a, a[10:]))
+
+	// unrelated audio has about half of the bits in common
+	b := randomFingerprint(r, 500)
+	assert.InDelta(t, 0.5, Similarity(a, b), 0.05)
+
+	// not enough overlap
+	assert.Equal(t, 0.0, Similarity(a[:10], a[:10]))
+}
+
+func TestFindDuplicates(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	a := randomFingerprint(r, 500)
+	b := randomFingerprint(r, 500)
+	c := randomFingerprint(r, 500)
+
+	// slightly different version of a
+	a2 := make([]uint32, len(a))
+	copy(a2, a)
+	for i := 0; i < len(a2); i += 10 {
+		a2[i] ^= 0xff
+	}
+
+	fingerprints := []Fingerprint{
+		{SceneID: 1, Duration: 100, Fingerprint: a},
+		{SceneID: 2, Duration: 200, Fingerprint: b},
+		{SceneID: 3, Duration: 105, Fingerprint: a2},
+		{SceneID: 4, Duration: 300, Fingerprint: c},
+		{SceneID: 5, Duration: 199, Fingerprint: b[5:]},
+		// multiple files of the same scene
+		{SceneID: 4, Duration: 300, Fingerprint: c},
+	}
+
+	tests := []struct {
+		name         string
+		durationDiff float64
+		want         [][]int
+	}{
+		{"any duration", -1, [][]int{{1, 3}, {2, 5}}},
+		{"within duration", 5, [][]int{{1, 3}, {2, 5}}},
+		{"outside duration", 2, [][]int{{2, 5}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FindDuplicates(fingerprints, 0.9, tt.durationDiff))
+		})
+	}
+}
@@ -102,23 +102,57 @@ func FindByGalleryID(ctx context.Context, r Queryer, galleryID int, sortBy strin
 	}, &findFilter)
 }
 
-func FindGalleryCover(ctx context.Context, r Queryer, galleryID int) (*models.Image, error) {
-	const useCoverJpg = true
-	img, err := findGalleryCover(ctx, r, galleryID, useCoverJpg)
-	if err != nil {
-		return nil, err
+// FindGalleryCover returns the cover image of the gallery. An image named
+// cover.jpg is always used if present. Otherwise, if minResolution is not
+// nil, then the first image with at least that resolution is used. If no
+// image qualifies, then the first image in the gallery is used.
+func FindGalleryCover(ctx context.Context, r Queryer, galleryID int, minResolution *models.ResolutionEnum) (*models.Image, error) {
+	galleryFilter := func() *models.ImageFilterType {
+		return &models.ImageFilterType{
+			Galleries: &models.MultiCriterionInput{
+				Value:    []string{strconv.Itoa(galleryID)},
+				Modifier: models.CriterionModifierIncludes,
+			},
+		}
+	}
+
+	// try to find cover.jpg in the gallery
+	coverFilter := galleryFilter()
+	coverFilter.Path = &models.StringCriterionInput{
+		Value:    coverFilenameSearchString,
+		Modifier: models.CriterionModifierEquals,
 	}
 
-	if img != nil {
-		return img, nil
+	img, err := findGalleryCover(ctx, r, coverFilter)
+	if err != nil || img != nil {
+		return img, err
+	}
+
+	if minResolution != nil && minResolution.IsValid() {
+		// resolution criteria have no "at least" modifier, so combine
+		// equals and greater than
+		resolutionFilter := galleryFilter()
+		resolutionFilter.Resolution = &models.ResolutionCriterionInput{
+			Value:    *minResolution,
+			Modifier: models.CriterionModifierEquals,
+		}
+		resolutionFilter.Or = galleryFilter()
+		resolutionFilter.Or.Resolution = &models.ResolutionCriterionInput{
+			Value:    *minResolution,
+			Modifier: models.CriterionModifierGreaterThan,
+		}
+
+		img, err := findGalleryCover(ctx, r, resolutionFilter)
+		if err != nil || img != nil {
+			return img, err
+		}
 	}
 
 	// return the first image in the gallery
-	return findGalleryCover(ctx, r, galleryID, !useCoverJpg)
+	return findGalleryCover(ctx, r, galleryFilter())
 }
 
-func findGalleryCover(ctx context.Context, r Queryer, galleryID int, useCoverJpg bool) (*models.Image, error) {
-	// try to find cover.jpg in the gallery
+func findGalleryCover(ctx context.Context, r Queryer, imageFilter *models.ImageFilterType) (*models.Image, error) {
 	perPage := 1
 	sortBy := "path"
 	sortDir := models.SortDirectionEnumAsc
@@ -129,20 +163,6 @@ func findGalleryCover(ctx context.Context, r Queryer, galleryID int, useCoverJpg
 		Direction: &sortDir,
 	}
 
-	imageFilter := &models.ImageFilterType{
-		Galleries: &models.MultiCriterionInput{
-			Value:    []string{strconv.Itoa(galleryID)},
-			Modifier: models.CriterionModifierIncludes,
-		},
-	}
-
-	if useCoverJpg {
-		imageFilter.Path = &models.StringCriterionInput{
-			Value:    coverFilenameSearchString,
-			Modifier: models.CriterionModifierEquals,
-		}
-	}
-
 	imgs, err := Query(ctx, r, imageFilter, &findFilter)
 	if err != nil {
 		return nil, err
@@ -43,6 +43,11 @@ type ScanConfig interface {
 	IsGenerateThumbnails() bool
 }
 
+// FolderGalleryLinker links galleries to the scenes in a folder.
+type FolderGalleryLinker interface {
+	LinkGallery(ctx context.Context, g *models.Gallery, folderID file.FolderID) error
+}
+
 type ScanHandler struct {
 	CreatorUpdater FinderCreatorUpdater
 	GalleryFinder  GalleryFinderCreator
@@ -51,6 +56,10 @@ type ScanHandler struct {
 
 	ScanConfig ScanConfig
 
+	// GalleryLinker is used to link new folder-based galleries to the
+	// scenes in their folder. Optional.
+	GalleryLinker FolderGalleryLinker
+
 	PluginCache *plugin.Cache
 
 	Paths *paths.Paths
@@ -137,7 +146,7 @@ func (h *ScanHandler) Handle(ctx context.Context, f file.File, oldFile file.File
 
 		if oldHash != "" && newHash != "" && oldHash != newHash {
 			// remove cache dir of gallery
-			_ = os.Remove(h.Paths.Generated.GetThumbnailPath(oldHash, models.DefaultGthumbWidth))
+			_ = os.Remove(h.Paths.ForPath(oldFile.Base().Path).Generated.GetThumbnailPath(oldHash, models.DefaultGthumbWidth))
 		}
 	}
 
@@ -230,11 +239,12 @@ func (h *ScanHandler) getOrCreateFolderBasedGallery(ctx context.Context, f file.
 	now := time.Now()
 	newGallery := &models.Gallery{
 		FolderID:  &folderID,
+		Path:      filepath.Dir(f.Base().Path),
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
 
-	logger.Infof("Creating folder-based gallery for %s", filepath.Dir(f.Base().Path))
+	logger.Infof("Creating folder-based gallery for %s", newGallery.Path)
 
 	if err := h.GalleryFinder.Create(ctx, newGallery, nil); err != nil {
 		return nil, fmt.Errorf("creating folder based gallery: %w", err)
@@ -248,6 +258,12 @@ func (h *ScanHandler) getOrCreateFolderBasedGallery(ctx context.Context, f file.
 		return nil, fmt.Errorf("associating existing folder images: %w", err)
 	}
 
+	if h.GalleryLinker != nil {
+		if err := h.GalleryLinker.LinkGallery(ctx, newGallery, folderID); err != nil {
+			return nil, fmt.Errorf("linking folder scenes: %w", err)
+		}
+	}
+
 	return newGallery, nil
 }
 
@@ -62,6 +62,11 @@ func NewThumbnailEncoder(ffmpegEncoder ffmpeg.FFMpeg) ThumbnailEncoder {
 // It returns nil and an error if an error occurs reading, decoding or encoding
 // the image, or if the image is not suitable for thumbnails.
 func (e *ThumbnailEncoder) GetThumbnail(f *file.ImageFile, maxSize int) ([]byte, error) {
+	// don't decode images that were too large when scanned
+	if f.Oversized {
+		return nil, fmt.Errorf("%w: image dimensions %dx%d exceed the maximum", ErrNotSupportedForThumbnail, f.Width, f.Height)
+	}
+
 	reader, err := f.Open(&file.OsFS{})
 	if err != nil {
 		return nil, err
@@ -2,6 +2,7 @@ package match
 
 import (
 	"context"
+	"sync"
 
 	"github.com/stashapp/stash/pkg/models"
 )
@@ -9,10 +10,120 @@ import (
 const singleFirstCharacterRegex = `^[\p{L}][.\-_ ]`
 
 // Cache is used to cache queries that should not change across an autotag process.
+// It is safe for concurrent use.
 type Cache struct {
+	mutex sync.Mutex
+
 	singleCharPerformers []*models.Performer
 	singleCharStudios    []*models.Studio
 	singleCharTags       []*models.Tag
+
+	// prefetched path matches, removed when used
+	pathPerformers map[pathKey][]*models.Performer
+	pathStudios    map[pathKey]*models.Studio
+	pathTags       map[pathKey][]*models.Tag
+}
+
+type pathKey struct {
+	path    string
+	trimExt bool
+}
+
+// PrefetchPath queries the performers, studio and tags matching the provided
+// path and stores them in the cache. The next call to PathToPerformers,
+// PathToStudio or PathToTags for the same path returns the stored result
+// without querying. Nil readers are skipped.
+//
+// This allows the matching queries to be performed concurrently, ahead of
+// the updates that use them.
+func (c *Cache) PrefetchPath(ctx context.Context, path string, trimExt bool, performerReader PerformerAutoTagQueryer, studioReader StudioAutoTagQueryer, tagReader TagAutoTagQueryer) error {
+	key := pathKey{path: path, trimExt: trimExt}
+
+	if performerReader != nil {
+		performers, err := pathToPerformers(ctx, path, performerReader, c, trimExt)
+		if err != nil {
+			return err
+		}
+
+		c.mutex.Lock()
+		if c.pathPerformers == nil {
+			c.pathPerformers = make(map[pathKey][]*models.Performer)
+		}
+		c.pathPerformers[key] = performers
+		c.mutex.Unlock()
+	}
+
+	if studioReader != nil {
+		studio, err := pathToStudio(ctx, path, studioReader, c, trimExt)
+		if err != nil {
+			return err
+		}
+
+		c.mutex.Lock()
+		if c.pathStudios == nil {
+			c.pathStudios = make(map[pathKey]*models.Studio)
+		}
+		c.pathStudios[key] = studio
+		c.mutex.Unlock()
+	}
+
+	if tagReader != nil {
+		tags, err := pathToTags(ctx, path, tagReader, c, trimExt)
+		if err != nil {
+			return err
+		}
+
+		c.mutex.Lock()
+		if c.pathTags == nil {
+			c.pathTags = make(map[pathKey][]*models.Tag)
+		}
+		c.pathTags[key] = tags
+		c.mutex.Unlock()
+	}
+
+	return nil
+}
+
+func (c *Cache) takePathPerformers(path string, trimExt bool) ([]*models.Performer, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	key := pathKey{path: path, trimExt: trimExt}
+	ret, found := c.pathPerformers[key]
+	delete(c.pathPerformers, key)
+	return ret, found
+}
+
+func (c *Cache) takePathStudio(path string, trimExt bool) (*models.Studio, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	key := pathKey{path: path, trimExt: trimExt}
+	ret, found := c.pathStudios[key]
+	delete(c.pathStudios, key)
+	return ret, found
+}
+
+func (c *Cache) takePathTags(path string, trimExt bool) ([]*models.Tag, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	key := pathKey{path: path, trimExt: trimExt}
+	ret, found := c.pathTags[key]
+	delete(c.pathTags, key)
+	return ret, found
 }
 
 // getSingleLetterPerformers returns all performers with names that start with single character words.
@@ -25,6 +136,9 @@ func getSingleLetterPerformers(ctx context.Context, c *Cache, reader PerformerAu
 		c = &Cache{}
 	}
 
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	if c.singleCharPerformers == nil {
 		pp := -1
 		performers, _, err := reader.Query(ctx, &models.PerformerFilterType{
@@ -58,6 +172,9 @@ func getSingleLetterStudios(ctx context.Context, c *Cache, reader StudioAutoTagQ
 		c = &Cache{}
 	}
 
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	if c.singleCharStudios == nil {
 		pp := -1
 		studios, _, err := reader.Query(ctx, &models.StudioFilterType{
@@ -91,6 +208,9 @@ func getSingleLetterTags(ctx context.Context, c *Cache, reader TagAutoTagQueryer
 		c = &Cache{}
 	}
 
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	if c.singleCharTags == nil {
 		pp := -1
 		tags, _, err := reader.Query(ctx, &models.TagFilterType{
@@ -44,6 +44,7 @@ type TagAutoTagQueryer interface {
 	QueryForAutoTag(ctx context.Context, words []string) ([]*models.Tag, error)
 	tag.Queryer
 	GetAliases(ctx context.Context, tagID int) ([]string, error)
+	GetAutoTagKeywords(ctx context.Context, tagID int) ([]string, error)
 }
 
 func getPathQueryRegex(name string) string {
@@ -161,6 +162,14 @@ func getPerformers(ctx context.Context, words []string, performerReader Performe
 }
 
 func PathToPerformers(ctx context.Context, path string, reader PerformerAutoTagQueryer, cache *Cache, trimExt bool) ([]*models.Performer, error) {
+	if ret, found := cache.takePathPerformers(path, trimExt); found {
+		return ret, nil
+	}
+
+	return pathToPerformers(ctx, path, reader, cache, trimExt)
+}
+
+func pathToPerformers(ctx context.Context, path string, reader PerformerAutoTagQueryer, cache *Cache, trimExt bool) ([]*models.Performer, error) {
 	words := getPathWords(path, trimExt)
 
 	performers, err := getPerformers(ctx, words, reader, cache)
@@ -216,6 +225,14 @@ func getStudios(ctx context.Context, words []string, reader StudioAutoTagQueryer
 // Where multiple matching studios are found, the one that matches the latest
 // position in the path is returned.
 func PathToStudio(ctx context.Context, path string, reader StudioAutoTagQueryer, cache *Cache, trimExt bool) (*models.Studio, error) {
+	if ret, found := cache.takePathStudio(path, trimExt); found {
+		return ret, nil
+	}
+
+	return pathToStudio(ctx, path, reader, cache, trimExt)
+}
+
+func pathToStudio(ctx context.Context, path string, reader StudioAutoTagQueryer, cache *Cache, trimExt bool) (*models.Studio, error) {
 	words := getPathWords(path, trimExt)
 	candidates, err := getStudios(ctx, words, reader, cache)
 
@@ -264,6 +281,14 @@ func getTags(ctx context.Context, words []string, reader TagAutoTagQueryer, cach
 }
 
 func PathToTags(ctx context.Context, path string, reader TagAutoTagQueryer, cache *Cache, trimExt bool) ([]*models.Tag, error) {
+	if ret, found := cache.takePathTags(path, trimExt); found {
+		return ret, nil
+	}
+
+	return pathToTags(ctx, path, reader, cache, trimExt)
+}
+
+func pathToTags(ctx context.Context, path string, reader TagAutoTagQueryer, cache *Cache, trimExt bool) ([]*models.Tag, error) {
 	words := getPathWords(path, trimExt)
 	tags, err := getTags(ctx, words, reader, cache)
 
@@ -283,6 +308,11 @@ func PathToTags(ctx context.Context, path string, reader TagAutoTagQueryer, cach
 			if err != nil {
 				return nil, err
 			}
+			keywords, err := reader.GetAutoTagKeywords(ctx, t.ID)
+			if err != nil {
+				return nil, err
+			}
+			aliases = append(aliases, keywords...)
 			for _, alias := range aliases {
 				if nameMatchesPath(alias, path) != -1 {
 					matches = true
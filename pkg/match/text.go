@@ -0,0 +1,108 @@
+package match
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// minTextMatchLength is the minimum number of characters in a performer name
+// or alias for it to be matched against free text. Shorter names produce too
+// many false positives.
+const minTextMatchLength = 3
+
+type textMatchName struct {
+	ascii   *regexp.Regexp
+	unicode *regexp.Regexp
+}
+
+func (n textMatchName) matches(text string, useUnicode bool) bool {
+	re := n.ascii
+	if useUnicode {
+		re = n.unicode
+	}
+	return regexpMatchesPath(re, text) != -1
+}
+
+type textMatchPerformer struct {
+	performer *models.Performer
+	names     []textMatchName
+}
+
+// PerformerTextMatcher matches performer names and aliases against free text,
+// such as scene titles and details. It uses the same normalisation as path
+// matching, so names must appear as whole words, with any separator
+// characters between the words of the name.
+type PerformerTextMatcher struct {
+	performers []textMatchPerformer
+}
+
+// NewPerformerTextMatcher returns a PerformerTextMatcher for the provided
+// performers. Performers set to be ignored by auto tag are excluded, as are
+// names and aliases shorter than three characters. Aliases are loaded using
+// the provided AliasLoader.
+func NewPerformerTextMatcher(ctx context.Context, performers []*models.Performer, aliasLoader models.AliasLoader) (*PerformerTextMatcher, error) {
+	ret := &PerformerTextMatcher{}
+
+	for _, p := range performers {
+		if p.IgnoreAutoTag {
+			continue
+		}
+
+		if err := p.LoadAliases(ctx, aliasLoader); err != nil {
+			return nil, err
+		}
+
+		entry := textMatchPerformer{performer: p}
+		for _, name := range append([]string{p.Name}, p.Aliases.List()...) {
+			name = strings.TrimSpace(name)
+			if utf8.RuneCountInString(name) < minTextMatchLength {
+				continue
+			}
+
+			entry.names = append(entry.names, textMatchName{
+				ascii:   nameToRegexp(name, false),
+				unicode: nameToRegexp(name, true),
+			})
+		}
+
+		if len(entry.names) > 0 {
+			ret.performers = append(ret.performers, entry)
+		}
+	}
+
+	return ret, nil
+}
+
+// Match returns the performers with a name or alias that appears in any of
+// the provided texts.
+func (m *PerformerTextMatcher) Match(texts ...string) []*models.Performer {
+	var ret []*models.Performer
+	for _, p := range m.performers {
+		if p.matchesAny(texts) {
+			ret = append(ret, p.performer)
+		}
+	}
+
+	return ret
+}
+
+func (p textMatchPerformer) matchesAny(texts []string) bool {
+	for _, text := range texts {
+		if text == "" {
+			continue
+		}
+
+		useUnicode := !allASCII(text)
+		for _, n := range p.names {
+			if n.matches(text, useUnicode) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,50 @@
+package match
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPerformerTextMatcher_Match(t *testing.T) {
+	performers := []*models.Performer{
+		{ID: 1, Name: "first last", Aliases: models.NewRelatedStrings([]string{})},
+		{ID: 2, Name: "another", Aliases: models.NewRelatedStrings([]string{"alias name"})},
+		{ID: 3, Name: "ab", Aliases: models.NewRelatedStrings([]string{"x"})},
+		{ID: 4, Name: "ignored", IgnoreAutoTag: true, Aliases: models.NewRelatedStrings([]string{})},
+		{ID: 5, Name: "伏字", Aliases: models.NewRelatedStrings([]string{"伏字伏字"})},
+	}
+
+	m, err := NewPerformerTextMatcher(context.Background(), performers, nil)
+	if err != nil {
+		t.Fatalf("NewPerformerTextMatcher() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		texts []string
+		want  []int
+	}{
+		{"name", []string{"A scene with First Last"}, []int{1}},
+		{"name with separator", []string{"first.last in a scene"}, []int{1}},
+		{"partial word", []string{"firstlast2"}, nil},
+		{"alias", []string{"", "details mention Alias Name here"}, []int{2}},
+		{"multiple", []string{"first last and another"}, []int{1, 2}},
+		{"short name", []string{"ab x"}, nil},
+		{"ignore auto tag", []string{"ignored"}, nil},
+		{"unicode alias", []string{"これは伏字伏字です"}, nil},
+		{"unicode alias separated", []string{"これは 伏字伏字 です"}, []int{5}},
+		{"none", []string{"nothing"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			for _, p := range m.Match(tt.texts...) {
+				got = append(got, p.ID)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
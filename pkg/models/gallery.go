@@ -94,9 +94,12 @@ type GalleryReader interface {
 	GalleryFinder
 	FindByChecksum(ctx context.Context, checksum string) ([]*Gallery, error)
 	FindByChecksums(ctx context.Context, checksums []string) ([]*Gallery, error)
+	FindByFolderID(ctx context.Context, folderID file.FolderID) ([]*Gallery, error)
+	FindByParentFolderID(ctx context.Context, folderID file.FolderID) ([]*Gallery, error)
 	FindByPath(ctx context.Context, path string) ([]*Gallery, error)
 	FindBySceneID(ctx context.Context, sceneID int) ([]*Gallery, error)
 	FindByImageID(ctx context.Context, imageID int) ([]*Gallery, error)
+	FindDuplicates(ctx context.Context, overlap float64) ([]*GalleryDuplicateGroup, error)
 
 	SceneIDLoader
 	PerformerIDLoader
@@ -16,7 +16,18 @@ type GenerateMetadataOptions struct {
 	MarkerScreenshots         *bool                   `json:"markerScreenshots"`
 	Transcodes                *bool                   `json:"transcodes"`
 	Phashes                   *bool                   `json:"phashes"`
+	AudioFingerprints         *bool                   `json:"audioFingerprints"`
 	InteractiveHeatmapsSpeeds *bool                   `json:"interactiveHeatmapsSpeeds"`
+
+	ContactSheets       *bool                        `json:"contactSheets"`
+	ContactSheetOptions *GenerateContactSheetOptions `json:"contactSheetOptions"`
+}
+
+type GenerateContactSheetOptions struct {
+	// Number of rows of images in a contact sheet
+	Rows *int `json:"rows"`
+	// Number of columns of images in a contact sheet
+	Columns *int `json:"columns"`
 }
 
 type GeneratePreviewOptions struct {
@@ -28,6 +28,10 @@ type ImageFilterType struct {
 	OCounter *IntCriterionInput `json:"o_counter"`
 	// Filter by resolution
 	Resolution *ResolutionCriterionInput `json:"resolution"`
+	// Filter by resolution label
+	ResolutionLabel *ResolutionLabelCriterionInput `json:"resolution_label"`
+	// Filter by orientation
+	Orientation *OrientationCriterionInput `json:"orientation"`
 	// Filter to only include images missing this property
 	IsMissing *string `json:"is_missing"`
 	// Filter to only include images with this studio
@@ -88,6 +88,12 @@ type VideoFile struct {
 
 	Interactive      bool `json:"interactive,omitempty"`
 	InteractiveSpeed *int `json:"interactive_speed,omitempty"`
+
+	InteractiveAverageSpeed *int `json:"interactive_average_speed,omitempty"`
+	InteractiveRange        *int `json:"interactive_range,omitempty"`
+	InteractiveDensity      *int `json:"interactive_density,omitempty"`
+
+	Loudness *float64 `json:"loudness,omitempty"`
 }
 
 type ImageFile struct {
@@ -62,7 +62,12 @@ type Scene struct {
 	ResumeTime   float64          `json:"resume_time,omitempty"`
 	PlayCount    int              `json:"play_count,omitempty"`
 	PlayDuration float64          `json:"play_duration,omitempty"`
+	IntroEnd     *float64         `json:"intro_end,omitempty"`
+	OutroStart   *float64         `json:"outro_start,omitempty"`
 	StashIDs     []models.StashID `json:"stash_ids,omitempty"`
+	// Duration is the duration of the primary file in seconds. It is
+	// informational only and is not used when importing.
+	Duration float64 `json:"duration,omitempty"`
 }
 
 func (s Scene) Filename(id int, basename string, hash string) string {
@@ -10,14 +10,27 @@ import (
 )
 
 type Tag struct {
-	Name          string        `json:"name,omitempty"`
-	Description   string        `json:"description,omitempty"`
-	Aliases       []string      `json:"aliases,omitempty"`
-	Image         string        `json:"image,omitempty"`
-	Parents       []string      `json:"parents,omitempty"`
-	IgnoreAutoTag bool          `json:"ignore_auto_tag,omitempty"`
-	CreatedAt     json.JSONTime `json:"created_at,omitempty"`
-	UpdatedAt     json.JSONTime `json:"updated_at,omitempty"`
+	Name          string   `json:"name,omitempty"`
+	Description   string   `json:"description,omitempty"`
+	Aliases       []string `json:"aliases,omitempty"`
+	Image         string   `json:"image,omitempty"`
+	Parents       []string `json:"parents,omitempty"`
+	IgnoreAutoTag bool     `json:"ignore_auto_tag,omitempty"`
+	// AutoTagKeywords are additional keywords matched when auto-tagging
+	AutoTagKeywords []string      `json:"auto_tag_keywords,omitempty"`
+	CreatedAt       json.JSONTime `json:"created_at,omitempty"`
+	UpdatedAt       json.JSONTime `json:"updated_at,omitempty"`
+}
+
+// TagHierarchyNode is a tag and its child tags in a nested export of the tag
+// hierarchy. A tag with more than one parent appears under each of them, but
+// only its first appearance includes its details and children.
+type TagHierarchyNode struct {
+	Name          string              `json:"name"`
+	Description   string              `json:"description,omitempty"`
+	Aliases       []string            `json:"aliases,omitempty"`
+	IgnoreAutoTag bool                `json:"ignore_auto_tag,omitempty"`
+	Children      []*TagHierarchyNode `json:"children,omitempty"`
 }
 
 func (s Tag) Filename() string {
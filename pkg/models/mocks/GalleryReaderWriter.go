@@ -157,6 +157,29 @@ func (_m *GalleryReaderWriter) FindByChecksums(ctx context.Context, checksums []
 	return r0, r1
 }
 
+// FindByFolderID provides a mock function with given fields: ctx, folderID
+func (_m *GalleryReaderWriter) FindByFolderID(ctx context.Context, folderID file.FolderID) ([]*models.Gallery, error) {
+	ret := _m.Called(ctx, folderID)
+
+	var r0 []*models.Gallery
+	if rf, ok := ret.Get(0).(func(context.Context, file.FolderID) []*models.Gallery); ok {
+		r0 = rf(ctx, folderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Gallery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, file.FolderID) error); ok {
+		r1 = rf(ctx, folderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByImageID provides a mock function with given fields: ctx, imageID
 func (_m *GalleryReaderWriter) FindByImageID(ctx context.Context, imageID int) ([]*models.Gallery, error) {
 	ret := _m.Called(ctx, imageID)
@@ -180,6 +203,29 @@ func (_m *GalleryReaderWriter) FindByImageID(ctx context.Context, imageID int) (
 	return r0, r1
 }
 
+// FindByParentFolderID provides a mock function with given fields: ctx, folderID
+func (_m *GalleryReaderWriter) FindByParentFolderID(ctx context.Context, folderID file.FolderID) ([]*models.Gallery, error) {
+	ret := _m.Called(ctx, folderID)
+
+	var r0 []*models.Gallery
+	if rf, ok := ret.Get(0).(func(context.Context, file.FolderID) []*models.Gallery); ok {
+		r0 = rf(ctx, folderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Gallery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, file.FolderID) error); ok {
+		r1 = rf(ctx, folderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByPath provides a mock function with given fields: ctx, path
 func (_m *GalleryReaderWriter) FindByPath(ctx context.Context, path string) ([]*models.Gallery, error) {
 	ret := _m.Called(ctx, path)
@@ -226,6 +272,29 @@ func (_m *GalleryReaderWriter) FindBySceneID(ctx context.Context, sceneID int) (
 	return r0, r1
 }
 
+// FindDuplicates provides a mock function with given fields: ctx, overlap
+func (_m *GalleryReaderWriter) FindDuplicates(ctx context.Context, overlap float64) ([]*models.GalleryDuplicateGroup, error) {
+	ret := _m.Called(ctx, overlap)
+
+	var r0 []*models.GalleryDuplicateGroup
+	if rf, ok := ret.Get(0).(func(context.Context, float64) []*models.GalleryDuplicateGroup); ok {
+		r0 = rf(ctx, overlap)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.GalleryDuplicateGroup)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, float64) error); ok {
+		r1 = rf(ctx, overlap)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindMany provides a mock function with given fields: ctx, ids
 func (_m *GalleryReaderWriter) FindMany(ctx context.Context, ids []int) ([]*models.Gallery, error) {
 	ret := _m.Called(ctx, ids)
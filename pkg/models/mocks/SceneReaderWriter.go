@@ -39,6 +39,27 @@ func (_m *SceneReaderWriter) All(ctx context.Context) ([]*models.Scene, error) {
 	return r0, r1
 }
 
+// BackfillPhashFromOSHash provides a mock function with given fields: ctx
+func (_m *SceneReaderWriter) BackfillPhashFromOSHash(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Count provides a mock function with given fields: ctx
 func (_m *SceneReaderWriter) Count(ctx context.Context) (int, error) {
 	ret := _m.Called(ctx)
@@ -102,6 +123,29 @@ func (_m *SceneReaderWriter) CountByPerformerID(ctx context.Context, performerID
 	return r0, r1
 }
 
+// CountByPerformerIDs provides a mock function with given fields: ctx, performerIDs
+func (_m *SceneReaderWriter) CountByPerformerIDs(ctx context.Context, performerIDs []int) ([]int, error) {
+	ret := _m.Called(ctx, performerIDs)
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []int); ok {
+		r0 = rf(ctx, performerIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, performerIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CountByStudioID provides a mock function with given fields: ctx, studioID
 func (_m *SceneReaderWriter) CountByStudioID(ctx context.Context, studioID int) (int, error) {
 	ret := _m.Called(ctx, studioID)
@@ -123,6 +167,29 @@ func (_m *SceneReaderWriter) CountByStudioID(ctx context.Context, studioID int)
 	return r0, r1
 }
 
+// CountByStudioIDs provides a mock function with given fields: ctx, studioIDs
+func (_m *SceneReaderWriter) CountByStudioIDs(ctx context.Context, studioIDs []int) ([]int, error) {
+	ret := _m.Called(ctx, studioIDs)
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []int); ok {
+		r0 = rf(ctx, studioIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, studioIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CountByTagID provides a mock function with given fields: ctx, tagID
 func (_m *SceneReaderWriter) CountByTagID(ctx context.Context, tagID int) (int, error) {
 	ret := _m.Called(ctx, tagID)
@@ -144,6 +211,29 @@ func (_m *SceneReaderWriter) CountByTagID(ctx context.Context, tagID int) (int,
 	return r0, r1
 }
 
+// CountByTagIDs provides a mock function with given fields: ctx, tagIDs
+func (_m *SceneReaderWriter) CountByTagIDs(ctx context.Context, tagIDs []int) ([]int, error) {
+	ret := _m.Called(ctx, tagIDs)
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []int); ok {
+		r0 = rf(ctx, tagIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, tagIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CountMissingChecksum provides a mock function with given fields: ctx
 func (_m *SceneReaderWriter) CountMissingChecksum(ctx context.Context) (int, error) {
 	ret := _m.Called(ctx)
@@ -385,6 +475,29 @@ func (_m *SceneReaderWriter) FindByOSHash(ctx context.Context, oshash string) ([
 	return r0, r1
 }
 
+// FindByParentFolderID provides a mock function with given fields: ctx, folderID
+func (_m *SceneReaderWriter) FindByParentFolderID(ctx context.Context, folderID file.FolderID) ([]*models.Scene, error) {
+	ret := _m.Called(ctx, folderID)
+
+	var r0 []*models.Scene
+	if rf, ok := ret.Get(0).(func(context.Context, file.FolderID) []*models.Scene); ok {
+		r0 = rf(ctx, folderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Scene)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, file.FolderID) error); ok {
+		r1 = rf(ctx, folderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByPath provides a mock function with given fields: ctx, path
 func (_m *SceneReaderWriter) FindByPath(ctx context.Context, path string) ([]*models.Scene, error) {
 	ret := _m.Called(ctx, path)
@@ -477,6 +590,75 @@ func (_m *SceneReaderWriter) FindMany(ctx context.Context, ids []int) ([]*models
 	return r0, r1
 }
 
+// FindMissingPhashWithOSHashMatch provides a mock function with given fields: ctx
+func (_m *SceneReaderWriter) FindMissingPhashWithOSHashMatch(ctx context.Context) ([]*models.Scene, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*models.Scene
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.Scene); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Scene)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindSharedFiles provides a mock function with given fields: ctx
+func (_m *SceneReaderWriter) FindSharedFiles(ctx context.Context) ([]*models.SceneSharedFile, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*models.SceneSharedFile
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.SceneSharedFile); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SceneSharedFile)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindStudioGroupsByPerformerID provides a mock function with given fields: ctx, performerID
+func (_m *SceneReaderWriter) FindStudioGroupsByPerformerID(ctx context.Context, performerID int) ([]*models.SceneStudioGroup, error) {
+	ret := _m.Called(ctx, performerID)
+
+	var r0 []*models.SceneStudioGroup
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*models.SceneStudioGroup); ok {
+		r0 = rf(ctx, performerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SceneStudioGroup)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, performerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCover provides a mock function with given fields: ctx, sceneID
 func (_m *SceneReaderWriter) GetCover(ctx context.Context, sceneID int) ([]byte, error) {
 	ret := _m.Called(ctx, sceneID)
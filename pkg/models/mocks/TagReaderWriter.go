@@ -37,6 +37,52 @@ func (_m *TagReaderWriter) All(ctx context.Context) ([]*models.Tag, error) {
 	return r0, r1
 }
 
+// AllAliases provides a mock function with given fields: ctx
+func (_m *TagReaderWriter) AllAliases(ctx context.Context) (map[int][]string, error) {
+	ret := _m.Called(ctx)
+
+	var r0 map[int][]string
+	if rf, ok := ret.Get(0).(func(context.Context) map[int][]string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int][]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AllRelationships provides a mock function with given fields: ctx
+func (_m *TagReaderWriter) AllRelationships(ctx context.Context) ([]models.TagRelationship, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []models.TagRelationship
+	if rf, ok := ret.Get(0).(func(context.Context) []models.TagRelationship); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TagRelationship)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Count provides a mock function with given fields: ctx
 func (_m *TagReaderWriter) Count(ctx context.Context) (int, error) {
 	ret := _m.Called(ctx)
@@ -431,6 +477,29 @@ func (_m *TagReaderWriter) GetAliases(ctx context.Context, tagID int) ([]string,
 	return r0, r1
 }
 
+// GetAutoTagKeywords provides a mock function with given fields: ctx, tagID
+func (_m *TagReaderWriter) GetAutoTagKeywords(ctx context.Context, tagID int) ([]string, error) {
+	ret := _m.Called(ctx, tagID)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, int) []string); ok {
+		r0 = rf(ctx, tagID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, tagID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetImage provides a mock function with given fields: ctx, tagID
 func (_m *TagReaderWriter) GetImage(ctx context.Context, tagID int) ([]byte, error) {
 	ret := _m.Called(ctx, tagID)
@@ -468,6 +537,29 @@ func (_m *TagReaderWriter) Merge(ctx context.Context, source []int, destination
 	return r0
 }
 
+// Purge provides a mock function with given fields: ctx, id
+func (_m *TagReaderWriter) Purge(ctx context.Context, id int) (*models.TagPurgeCounts, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.TagPurgeCounts
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.TagPurgeCounts); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TagPurgeCounts)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Query provides a mock function with given fields: ctx, tagFilter, findFilter
 func (_m *TagReaderWriter) Query(ctx context.Context, tagFilter *models.TagFilterType, findFilter *models.FindFilterType) ([]*models.Tag, int, error) {
 	ret := _m.Called(ctx, tagFilter, findFilter)
@@ -558,6 +650,20 @@ func (_m *TagReaderWriter) UpdateAliases(ctx context.Context, tagID int, aliases
 	return r0
 }
 
+// UpdateAutoTagKeywords provides a mock function with given fields: ctx, tagID, keywords
+func (_m *TagReaderWriter) UpdateAutoTagKeywords(ctx context.Context, tagID int, keywords []string) error {
+	ret := _m.Called(ctx, tagID, keywords)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []string) error); ok {
+		r0 = rf(ctx, tagID, keywords)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateChildTags provides a mock function with given fields: ctx, tagID, parentIDs
 func (_m *TagReaderWriter) UpdateChildTags(ctx context.Context, tagID int, parentIDs []int) error {
 	ret := _m.Called(ctx, tagID, parentIDs)
@@ -99,6 +99,14 @@ func (g Gallery) PrimaryChecksum() string {
 	return ""
 }
 
+// GalleryDuplicateGroup is a group of galleries which share images.
+type GalleryDuplicateGroup struct {
+	Galleries []*Gallery `json:"galleries"`
+	// Overlap is the lowest fraction of shared images between the galleries
+	// that formed the group.
+	Overlap float64 `json:"overlap"`
+}
+
 // GalleryPartial represents part of a Gallery object. It is used to update
 // the database entry. Only non-nil fields will be updated.
 type GalleryPartial struct {
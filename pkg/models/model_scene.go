@@ -24,6 +24,8 @@ type Scene struct {
 	Organized bool `json:"organized"`
 	OCounter  int  `json:"o_counter"`
 	StudioID  *int `json:"studio_id"`
+	// Stub is true if the scene was created as a placeholder without a file
+	Stub bool `json:"stub"`
 
 	// transient - not persisted
 	Files         RelatedVideoFiles
@@ -43,6 +45,11 @@ type Scene struct {
 	PlayDuration float64    `json:"play_duration"`
 	PlayCount    int        `json:"play_count"`
 
+	// IntroEnd is the time in seconds where the intro ends, if any
+	IntroEnd *float64 `json:"intro_end"`
+	// OutroStart is the time in seconds where the outro starts, if any
+	OutroStart *float64 `json:"outro_start"`
+
 	GalleryIDs   RelatedIDs      `json:"gallery_ids"`
 	TagIDs       RelatedIDs      `json:"tag_ids"`
 	PerformerIDs RelatedIDs      `json:"performer_ids"`
@@ -151,12 +158,15 @@ type ScenePartial struct {
 	Organized    OptionalBool
 	OCounter     OptionalInt
 	StudioID     OptionalInt
+	Stub         OptionalBool
 	CreatedAt    OptionalTime
 	UpdatedAt    OptionalTime
 	ResumeTime   OptionalFloat64
 	PlayDuration OptionalFloat64
 	PlayCount    OptionalInt
 	LastPlayedAt OptionalTime
+	IntroEnd     OptionalFloat64
+	OutroStart   OptionalFloat64
 
 	GalleryIDs    *UpdateIDs
 	TagIDs        *UpdateIDs
@@ -205,6 +215,8 @@ type SceneUpdateInput struct {
 	PlayDuration  *float64  `json:"play_duration"`
 	PlayCount     *int      `json:"play_count"`
 	PrimaryFileID *string   `json:"primary_file_id"`
+	IntroEnd      *float64  `json:"intro_end"`
+	OutroStart    *float64  `json:"outro_start"`
 }
 
 // UpdateInput constructs a SceneUpdateInput using the populated fields in the ScenePartial object.
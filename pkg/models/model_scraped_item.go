@@ -11,6 +11,8 @@ type ScrapedStudio struct {
 	URL          *string `json:"url"`
 	Image        *string `json:"image"`
 	RemoteSiteID *string `json:"remote_site_id"`
+	// Only set by studio scrapes
+	Parent *ScrapedStudio `json:"parent"`
 }
 
 func (ScrapedStudio) IsScrapedContent() {}
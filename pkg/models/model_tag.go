@@ -23,6 +23,18 @@ type TagPartial struct {
 	UpdatedAt     *SQLiteTimestamp `db:"updated_at" json:"updated_at"`
 }
 
+// TagRelationship is a parent/child relationship between two tags.
+type TagRelationship struct {
+	ParentID int `db:"parent_id" json:"parent_id"`
+	ChildID  int `db:"child_id" json:"child_id"`
+}
+
+// TagCount is a tag and the number of objects it is applied to.
+type TagCount struct {
+	TagID int `db:"tag_id" json:"tag_id"`
+	Count int `db:"count" json:"count"`
+}
+
 type TagPath struct {
 	Tag
 	Path string `db:"path" json:"path"`
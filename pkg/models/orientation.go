@@ -0,0 +1,50 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type OrientationEnum string
+
+const (
+	OrientationEnumPortrait  OrientationEnum = "PORTRAIT"
+	OrientationEnumLandscape OrientationEnum = "LANDSCAPE"
+	OrientationEnumSquare    OrientationEnum = "SQUARE"
+)
+
+var AllOrientationEnum = []OrientationEnum{
+	OrientationEnumPortrait,
+	OrientationEnumLandscape,
+	OrientationEnumSquare,
+}
+
+func (e OrientationEnum) IsValid() bool {
+	switch e {
+	case OrientationEnumPortrait, OrientationEnumLandscape, OrientationEnumSquare:
+		return true
+	}
+	return false
+}
+
+func (e OrientationEnum) String() string {
+	return string(e)
+}
+
+func (e *OrientationEnum) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrientationEnum(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrientationEnum", str)
+	}
+	return nil
+}
+
+func (e OrientationEnum) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
@@ -11,6 +11,15 @@ type Paths struct {
 
 	Scene        *scenePaths
 	SceneMarkers *sceneMarkerPaths
+
+	overrides []generatedOverride
+}
+
+// generatedOverride is the paths used for the generated files of files
+// within dir.
+type generatedOverride struct {
+	dir   string
+	paths *Paths
 }
 
 func NewPaths(generatedPath string) Paths {
@@ -22,6 +31,43 @@ func NewPaths(generatedPath string) Paths {
 	return p
 }
 
+// AddGeneratedOverride sets the generated path to use for the generated
+// files of files within dir. Overrides are not synchronised, so they must be
+// added before p is shared.
+func (p *Paths) AddGeneratedOverride(dir string, generatedPath string) {
+	op := NewPaths(generatedPath)
+	p.overrides = append(p.overrides, generatedOverride{
+		dir:   dir,
+		paths: &op,
+	})
+}
+
+// ForPath returns the paths to use for the generated files of the file with
+// the provided path. The override of the deepest directory containing the
+// path is used. Returns p if no override applies.
+func (p *Paths) ForPath(path string) *Paths {
+	ret := p
+	longest := -1
+	for _, o := range p.overrides {
+		if len(o.dir) > longest && fsutil.IsPathInDir(o.dir, path) {
+			ret = o.paths
+			longest = len(o.dir)
+		}
+	}
+
+	return ret
+}
+
+// All returns p and the paths of each of the overrides.
+func (p *Paths) All() []*Paths {
+	ret := []*Paths{p}
+	for _, o := range p.overrides {
+		ret = append(ret, o.paths)
+	}
+
+	return ret
+}
+
 func GetStashHomeDirectory() string {
 	return filepath.Join(fsutil.GetHomeDirectory(), ".stash")
 }
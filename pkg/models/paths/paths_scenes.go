@@ -54,6 +54,10 @@ func (sp *scenePaths) GetSpriteVttFilePath(checksum string) string {
 	return filepath.Join(sp.Vtt, checksum+"_thumbs.vtt")
 }
 
+func (sp *scenePaths) GetContactSheetPath(checksum string) string {
+	return filepath.Join(sp.Vtt, checksum+"_contact.jpg")
+}
+
 func (sp *scenePaths) GetInteractiveHeatmapPath(checksum string) string {
 	return filepath.Join(sp.InteractiveHeatmap, checksum+".png")
 }
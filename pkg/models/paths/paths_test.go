@@ -0,0 +1,65 @@
+package paths
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathsForPath(t *testing.T) {
+	var (
+		root      = filepath.Join(string(filepath.Separator), "a")
+		dirB      = filepath.Join(root, "b")
+		dirBC     = filepath.Join(root, "bc")
+		dirBNest  = filepath.Join(dirB, "nested")
+		generated = filepath.Join(string(filepath.Separator), "generated")
+		genB      = filepath.Join(string(filepath.Separator), "generated_b")
+		genNested = filepath.Join(string(filepath.Separator), "generated_nested")
+	)
+
+	p := NewPaths(generated)
+	p.AddGeneratedOverride(dirB, genB)
+	p.AddGeneratedOverride(dirBNest, genNested)
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"no override", filepath.Join(root, "file.mp4"), generated},
+		{"in override dir", filepath.Join(dirB, "file.mp4"), genB},
+		{"in override subdir", filepath.Join(dirB, "sub", "file.mp4"), genB},
+		{"sibling with common prefix", filepath.Join(dirBC, "file.mp4"), generated},
+		{"deepest override", filepath.Join(dirBNest, "file.mp4"), genNested},
+		{"override dir itself", dirB, genB},
+		{"outside library", filepath.Join(string(filepath.Separator), "other", "file.mp4"), generated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := p.ForPath(tt.path)
+			assert.Equal(t, filepath.Join(tt.want, "screenshots"), got.Generated.Screenshots)
+			assert.Equal(t, got.Generated.Screenshots, filepath.Dir(got.Scene.GetScreenshotPath("hash")))
+		})
+	}
+}
+
+func TestPathsAddGeneratedOverride(t *testing.T) {
+	generated := filepath.Join(string(filepath.Separator), "generated")
+	p := NewPaths(generated)
+
+	// order of overrides does not matter
+	nested := filepath.Join(string(filepath.Separator), "a", "b")
+	p.AddGeneratedOverride(nested, "nested")
+	p.AddGeneratedOverride(filepath.Dir(nested), "parent")
+
+	assert.Equal(t, filepath.Join("nested", "thumbnails"), p.ForPath(filepath.Join(nested, "file.jpg")).Generated.Thumbnails)
+
+	all := p.All()
+	if assert.Len(t, all, 3) {
+		assert.Same(t, &p, all[0])
+		assert.Equal(t, filepath.Join("nested", "markers"), all[1].Generated.Markers)
+		assert.Equal(t, filepath.Join("parent", "markers"), all[2].Generated.Markers)
+	}
+}
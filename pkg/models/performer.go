@@ -56,6 +56,53 @@ func (e GenderEnum) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+// PerformerCoverageGapEnum is an asymmetry between the scenes and galleries
+// that a performer appears in.
+type PerformerCoverageGapEnum string
+
+const (
+	// PerformerCoverageGapEnumGalleriesWithoutScenes is for performers that
+	// appear in galleries but not in any scene.
+	PerformerCoverageGapEnumGalleriesWithoutScenes PerformerCoverageGapEnum = "GALLERIES_WITHOUT_SCENES"
+	// PerformerCoverageGapEnumScenesWithoutGalleries is for performers that
+	// appear in scenes but not in any gallery.
+	PerformerCoverageGapEnumScenesWithoutGalleries PerformerCoverageGapEnum = "SCENES_WITHOUT_GALLERIES"
+)
+
+var AllPerformerCoverageGapEnum = []PerformerCoverageGapEnum{
+	PerformerCoverageGapEnumGalleriesWithoutScenes,
+	PerformerCoverageGapEnumScenesWithoutGalleries,
+}
+
+func (e PerformerCoverageGapEnum) IsValid() bool {
+	switch e {
+	case PerformerCoverageGapEnumGalleriesWithoutScenes, PerformerCoverageGapEnumScenesWithoutGalleries:
+		return true
+	}
+	return false
+}
+
+func (e PerformerCoverageGapEnum) String() string {
+	return string(e)
+}
+
+func (e *PerformerCoverageGapEnum) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PerformerCoverageGapEnum(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PerformerCoverageGapEnum", str)
+	}
+	return nil
+}
+
+func (e PerformerCoverageGapEnum) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type GenderCriterionInput struct {
 	Value    *GenderEnum       `json:"value"`
 	Modifier CriterionModifier `json:"modifier"`
@@ -134,12 +181,24 @@ type PerformerFilterType struct {
 	Birthdate *DateCriterionInput `json:"birth_date"`
 	// Filter by death date
 	DeathDate *DateCriterionInput `json:"death_date"`
+	// Filter by active status
+	Active *bool `json:"active"`
+	// Filter by performers appearing in galleries but not scenes, or vice versa
+	CoverageGap *PerformerCoverageGapEnum `json:"coverage_gap"`
 	// Filter by created at
 	CreatedAt *TimestampCriterionInput `json:"created_at"`
 	// Filter by updated at
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
 }
 
+// PerformerDisambiguationChange is a change to the disambiguation of a
+// performer.
+type PerformerDisambiguationChange struct {
+	Performer         *Performer `json:"performer"`
+	OldDisambiguation *string    `json:"old_disambiguation"`
+	NewDisambiguation *string    `json:"new_disambiguation"`
+}
+
 type PerformerFinder interface {
 	FindMany(ctx context.Context, ids []int) ([]*Performer, error)
 }
@@ -156,12 +215,16 @@ type PerformerReader interface {
 	CountByTagID(ctx context.Context, tagID int) (int, error)
 	Count(ctx context.Context) (int, error)
 	All(ctx context.Context) ([]*Performer, error)
+	// TagProfile returns the tags of the scenes of the performer, ordered
+	// by the number of scenes they are applied to.
+	TagProfile(ctx context.Context, performerID int, limit int) ([]*TagCount, error)
 	// TODO - this interface is temporary until the filter schema can fully
 	// support the query needed
 	QueryForAutoTag(ctx context.Context, words []string) ([]*Performer, error)
 	Query(ctx context.Context, performerFilter *PerformerFilterType, findFilter *FindFilterType) ([]*Performer, int, error)
 	AliasLoader
 	GetImage(ctx context.Context, performerID int) ([]byte, error)
+	HasImage(ctx context.Context, performerID int) (bool, error)
 	StashIDLoader
 	TagIDLoader
 }
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// PrimaryFilePolicy determines which file is used as the primary file of a
+// scene with multiple files.
+type PrimaryFilePolicy string
+
+const (
+	// PrimaryFilePolicyExisting keeps the existing primary file. If it is
+	// removed, then the first remaining file is used.
+	PrimaryFilePolicyExisting PrimaryFilePolicy = "EXISTING"
+	// PrimaryFilePolicyHighestResolution uses the file with the highest
+	// resolution.
+	PrimaryFilePolicyHighestResolution PrimaryFilePolicy = "HIGHEST_RESOLUTION"
+	// PrimaryFilePolicyLargestSize uses the largest file.
+	PrimaryFilePolicyLargestSize PrimaryFilePolicy = "LARGEST_SIZE"
+	// PrimaryFilePolicyPathPriority uses the file in the earliest of a list
+	// of paths.
+	PrimaryFilePolicyPathPriority PrimaryFilePolicy = "PATH_PRIORITY"
+	// PrimaryFilePolicyNewest uses the file with the latest modification
+	// time.
+	PrimaryFilePolicyNewest PrimaryFilePolicy = "NEWEST"
+)
+
+var AllPrimaryFilePolicy = []PrimaryFilePolicy{
+	PrimaryFilePolicyExisting,
+	PrimaryFilePolicyHighestResolution,
+	PrimaryFilePolicyLargestSize,
+	PrimaryFilePolicyPathPriority,
+	PrimaryFilePolicyNewest,
+}
+
+func (e PrimaryFilePolicy) IsValid() bool {
+	switch e {
+	case PrimaryFilePolicyExisting, PrimaryFilePolicyHighestResolution, PrimaryFilePolicyLargestSize, PrimaryFilePolicyPathPriority, PrimaryFilePolicyNewest:
+		return true
+	}
+	return false
+}
+
+func (e PrimaryFilePolicy) String() string {
+	return string(e)
+}
+
+func (e *PrimaryFilePolicy) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PrimaryFilePolicy(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PrimaryFilePolicy", str)
+	}
+	return nil
+}
+
+func (e PrimaryFilePolicy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
@@ -0,0 +1,132 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type ResolutionLabelEnum string
+
+const (
+	ResolutionLabelEnumSd     ResolutionLabelEnum = "SD"
+	ResolutionLabelEnumHd     ResolutionLabelEnum = "HD"
+	ResolutionLabelEnumFullHd ResolutionLabelEnum = "FULL_HD"
+	ResolutionLabelEnumFourK  ResolutionLabelEnum = "FOUR_K"
+	ResolutionLabelEnumEightK ResolutionLabelEnum = "EIGHT_K"
+)
+
+// AllResolutionLabelEnum is the list of resolution labels, from lowest to
+// highest resolution.
+var AllResolutionLabelEnum = []ResolutionLabelEnum{
+	ResolutionLabelEnumSd,
+	ResolutionLabelEnumHd,
+	ResolutionLabelEnumFullHd,
+	ResolutionLabelEnumFourK,
+	ResolutionLabelEnumEightK,
+}
+
+// DefaultResolutionLabelBreakpoints are the default minimum sizes of the
+// shorter side of HD, full HD, 4K and 8K media.
+var DefaultResolutionLabelBreakpoints = []int{720, 1080, 2160, 4320}
+
+func (e ResolutionLabelEnum) IsValid() bool {
+	switch e {
+	case ResolutionLabelEnumSd, ResolutionLabelEnumHd, ResolutionLabelEnumFullHd, ResolutionLabelEnumFourK, ResolutionLabelEnumEightK:
+		return true
+	}
+	return false
+}
+
+func (e ResolutionLabelEnum) String() string {
+	return string(e)
+}
+
+func (e *ResolutionLabelEnum) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ResolutionLabelEnum(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ResolutionLabelEnum", str)
+	}
+	return nil
+}
+
+func (e ResolutionLabelEnum) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// ValidateResolutionLabelBreakpoints returns an error if breakpoints does not
+// contain a minimum size for each label above SD in increasing order.
+func ValidateResolutionLabelBreakpoints(breakpoints []int) error {
+	if len(breakpoints) != len(AllResolutionLabelEnum)-1 {
+		return fmt.Errorf("expected %d resolution label breakpoints, got %d", len(AllResolutionLabelEnum)-1, len(breakpoints))
+	}
+
+	last := 0
+	for _, b := range breakpoints {
+		if b <= last {
+			return errors.New("resolution label breakpoints must be positive and increasing")
+		}
+		last = b
+	}
+
+	return nil
+}
+
+// labelIndex returns the index of e in AllResolutionLabelEnum.
+func (e ResolutionLabelEnum) labelIndex() int {
+	for i, v := range AllResolutionLabelEnum {
+		if v == e {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// GetMinResolution returns the minimum size of the shorter side of media with
+// this label, using the provided breakpoints.
+func (e ResolutionLabelEnum) GetMinResolution(breakpoints []int) int {
+	i := e.labelIndex()
+	if i <= 0 {
+		return 0
+	}
+
+	return breakpoints[i-1]
+}
+
+// GetMaxResolution returns the maximum size of the shorter side of media with
+// this label, using the provided breakpoints. Returns -1 for the highest
+// label, which is unbounded.
+func (e ResolutionLabelEnum) GetMaxResolution(breakpoints []int) int {
+	i := e.labelIndex()
+	if i < 0 || i >= len(breakpoints) {
+		return -1
+	}
+
+	return breakpoints[i] - 1
+}
+
+// GetResolutionLabel returns the resolution label of media with the provided
+// dimensions, using the provided breakpoints.
+func GetResolutionLabel(width, height int, breakpoints []int) ResolutionLabelEnum {
+	size := width
+	if height < size {
+		size = height
+	}
+
+	ret := AllResolutionLabelEnum[0]
+	for i, b := range breakpoints {
+		if size < b {
+			break
+		}
+		ret = AllResolutionLabelEnum[i+1]
+	}
+
+	return ret
+}
@@ -0,0 +1,65 @@
+package models
+
+import "testing"
+
+func TestGetResolutionLabel(t *testing.T) {
+	breakpoints := DefaultResolutionLabelBreakpoints
+
+	tests := []struct {
+		name   string
+		width  int
+		height int
+		want   ResolutionLabelEnum
+	}{
+		{"480p", 854, 480, ResolutionLabelEnumSd},
+		{"720p", 1280, 720, ResolutionLabelEnumHd},
+		{"portrait 1080p", 1080, 1920, ResolutionLabelEnumFullHd},
+		{"4k", 3840, 2160, ResolutionLabelEnumFourK},
+		{"8k", 7680, 4320, ResolutionLabelEnumEightK},
+		{"unknown", 0, 0, ResolutionLabelEnumSd},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetResolutionLabel(tt.width, tt.height, breakpoints); got != tt.want {
+				t.Errorf("GetResolutionLabel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolutionLabelRangesMatchLabel(t *testing.T) {
+	breakpoints := []int{700, 1000, 2000, 4000}
+
+	for _, label := range AllResolutionLabelEnum {
+		min := label.GetMinResolution(breakpoints)
+		if got := GetResolutionLabel(min, min, breakpoints); got != label {
+			t.Errorf("GetResolutionLabel(%d) = %v, want %v", min, got, label)
+		}
+
+		if max := label.GetMaxResolution(breakpoints); max >= 0 {
+			if got := GetResolutionLabel(max, max, breakpoints); got != label {
+				t.Errorf("GetResolutionLabel(%d) = %v, want %v", max, got, label)
+			}
+		}
+	}
+}
+
+func TestValidateResolutionLabelBreakpoints(t *testing.T) {
+	tests := []struct {
+		name        string
+		breakpoints []int
+		wantErr     bool
+	}{
+		{"default", DefaultResolutionLabelBreakpoints, false},
+		{"too few", []int{720, 1080, 2160}, true},
+		{"not increasing", []int{720, 720, 2160, 4320}, true},
+		{"not positive", []int{0, 1080, 2160, 4320}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateResolutionLabelBreakpoints(tt.breakpoints); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateResolutionLabelBreakpoints() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
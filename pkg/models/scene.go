@@ -29,6 +29,8 @@ type SceneFilterType struct {
 	Phash *StringCriterionInput `json:"phash"`
 	// Filter by path
 	Path *StringCriterionInput `json:"path"`
+	// Filter by directory of the scene files
+	Directory *DirectoryCriterionInput `json:"directory"`
 	// Filter by file count
 	FileCount *IntCriterionInput `json:"file_count"`
 	// Filter by rating expressed as 1-5
@@ -43,8 +45,16 @@ type SceneFilterType struct {
 	Duplicated *PHashDuplicationCriterionInput `json:"duplicated"`
 	// Filter by resolution
 	Resolution *ResolutionCriterionInput `json:"resolution"`
+	// Filter by resolution label
+	ResolutionLabel *ResolutionLabelCriterionInput `json:"resolution_label"`
+	// Filter by orientation
+	Orientation *OrientationCriterionInput `json:"orientation"`
 	// Filter by duration (in seconds)
 	Duration *IntCriterionInput `json:"duration"`
+	// Filter by bitrate divided by pixels per second
+	BitratePerPixel *FloatCriterionInput `json:"bitrate_per_pixel"`
+	// Filter by integrated audio loudness (in LUFS)
+	Loudness *FloatCriterionInput `json:"loudness"`
 	// Filter to only include scenes which have markers. `true` or `false`
 	HasMarkers *string `json:"has_markers"`
 	// Filter to only include scenes missing this property
@@ -61,12 +71,17 @@ type SceneFilterType struct {
 	PerformerTags *HierarchicalMultiCriterionInput `json:"performer_tags"`
 	// Filter scenes that have performers that have been favorited
 	PerformerFavorite *bool `json:"performer_favorite"`
-	// Filter scenes by performer age at time of scene
+	// Filter scenes by performer age at the scene date. Scenes without a date
+	// and performers without a birthdate are excluded.
 	PerformerAge *IntCriterionInput `json:"performer_age"`
 	// Filter to only include scenes with these performers
 	Performers *MultiCriterionInput `json:"performers"`
 	// Filter by performer count
 	PerformerCount *IntCriterionInput `json:"performer_count"`
+	// Filter by scene marker count
+	MarkerCount *IntCriterionInput `json:"marker_count"`
+	// Filter by stub
+	Stub *bool `json:"stub"`
 	// Filter by StashID
 	StashID *StringCriterionInput `json:"stash_id"`
 	// Filter by StashID Endpoint
@@ -77,6 +92,12 @@ type SceneFilterType struct {
 	Interactive *bool `json:"interactive"`
 	// Filter by InteractiveSpeed
 	InteractiveSpeed *IntCriterionInput `json:"interactive_speed"`
+	// Filter by average funscript speed
+	InteractiveAverageSpeed *IntCriterionInput `json:"interactive_average_speed"`
+	// Filter by funscript stroke range
+	InteractiveRange *IntCriterionInput `json:"interactive_range"`
+	// Filter by funscript actions per minute
+	InteractiveDensity *IntCriterionInput `json:"interactive_density"`
 	// Filter by captions
 	Captions *StringCriterionInput `json:"captions"`
 	// Filter by resume time
@@ -111,6 +132,39 @@ type SceneQueryResult struct {
 	resolveErr error
 }
 
+// SceneMarkerCoverageOptions are the options for finding the scenes where
+// markers cover at most MaxCoverage of the duration.
+type SceneMarkerCoverageOptions struct {
+	SceneFilter *SceneFilterType
+	FindFilter  *FindFilterType
+
+	// MaxCoverage is the maximum fraction of the duration covered by markers.
+	MaxCoverage float64
+	// MaxMarkerLength is the maximum number of seconds covered by each
+	// marker. Each marker covers until the next marker if not positive.
+	MaxMarkerLength float64
+}
+
+// SceneMarkerCoverage is the fraction of the duration of a scene's primary
+// file covered by its markers. Each marker covers until the start of the
+// next marker, or until the end of the scene for the last marker.
+type SceneMarkerCoverage struct {
+	SceneID     int
+	Coverage    float64
+	MarkerCount int
+}
+
+// SceneDirectory is a directory containing scene files, along with the
+// number of scenes in it.
+type SceneDirectory struct {
+	Path       string  `json:"path"`
+	ParentPath *string `json:"parent_path"`
+	// Number of scenes with a file directly in the directory
+	SceneCount int `json:"scene_count"`
+	// Number of scenes with a file in the directory or any subdirectory
+	TotalSceneCount int `json:"total_scene_count"`
+}
+
 type SceneDestroyInput struct {
 	ID              string `json:"id"`
 	DeleteFile      *bool  `json:"delete_file"`
@@ -137,6 +191,19 @@ func (r *SceneQueryResult) Resolve(ctx context.Context) ([]*Scene, error) {
 	return r.scenes, r.resolveErr
 }
 
+// SceneStudioGroup is a group of scenes with the same studio.
+type SceneStudioGroup struct {
+	// StudioID is nil for scenes without a studio
+	StudioID *int
+	SceneIDs []int
+}
+
+// SceneSharedFile is a file that is linked to more than one scene.
+type SceneSharedFile struct {
+	FileID   file.ID
+	SceneIDs []int
+}
+
 type SceneFinder interface {
 	// TODO - rename this to Find and remove existing method
 	FindMany(ctx context.Context, ids []int) ([]*Scene, error)
@@ -148,10 +215,17 @@ type SceneReader interface {
 	Find(ctx context.Context, id int) (*Scene, error)
 	FindByChecksum(ctx context.Context, checksum string) ([]*Scene, error)
 	FindByOSHash(ctx context.Context, oshash string) ([]*Scene, error)
+	FindByParentFolderID(ctx context.Context, folderID file.FolderID) ([]*Scene, error)
 	FindByPath(ctx context.Context, path string) ([]*Scene, error)
 	FindByPerformerID(ctx context.Context, performerID int) ([]*Scene, error)
 	FindByGalleryID(ctx context.Context, performerID int) ([]*Scene, error)
 	FindDuplicates(ctx context.Context, distance int) ([][]*Scene, error)
+	// FindSharedFiles returns the files that are linked to more than one
+	// scene, in order of file ID.
+	FindSharedFiles(ctx context.Context) ([]*SceneSharedFile, error)
+	// FindMissingPhashWithOSHashMatch returns the scenes with a file without
+	// a phash, where another scene file with the same oshash has a phash.
+	FindMissingPhashWithOSHashMatch(ctx context.Context) ([]*Scene, error)
 
 	GalleryIDLoader
 	PerformerIDLoader
@@ -161,6 +235,12 @@ type SceneReader interface {
 	VideoFileLoader
 
 	CountByPerformerID(ctx context.Context, performerID int) (int, error)
+	// CountByPerformerIDs returns the number of scenes of each performer,
+	// in the same order as performerIDs.
+	CountByPerformerIDs(ctx context.Context, performerIDs []int) ([]int, error)
+	// FindStudioGroupsByPerformerID returns the scenes of the performer,
+	// grouped by studio, in descending order of the number of scenes.
+	FindStudioGroupsByPerformerID(ctx context.Context, performerID int) ([]*SceneStudioGroup, error)
 	// FindByStudioID(studioID int) ([]*Scene, error)
 	FindByMovieID(ctx context.Context, movieID int) ([]*Scene, error)
 	CountByMovieID(ctx context.Context, movieID int) (int, error)
@@ -169,7 +249,13 @@ type SceneReader interface {
 	Duration(ctx context.Context) (float64, error)
 	// SizeCount() (string, error)
 	CountByStudioID(ctx context.Context, studioID int) (int, error)
+	// CountByStudioIDs returns the number of scenes of each studio, in the
+	// same order as studioIDs.
+	CountByStudioIDs(ctx context.Context, studioIDs []int) ([]int, error)
 	CountByTagID(ctx context.Context, tagID int) (int, error)
+	// CountByTagIDs returns the number of scenes with each tag, in the same
+	// order as tagIDs.
+	CountByTagIDs(ctx context.Context, tagIDs []int) ([]int, error)
 	CountMissingChecksum(ctx context.Context) (int, error)
 	CountMissingOSHash(ctx context.Context) (int, error)
 	Wall(ctx context.Context, q *string) ([]*Scene, error)
@@ -190,6 +276,10 @@ type SceneWriter interface {
 	Destroy(ctx context.Context, id int) error
 	UpdateCover(ctx context.Context, sceneID int, cover []byte) error
 	DestroyCover(ctx context.Context, sceneID int) error
+	// BackfillPhashFromOSHash sets the phash of scene files without one from
+	// another scene file with the same oshash. Returns the number of files
+	// updated.
+	BackfillPhashFromOSHash(ctx context.Context) (int, error)
 }
 
 type SceneReaderWriter interface {
@@ -0,0 +1,140 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// SceneMergeField is a scene field which can be merged using a merge rule.
+type SceneMergeField string
+
+const (
+	SceneMergeFieldTitle       SceneMergeField = "TITLE"
+	SceneMergeFieldCode        SceneMergeField = "CODE"
+	SceneMergeFieldDetails     SceneMergeField = "DETAILS"
+	SceneMergeFieldDirector    SceneMergeField = "DIRECTOR"
+	SceneMergeFieldURL         SceneMergeField = "URL"
+	SceneMergeFieldDate        SceneMergeField = "DATE"
+	SceneMergeFieldRating      SceneMergeField = "RATING"
+	SceneMergeFieldOrganized   SceneMergeField = "ORGANIZED"
+	SceneMergeFieldOCounter    SceneMergeField = "O_COUNTER"
+	SceneMergeFieldPlayCount   SceneMergeField = "PLAY_COUNT"
+	SceneMergeFieldStudio      SceneMergeField = "STUDIO"
+	SceneMergeFieldTags        SceneMergeField = "TAGS"
+	SceneMergeFieldPerformers  SceneMergeField = "PERFORMERS"
+	SceneMergeFieldGalleries   SceneMergeField = "GALLERIES"
+	SceneMergeFieldMovies      SceneMergeField = "MOVIES"
+	SceneMergeFieldStashIDs    SceneMergeField = "STASH_IDS"
+	SceneMergeFieldPrimaryFile SceneMergeField = "PRIMARY_FILE"
+)
+
+var AllSceneMergeField = []SceneMergeField{
+	SceneMergeFieldTitle,
+	SceneMergeFieldCode,
+	SceneMergeFieldDetails,
+	SceneMergeFieldDirector,
+	SceneMergeFieldURL,
+	SceneMergeFieldDate,
+	SceneMergeFieldRating,
+	SceneMergeFieldOrganized,
+	SceneMergeFieldOCounter,
+	SceneMergeFieldPlayCount,
+	SceneMergeFieldStudio,
+	SceneMergeFieldTags,
+	SceneMergeFieldPerformers,
+	SceneMergeFieldGalleries,
+	SceneMergeFieldMovies,
+	SceneMergeFieldStashIDs,
+	SceneMergeFieldPrimaryFile,
+}
+
+func (e SceneMergeField) IsValid() bool {
+	for _, v := range AllSceneMergeField {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (e SceneMergeField) String() string {
+	return string(e)
+}
+
+func (e *SceneMergeField) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SceneMergeField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SceneMergeField", str)
+	}
+	return nil
+}
+
+func (e SceneMergeField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// SceneMergeMode determines how the values of a field are merged.
+type SceneMergeMode string
+
+const (
+	// SceneMergeModeKeepLongest keeps the longest value.
+	SceneMergeModeKeepLongest SceneMergeMode = "KEEP_LONGEST"
+	// SceneMergeModeKeepMax keeps the highest value.
+	SceneMergeModeKeepMax SceneMergeMode = "KEEP_MAX"
+	// SceneMergeModeUnion combines the values of all scenes.
+	SceneMergeModeUnion SceneMergeMode = "UNION"
+	// SceneMergeModePreferSource keeps the first value that is set, in the
+	// order of the merged scenes.
+	SceneMergeModePreferSource SceneMergeMode = "PREFER_SOURCE"
+)
+
+var AllSceneMergeMode = []SceneMergeMode{
+	SceneMergeModeKeepLongest,
+	SceneMergeModeKeepMax,
+	SceneMergeModeUnion,
+	SceneMergeModePreferSource,
+}
+
+func (e SceneMergeMode) IsValid() bool {
+	switch e {
+	case SceneMergeModeKeepLongest, SceneMergeModeKeepMax, SceneMergeModeUnion, SceneMergeModePreferSource:
+		return true
+	}
+	return false
+}
+
+func (e SceneMergeMode) String() string {
+	return string(e)
+}
+
+func (e *SceneMergeMode) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SceneMergeMode(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SceneMergeMode", str)
+	}
+	return nil
+}
+
+func (e SceneMergeMode) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// SceneMergeRule sets the merge mode of a field.
+type SceneMergeRule struct {
+	Field SceneMergeField `json:"field"`
+	Mode  SceneMergeMode  `json:"mode"`
+	// Separator used to join the details of the scenes in UNION mode.
+	// Only valid for the details field.
+	Separator *string `json:"separator"`
+}
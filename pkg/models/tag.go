@@ -64,8 +64,11 @@ type TagReader interface {
 	Query(ctx context.Context, tagFilter *TagFilterType, findFilter *FindFilterType) ([]*Tag, int, error)
 	GetImage(ctx context.Context, tagID int) ([]byte, error)
 	GetAliases(ctx context.Context, tagID int) ([]string, error)
+	AllAliases(ctx context.Context) (map[int][]string, error)
+	GetAutoTagKeywords(ctx context.Context, tagID int) ([]string, error)
 	FindAllAncestors(ctx context.Context, tagID int, excludeIDs []int) ([]*TagPath, error)
 	FindAllDescendants(ctx context.Context, tagID int, excludeIDs []int) ([]*TagPath, error)
+	AllRelationships(ctx context.Context) ([]TagRelationship, error)
 }
 
 type TagWriter interface {
@@ -76,6 +79,8 @@ type TagWriter interface {
 	UpdateImage(ctx context.Context, tagID int, image []byte) error
 	DestroyImage(ctx context.Context, tagID int) error
 	UpdateAliases(ctx context.Context, tagID int, aliases []string) error
+	UpdateAutoTagKeywords(ctx context.Context, tagID int, keywords []string) error
+	Purge(ctx context.Context, id int) (*TagPurgeCounts, error)
 	Merge(ctx context.Context, source []int, destination int) error
 	UpdateParentTags(ctx context.Context, tagID int, parentIDs []int) error
 	UpdateChildTags(ctx context.Context, tagID int, parentIDs []int) error
@@ -85,3 +90,21 @@ type TagReaderWriter interface {
 	TagReader
 	TagWriter
 }
+
+// TagHierarchyImportCounts is the number of tags that were created, and the
+// number of existing tags that were matched by name, when importing a tag
+// hierarchy.
+type TagHierarchyImportCounts struct {
+	Created int `json:"created"`
+	Matched int `json:"matched"`
+}
+
+// TagPurgeCounts is the number of objects that a tag was removed from when
+// it was purged.
+type TagPurgeCounts struct {
+	Scenes       int `json:"scenes"`
+	Images       int `json:"images"`
+	Galleries    int `json:"galleries"`
+	Performers   int `json:"performers"`
+	SceneMarkers int `json:"scene_markers"`
+}
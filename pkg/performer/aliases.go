@@ -0,0 +1,84 @@
+package performer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+type AliasNormalizer interface {
+	All(ctx context.Context) ([]*models.Performer, error)
+	models.AliasLoader
+	UpdatePartial(ctx context.Context, id int, updatedPerformer models.PerformerPartial) (*models.Performer, error)
+}
+
+// NormalizeAliases returns the aliases with whitespace trimmed and collapsed
+// to single spaces. Empty aliases, aliases equal to the name and duplicate
+// aliases are removed, ignoring case. The first of the duplicate aliases is
+// kept.
+func NormalizeAliases(name string, aliases []string) []string {
+	var ret []string
+	for _, a := range aliases {
+		a = strings.Join(strings.Fields(a), " ")
+		if a == "" || strings.EqualFold(a, name) {
+			continue
+		}
+
+		ret = append(ret, a)
+	}
+
+	return stringslice.StrUniqueFold(ret)
+}
+
+// NormalizeAllAliases normalizes the aliases of all performers using
+// NormalizeAliases. Returns the performers whose aliases were changed.
+func NormalizeAllAliases(ctx context.Context, qb AliasNormalizer) ([]*models.Performer, error) {
+	performers, err := qb.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*models.Performer
+	for _, p := range performers {
+		if err := p.LoadAliases(ctx, qb); err != nil {
+			return nil, err
+		}
+
+		aliases := p.Aliases.List()
+		normalized := NormalizeAliases(p.Name, aliases)
+		if aliasesEqual(aliases, normalized) {
+			continue
+		}
+
+		partial := models.NewPerformerPartial()
+		partial.Aliases = &models.UpdateStrings{
+			Values: normalized,
+			Mode:   models.RelationshipUpdateModeSet,
+		}
+
+		updated, err := qb.UpdatePartial(ctx, p.ID, partial)
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, updated)
+	}
+
+	return ret, nil
+}
+
+func aliasesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,28 @@
+package performer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeAliases(t *testing.T) {
+	tests := []struct {
+		name    string
+		aliases []string
+		want    []string
+	}{
+		{"none", nil, nil},
+		{"unchanged", []string{"Alias 1", "Alias 2"}, []string{"Alias 1", "Alias 2"}},
+		{"whitespace", []string{"  Alias \t 1 ", "Alias  2"}, []string{"Alias 1", "Alias 2"}},
+		{"empty", []string{"", "  ", "Alias"}, []string{"Alias"}},
+		{"duplicates", []string{"Alias", "alias", "ALIAS ", "Other"}, []string{"Alias", "Other"}},
+		{"name", []string{"Performer Name", "performer  name", "Alias"}, []string{"Alias"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeAliases("Performer Name", tt.aliases))
+		})
+	}
+}
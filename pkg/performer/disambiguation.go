@@ -0,0 +1,105 @@
+package performer
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+type DisambiguationRenumberer interface {
+	FindByNames(ctx context.Context, names []string, nocase bool) ([]*models.Performer, error)
+	UpdatePartial(ctx context.Context, id int, updatedPerformer models.PerformerPartial) (*models.Performer, error)
+}
+
+type disambiguationChange struct {
+	performer *models.Performer
+	new       string
+}
+
+// planDisambiguationRenumber returns the changes required to renumber the
+// numeric disambiguations of the provided performers contiguously from 1,
+// preserving their order. Performers without a disambiguation or with a
+// non-numeric disambiguation are not changed.
+//
+// The changes are returned in the order they must be applied to avoid
+// duplicate disambiguations.
+func planDisambiguationRenumber(performers []*models.Performer) []disambiguationChange {
+	type numbered struct {
+		performer *models.Performer
+		value     int
+	}
+
+	var toRenumber []numbered
+	for _, p := range performers {
+		v, err := strconv.Atoi(p.Disambiguation)
+		// only renumber canonical positive numbers, so that new values
+		// can't conflict with values that are not renumbered
+		if err != nil || v <= 0 || strconv.Itoa(v) != p.Disambiguation {
+			continue
+		}
+
+		toRenumber = append(toRenumber, numbered{performer: p, value: v})
+	}
+
+	sort.Slice(toRenumber, func(i, j int) bool {
+		return toRenumber[i].value < toRenumber[j].value
+	})
+
+	// existing values are distinct and ascending, so each new value is no
+	// greater than the existing value and is unused when applied in order
+	var ret []disambiguationChange
+	for i, n := range toRenumber {
+		newValue := strconv.Itoa(i + 1)
+		if newValue != n.performer.Disambiguation {
+			ret = append(ret, disambiguationChange{
+				performer: n.performer,
+				new:       newValue,
+			})
+		}
+	}
+
+	return ret
+}
+
+// RenumberDisambiguations reassigns contiguous numeric disambiguations to
+// the performers with the provided name. The performer without a
+// disambiguation and performers with non-numeric disambiguations are not
+// changed. Returns the changes made.
+func RenumberDisambiguations(ctx context.Context, qb DisambiguationRenumberer, name string) ([]*models.PerformerDisambiguationChange, error) {
+	performers, err := qb.FindByNames(ctx, []string{name}, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// FindByNames may match other names depending on the collation
+	var matching []*models.Performer
+	for _, p := range performers {
+		if p.Name == name {
+			matching = append(matching, p)
+		}
+	}
+
+	ret := []*models.PerformerDisambiguationChange{}
+	for _, c := range planDisambiguationRenumber(matching) {
+		old := c.performer.Disambiguation
+		newValue := c.new
+
+		partial := models.NewPerformerPartial()
+		partial.Disambiguation = models.NewOptionalString(newValue)
+
+		updated, err := qb.UpdatePartial(ctx, c.performer.ID, partial)
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, &models.PerformerDisambiguationChange{
+			Performer:         updated,
+			OldDisambiguation: &old,
+			NewDisambiguation: &newValue,
+		})
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,54 @@
+package performer
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanDisambiguationRenumber(t *testing.T) {
+	newPerformer := func(id int, disambiguation string) *models.Performer {
+		return &models.Performer{ID: id, Name: "name", Disambiguation: disambiguation}
+	}
+
+	tests := []struct {
+		name           string
+		disambiguation []string
+		want           map[int]string
+	}{
+		{"none", nil, map[int]string{}},
+		{"contiguous", []string{"", "1", "2"}, map[int]string{}},
+		{"gaps", []string{"", "5", "2", "9"}, map[int]string{1: "2", 2: "1", 3: "3"}},
+		{"no unnumbered", []string{"3", "7"}, map[int]string{0: "1", 1: "2"}},
+		{"non-numeric", []string{"", "US", "04", "6"}, map[int]string{3: "1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var performers []*models.Performer
+			for i, d := range tt.disambiguation {
+				performers = append(performers, newPerformer(i, d))
+			}
+
+			changes := planDisambiguationRenumber(performers)
+
+			got := make(map[int]string)
+			used := make(map[string]bool)
+			for _, p := range performers {
+				used[p.Disambiguation] = true
+			}
+
+			for _, c := range changes {
+				got[c.performer.ID] = c.new
+
+				// applying changes in order must not create duplicates
+				assert.False(t, used[c.new], "disambiguation %s is in use", c.new)
+				delete(used, c.performer.Disambiguation)
+				used[c.new] = true
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -24,6 +24,7 @@ const (
 
 	SceneCreatePost  HookTriggerEnum = "Scene.Create.Post"
 	SceneUpdatePost  HookTriggerEnum = "Scene.Update.Post"
+	SceneMergePost   HookTriggerEnum = "Scene.Merge.Post"
 	SceneDestroyPost HookTriggerEnum = "Scene.Destroy.Post"
 
 	ImageCreatePost  HookTriggerEnum = "Image.Create.Post"
@@ -59,6 +60,7 @@ var AllHookTriggerEnum = []HookTriggerEnum{
 
 	SceneCreatePost,
 	SceneUpdatePost,
+	SceneMergePost,
 	SceneDestroyPost,
 
 	ImageCreatePost,
@@ -96,6 +98,7 @@ func (e HookTriggerEnum) IsValid() bool {
 
 		SceneCreatePost,
 		SceneUpdatePost,
+		SceneMergePost,
 		SceneDestroyPost,
 
 		ImageCreatePost,
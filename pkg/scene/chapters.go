@@ -0,0 +1,78 @@
+package scene
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Chapter is a titled timestamp parsed from a chapter list.
+type Chapter struct {
+	Seconds float64
+	Title   string
+}
+
+// chapterRE matches a line starting with a MM:SS or HH:MM:SS timestamp,
+// optionally wrapped in brackets and followed by a separator, then the title.
+var chapterRE = regexp.MustCompile(`^[\[(]?((?:\d+:)?\d{1,2}:\d{2})[\])]?\s*(?:[-–—|:]\s*)?(.*\S)\s*$`)
+
+// ParseChapters parses a chapter list in the format used in video
+// descriptions, with one "MM:SS Title" or "HH:MM:SS Title" chapter per line.
+// Blank lines are ignored. Lines that cannot be parsed are returned
+// separately.
+func ParseChapters(text string) (chapters []Chapter, unparsed []string) {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		c, ok := parseChapter(line)
+		if !ok {
+			unparsed = append(unparsed, line)
+			continue
+		}
+
+		chapters = append(chapters, c)
+	}
+
+	return chapters, unparsed
+}
+
+func parseChapter(line string) (Chapter, bool) {
+	m := chapterRE.FindStringSubmatch(line)
+	if m == nil {
+		return Chapter{}, false
+	}
+
+	seconds, ok := parseChapterTimestamp(m[1])
+	if !ok {
+		return Chapter{}, false
+	}
+
+	return Chapter{
+		Seconds: seconds,
+		Title:   m[2],
+	}, true
+}
+
+func parseChapterTimestamp(s string) (float64, bool) {
+	parts := strings.Split(s, ":")
+
+	seconds := 0
+	for i, p := range parts {
+		v, err := strconv.Atoi(p)
+		if err != nil {
+			return 0, false
+		}
+
+		// minutes and seconds must be less than 60 when preceded by a larger unit
+		if i > 0 && v >= 60 {
+			return 0, false
+		}
+
+		seconds = seconds*60 + v
+	}
+
+	return float64(seconds), true
+}
@@ -0,0 +1,61 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseChapters(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		wantChapters []Chapter
+		wantUnparsed []string
+	}{
+		{
+			"minutes and seconds",
+			"00:00 Intro\n02:15 Part 2\n",
+			[]Chapter{
+				{0, "Intro"},
+				{135, "Part 2"},
+			},
+			nil,
+		},
+		{
+			"hours",
+			"0:00 Intro\n1:02:03 Late",
+			[]Chapter{
+				{0, "Intro"},
+				{3723, "Late"},
+			},
+			nil,
+		},
+		{
+			"separators and brackets",
+			"[00:10] - First\r\n(01:00) | Second\n2:00: Third",
+			[]Chapter{
+				{10, "First"},
+				{60, "Second"},
+				{120, "Third"},
+			},
+			nil,
+		},
+		{
+			"blank and invalid lines",
+			"Chapters:\n\n00:30 Valid\n00:75 Bad seconds\n01:00\nno timestamp",
+			[]Chapter{
+				{30, "Valid"},
+			},
+			[]string{"Chapters:", "00:75 Bad seconds", "01:00", "no timestamp"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chapters, unparsed := ParseChapters(tt.text)
+			assert.Equal(t, tt.wantChapters, chapters)
+			assert.Equal(t, tt.wantUnparsed, unparsed)
+		})
+	}
+}
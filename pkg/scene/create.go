@@ -60,7 +60,7 @@ func (s *Service) Create(ctx context.Context, input *models.Scene, fileIDs []fil
 		// only do this if there is a file associated
 		if len(fileIDs) > 0 {
 			txn.AddPostCommitHook(ctx, func(ctx context.Context) error {
-				if err := SetScreenshot(s.Paths, ret.GetHash(s.Config.GetVideoFileNamingAlgorithm()), coverImage); err != nil {
+				if err := SetScreenshot(s.Paths.ForPath(ret.Path), ret.GetHash(s.Config.GetVideoFileNamingAlgorithm()), coverImage); err != nil {
 					logger.Errorf("Error setting screenshot: %v", err)
 				}
 
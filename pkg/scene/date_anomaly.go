@@ -0,0 +1,70 @@
+package scene
+
+import (
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// DateAnomaly is a reason for the date of a scene to be suspicious.
+type DateAnomaly string
+
+const (
+	// DateAnomalyFuture indicates that the scene date is after today.
+	DateAnomalyFuture DateAnomaly = "FUTURE"
+	// DateAnomalyBeforeFloor indicates that the scene date is before the
+	// configured floor year.
+	DateAnomalyBeforeFloor DateAnomaly = "BEFORE_FLOOR"
+	// DateAnomalyFileModTime indicates that the scene date differs from the
+	// modification time of the primary file by more than the tolerance.
+	DateAnomalyFileModTime DateAnomaly = "FILE_MOD_TIME"
+)
+
+// DateAnomalyOptions are the rules used to detect suspicious scene dates.
+type DateAnomalyOptions struct {
+	// Now is the current time. Dates after the day of Now are in the future.
+	Now time.Time
+	// FloorYear is the earliest plausible year. Not checked if 0.
+	FloorYear int
+	// FileModTimeTolerance is the maximum difference between the scene date
+	// and the modification time of the primary file. Not checked if 0.
+	FileModTimeTolerance time.Duration
+}
+
+// DateAnomalies returns the reasons that the date of s is suspicious, or nil
+// if s has no date or the date is plausible. The primary file of s must be
+// loaded to compare the date with the file modification time.
+func DateAnomalies(s *models.Scene, options DateAnomalyOptions) []DateAnomaly {
+	if s.Date == nil || s.Date.IsZero() {
+		return nil
+	}
+
+	date := s.Date.Time
+
+	var ret []DateAnomaly
+
+	y, m, d := options.Now.Date()
+	tomorrow := time.Date(y, m, d+1, 0, 0, 0, 0, date.Location())
+	if !date.Before(tomorrow) {
+		ret = append(ret, DateAnomalyFuture)
+	}
+
+	if options.FloorYear > 0 && date.Year() < options.FloorYear {
+		ret = append(ret, DateAnomalyBeforeFloor)
+	}
+
+	if options.FileModTimeTolerance > 0 {
+		if f := s.Files.Primary(); f != nil && !f.ModTime.IsZero() {
+			diff := f.ModTime.Sub(date)
+			if diff < 0 {
+				diff = -diff
+			}
+
+			if diff > options.FileModTimeTolerance {
+				ret = append(ret, DateAnomalyFileModTime)
+			}
+		}
+	}
+
+	return ret
+}
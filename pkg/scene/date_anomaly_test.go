@@ -0,0 +1,106 @@
+package scene
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDateAnomalies(t *testing.T) {
+	now := time.Date(2022, 6, 15, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	makeScene := func(date string, modTime time.Time) *models.Scene {
+		ret := &models.Scene{
+			Files: models.NewRelatedVideoFiles([]*file.VideoFile{
+				{
+					BaseFile: &file.BaseFile{
+						DirEntry: file.DirEntry{
+							ModTime: modTime,
+						},
+					},
+				},
+			}),
+		}
+
+		if date != "" {
+			d := models.NewDate(date)
+			ret.Date = &d
+		}
+
+		return ret
+	}
+
+	options := DateAnomalyOptions{
+		Now:                  now,
+		FloorYear:            1990,
+		FileModTimeTolerance: 365 * day,
+	}
+
+	tests := []struct {
+		name    string
+		scene   *models.Scene
+		options DateAnomalyOptions
+		want    []DateAnomaly
+	}{
+		{
+			"no date",
+			makeScene("", now),
+			options,
+			nil,
+		},
+		{
+			"plausible",
+			makeScene("2022-01-01", now),
+			options,
+			nil,
+		},
+		{
+			"today",
+			makeScene("2022-06-15", now),
+			options,
+			nil,
+		},
+		{
+			"future",
+			makeScene("2022-06-16", now),
+			options,
+			[]DateAnomaly{DateAnomalyFuture},
+		},
+		{
+			"before floor",
+			makeScene("1989-12-31", now),
+			options,
+			[]DateAnomaly{DateAnomalyBeforeFloor, DateAnomalyFileModTime},
+		},
+		{
+			"mod time mismatch",
+			makeScene("2010-01-01", now),
+			options,
+			[]DateAnomaly{DateAnomalyFileModTime},
+		},
+		{
+			"mod time not checked",
+			makeScene("2010-01-01", now),
+			DateAnomalyOptions{
+				Now: now,
+			},
+			nil,
+		},
+		{
+			"zero mod time",
+			makeScene("2010-01-01", time.Time{}),
+			options,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DateAnomalies(tt.scene, tt.options))
+		})
+	}
+}
@@ -21,13 +21,15 @@ type FileDeleter struct {
 
 // MarkGeneratedFiles marks for deletion the generated files for the provided scene.
 func (d *FileDeleter) MarkGeneratedFiles(scene *models.Scene) error {
-	sceneHash := scene.GetHash(d.FileNamingAlgo)
+	return d.markGeneratedFiles(d.Paths.ForPath(scene.Path), scene.GetHash(d.FileNamingAlgo))
+}
 
+func (d *FileDeleter) markGeneratedFiles(p *paths.Paths, sceneHash string) error {
 	if sceneHash == "" {
 		return nil
 	}
 
-	markersFolder := filepath.Join(d.Paths.Generated.Markers, sceneHash)
+	markersFolder := filepath.Join(p.Generated.Markers, sceneHash)
 
 	exists, _ := fsutil.FileExists(markersFolder)
 	if exists {
@@ -38,49 +40,55 @@ func (d *FileDeleter) MarkGeneratedFiles(scene *models.Scene) error {
 
 	var files []string
 
-	thumbPath := d.Paths.Scene.GetThumbnailScreenshotPath(sceneHash)
+	thumbPath := p.Scene.GetThumbnailScreenshotPath(sceneHash)
 	exists, _ = fsutil.FileExists(thumbPath)
 	if exists {
 		files = append(files, thumbPath)
 	}
 
-	normalPath := d.Paths.Scene.GetScreenshotPath(sceneHash)
+	normalPath := p.Scene.GetScreenshotPath(sceneHash)
 	exists, _ = fsutil.FileExists(normalPath)
 	if exists {
 		files = append(files, normalPath)
 	}
 
-	streamPreviewPath := d.Paths.Scene.GetVideoPreviewPath(sceneHash)
+	streamPreviewPath := p.Scene.GetVideoPreviewPath(sceneHash)
 	exists, _ = fsutil.FileExists(streamPreviewPath)
 	if exists {
 		files = append(files, streamPreviewPath)
 	}
 
-	streamPreviewImagePath := d.Paths.Scene.GetWebpPreviewPath(sceneHash)
+	streamPreviewImagePath := p.Scene.GetWebpPreviewPath(sceneHash)
 	exists, _ = fsutil.FileExists(streamPreviewImagePath)
 	if exists {
 		files = append(files, streamPreviewImagePath)
 	}
 
-	transcodePath := d.Paths.Scene.GetTranscodePath(sceneHash)
+	transcodePath := p.Scene.GetTranscodePath(sceneHash)
 	exists, _ = fsutil.FileExists(transcodePath)
 	if exists {
 		files = append(files, transcodePath)
 	}
 
-	spritePath := d.Paths.Scene.GetSpriteImageFilePath(sceneHash)
+	spritePath := p.Scene.GetSpriteImageFilePath(sceneHash)
 	exists, _ = fsutil.FileExists(spritePath)
 	if exists {
 		files = append(files, spritePath)
 	}
 
-	vttPath := d.Paths.Scene.GetSpriteVttFilePath(sceneHash)
+	vttPath := p.Scene.GetSpriteVttFilePath(sceneHash)
 	exists, _ = fsutil.FileExists(vttPath)
 	if exists {
 		files = append(files, vttPath)
 	}
 
-	heatmapPath := d.Paths.Scene.GetInteractiveHeatmapPath(sceneHash)
+	contactSheetPath := p.Scene.GetContactSheetPath(sceneHash)
+	exists, _ = fsutil.FileExists(contactSheetPath)
+	if exists {
+		files = append(files, contactSheetPath)
+	}
+
+	heatmapPath := p.Scene.GetInteractiveHeatmapPath(sceneHash)
 	exists, _ = fsutil.FileExists(heatmapPath)
 	if exists {
 		files = append(files, heatmapPath)
@@ -92,9 +100,10 @@ func (d *FileDeleter) MarkGeneratedFiles(scene *models.Scene) error {
 // MarkMarkerFiles deletes generated files for a scene marker with the
 // provided scene and timestamp.
 func (d *FileDeleter) MarkMarkerFiles(scene *models.Scene, seconds int) error {
-	videoPath := d.Paths.SceneMarkers.GetVideoPreviewPath(scene.GetHash(d.FileNamingAlgo), seconds)
-	imagePath := d.Paths.SceneMarkers.GetWebpPreviewPath(scene.GetHash(d.FileNamingAlgo), seconds)
-	screenshotPath := d.Paths.SceneMarkers.GetScreenshotPath(scene.GetHash(d.FileNamingAlgo), seconds)
+	markerPaths := d.Paths.ForPath(scene.Path).SceneMarkers
+	videoPath := markerPaths.GetVideoPreviewPath(scene.GetHash(d.FileNamingAlgo), seconds)
+	imagePath := markerPaths.GetWebpPreviewPath(scene.GetHash(d.FileNamingAlgo), seconds)
+	screenshotPath := markerPaths.GetScreenshotPath(scene.GetHash(d.FileNamingAlgo), seconds)
 
 	var files []string
 
@@ -0,0 +1,86 @@
+package scene
+
+import (
+	"sort"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// DirectoryTree returns the directories of the provided folders that
+// contain scene files, either directly or in a subdirectory. sceneIDs maps
+// folder ids to the ids of the scenes with a file directly in that folder.
+//
+// Folders whose parent is not in the provided folders are treated as root
+// directories. If maxDepth is not negative, then only directories up to
+// maxDepth levels below a root directory are returned.
+//
+// The returned directories are sorted by path.
+func DirectoryTree(folders []*file.Folder, sceneIDs map[file.FolderID][]int, maxDepth int) []*models.SceneDirectory {
+	byID := make(map[file.FolderID]*file.Folder, len(folders))
+	for _, f := range folders {
+		byID[f.ID] = f
+	}
+
+	parent := func(f *file.Folder) *file.Folder {
+		if f.ParentFolderID == nil {
+			return nil
+		}
+		return byID[*f.ParentFolderID]
+	}
+
+	// total scenes are collected as sets, so that scenes with multiple
+	// files in a directory tree are only counted once
+	totals := make(map[file.FolderID]map[int]struct{})
+	for _, f := range folders {
+		for _, id := range sceneIDs[f.ID] {
+			for ff := f; ff != nil; ff = parent(ff) {
+				set := totals[ff.ID]
+				if set == nil {
+					set = make(map[int]struct{})
+					totals[ff.ID] = set
+				}
+				set[id] = struct{}{}
+			}
+		}
+	}
+
+	depth := func(f *file.Folder) int {
+		ret := 0
+		for ff := parent(f); ff != nil; ff = parent(ff) {
+			ret++
+		}
+		return ret
+	}
+
+	var ret []*models.SceneDirectory
+	for _, f := range folders {
+		total := len(totals[f.ID])
+		if total == 0 {
+			continue
+		}
+
+		if maxDepth >= 0 && depth(f) > maxDepth {
+			continue
+		}
+
+		d := &models.SceneDirectory{
+			Path:            f.Path,
+			SceneCount:      len(sceneIDs[f.ID]),
+			TotalSceneCount: total,
+		}
+
+		if p := parent(f); p != nil {
+			parentPath := p.Path
+			d.ParentPath = &parentPath
+		}
+
+		ret = append(ret, d)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Path < ret[j].Path
+	})
+
+	return ret
+}
@@ -0,0 +1,73 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirectoryTree(t *testing.T) {
+	folderID := func(id int) *file.FolderID {
+		v := file.FolderID(id)
+		return &v
+	}
+	strPtr := func(s string) *string { return &s }
+
+	folders := []*file.Folder{
+		{ID: 1, Path: "/root"},
+		{ID: 2, Path: "/root/a", ParentFolderID: folderID(1)},
+		{ID: 3, Path: "/root/a/b", ParentFolderID: folderID(2)},
+		{ID: 4, Path: "/root/c", ParentFolderID: folderID(1)},
+		{ID: 5, Path: "/root/empty", ParentFolderID: folderID(1)},
+	}
+
+	sceneIDs := map[file.FolderID][]int{
+		2: {1, 2},
+		// scene 2 has a file in both a and a/b
+		3: {2, 3},
+		4: {4},
+	}
+
+	root := &models.SceneDirectory{
+		Path:            "/root",
+		SceneCount:      0,
+		TotalSceneCount: 4,
+	}
+	a := &models.SceneDirectory{
+		Path:            "/root/a",
+		ParentPath:      strPtr("/root"),
+		SceneCount:      2,
+		TotalSceneCount: 3,
+	}
+	b := &models.SceneDirectory{
+		Path:            "/root/a/b",
+		ParentPath:      strPtr("/root/a"),
+		SceneCount:      2,
+		TotalSceneCount: 2,
+	}
+	c := &models.SceneDirectory{
+		Path:            "/root/c",
+		ParentPath:      strPtr("/root"),
+		SceneCount:      1,
+		TotalSceneCount: 1,
+	}
+
+	tests := []struct {
+		name     string
+		maxDepth int
+		want     []*models.SceneDirectory
+	}{
+		{"all", -1, []*models.SceneDirectory{root, a, b, c}},
+		{"root only", 0, []*models.SceneDirectory{root}},
+		{"depth 1", 1, []*models.SceneDirectory{root, a, c}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DirectoryTree(folders, sceneIDs, tt.maxDepth)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
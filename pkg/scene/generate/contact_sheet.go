@@ -0,0 +1,130 @@
+package generate
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+
+	"github.com/disintegration/imaging"
+	"github.com/stashapp/stash/pkg/ffmpeg/transcoder"
+)
+
+const (
+	contactSheetScreenshotWidth = 320
+
+	// timestamp glyphs are scaled by this factor
+	contactSheetTextScale   = 2
+	contactSheetTextPadding = 3
+)
+
+// contactSheetGlyphs are 3x5 bitmaps of the characters used in timestamps.
+// Each string is a row, with '#' for a set pixel.
+var contactSheetGlyphs = map[rune][5]string{
+	'0': {"###", "#.#", "#.#", "#.#", "###"},
+	'1': {".#.", "##.", ".#.", ".#.", "###"},
+	'2': {"###", "..#", "###", "#..", "###"},
+	'3': {"###", "..#", "###", "..#", "###"},
+	'4': {"#.#", "#.#", "###", "..#", "..#"},
+	'5': {"###", "#..", "###", "..#", "###"},
+	'6': {"###", "#..", "###", "#.#", "###"},
+	'7': {"###", "..#", "..#", "..#", "..#"},
+	'8': {"###", "#.#", "###", "#.#", "###"},
+	'9': {"###", "#.#", "###", "..#", "###"},
+	':': {"...", ".#.", "...", ".#.", "..."},
+}
+
+// ContactSheetScreenshot returns the frame of the video at the provided time,
+// scaled to the width of a contact sheet image.
+func (g Generator) ContactSheetScreenshot(ctx context.Context, input string, seconds float64) (image.Image, error) {
+	lockCtx := g.LockManager.ReadLock(ctx, input)
+	defer lockCtx.Cancel()
+
+	ssOptions := transcoder.ScreenshotOptions{
+		OutputPath: "-",
+		OutputType: transcoder.ScreenshotOutputTypeBMP,
+		Width:      contactSheetScreenshotWidth,
+	}
+
+	args := transcoder.ScreenshotTime(input, seconds, ssOptions)
+
+	return g.generateImage(lockCtx, args)
+}
+
+// CombineContactSheetImages combines the images into a grid with the
+// provided number of columns. The time of each image, in seconds, is drawn
+// over its bottom right corner.
+func (g Generator) CombineContactSheetImages(images []image.Image, times []float64, columns int) image.Image {
+	width := images[0].Bounds().Size().X
+	height := images[0].Bounds().Size().Y
+	rows := (len(images) + columns - 1) / columns
+
+	montage := imaging.New(width*columns, height*rows, color.NRGBA{})
+	for index, img := range images {
+		x := width * (index % columns)
+		y := height * (index / columns)
+		montage = imaging.Paste(montage, img, image.Pt(x, y))
+
+		if index < len(times) {
+			drawTimestamp(montage, formatContactSheetTime(times[index]), image.Pt(x+width, y+height))
+		}
+	}
+
+	return montage
+}
+
+// formatContactSheetTime formats seconds as m:ss, or h:mm:ss if at least an
+// hour.
+func formatContactSheetTime(seconds float64) string {
+	s := int(seconds)
+	h := s / 3600
+	m := (s % 3600) / 60
+	s %= 60
+
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
+
+// drawTimestamp draws text on a translucent box, with the bottom right
+// corner of the box at the provided point.
+func drawTimestamp(dst *image.NRGBA, text string, bottomRight image.Point) {
+	const (
+		glyphWidth  = 3 * contactSheetTextScale
+		glyphHeight = 5 * contactSheetTextScale
+		spacing     = contactSheetTextScale
+	)
+
+	runes := []rune(text)
+	textWidth := len(runes)*(glyphWidth+spacing) - spacing
+
+	box := image.Rect(
+		bottomRight.X-textWidth-2*contactSheetTextPadding,
+		bottomRight.Y-glyphHeight-2*contactSheetTextPadding,
+		bottomRight.X,
+		bottomRight.Y,
+	)
+	draw.Draw(dst, box, image.NewUniform(color.NRGBA{A: 160}), image.Point{}, draw.Over)
+
+	white := color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+	x := box.Min.X + contactSheetTextPadding
+	y := box.Min.Y + contactSheetTextPadding
+	for _, r := range runes {
+		glyph := contactSheetGlyphs[r]
+		for row, line := range glyph {
+			for col, c := range line {
+				if c != '#' {
+					continue
+				}
+
+				px := image.Rect(0, 0, contactSheetTextScale, contactSheetTextScale).
+					Add(image.Pt(x+col*contactSheetTextScale, y+row*contactSheetTextScale))
+				draw.Draw(dst, px, image.NewUniform(white), image.Point{}, draw.Src)
+			}
+		}
+
+		x += glyphWidth + spacing
+	}
+}
@@ -0,0 +1,76 @@
+package generate
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatContactSheetTime(t *testing.T) {
+	tests := []struct {
+		seconds float64
+		want    string
+	}{
+		{0, "0:00"},
+		{59.9, "0:59"},
+		{61, "1:01"},
+		{3599, "59:59"},
+		{3600, "1:00:00"},
+		{36061.5, "10:01:01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatContactSheetTime(tt.seconds))
+		})
+	}
+}
+
+func TestCombineContactSheetImages(t *testing.T) {
+	const size = 40
+
+	colors := []color.NRGBA{
+		{R: 255, A: 255},
+		{G: 255, A: 255},
+		{B: 255, A: 255},
+		{R: 255, G: 255, A: 255},
+		{G: 255, B: 255, A: 255},
+	}
+
+	var images []image.Image
+	for _, c := range colors {
+		img := image.NewNRGBA(image.Rect(0, 0, size, size))
+		for y := 0; y < size; y++ {
+			for x := 0; x < size; x++ {
+				img.SetNRGBA(x, y, c)
+			}
+		}
+		images = append(images, img)
+	}
+
+	// the last image has no time, so no timestamp is drawn over it
+	times := []float64{1, 2, 3, 4}
+
+	got := Generator{}.CombineContactSheetImages(images, times, 2)
+
+	// the last row is partially filled
+	assert.Equal(t, image.Rect(0, 0, 2*size, 3*size), got.Bounds())
+
+	for i, c := range colors {
+		x := size * (i % 2)
+		y := size * (i / 2)
+
+		assert.Equal(t, c, got.At(x, y), "top left of image %d", i)
+
+		bottomRight := got.At(x+size-1, y+size-1)
+		if i < len(times) {
+			assert.NotEqual(t, c, bottomRight, "timestamp of image %d", i)
+		} else {
+			assert.Equal(t, c, bottomRight, "timestamp of image %d", i)
+		}
+	}
+
+	assert.Equal(t, color.NRGBA{}, got.At(size, 2*size), "empty cell")
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
@@ -115,7 +116,54 @@ func (g Generator) generate(ctx *fsutil.LockContext, args []string) error {
 
 	ctx.AttachCommand(cmd)
 
-	if err := cmd.Wait(); err != nil {
+	if err := ctx.Wait(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitErr.Stderr = stderr.Bytes()
+			err = exitErr
+		}
+		return fmt.Errorf("error running ffmpeg command <%s>: %w", strings.Join(args, " "), err)
+	}
+
+	return nil
+}
+
+// generateWithProgress runs ffmpeg with the given args and waits for it to
+// finish. args must include the ffmpeg Progress flags. If progressFn is not
+// nil, it is called with the fraction of duration that has been processed.
+func (g Generator) generateWithProgress(ctx *fsutil.LockContext, args []string, duration float64, progressFn func(float64)) error {
+	cmd := g.Encoder.Command(ctx, args)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("error creating stdout pipe: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("error starting command: %w", err)
+	}
+
+	ctx.AttachCommand(cmd)
+
+	_ = ffmpeg.ParseProgress(stdout, func(seconds float64) {
+		if progressFn == nil || duration <= 0 {
+			return
+		}
+
+		p := seconds / duration
+		if p > 1 {
+			p = 1
+		}
+		progressFn(p)
+	})
+
+	// drain any remaining output so that ffmpeg does not block
+	_, _ = io.Copy(io.Discard, stdout)
+
+	if err := ctx.Wait(); err != nil {
 		var exitErr *exec.ExitError
 		if errors.As(err, &exitErr) {
 			exitErr.Stderr = stderr.Bytes()
@@ -143,7 +191,7 @@ func (g Generator) generateOutput(lockCtx *fsutil.LockContext, args []string) ([
 
 	lockCtx.AttachCommand(cmd)
 
-	if err := cmd.Wait(); err != nil {
+	if err := lockCtx.Wait(); err != nil {
 		var exitErr *exec.ExitError
 		if errors.As(err, &exitErr) {
 			exitErr.Stderr = stderr.Bytes()
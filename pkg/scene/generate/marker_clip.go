@@ -0,0 +1,56 @@
+package generate
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+type MarkerClipOptions struct {
+	Seconds  float64
+	Duration float64
+
+	// Progress is called with the fraction of the clip that has been
+	// written. May be nil.
+	Progress func(float64)
+}
+
+// MarkerClip cuts a clip of options.Duration seconds out of input, starting
+// at options.Seconds, and writes it to output. The container format is
+// determined by the extension of output.
+//
+// Streams are copied without re-encoding, so the clip starts at the
+// keyframe nearest to options.Seconds.
+func (g Generator) MarkerClip(ctx context.Context, input string, output string, options MarkerClipOptions) error {
+	lockCtx := g.LockManager.ReadLock(ctx, input)
+	defer lockCtx.Cancel()
+
+	pattern := "*" + filepath.Ext(output)
+	if err := g.generateFile(lockCtx, g.MarkerPaths, pattern, output, g.markerClip(input, options)); err != nil {
+		return err
+	}
+
+	logger.Debug("created marker clip: ", output)
+
+	return nil
+}
+
+func (g Generator) markerClip(input string, options MarkerClipOptions) generateFn {
+	return func(lockCtx *fsutil.LockContext, tmpFn string) error {
+		var args ffmpeg.Args
+		args = args.LogLevel(ffmpeg.LogLevelError).Overwrite()
+		args = args.Seek(options.Seconds)
+		args = args.Input(input)
+		args = args.Duration(options.Duration)
+		args = args.VideoCodec(ffmpeg.VideoCodecCopy)
+		args = args.AudioCodec(ffmpeg.AudioCodecCopy)
+		args = append(args, "-avoid_negative_ts", "make_zero")
+		args = args.Progress()
+		args = args.Output(tmpFn)
+
+		return g.generateWithProgress(lockCtx, args, options.Duration, options.Progress)
+	}
+}
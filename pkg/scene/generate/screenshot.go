@@ -2,6 +2,7 @@ package generate
 
 import (
 	"context"
+	"image"
 
 	"github.com/stashapp/stash/pkg/ffmpeg/transcoder"
 	"github.com/stashapp/stash/pkg/fsutil"
@@ -17,8 +18,16 @@ const (
 	screenshotDurationProportion = 0.2
 )
 
+// screenshotCandidateProportions are the proportions of the video duration
+// that are sampled when looking for a non-blank frame for the screenshot.
+var screenshotCandidateProportions = []float64{0.2, 0.3, 0.4, 0.5, 0.6}
+
 type ScreenshotOptions struct {
 	At *float64
+
+	// AvoidBlankFrames samples a number of candidate frames and uses the
+	// frame with the most detail when At is not set.
+	AvoidBlankFrames bool
 }
 
 func (g Generator) Screenshot(ctx context.Context, input string, hash string, videoWidth int, videoDuration float64, options ScreenshotOptions) error {
@@ -37,6 +46,8 @@ func (g Generator) Screenshot(ctx context.Context, input string, hash string, vi
 	at := screenshotDurationProportion * videoDuration
 	if options.At != nil {
 		at = *options.At
+	} else if options.AvoidBlankFrames {
+		at = g.bestScreenshotTime(lockCtx, input, videoDuration)
 	}
 
 	if err := g.generateFile(lockCtx, g.ScenePaths, jpgPattern, output, g.screenshot(input, screenshotOptions{
@@ -52,6 +63,62 @@ func (g Generator) Screenshot(ctx context.Context, input string, hash string, vi
 	return nil
 }
 
+// bestScreenshotTime samples frames at the candidate proportions of the
+// video duration and returns the time of the frame with the highest
+// luminance variance. Near-black or otherwise uniform frames have a low
+// variance, so they are avoided where a better frame is available.
+func (g Generator) bestScreenshotTime(lockCtx *fsutil.LockContext, input string, videoDuration float64) float64 {
+	best := screenshotDurationProportion * videoDuration
+	bestVariance := -1.0
+
+	for _, p := range screenshotCandidateProportions {
+		t := p * videoDuration
+
+		args := transcoder.ScreenshotTime(input, t, transcoder.ScreenshotOptions{
+			OutputPath: "-",
+			OutputType: transcoder.ScreenshotOutputTypeBMP,
+			Width:      spriteScreenshotWidth,
+		})
+
+		img, err := g.generateImage(lockCtx, args)
+		if err != nil {
+			logger.Warnf("[generator] error sampling frame at %.2fs of %s: %v", t, input, err)
+			continue
+		}
+
+		if v := luminanceVariance(img); v > bestVariance {
+			best = t
+			bestVariance = v
+		}
+	}
+
+	return best
+}
+
+// luminanceVariance returns the variance of the luminance of the pixels in
+// the provided image.
+func luminanceVariance(img image.Image) float64 {
+	bounds := img.Bounds()
+	n := float64(bounds.Dx() * bounds.Dy())
+	if n == 0 {
+		return 0
+	}
+
+	var sum, sumSq float64
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			// ITU-R BT.601 luma, scaled to 0-255
+			l := (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)) / 257
+			sum += l
+			sumSq += l * l
+		}
+	}
+
+	mean := sum / n
+	return sumSq/n - mean*mean
+}
+
 func (g Generator) Thumbnail(ctx context.Context, input string, hash string, videoDuration float64, options ScreenshotOptions) error {
 	lockCtx := g.LockManager.ReadLock(ctx, input)
 	defer lockCtx.Cancel()
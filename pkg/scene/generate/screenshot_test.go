@@ -0,0 +1,61 @@
+package generate
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLuminanceVariance(t *testing.T) {
+	filled := func(c color.Color) image.Image {
+		img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+		for y := 0; y < 4; y++ {
+			for x := 0; x < 4; x++ {
+				img.Set(x, y, c)
+			}
+		}
+		return img
+	}
+
+	halves := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			if x < 2 {
+				halves.Set(x, y, color.Black)
+			} else {
+				halves.Set(x, y, color.White)
+			}
+		}
+	}
+
+	dark := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			dark.Set(x, y, color.Gray{Y: uint8(x * 4)})
+		}
+	}
+
+	tests := []struct {
+		name string
+		img  image.Image
+		want float64
+	}{
+		{"black", filled(color.Black), 0},
+		{"uniform grey", filled(color.Gray{Y: 128}), 0},
+		{"half black half white", halves, 127.5 * 127.5},
+		{"empty", image.NewRGBA(image.Rect(0, 0, 0, 0)), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, luminanceVariance(tt.img), 0.01)
+		})
+	}
+
+	// a near-black frame with some detail is preferred to a uniform frame,
+	// but not to a detailed frame
+	assert.Greater(t, luminanceVariance(dark), luminanceVariance(filled(color.Gray{Y: 200})))
+	assert.Less(t, luminanceVariance(dark), luminanceVariance(halves))
+}
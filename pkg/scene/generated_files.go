@@ -0,0 +1,83 @@
+package scene
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models/paths"
+)
+
+// generatedFileSuffixes maps generated directories to the suffixes of the
+// scene files they contain. Longer suffixes must be listed first.
+func generatedFileSuffixes(p *paths.Paths) map[string][]string {
+	return map[string][]string{
+		p.Generated.Screenshots:        {".thumb.jpg", ".jpg", ".mp4", ".webp"},
+		p.Generated.Vtt:                {"_sprite.jpg", "_thumbs.vtt", "_contact.jpg"},
+		p.Generated.Transcodes:         {".mp4"},
+		p.Generated.InteractiveHeatmap: {".png"},
+	}
+}
+
+// GeneratedFilesByHash returns the paths of the generated scene files that
+// exist on disk, keyed by the scene hash encoded in their path. Marker
+// directories are returned as a single path.
+func GeneratedFilesByHash(p *paths.Paths) (map[string][]string, error) {
+	ret := make(map[string][]string)
+
+	for dir, suffixes := range generatedFileSuffixes(p) {
+		entries, err := readDir(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range entries {
+			if e.IsDir() {
+				continue
+			}
+
+			name := e.Name()
+			for _, suffix := range suffixes {
+				if hash := strings.TrimSuffix(name, suffix); hash != name && hash != "" {
+					ret[hash] = append(ret[hash], filepath.Join(dir, name))
+					break
+				}
+			}
+		}
+	}
+
+	// marker directories are named by hash
+	entries, err := readDir(p.Generated.Markers)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		if e.IsDir() {
+			ret[e.Name()] = append(ret[e.Name()], filepath.Join(p.Generated.Markers, e.Name()))
+		}
+	}
+
+	for _, v := range ret {
+		sort.Strings(v)
+	}
+
+	return ret, nil
+}
+
+// readDir returns the entries of the directory. Returns no entries if the
+// directory does not exist.
+func readDir(dir string) ([]os.DirEntry, error) {
+	if dir == "" {
+		return nil, nil
+	}
+
+	ret, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	return ret, err
+}
@@ -0,0 +1,63 @@
+package scene
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models/paths"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneratedFilesByHash(t *testing.T) {
+	dir := t.TempDir()
+	p := paths.NewPaths(dir)
+
+	files := []string{
+		p.Scene.GetScreenshotPath("a"),
+		p.Scene.GetThumbnailScreenshotPath("a"),
+		p.Scene.GetVideoPreviewPath("a"),
+		p.Scene.GetSpriteImageFilePath("a"),
+		p.Scene.GetSpriteVttFilePath("b"),
+		p.Scene.GetTranscodePath("b"),
+		p.Scene.GetInteractiveHeatmapPath("c"),
+		p.Scene.GetContactSheetPath("c"),
+		p.SceneMarkers.GetVideoPreviewPath("d", 10),
+		// not a generated scene file
+		filepath.Join(p.Generated.Screenshots, "unknown.txt"),
+	}
+
+	for _, f := range files {
+		if err := os.MkdirAll(filepath.Dir(f), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(f, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := GeneratedFilesByHash(&p)
+	if err != nil {
+		t.Fatalf("GeneratedFilesByHash() error = %v", err)
+	}
+
+	assert.Equal(t, map[string][]string{
+		"a": {
+			p.Scene.GetScreenshotPath("a"),
+			p.Scene.GetVideoPreviewPath("a"),
+			p.Scene.GetThumbnailScreenshotPath("a"),
+			p.Scene.GetSpriteImageFilePath("a"),
+		},
+		"b": {
+			p.Scene.GetTranscodePath("b"),
+			p.Scene.GetSpriteVttFilePath("b"),
+		},
+		"c": {
+			p.Scene.GetInteractiveHeatmapPath("c"),
+			p.Scene.GetContactSheetPath("c"),
+		},
+		"d": {
+			filepath.Join(p.Generated.Markers, "d"),
+		},
+	}, got)
+}
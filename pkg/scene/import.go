@@ -112,6 +112,8 @@ func (i *Importer) sceneJSONToScene(sceneJSON jsonschema.Scene) models.Scene {
 	newScene.ResumeTime = sceneJSON.ResumeTime
 	newScene.PlayDuration = sceneJSON.PlayDuration
 	newScene.PlayCount = sceneJSON.PlayCount
+	newScene.IntroEnd = sceneJSON.IntroEnd
+	newScene.OutroStart = sceneJSON.OutroStart
 
 	return newScene
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
 
 	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/fsutil"
@@ -33,6 +32,11 @@ func (s *Service) Merge(ctx context.Context, sourceIDs []int, destinationID int,
 		return fmt.Errorf("finding source scenes: %w", err)
 	}
 
+	if err := dest.LoadFiles(ctx, s.Repository); err != nil {
+		return fmt.Errorf("loading files of destination scene: %w", err)
+	}
+
+	files := dest.Files.List()
 	var fileIDs []file.ID
 
 	for _, src := range sources {
@@ -44,6 +48,7 @@ func (s *Service) Merge(ctx context.Context, sourceIDs []int, destinationID int,
 
 		for _, f := range src.Files.List() {
 			fileIDs = append(fileIDs, f.Base().ID)
+			files = append(files, f)
 		}
 
 		if err := s.mergeSceneMarkers(ctx, dest, src); err != nil {
@@ -57,12 +62,12 @@ func (s *Service) Merge(ctx context.Context, sourceIDs []int, destinationID int,
 			return fmt.Errorf("moving files to destination scene: %w", err)
 		}
 
-		// if scene didn't already have a primary file, then set it now
-		if dest.PrimaryFileID == nil {
-			scenePartial.PrimaryFileID = &fileIDs[0]
-		} else {
-			// don't allow changing primary file ID from the input values
-			scenePartial.PrimaryFileID = nil
+		// don't allow changing primary file ID from the input values
+		scenePartial.PrimaryFileID = nil
+
+		primary := NewPrimaryFileSelector(s.Config).Select(files, dest.PrimaryFileID)
+		if dest.PrimaryFileID == nil || *dest.PrimaryFileID != primary.ID {
+			scenePartial.PrimaryFileID = &primary.ID
 		}
 	}
 
@@ -95,6 +100,10 @@ func (s *Service) mergeSceneMarkers(ctx context.Context, dest *models.Scene, src
 
 	destHash := dest.GetHash(s.Config.GetVideoFileNamingAlgorithm())
 
+	// the scenes may use different generated paths
+	srcPaths := s.Paths.ForPath(src.Path).SceneMarkers
+	destPaths := s.Paths.ForPath(dest.Path).SceneMarkers
+
 	for _, m := range markers {
 		srcHash := src.GetHash(s.Config.GetVideoFileNamingAlgorithm())
 
@@ -108,16 +117,16 @@ func (s *Service) mergeSceneMarkers(ctx context.Context, dest *models.Scene, src
 		// move generated files to new location
 		toRename = append(toRename, []rename{
 			{
-				src:  s.Paths.SceneMarkers.GetScreenshotPath(srcHash, int(m.Seconds)),
-				dest: s.Paths.SceneMarkers.GetScreenshotPath(destHash, int(m.Seconds)),
+				src:  srcPaths.GetScreenshotPath(srcHash, int(m.Seconds)),
+				dest: destPaths.GetScreenshotPath(destHash, int(m.Seconds)),
 			},
 			{
-				src:  s.Paths.SceneMarkers.GetThumbnailPath(srcHash, int(m.Seconds)),
-				dest: s.Paths.SceneMarkers.GetThumbnailPath(destHash, int(m.Seconds)),
+				src:  srcPaths.GetThumbnailPath(srcHash, int(m.Seconds)),
+				dest: destPaths.GetThumbnailPath(destHash, int(m.Seconds)),
 			},
 			{
-				src:  s.Paths.SceneMarkers.GetWebpPreviewPath(srcHash, int(m.Seconds)),
-				dest: s.Paths.SceneMarkers.GetWebpPreviewPath(destHash, int(m.Seconds)),
+				src:  srcPaths.GetWebpPreviewPath(srcHash, int(m.Seconds)),
+				dest: destPaths.GetWebpPreviewPath(destHash, int(m.Seconds)),
 			},
 		}...)
 	}
@@ -130,7 +139,7 @@ func (s *Service) mergeSceneMarkers(ctx context.Context, dest *models.Scene, src
 				destExists, _ := fsutil.FileExists(e.dest)
 
 				if srcExists && !destExists {
-					if err := os.Rename(e.src, e.dest); err != nil {
+					if err := fsutil.SafeMove(e.src, e.dest); err != nil {
 						logger.Errorf("Error renaming generated marker file from %s to %s: %v", e.src, e.dest, err)
 					}
 				}
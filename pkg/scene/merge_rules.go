@@ -0,0 +1,418 @@
+package scene
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil/intslice"
+)
+
+// DefaultMergeRules are the merge modes used by MergeWithRules for fields
+// without a rule.
+var DefaultMergeRules = map[models.SceneMergeField]models.SceneMergeMode{
+	models.SceneMergeFieldTitle:       models.SceneMergeModePreferSource,
+	models.SceneMergeFieldCode:        models.SceneMergeModePreferSource,
+	models.SceneMergeFieldDetails:     models.SceneMergeModeKeepLongest,
+	models.SceneMergeFieldDirector:    models.SceneMergeModePreferSource,
+	models.SceneMergeFieldURL:         models.SceneMergeModePreferSource,
+	models.SceneMergeFieldDate:        models.SceneMergeModePreferSource,
+	models.SceneMergeFieldRating:      models.SceneMergeModeKeepMax,
+	models.SceneMergeFieldOrganized:   models.SceneMergeModeKeepMax,
+	models.SceneMergeFieldOCounter:    models.SceneMergeModeKeepMax,
+	models.SceneMergeFieldPlayCount:   models.SceneMergeModeKeepMax,
+	models.SceneMergeFieldStudio:      models.SceneMergeModePreferSource,
+	models.SceneMergeFieldTags:        models.SceneMergeModeUnion,
+	models.SceneMergeFieldPerformers:  models.SceneMergeModeUnion,
+	models.SceneMergeFieldGalleries:   models.SceneMergeModeUnion,
+	models.SceneMergeFieldMovies:      models.SceneMergeModeUnion,
+	models.SceneMergeFieldStashIDs:    models.SceneMergeModeUnion,
+	models.SceneMergeFieldPrimaryFile: models.SceneMergeModeKeepMax,
+}
+
+// defaultDetailsSeparator is used to join details in UNION mode when the
+// rule has no separator.
+const defaultDetailsSeparator = "\n\n"
+
+// paragraphSeparatorRE matches the blank lines between paragraphs of details.
+var paragraphSeparatorRE = regexp.MustCompile(`\r?\n\s*\n`)
+
+var (
+	stringMergeModes = []models.SceneMergeMode{models.SceneMergeModeKeepLongest, models.SceneMergeModePreferSource}
+	valueMergeModes  = []models.SceneMergeMode{models.SceneMergeModeKeepMax, models.SceneMergeModePreferSource}
+	listMergeModes   = []models.SceneMergeMode{models.SceneMergeModeUnion, models.SceneMergeModeKeepLongest, models.SceneMergeModePreferSource}
+)
+
+// validMergeModes are the merge modes supported by each field. For the
+// primary file, KEEP_MAX keeps the highest resolution file and KEEP_LONGEST
+// keeps the longest file.
+var validMergeModes = map[models.SceneMergeField][]models.SceneMergeMode{
+	models.SceneMergeFieldTitle:       stringMergeModes,
+	models.SceneMergeFieldCode:        stringMergeModes,
+	models.SceneMergeFieldDetails:     {models.SceneMergeModeKeepLongest, models.SceneMergeModePreferSource, models.SceneMergeModeUnion},
+	models.SceneMergeFieldDirector:    stringMergeModes,
+	models.SceneMergeFieldURL:         stringMergeModes,
+	models.SceneMergeFieldDate:        valueMergeModes,
+	models.SceneMergeFieldRating:      valueMergeModes,
+	models.SceneMergeFieldOrganized:   valueMergeModes,
+	models.SceneMergeFieldOCounter:    valueMergeModes,
+	models.SceneMergeFieldPlayCount:   valueMergeModes,
+	models.SceneMergeFieldStudio:      {models.SceneMergeModePreferSource},
+	models.SceneMergeFieldTags:        listMergeModes,
+	models.SceneMergeFieldPerformers:  listMergeModes,
+	models.SceneMergeFieldGalleries:   listMergeModes,
+	models.SceneMergeFieldMovies:      listMergeModes,
+	models.SceneMergeFieldStashIDs:    listMergeModes,
+	models.SceneMergeFieldPrimaryFile: {models.SceneMergeModeKeepMax, models.SceneMergeModeKeepLongest, models.SceneMergeModePreferSource},
+}
+
+// MergeRules returns the merge mode of each field, using the provided
+// rules and the default rules for other fields. Returns an error if a mode
+// is not supported by its field.
+func MergeRules(rules []*models.SceneMergeRule) (map[models.SceneMergeField]models.SceneMergeMode, error) {
+	ret := make(map[models.SceneMergeField]models.SceneMergeMode, len(DefaultMergeRules))
+	for k, v := range DefaultMergeRules {
+		ret[k] = v
+	}
+
+	for _, r := range rules {
+		valid := false
+		for _, m := range validMergeModes[r.Field] {
+			if m == r.Mode {
+				valid = true
+				break
+			}
+		}
+
+		if !valid {
+			return nil, fmt.Errorf("merge mode %s is not supported for field %s", r.Mode, r.Field)
+		}
+
+		if r.Separator != nil && (r.Field != models.SceneMergeFieldDetails || r.Mode != models.SceneMergeModeUnion) {
+			return nil, fmt.Errorf("separator is only supported for field %s with merge mode %s", models.SceneMergeFieldDetails, models.SceneMergeModeUnion)
+		}
+
+		ret[r.Field] = r.Mode
+	}
+
+	return ret, nil
+}
+
+// detailsSeparator returns the separator used to join details in UNION
+// mode.
+func detailsSeparator(rules []*models.SceneMergeRule) string {
+	for _, r := range rules {
+		if r.Field == models.SceneMergeFieldDetails && r.Separator != nil {
+			return *r.Separator
+		}
+	}
+
+	return defaultDetailsSeparator
+}
+
+// MergeWithRules merges the scenes with the provided ids into the first
+// scene, merging each field according to the provided rules. Fields without
+// a rule use DefaultMergeRules.
+func (s *Service) MergeWithRules(ctx context.Context, ids []int, rules []*models.SceneMergeRule) error {
+	modes, err := MergeRules(rules)
+	if err != nil {
+		return err
+	}
+
+	ids = intslice.IntAppendUniques(nil, ids)
+	if len(ids) < 2 {
+		return errors.New("at least two scenes are required to merge")
+	}
+
+	scenes := make([]*models.Scene, len(ids))
+	for i, id := range ids {
+		scn, err := s.Repository.Find(ctx, id)
+		if err != nil {
+			return fmt.Errorf("finding scene %d: %w", id, err)
+		}
+
+		if scn == nil {
+			return fmt.Errorf("scene with id %d not found", id)
+		}
+
+		if err := scn.LoadRelationships(ctx, s.Repository); err != nil {
+			return fmt.Errorf("loading scene relationships from %d: %w", id, err)
+		}
+
+		scenes[i] = scn
+	}
+
+	partial, primaryFileID := mergeScenes(scenes, modes, detailsSeparator(rules))
+
+	destinationID := ids[0]
+	if err := s.Merge(ctx, ids[1:], destinationID, partial); err != nil {
+		return err
+	}
+
+	if primaryFileID != nil {
+		dest := scenes[0]
+		if dest.PrimaryFileID == nil || *dest.PrimaryFileID != *primaryFileID {
+			p := models.NewScenePartial()
+			p.PrimaryFileID = primaryFileID
+			if _, err := s.Repository.UpdatePartial(ctx, destinationID, p); err != nil {
+				return fmt.Errorf("setting primary file: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// mergeScenes returns the values of the first scene after merging the
+// provided scenes according to modes, and the id of the file that should be
+// the primary file. Details are joined using separator in UNION mode. The
+// relationships of the scenes must be loaded.
+func mergeScenes(scenes []*models.Scene, modes map[models.SceneMergeField]models.SceneMergeMode, separator string) (models.ScenePartial, *file.ID) {
+	ret := models.NewScenePartial()
+
+	ret.Title = models.NewOptionalString(mergeString(scenes, modes[models.SceneMergeFieldTitle], func(s *models.Scene) string { return s.Title }))
+	ret.Code = models.NewOptionalString(mergeString(scenes, modes[models.SceneMergeFieldCode], func(s *models.Scene) string { return s.Code }))
+	ret.Details = models.NewOptionalString(mergeDetails(scenes, modes[models.SceneMergeFieldDetails], separator))
+	ret.Director = models.NewOptionalString(mergeString(scenes, modes[models.SceneMergeFieldDirector], func(s *models.Scene) string { return s.Director }))
+	ret.URL = models.NewOptionalString(mergeString(scenes, modes[models.SceneMergeFieldURL], func(s *models.Scene) string { return s.URL }))
+
+	if d := mergeDate(scenes, modes[models.SceneMergeFieldDate]); d != nil {
+		ret.Date = models.NewOptionalDate(*d)
+	}
+
+	if r := mergeIntPtr(scenes, modes[models.SceneMergeFieldRating], func(s *models.Scene) *int { return s.Rating }); r != nil {
+		ret.Rating = models.NewOptionalInt(*r)
+	}
+
+	if studioID := mergeIntPtr(scenes, modes[models.SceneMergeFieldStudio], func(s *models.Scene) *int { return s.StudioID }); studioID != nil {
+		ret.StudioID = models.NewOptionalInt(*studioID)
+	}
+
+	organized := scenes[0].Organized
+	if modes[models.SceneMergeFieldOrganized] == models.SceneMergeModeKeepMax {
+		for _, s := range scenes {
+			organized = organized || s.Organized
+		}
+	}
+	ret.Organized = models.NewOptionalBool(organized)
+
+	ret.OCounter = models.NewOptionalInt(mergeInt(scenes, modes[models.SceneMergeFieldOCounter], func(s *models.Scene) int { return s.OCounter }))
+	ret.PlayCount = models.NewOptionalInt(mergeInt(scenes, modes[models.SceneMergeFieldPlayCount], func(s *models.Scene) int { return s.PlayCount }))
+
+	ret.TagIDs = &models.UpdateIDs{
+		IDs:  mergeIDs(scenes, modes[models.SceneMergeFieldTags], func(s *models.Scene) []int { return s.TagIDs.List() }),
+		Mode: models.RelationshipUpdateModeSet,
+	}
+	ret.PerformerIDs = &models.UpdateIDs{
+		IDs:  mergeIDs(scenes, modes[models.SceneMergeFieldPerformers], func(s *models.Scene) []int { return s.PerformerIDs.List() }),
+		Mode: models.RelationshipUpdateModeSet,
+	}
+	ret.GalleryIDs = &models.UpdateIDs{
+		IDs:  mergeIDs(scenes, modes[models.SceneMergeFieldGalleries], func(s *models.Scene) []int { return s.GalleryIDs.List() }),
+		Mode: models.RelationshipUpdateModeSet,
+	}
+	ret.MovieIDs = &models.UpdateMovieIDs{
+		Movies: mergeMovies(scenes, modes[models.SceneMergeFieldMovies]),
+		Mode:   models.RelationshipUpdateModeSet,
+	}
+	ret.StashIDs = &models.UpdateStashIDs{
+		StashIDs: mergeStashIDs(scenes, modes[models.SceneMergeFieldStashIDs]),
+		Mode:     models.RelationshipUpdateModeSet,
+	}
+
+	return ret, mergePrimaryFile(scenes, modes[models.SceneMergeFieldPrimaryFile])
+}
+
+func mergeString(scenes []*models.Scene, mode models.SceneMergeMode, get func(s *models.Scene) string) string {
+	ret := ""
+	for _, s := range scenes {
+		v := get(s)
+		if mode == models.SceneMergeModeKeepLongest {
+			if len(v) > len(ret) {
+				ret = v
+			}
+		} else if v != "" {
+			return v
+		}
+	}
+
+	return ret
+}
+
+// mergeDetails merges the details of the scenes. In UNION mode, the
+// paragraphs of all scenes are joined using separator, omitting paragraphs
+// that are identical to an earlier paragraph.
+func mergeDetails(scenes []*models.Scene, mode models.SceneMergeMode, separator string) string {
+	if mode != models.SceneMergeModeUnion {
+		return mergeString(scenes, mode, func(s *models.Scene) string { return s.Details })
+	}
+
+	var paragraphs []string
+	found := make(map[string]bool)
+	for _, s := range scenes {
+		for _, p := range paragraphSeparatorRE.Split(s.Details, -1) {
+			p = strings.TrimSpace(p)
+			if p != "" && !found[p] {
+				found[p] = true
+				paragraphs = append(paragraphs, p)
+			}
+		}
+	}
+
+	return strings.Join(paragraphs, separator)
+}
+
+func mergeDate(scenes []*models.Scene, mode models.SceneMergeMode) *models.Date {
+	var ret *models.Date
+	for _, s := range scenes {
+		if s.Date == nil {
+			continue
+		}
+
+		if mode != models.SceneMergeModeKeepMax {
+			return s.Date
+		}
+
+		if ret == nil || s.Date.After(ret.Time) {
+			ret = s.Date
+		}
+	}
+
+	return ret
+}
+
+func mergeIntPtr(scenes []*models.Scene, mode models.SceneMergeMode, get func(s *models.Scene) *int) *int {
+	var ret *int
+	for _, s := range scenes {
+		v := get(s)
+		if v == nil {
+			continue
+		}
+
+		if mode != models.SceneMergeModeKeepMax {
+			return v
+		}
+
+		if ret == nil || *v > *ret {
+			ret = v
+		}
+	}
+
+	return ret
+}
+
+func mergeInt(scenes []*models.Scene, mode models.SceneMergeMode, get func(s *models.Scene) int) int {
+	ret := 0
+	for _, s := range scenes {
+		v := get(s)
+		if mode != models.SceneMergeModeKeepMax {
+			if v != 0 {
+				return v
+			}
+		} else if v > ret {
+			ret = v
+		}
+	}
+
+	return ret
+}
+
+// mergeList returns the index of the scene whose list should be used for
+// the KEEP_LONGEST and PREFER_SOURCE modes.
+func mergeList(scenes []*models.Scene, mode models.SceneMergeMode, length func(s *models.Scene) int) int {
+	ret := 0
+	for i, s := range scenes {
+		l := length(s)
+		if mode == models.SceneMergeModePreferSource {
+			if l > 0 {
+				return i
+			}
+		} else if l > length(scenes[ret]) {
+			ret = i
+		}
+	}
+
+	return ret
+}
+
+func mergeIDs(scenes []*models.Scene, mode models.SceneMergeMode, get func(s *models.Scene) []int) []int {
+	if mode == models.SceneMergeModeUnion {
+		var ret []int
+		for _, s := range scenes {
+			ret = intslice.IntAppendUniques(ret, get(s))
+		}
+		return ret
+	}
+
+	i := mergeList(scenes, mode, func(s *models.Scene) int { return len(get(s)) })
+	return get(scenes[i])
+}
+
+func mergeMovies(scenes []*models.Scene, mode models.SceneMergeMode) []models.MoviesScenes {
+	if mode == models.SceneMergeModeUnion {
+		var ret []models.MoviesScenes
+		found := make(map[int]bool)
+		for _, s := range scenes {
+			for _, m := range s.Movies.List() {
+				if !found[m.MovieID] {
+					found[m.MovieID] = true
+					ret = append(ret, m)
+				}
+			}
+		}
+		return ret
+	}
+
+	i := mergeList(scenes, mode, func(s *models.Scene) int { return len(s.Movies.List()) })
+	return scenes[i].Movies.List()
+}
+
+func mergeStashIDs(scenes []*models.Scene, mode models.SceneMergeMode) []models.StashID {
+	if mode == models.SceneMergeModeUnion {
+		// scenes can only have one stash id per endpoint
+		var ret []models.StashID
+		found := make(map[string]bool)
+		for _, s := range scenes {
+			for _, id := range s.StashIDs.List() {
+				if !found[id.Endpoint] {
+					found[id.Endpoint] = true
+					ret = append(ret, id)
+				}
+			}
+		}
+		return ret
+	}
+
+	i := mergeList(scenes, mode, func(s *models.Scene) int { return len(s.StashIDs.List()) })
+	return scenes[i].StashIDs.List()
+}
+
+func mergePrimaryFile(scenes []*models.Scene, mode models.SceneMergeMode) *file.ID {
+	var ret *file.VideoFile
+	for _, s := range scenes {
+		for _, f := range s.Files.List() {
+			switch {
+			case ret == nil:
+				ret = f
+			case mode == models.SceneMergeModeKeepMax:
+				if f.Width*f.Height > ret.Width*ret.Height {
+					ret = f
+				}
+			case mode == models.SceneMergeModeKeepLongest:
+				if f.Duration > ret.Duration {
+					ret = f
+				}
+			}
+		}
+	}
+
+	if ret == nil {
+		return nil
+	}
+
+	return &ret.ID
+}
@@ -0,0 +1,174 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeRules(t *testing.T) {
+	rules, err := MergeRules([]*models.SceneMergeRule{
+		{Field: models.SceneMergeFieldTitle, Mode: models.SceneMergeModeKeepLongest},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, models.SceneMergeModeKeepLongest, rules[models.SceneMergeFieldTitle])
+		assert.Equal(t, models.SceneMergeModeUnion, rules[models.SceneMergeFieldTags])
+	}
+
+	_, err = MergeRules([]*models.SceneMergeRule{
+		{Field: models.SceneMergeFieldTitle, Mode: models.SceneMergeModeUnion},
+	})
+	assert.Error(t, err)
+
+	separator := " | "
+	_, err = MergeRules([]*models.SceneMergeRule{
+		{Field: models.SceneMergeFieldDetails, Mode: models.SceneMergeModeUnion, Separator: &separator},
+	})
+	assert.NoError(t, err)
+
+	_, err = MergeRules([]*models.SceneMergeRule{
+		{Field: models.SceneMergeFieldDetails, Mode: models.SceneMergeModeKeepLongest, Separator: &separator},
+	})
+	assert.Error(t, err)
+}
+
+func TestMergeDetails(t *testing.T) {
+	scenes := func(details ...string) []*models.Scene {
+		var ret []*models.Scene
+		for _, d := range details {
+			ret = append(ret, &models.Scene{Details: d})
+		}
+		return ret
+	}
+
+	tests := []struct {
+		name      string
+		scenes    []*models.Scene
+		mode      models.SceneMergeMode
+		separator string
+		want      string
+	}{
+		{"keep longest", scenes("short", "much longer"), models.SceneMergeModeKeepLongest, "", "much longer"},
+		{"prefer source", scenes("", "first", "second"), models.SceneMergeModePreferSource, "", "first"},
+		{"union identical", scenes("synopsis", "synopsis", " synopsis\n"), models.SceneMergeModeUnion, defaultDetailsSeparator, "synopsis"},
+		{"union paragraphs", scenes("one\n\ntwo", "two\r\n\r\nthree", ""), models.SceneMergeModeUnion, defaultDetailsSeparator, "one\n\ntwo\n\nthree"},
+		{"union separator", scenes("one", "two", "one"), models.SceneMergeModeUnion, " | ", "one | two"},
+		{"union empty", scenes("", ""), models.SceneMergeModeUnion, defaultDetailsSeparator, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, mergeDetails(tt.scenes, tt.mode, tt.separator))
+		})
+	}
+}
+
+func TestMergeScenes(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+	date := func(s string) *models.Date {
+		d := models.NewDate(s)
+		return &d
+	}
+	videoFile := func(id file.ID, width, height int, duration float64) *file.VideoFile {
+		return &file.VideoFile{
+			BaseFile: &file.BaseFile{ID: id},
+			Width:    width,
+			Height:   height,
+			Duration: duration,
+		}
+	}
+	newScene := func(s models.Scene, tagIDs []int, stashIDs []models.StashID, files []*file.VideoFile) *models.Scene {
+		if tagIDs == nil {
+			tagIDs = []int{}
+		}
+		if stashIDs == nil {
+			stashIDs = []models.StashID{}
+		}
+		if files == nil {
+			files = []*file.VideoFile{}
+		}
+		s.TagIDs = models.NewRelatedIDs(tagIDs)
+		s.PerformerIDs = models.NewRelatedIDs([]int{})
+		s.GalleryIDs = models.NewRelatedIDs([]int{})
+		s.Movies = models.NewRelatedMovies([]models.MoviesScenes{})
+		s.StashIDs = models.NewRelatedStashIDs(stashIDs)
+		s.Files = models.NewRelatedVideoFiles(files)
+		return &s
+	}
+
+	scenes := []*models.Scene{
+		newScene(models.Scene{
+			ID:       1,
+			Title:    "",
+			Details:  "short",
+			Date:     date("2001-01-01"),
+			OCounter: 2,
+		}, []int{1, 2}, []models.StashID{{Endpoint: "a", StashID: "1"}}, []*file.VideoFile{
+			videoFile(1, 640, 480, 100),
+		}),
+		newScene(models.Scene{
+			ID:        2,
+			Title:     "title 2",
+			Details:   "much longer details",
+			Date:      date("2002-01-01"),
+			Rating:    intPtr(60),
+			Organized: true,
+			StudioID:  intPtr(3),
+		}, []int{2, 3}, []models.StashID{{Endpoint: "a", StashID: "2"}, {Endpoint: "b", StashID: "3"}}, []*file.VideoFile{
+			videoFile(2, 1920, 1080, 90),
+			videoFile(3, 1280, 720, 120),
+		}),
+		newScene(models.Scene{
+			ID:     3,
+			Title:  "a much longer title",
+			Rating: intPtr(80),
+		}, nil, nil, nil),
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		got, primaryFileID := mergeScenes(scenes, DefaultMergeRules, defaultDetailsSeparator)
+
+		assert.Equal(t, "title 2", got.Title.Value)
+		assert.Equal(t, "much longer details", got.Details.Value)
+		assert.Equal(t, "2001-01-01", got.Date.Value.String())
+		assert.Equal(t, 80, got.Rating.Value)
+		assert.True(t, got.Organized.Value)
+		assert.Equal(t, 2, got.OCounter.Value)
+		assert.Equal(t, 3, got.StudioID.Value)
+		assert.Equal(t, []int{1, 2, 3}, got.TagIDs.IDs)
+		assert.Equal(t, []models.StashID{{Endpoint: "a", StashID: "1"}, {Endpoint: "b", StashID: "3"}}, got.StashIDs.StashIDs)
+		if assert.NotNil(t, primaryFileID) {
+			assert.Equal(t, file.ID(2), *primaryFileID)
+		}
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		modes, err := MergeRules([]*models.SceneMergeRule{
+			{Field: models.SceneMergeFieldTitle, Mode: models.SceneMergeModeKeepLongest},
+			{Field: models.SceneMergeFieldDetails, Mode: models.SceneMergeModePreferSource},
+			{Field: models.SceneMergeFieldDate, Mode: models.SceneMergeModeKeepMax},
+			{Field: models.SceneMergeFieldRating, Mode: models.SceneMergeModePreferSource},
+			{Field: models.SceneMergeFieldOrganized, Mode: models.SceneMergeModePreferSource},
+			{Field: models.SceneMergeFieldTags, Mode: models.SceneMergeModePreferSource},
+			{Field: models.SceneMergeFieldStashIDs, Mode: models.SceneMergeModeKeepLongest},
+			{Field: models.SceneMergeFieldPrimaryFile, Mode: models.SceneMergeModeKeepLongest},
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		got, primaryFileID := mergeScenes(scenes, modes, defaultDetailsSeparator)
+
+		assert.Equal(t, "a much longer title", got.Title.Value)
+		assert.Equal(t, "short", got.Details.Value)
+		assert.Equal(t, "2002-01-01", got.Date.Value.String())
+		assert.Equal(t, 60, got.Rating.Value)
+		assert.False(t, got.Organized.Value)
+		assert.Equal(t, []int{1, 2}, got.TagIDs.IDs)
+		assert.Equal(t, []models.StashID{{Endpoint: "a", StashID: "2"}, {Endpoint: "b", StashID: "3"}}, got.StashIDs.StashIDs)
+		if assert.NotNil(t, primaryFileID) {
+			assert.Equal(t, file.ID(3), *primaryFileID)
+		}
+	})
+}
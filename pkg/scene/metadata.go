@@ -0,0 +1,123 @@
+package scene
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/jsonschema"
+	"github.com/stashapp/stash/pkg/performer"
+	"github.com/stashapp/stash/pkg/studio"
+)
+
+type PerformerFinder interface {
+	FindBySceneID(ctx context.Context, sceneID int) ([]*models.Performer, error)
+}
+
+// ToMetadataJSON converts a scene into a JSON object containing only its
+// shareable metadata. Local details such as file paths, the cover image,
+// ratings and play history are not included. The scene's primary file and
+// stash IDs must be loaded.
+func ToMetadataJSON(ctx context.Context, studioReader studio.Finder, performerReader PerformerFinder, tagReader TagFinder, scene *models.Scene) (*jsonschema.Scene, error) {
+	ret := jsonschema.Scene{
+		Title:    scene.Title,
+		Code:     scene.Code,
+		URL:      scene.URL,
+		Details:  scene.Details,
+		Director: scene.Director,
+		StashIDs: scene.StashIDs.List(),
+	}
+
+	if scene.Date != nil {
+		ret.Date = scene.Date.String()
+	}
+
+	if f := scene.Files.Primary(); f != nil {
+		ret.Duration = f.Duration
+	}
+
+	var err error
+	ret.Studio, err = GetStudioName(ctx, studioReader, scene)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scene studio name: %v", err)
+	}
+
+	performers, err := performerReader.FindBySceneID(ctx, scene.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scene performers: %v", err)
+	}
+	ret.Performers = performer.GetNames(performers)
+
+	ret.Tags, err = GetTagNames(ctx, tagReader, scene)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// MetadataPartial returns a partial which applies the metadata of the input
+// to an existing scene. Empty fields in the input are left unchanged, and
+// performers, tags and stash IDs are added to the existing ones. The studio,
+// performers and tags are matched by name, and handled according to
+// MissingRefBehaviour if they do not exist. Files, galleries, movies and
+// markers in the input are ignored.
+func (i *Importer) MetadataPartial(ctx context.Context) (models.ScenePartial, error) {
+	i.scene = i.sceneJSONToScene(i.Input)
+
+	if err := i.populateStudio(ctx); err != nil {
+		return models.ScenePartial{}, err
+	}
+
+	if err := i.populatePerformers(ctx); err != nil {
+		return models.ScenePartial{}, err
+	}
+
+	if err := i.populateTags(ctx); err != nil {
+		return models.ScenePartial{}, err
+	}
+
+	ret := models.NewScenePartial()
+	if i.scene.Title != "" {
+		ret.Title = models.NewOptionalString(i.scene.Title)
+	}
+	if i.scene.Code != "" {
+		ret.Code = models.NewOptionalString(i.scene.Code)
+	}
+	if i.scene.Details != "" {
+		ret.Details = models.NewOptionalString(i.scene.Details)
+	}
+	if i.scene.Director != "" {
+		ret.Director = models.NewOptionalString(i.scene.Director)
+	}
+	if i.scene.URL != "" {
+		ret.URL = models.NewOptionalString(i.scene.URL)
+	}
+	if i.scene.Date != nil {
+		ret.Date = models.NewOptionalDate(*i.scene.Date)
+	}
+	if i.scene.StudioID != nil {
+		ret.StudioID = models.NewOptionalInt(*i.scene.StudioID)
+	}
+
+	if ids := i.scene.PerformerIDs.List(); len(ids) > 0 {
+		ret.PerformerIDs = &models.UpdateIDs{
+			IDs:  ids,
+			Mode: models.RelationshipUpdateModeAdd,
+		}
+	}
+	if ids := i.scene.TagIDs.List(); len(ids) > 0 {
+		ret.TagIDs = &models.UpdateIDs{
+			IDs:  ids,
+			Mode: models.RelationshipUpdateModeAdd,
+		}
+	}
+	if stashIDs := i.scene.StashIDs.List(); len(stashIDs) > 0 {
+		ret.StashIDs = &models.UpdateStashIDs{
+			StashIDs: stashIDs,
+			Mode:     models.RelationshipUpdateModeAdd,
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,82 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/jsonschema"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestImporterMetadataPartial(t *testing.T) {
+	studioReaderWriter := &mocks.StudioReaderWriter{}
+	performerReaderWriter := &mocks.PerformerReaderWriter{}
+	tagReaderWriter := &mocks.TagReaderWriter{}
+
+	stashID := models.StashID{
+		StashID:  "stashID",
+		Endpoint: "endpoint",
+	}
+
+	i := Importer{
+		StudioWriter:    studioReaderWriter,
+		PerformerWriter: performerReaderWriter,
+		TagWriter:       tagReaderWriter,
+		Input: jsonschema.Scene{
+			Title:      title,
+			Date:       date,
+			Studio:     existingStudioName,
+			Performers: []string{missingPerformerName},
+			Tags:       []string{existingTagName},
+			StashIDs:   []models.StashID{stashID},
+			Files:      []string{"/path/to/file.mp4"},
+			Duration:   123.4,
+		},
+		MissingRefBehaviour: models.ImportMissingRefEnumCreate,
+	}
+
+	studioReaderWriter.On("FindByName", testCtx, existingStudioName, false).Return(&models.Studio{
+		ID: existingStudioID,
+	}, nil).Once()
+	performerReaderWriter.On("FindByNames", testCtx, []string{missingPerformerName}, false).Return(nil, nil).Once()
+	performerReaderWriter.On("Create", testCtx, mock.AnythingOfType("*models.Performer")).Run(func(args mock.Arguments) {
+		p := args.Get(1).(*models.Performer)
+		p.ID = existingPerformerID
+	}).Return(nil).Once()
+	tagReaderWriter.On("FindByNames", testCtx, []string{existingTagName}, false).Return([]*models.Tag{
+		{
+			ID:   existingTagID,
+			Name: existingTagName,
+		},
+	}, nil).Once()
+
+	partial, err := i.MetadataPartial(testCtx)
+	assert.Nil(t, err)
+
+	assert.Equal(t, models.NewOptionalString(title), partial.Title)
+	assert.Equal(t, models.NewOptionalDate(models.NewDate(date)), partial.Date)
+	assert.Equal(t, models.NewOptionalInt(existingStudioID), partial.StudioID)
+	assert.Equal(t, &models.UpdateIDs{
+		IDs:  []int{existingPerformerID},
+		Mode: models.RelationshipUpdateModeAdd,
+	}, partial.PerformerIDs)
+	assert.Equal(t, &models.UpdateIDs{
+		IDs:  []int{existingTagID},
+		Mode: models.RelationshipUpdateModeAdd,
+	}, partial.TagIDs)
+	assert.Equal(t, &models.UpdateStashIDs{
+		StashIDs: []models.StashID{stashID},
+		Mode:     models.RelationshipUpdateModeAdd,
+	}, partial.StashIDs)
+
+	// empty fields are left unchanged
+	assert.False(t, partial.Details.Set)
+	assert.False(t, partial.URL.Set)
+	assert.Nil(t, partial.GalleryIDs)
+
+	studioReaderWriter.AssertExpectations(t)
+	performerReaderWriter.AssertExpectations(t)
+	tagReaderWriter.AssertExpectations(t)
+}
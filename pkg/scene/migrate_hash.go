@@ -45,6 +45,10 @@ func MigrateHash(p *paths.Paths, oldHash string, newHash string) {
 	migrateSceneFiles(oldPath, newPath)
 	migrateVttFile(newVttPath, oldPath, newPath)
 
+	oldPath = scenePaths.GetContactSheetPath(oldHash)
+	newPath = scenePaths.GetContactSheetPath(newHash)
+	migrateSceneFiles(oldPath, newPath)
+
 	oldPath = scenePaths.GetInteractiveHeatmapPath(oldHash)
 	newPath = scenePaths.GetInteractiveHeatmapPath(newHash)
 	migrateSceneFiles(oldPath, newPath)
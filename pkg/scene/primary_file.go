@@ -0,0 +1,119 @@
+package scene
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// PrimaryFileSelector selects the primary file of a scene with multiple
+// files.
+type PrimaryFileSelector struct {
+	Policy models.PrimaryFilePolicy
+
+	// PathPriority is the list of paths used by the PATH_PRIORITY policy,
+	// in order of priority. Files outside of these paths have the lowest
+	// priority.
+	PathPriority []string
+
+	// ExtensionPriority is the list of file extensions used to choose
+	// between files that are equal under the policy, in order of priority.
+	// It is not used by the EXISTING policy.
+	ExtensionPriority []string
+}
+
+// NewPrimaryFileSelector returns a PrimaryFileSelector using the policy from
+// the provided configuration.
+func NewPrimaryFileSelector(c PrimaryFileConfig) PrimaryFileSelector {
+	return PrimaryFileSelector{
+		Policy:            c.GetPrimaryFilePolicy(),
+		PathPriority:      c.GetPrimaryFilePathPriority(),
+		ExtensionPriority: c.GetPrimaryFileExtensionPriority(),
+	}
+}
+
+// Select returns the file in files to use as the primary file. current is
+// the id of the existing primary file, or nil if there is none. When files
+// are equal under the policy, the file with the highest priority extension
+// is preferred, followed by the existing primary file and then the earliest
+// file in files. Returns nil if files is empty.
+func (s PrimaryFileSelector) Select(files []*file.VideoFile, current *file.ID) *file.VideoFile {
+	if len(files) == 0 {
+		return nil
+	}
+
+	ret := files[0]
+	if current != nil {
+		for _, f := range files {
+			if f.ID == *current {
+				ret = f
+				break
+			}
+		}
+	}
+
+	for _, f := range files {
+		if s.isPreferred(f, ret) {
+			ret = f
+		}
+	}
+
+	return ret
+}
+
+// isPreferred returns true if f should be used as the primary file instead
+// of other.
+func (s PrimaryFileSelector) isPreferred(f *file.VideoFile, other *file.VideoFile) bool {
+	switch s.Policy {
+	case models.PrimaryFilePolicyHighestResolution:
+		if f.Width*f.Height != other.Width*other.Height {
+			return f.Width*f.Height > other.Width*other.Height
+		}
+	case models.PrimaryFilePolicyLargestSize:
+		if f.Size != other.Size {
+			return f.Size > other.Size
+		}
+	case models.PrimaryFilePolicyPathPriority:
+		if s.pathRank(f.Path) != s.pathRank(other.Path) {
+			return s.pathRank(f.Path) < s.pathRank(other.Path)
+		}
+	case models.PrimaryFilePolicyNewest:
+		if !f.ModTime.Equal(other.ModTime) {
+			return f.ModTime.After(other.ModTime)
+		}
+	default:
+		return false
+	}
+
+	return s.extensionRank(f.Path) < s.extensionRank(other.Path)
+}
+
+// pathRank returns the index of the first path in PathPriority that
+// contains p, or the length of PathPriority if none do.
+func (s PrimaryFileSelector) pathRank(p string) int {
+	for i, dir := range s.PathPriority {
+		if fsutil.IsPathInDir(dir, p) {
+			return i
+		}
+	}
+
+	return len(s.PathPriority)
+}
+
+// extensionRank returns the index of the extension of p in
+// ExtensionPriority, or the length of ExtensionPriority if it is not
+// present. Extensions are matched case-insensitively, with or without a
+// leading period.
+func (s PrimaryFileSelector) extensionRank(p string) int {
+	ext := strings.TrimPrefix(filepath.Ext(p), ".")
+	for i, e := range s.ExtensionPriority {
+		if strings.EqualFold(strings.TrimPrefix(e, "."), ext) {
+			return i
+		}
+	}
+
+	return len(s.ExtensionPriority)
+}
@@ -0,0 +1,74 @@
+package scene
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrimaryFileSelector_Select(t *testing.T) {
+	fileID := func(id file.ID) *file.ID { return &id }
+	videoFile := func(id file.ID, path string, width, height int, size int64, modTime time.Time) *file.VideoFile {
+		return &file.VideoFile{
+			BaseFile: &file.BaseFile{
+				DirEntry: file.DirEntry{
+					ModTime: modTime,
+				},
+				ID:   id,
+				Path: path,
+				Size: size,
+			},
+			Width:  width,
+			Height: height,
+		}
+	}
+
+	now := time.Now()
+	small := videoFile(1, filepath.Join("archive", "small.mp4"), 640, 480, 300, now)
+	large := videoFile(2, filepath.Join("library", "large.mp4"), 1920, 1080, 200, now.Add(-time.Hour))
+	newest := videoFile(3, filepath.Join("incoming", "newest.mp4"), 1280, 720, 100, now.Add(time.Hour))
+	smallCopy := videoFile(4, filepath.Join("library", "copy.mp4"), 640, 480, 300, now)
+	smallMKV := videoFile(5, filepath.Join("library", "small.MKV"), 640, 480, 300, now)
+	files := []*file.VideoFile{small, large, newest}
+
+	tests := []struct {
+		name         string
+		policy       models.PrimaryFilePolicy
+		pathPriority []string
+		extensions   []string
+		files        []*file.VideoFile
+		current      *file.ID
+		want         *file.VideoFile
+	}{
+		{"no files", models.PrimaryFilePolicyHighestResolution, nil, nil, nil, nil, nil},
+		{"existing", models.PrimaryFilePolicyExisting, nil, nil, files, fileID(3), newest},
+		{"existing removed", models.PrimaryFilePolicyExisting, nil, nil, files, fileID(4), small},
+		{"existing none", models.PrimaryFilePolicyExisting, nil, nil, files, nil, small},
+		{"unset", "", nil, nil, files, fileID(2), large},
+		{"highest resolution", models.PrimaryFilePolicyHighestResolution, nil, nil, files, fileID(1), large},
+		{"largest size", models.PrimaryFilePolicyLargestSize, nil, nil, files, fileID(3), small},
+		{"newest", models.PrimaryFilePolicyNewest, nil, nil, files, nil, newest},
+		{"path priority", models.PrimaryFilePolicyPathPriority, []string{"incoming", "library"}, nil, files, fileID(2), newest},
+		{"path priority unmatched", models.PrimaryFilePolicyPathPriority, []string{"other"}, nil, files, fileID(2), large},
+		{"tie keeps existing", models.PrimaryFilePolicyHighestResolution, nil, nil, []*file.VideoFile{small, smallCopy}, fileID(4), smallCopy},
+		{"tie extension priority", models.PrimaryFilePolicyHighestResolution, nil, []string{"mkv", ".mp4"}, []*file.VideoFile{small, smallMKV}, fileID(1), smallMKV},
+		{"tie extension unmatched", models.PrimaryFilePolicyLargestSize, nil, []string{"webm"}, []*file.VideoFile{small, smallMKV}, fileID(5), smallMKV},
+		{"extension priority after policy", models.PrimaryFilePolicyHighestResolution, nil, []string{"mkv"}, []*file.VideoFile{large, smallMKV}, nil, large},
+		{"existing ignores extension priority", models.PrimaryFilePolicyExisting, nil, []string{"mkv"}, []*file.VideoFile{small, smallMKV}, fileID(1), small},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := PrimaryFileSelector{
+				Policy:            tt.policy,
+				PathPriority:      tt.pathPriority,
+				ExtensionPriority: tt.extensions,
+			}
+			got := s.Select(tt.files, tt.current)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
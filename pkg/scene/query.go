@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/stashapp/stash/pkg/job"
@@ -128,3 +129,48 @@ func FilterFromPaths(paths []string) *models.SceneFilterType {
 
 	return ret
 }
+
+// FindPerformerCoverScene returns the highest rated scene of the performer,
+// or their most recent scene if none of their scenes are rated. Returns nil
+// if the performer has no scenes.
+func FindPerformerCoverScene(ctx context.Context, qb Queryer, performerID int) (*models.Scene, error) {
+	performerCriterion := &models.MultiCriterionInput{
+		Value:    []string{strconv.Itoa(performerID)},
+		Modifier: models.CriterionModifierIncludes,
+	}
+	perPage := 1
+	direction := models.SortDirectionEnumDesc
+
+	ratingSort := "rating"
+	scenes, err := Query(ctx, qb, &models.SceneFilterType{
+		Performers: performerCriterion,
+		Rating100: &models.IntCriterionInput{
+			Modifier: models.CriterionModifierNotNull,
+		},
+	}, &models.FindFilterType{
+		PerPage:   &perPage,
+		Sort:      &ratingSort,
+		Direction: &direction,
+	})
+	if err != nil || len(scenes) > 0 {
+		return firstScene(scenes), err
+	}
+
+	dateSort := "date"
+	scenes, err = Query(ctx, qb, &models.SceneFilterType{
+		Performers: performerCriterion,
+	}, &models.FindFilterType{
+		PerPage:   &perPage,
+		Sort:      &dateSort,
+		Direction: &direction,
+	})
+	return firstScene(scenes), err
+}
+
+func firstScene(scenes []*models.Scene) *models.Scene {
+	if len(scenes) == 0 {
+		return nil
+	}
+
+	return scenes[0]
+}
@@ -0,0 +1,163 @@
+package scene
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	minRating100 = 1
+	maxRating100 = 100
+)
+
+// FingerprintRating is the rating of the scenes with a file matching a
+// fingerprint.
+type FingerprintRating struct {
+	Fingerprint string
+	// Rating expressed in 1-100 scale
+	Rating int
+}
+
+// ReadFingerprintRatings reads a mapping of fingerprint to rating. The
+// mapping is either a JSON object mapping fingerprints to ratings, or CSV
+// with the fingerprint in the first column and the rating in the second. A
+// CSV header row is skipped. Ratings are expressed in 1-100 scale.
+func ReadFingerprintRatings(r io.Reader) ([]FingerprintRating, error) {
+	br := bufio.NewReader(r)
+
+	// determine the format from the first non-whitespace character
+	for {
+		b, err := br.Peek(1)
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if !bytes.ContainsAny(b, " \t\r\n") {
+			if b[0] == '{' {
+				return readJSONFingerprintRatings(br)
+			}
+			return readCSVFingerprintRatings(br)
+		}
+
+		if _, err := br.ReadByte(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func readJSONFingerprintRatings(r io.Reader) ([]FingerprintRating, error) {
+	// decode in order so that ratings are applied in file order
+	dec := json.NewDecoder(r)
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("reading JSON ratings: %w", err)
+	}
+
+	var ret []FingerprintRating
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("reading JSON ratings: %w", err)
+		}
+		fp, _ := t.(string)
+
+		var rating int
+		if err := dec.Decode(&rating); err != nil {
+			return nil, fmt.Errorf("reading rating for %q: %w", fp, err)
+		}
+
+		v, err := newFingerprintRating(fp, rating)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, v)
+	}
+
+	return ret, nil
+}
+
+func readCSVFingerprintRatings(r io.Reader) ([]FingerprintRating, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	var ret []FingerprintRating
+	for line := 1; ; line++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading CSV ratings: %w", err)
+		}
+
+		if len(record) < 2 {
+			return nil, fmt.Errorf("line %d: expected fingerprint and rating", line)
+		}
+
+		rating, err := strconv.Atoi(strings.TrimSpace(record[1]))
+		if err != nil {
+			// skip the header row
+			if line == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("line %d: invalid rating %q", line, record[1])
+		}
+
+		v, err := newFingerprintRating(record[0], rating)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		ret = append(ret, v)
+	}
+
+	return ret, nil
+}
+
+func newFingerprintRating(fp string, rating int) (FingerprintRating, error) {
+	fp = strings.TrimSpace(fp)
+	if fp == "" {
+		return FingerprintRating{}, errors.New("fingerprint is empty")
+	}
+
+	if rating < minRating100 || rating > maxRating100 {
+		return FingerprintRating{}, fmt.Errorf("rating %d for %q must be between %d and %d", rating, fp, minRating100, maxRating100)
+	}
+
+	return FingerprintRating{
+		Fingerprint: fp,
+		Rating:      rating,
+	}, nil
+}
+
+type FingerprintRatingFinder interface {
+	FindByChecksum(ctx context.Context, checksum string) ([]*models.Scene, error)
+	FindByOSHash(ctx context.Context, oshash string) ([]*models.Scene, error)
+}
+
+// FindByFingerprintRating returns the scenes with a file with an MD5 or
+// oshash matching the fingerprint of r.
+func FindByFingerprintRating(ctx context.Context, qb FingerprintRatingFinder, r FingerprintRating) ([]*models.Scene, error) {
+	ret, err := qb.FindByChecksum(ctx, r.Fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) > 0 {
+		return ret, nil
+	}
+
+	return qb.FindByOSHash(ctx, r.Fingerprint)
+}
@@ -0,0 +1,82 @@
+package scene
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadFingerprintRatings(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []FingerprintRating
+		wantErr bool
+	}{
+		{
+			"csv",
+			"abc,20\ndef, 100\n",
+			[]FingerprintRating{{"abc", 20}, {"def", 100}},
+			false,
+		},
+		{
+			"csv with header",
+			"fingerprint,rating\nabc,20\n",
+			[]FingerprintRating{{"abc", 20}},
+			false,
+		},
+		{
+			"csv invalid rating",
+			"fingerprint,rating\nabc,x\n",
+			nil,
+			true,
+		},
+		{
+			"csv missing rating",
+			"abc\n",
+			nil,
+			true,
+		},
+		{
+			"csv out of range",
+			"abc,101\n",
+			nil,
+			true,
+		},
+		{
+			"json",
+			"\n {\"abc\": 20, \"def\": 1}",
+			[]FingerprintRating{{"abc", 20}, {"def", 1}},
+			false,
+		},
+		{
+			"json out of range",
+			`{"abc": 0}`,
+			nil,
+			true,
+		},
+		{
+			"json invalid rating",
+			`{"abc": "x"}`,
+			nil,
+			true,
+		},
+		{
+			"empty",
+			"  \n",
+			nil,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadFingerprintRatings(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadFingerprintRatings() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package scene
+
+// EstimateReclaimableSize returns an approximate number of bytes that would
+// be saved by transcoding a file of the provided size and duration, in
+// seconds, to the target bitrate, in bits per second. The estimate ignores
+// container overhead and audio, and is 0 if the file is already at or below
+// the target size.
+func EstimateReclaimableSize(size int64, duration float64, targetBitrate int64) int64 {
+	if duration <= 0 || targetBitrate <= 0 {
+		return 0
+	}
+
+	targetSize := int64(duration * float64(targetBitrate) / 8)
+	if targetSize >= size {
+		return 0
+	}
+
+	return size - targetSize
+}
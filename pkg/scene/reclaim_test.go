@@ -0,0 +1,28 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateReclaimableSize(t *testing.T) {
+	tests := []struct {
+		name          string
+		size          int64
+		duration      float64
+		targetBitrate int64
+		want          int64
+	}{
+		{"no duration", 1000, 0, 8000, 0},
+		{"no target bitrate", 1000, 10, 0, 0},
+		{"below target", 1000, 10, 8000, 0},
+		{"above target", 50000, 10, 8000, 40000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EstimateReclaimableSize(tt.size, tt.duration, tt.targetBitrate)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/models/paths"
 	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 	"github.com/stashapp/stash/pkg/txn"
 )
 
@@ -22,16 +23,58 @@ var (
 type CreatorUpdater interface {
 	FindByFileID(ctx context.Context, fileID file.ID) ([]*models.Scene, error)
 	FindByFingerprints(ctx context.Context, fp []file.Fingerprint) ([]*models.Scene, error)
+	FindStubsByFingerprints(ctx context.Context, fp []file.Fingerprint) ([]*models.Scene, error)
 	Creator
 	UpdatePartial(ctx context.Context, id int, updatedScene models.ScenePartial) (*models.Scene, error)
 	AddFileID(ctx context.Context, id int, fileID file.ID) error
 	models.VideoFileLoader
 }
 
+// Fields of scenes that may be set when scanning.
+const (
+	ScanFieldStudio = "studio"
+	ScanFieldDate   = "date"
+)
+
+// ScanFields are the fields of scenes that may be set when scanning, if they
+// are empty.
+var ScanFields = []string{ScanFieldStudio, ScanFieldDate}
+
 type ScanGenerator interface {
 	Generate(ctx context.Context, s *models.Scene, f *file.VideoFile) error
 }
 
+// ScanRegenerator regenerates the generated files of scenes when the
+// contents of their primary file change.
+type ScanRegenerator interface {
+	// Regenerate replaces the stale generated files, named using staleHash,
+	// of the scene.
+	Regenerate(ctx context.Context, s *models.Scene, f *file.VideoFile, staleHash string) error
+}
+
+// FileStudioMatcher matches a studio using the contents of a video file.
+type FileStudioMatcher interface {
+	// MatchStudio sets the studio of the scenes with the provided ids, which
+	// have no studio, to the studio matched for the file. It is called after
+	// the scan transaction is committed, since reading the contents of the
+	// file may be slow, and must use its own transaction.
+	MatchStudio(ctx context.Context, sceneIDs []int, f *file.VideoFile) error
+}
+
+// FilePathTagger determines tags using the path of a file.
+type FilePathTagger interface {
+	// PathTags returns the ids of the tags for the path of the file.
+	PathTags(ctx context.Context, f file.File) ([]int, error)
+}
+
+// FolderGalleryLinker links scenes to the galleries in the folder of a file.
+type FolderGalleryLinker interface {
+	// LinkScene links the scene to the galleries in the folder of the file.
+	// The date of the scene must not be set from the galleries if
+	// protectDate is true.
+	LinkScene(ctx context.Context, s *models.Scene, f file.File, protectDate bool) error
+}
+
 type ScanHandler struct {
 	CreatorUpdater CreatorUpdater
 
@@ -40,6 +83,35 @@ type ScanHandler struct {
 	CaptionUpdater video.CaptionUpdater
 	PluginCache    *plugin.Cache
 
+	// Regenerator is used to regenerate the generated files of scenes when
+	// the contents of their primary file change. If nil, the generated files
+	// are kept and renamed to the new hash of the file. Optional.
+	Regenerator ScanRegenerator
+
+	// StudioMatcher is used to set the studio of scenes without a studio.
+	// Optional.
+	StudioMatcher FileStudioMatcher
+
+	// PathTagger is used to add tags to scenes when a file is first
+	// associated with them. Optional.
+	PathTagger FilePathTagger
+
+	// GalleryLinker is used to link scenes to the galleries in the folder
+	// of their files. Optional.
+	GalleryLinker FolderGalleryLinker
+
+	// CollisionReporter is notified of files which are not associated with
+	// an existing scene because of a suspected oshash collision. Optional.
+	CollisionReporter file.CollisionReporter
+
+	// ProtectedFields are the fields in ScanFields which are never set,
+	// even if they are empty.
+	ProtectedFields []string
+
+	// PrimaryFileSelector selects the primary file of scenes that a file
+	// is added to.
+	PrimaryFileSelector PrimaryFileSelector
+
 	FileNamingAlgorithm models.HashAlgorithm
 	Paths               *paths.Paths
 }
@@ -90,10 +162,9 @@ func (h *ScanHandler) Handle(ctx context.Context, f file.File, oldFile file.File
 	}
 
 	if len(existing) == 0 {
-		// try also to match file by fingerprints
-		existing, err = h.CreatorUpdater.FindByFingerprints(ctx, videoFile.Fingerprints)
+		existing, err = h.findByFingerprints(ctx, videoFile)
 		if err != nil {
-			return fmt.Errorf("finding existing scene by fingerprints: %w", err)
+			return err
 		}
 	}
 
@@ -118,22 +189,50 @@ func (h *ScanHandler) Handle(ctx context.Context, f file.File, oldFile file.File
 
 		h.PluginCache.RegisterPostHooks(ctx, newScene.ID, plugin.SceneCreatePost, nil, nil)
 
+		if err := h.addPathTags(ctx, newScene, videoFile); err != nil {
+			return err
+		}
+
 		existing = []*models.Scene{newScene}
 	}
 
+	noStudioIDs := h.noStudioSceneIDs(existing)
+
+	if err := h.linkGalleries(ctx, existing, videoFile); err != nil {
+		return err
+	}
+
+	staleHash := ""
 	if oldFile != nil {
-		// migrate hashes from the old file to the new
 		oldHash := GetHash(oldFile, h.FileNamingAlgorithm)
 		newHash := GetHash(f, h.FileNamingAlgorithm)
 
-		if oldHash != "" && newHash != "" && oldHash != newHash {
-			MigrateHash(h.Paths, oldHash, newHash)
+		switch {
+		case h.Regenerator != nil && contentsChanged(oldFile, f):
+			staleHash = oldHash
+		case oldHash != "" && newHash != "" && oldHash != newHash:
+			// migrate hashes from the old file to the new
+			MigrateHash(h.Paths.ForPath(f.Base().Path), oldHash, newHash)
 		}
 	}
 
 	// do this after the commit so that cover generation doesn't hold up the transaction
 	txn.AddPostCommitHook(ctx, func(ctx context.Context) error {
+		if len(noStudioIDs) > 0 {
+			if err := h.StudioMatcher.MatchStudio(ctx, noStudioIDs, videoFile); err != nil {
+				// just log if matching fails. We can try again on rescan
+				logger.Warnf("Error matching studio for %s: %v", videoFile.Path, err)
+			}
+		}
+
 		for _, s := range existing {
+			if staleHash != "" && s.PrimaryFileID != nil && *s.PrimaryFileID == videoFile.ID {
+				logger.Infof("Contents of %s changed. Regenerating generated files of scene %s", videoFile.Path, s.DisplayName())
+				if err := h.Regenerator.Regenerate(ctx, s, videoFile, staleHash); err != nil {
+					logger.Errorf("Error regenerating content for %s: %v", videoFile.Path, err)
+				}
+			}
+
 			if err := h.CoverGenerator.GenerateCover(ctx, s, videoFile); err != nil {
 				// just log if cover generation fails. We can try again on rescan
 				logger.Errorf("Error generating cover for %s: %v", videoFile.Path, err)
@@ -151,20 +250,228 @@ func (h *ScanHandler) Handle(ctx context.Context, f file.File, oldFile file.File
 	return nil
 }
 
+// Relink adds a video file which is not linked to any scene to the scenes
+// matching its fingerprints, in the same way as when the file is scanned.
+// The scenes are linked to the galleries in the folder of the file, and their
+// studio is matched after the transaction is committed. Returns the scenes
+// that the file was added to.
+func (h *ScanHandler) Relink(ctx context.Context, f *file.VideoFile) ([]*models.Scene, error) {
+	existing, err := h.findByFingerprints(ctx, f)
+	if err != nil || len(existing) == 0 {
+		return nil, err
+	}
+
+	if err := h.associateExisting(ctx, existing, f, false); err != nil {
+		return nil, err
+	}
+
+	noStudioIDs := h.noStudioSceneIDs(existing)
+
+	if err := h.linkGalleries(ctx, existing, f); err != nil {
+		return nil, err
+	}
+
+	if len(noStudioIDs) > 0 {
+		txn.AddPostCommitHook(ctx, func(ctx context.Context) error {
+			if err := h.StudioMatcher.MatchStudio(ctx, noStudioIDs, f); err != nil {
+				logger.Warnf("Error matching studio for %s: %v", f.Path, err)
+			}
+			return nil
+		})
+	}
+
+	return existing, nil
+}
+
+// noStudioSceneIDs returns the ids of the scenes without a studio, if the
+// studio may be matched.
+func (h *ScanHandler) noStudioSceneIDs(scenes []*models.Scene) []int {
+	if h.StudioMatcher == nil || h.isProtected(ScanFieldStudio) {
+		return nil
+	}
+
+	var ret []int
+	for _, s := range scenes {
+		if s.StudioID == nil {
+			ret = append(ret, s.ID)
+		}
+	}
+
+	return ret
+}
+
+// isProtected returns true if the field is never set when scanning.
+func (h *ScanHandler) isProtected(field string) bool {
+	return stringslice.StrInclude(h.ProtectedFields, field)
+}
+
+// linkGalleries links the scenes to the galleries in the folder of the file.
+// The date of the scenes is not set from the galleries if it is protected.
+func (h *ScanHandler) linkGalleries(ctx context.Context, scenes []*models.Scene, f *file.VideoFile) error {
+	if h.GalleryLinker == nil {
+		return nil
+	}
+
+	protectDate := h.isProtected(ScanFieldDate)
+	for _, s := range scenes {
+		if err := h.GalleryLinker.LinkScene(ctx, s, f, protectDate); err != nil {
+			return fmt.Errorf("linking folder galleries: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// findByFingerprints returns the scenes with files matching the fingerprints
+// of the provided file. If there are none, then the stub scenes matching the
+// fingerprints are returned.
+func (h *ScanHandler) findByFingerprints(ctx context.Context, f *file.VideoFile) ([]*models.Scene, error) {
+	existing, err := h.CreatorUpdater.FindByFingerprints(ctx, f.Fingerprints)
+	if err != nil {
+		return nil, fmt.Errorf("finding existing scene by fingerprints: %w", err)
+	}
+
+	existing, err = h.excludeCollisions(ctx, existing, f)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(existing) == 0 {
+		// try to match the file to a stub scene
+		existing, err = h.CreatorUpdater.FindStubsByFingerprints(ctx, f.Fingerprints)
+		if err != nil {
+			return nil, fmt.Errorf("finding stub scene by fingerprints: %w", err)
+		}
+	}
+
+	return existing, nil
+}
+
+// addPathTags adds the tags matched from the path of the file to the scene.
+func (h *ScanHandler) addPathTags(ctx context.Context, s *models.Scene, f *file.VideoFile) error {
+	if h.PathTagger == nil {
+		return nil
+	}
+
+	tagIDs, err := h.PathTagger.PathTags(ctx, f)
+	if err != nil {
+		return fmt.Errorf("matching tags from path: %w", err)
+	}
+
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	logger.Infof("Adding tags to scene %s from path %s", s.DisplayName(), f.Path)
+
+	partial := models.NewScenePartial()
+	partial.TagIDs = &models.UpdateIDs{
+		IDs:  tagIDs,
+		Mode: models.RelationshipUpdateModeAdd,
+	}
+
+	if _, err := h.CreatorUpdater.UpdatePartial(ctx, s.ID, partial); err != nil {
+		return fmt.Errorf("adding tags to scene: %w", err)
+	}
+
+	return nil
+}
+
+// excludeCollisions returns the scenes which have a file matching the
+// provided file. Scenes which only match because of an oshash collision are
+// excluded and reported, so that they are not merged.
+func (h *ScanHandler) excludeCollisions(ctx context.Context, existing []*models.Scene, f *file.VideoFile) ([]*models.Scene, error) {
+	var ret []*models.Scene
+	for _, s := range existing {
+		if err := s.LoadFiles(ctx, h.CreatorUpdater); err != nil {
+			return nil, err
+		}
+
+		var collisions []string
+		matched := false
+		for _, sf := range s.Files.List() {
+			if file.IsOshashCollision(f.Base(), sf.Base()) {
+				collisions = append(collisions, sf.Path)
+			} else if sharesFingerprint(f.Fingerprints, sf.Fingerprints) {
+				matched = true
+			}
+		}
+
+		if matched {
+			ret = append(ret, s)
+			continue
+		}
+
+		for _, c := range collisions {
+			logger.Warnf("Suspected oshash collision between %s and %s of scene %s. Not adding to existing scene; please review manually.", f.Path, c, s.DisplayName())
+			if h.CollisionReporter != nil {
+				sceneID := s.ID
+				h.CollisionReporter.ReportOshashCollision(ctx, file.OshashCollision{
+					Path:      f.Path,
+					OtherPath: c,
+					SceneID:   &sceneID,
+				})
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+// contentsChanged returns true if the fingerprints of the file differ from
+// those of the old file. Returns true if the files have no fingerprint types
+// in common.
+func contentsChanged(oldFile file.File, f file.File) bool {
+	common := false
+	for _, fp := range f.Base().Fingerprints {
+		o := oldFile.Base().Fingerprints.For(fp.Type)
+		if o == nil {
+			continue
+		}
+
+		if o.Fingerprint != fp.Fingerprint {
+			return true
+		}
+		common = true
+	}
+
+	return !common
+}
+
+func sharesFingerprint(f file.Fingerprints, other file.Fingerprints) bool {
+	for _, fp := range f {
+		if o := other.For(fp.Type); o != nil && o.Fingerprint == fp.Fingerprint {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (h *ScanHandler) associateExisting(ctx context.Context, existing []*models.Scene, f *file.VideoFile, updateExisting bool) error {
 	for _, s := range existing {
 		if err := s.LoadFiles(ctx, h.CreatorUpdater); err != nil {
 			return err
 		}
 
+		// use the scanned file in place of the stored one
 		found := false
-		for _, sf := range s.Files.List() {
+		files := s.Files.List()
+		for i, sf := range files {
 			if sf.ID == f.ID {
 				found = true
+				files[i] = f
 				break
 			}
 		}
 
+		if !found {
+			files = append(files, f)
+		}
+
+		primary := h.PrimaryFileSelector.Select(files, s.PrimaryFileID)
+		primaryChanged := s.PrimaryFileID == nil || *s.PrimaryFileID != primary.ID
+
 		if !found {
 			logger.Infof("Adding %s to scene %s", f.Path, s.DisplayName())
 
@@ -172,8 +479,25 @@ func (h *ScanHandler) associateExisting(ctx context.Context, existing []*models.
 				return fmt.Errorf("adding file to scene: %w", err)
 			}
 
+			if err := h.addPathTags(ctx, s, f); err != nil {
+				return err
+			}
+		}
+
+		if !found || primaryChanged {
 			// update updated_at time
-			if _, err := h.CreatorUpdater.UpdatePartial(ctx, s.ID, models.NewScenePartial()); err != nil {
+			partial := models.NewScenePartial()
+			if s.Stub {
+				// the scene is no longer a stub once it has a file
+				partial.Stub = models.NewOptionalBool(false)
+			}
+
+			if primaryChanged {
+				logger.Infof("Setting primary file of scene %s to %s", s.DisplayName(), primary.Path)
+				partial.PrimaryFileID = &primary.ID
+			}
+
+			if _, err := h.CreatorUpdater.UpdatePartial(ctx, s.ID, partial); err != nil {
 				return fmt.Errorf("updating scene: %w", err)
 			}
 		}
@@ -0,0 +1,293 @@
+package scene
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/txn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentsChanged(t *testing.T) {
+	newFile := func(fps ...file.Fingerprint) file.File {
+		return &file.VideoFile{
+			BaseFile: &file.BaseFile{
+				Fingerprints: fps,
+			},
+		}
+	}
+
+	oshash := func(v string) file.Fingerprint {
+		return file.Fingerprint{Type: file.FingerprintTypeOshash, Fingerprint: v}
+	}
+	md5 := func(v string) file.Fingerprint {
+		return file.Fingerprint{Type: file.FingerprintTypeMD5, Fingerprint: v}
+	}
+
+	tests := []struct {
+		name    string
+		oldFile file.File
+		f       file.File
+		want    bool
+	}{
+		{"same", newFile(oshash("a"), md5("b")), newFile(oshash("a"), md5("b")), false},
+		{"oshash changed", newFile(oshash("a")), newFile(oshash("c")), true},
+		{"md5 changed", newFile(oshash("a"), md5("b")), newFile(oshash("a"), md5("c")), true},
+		{"new fingerprint type", newFile(oshash("a")), newFile(oshash("a"), md5("b")), false},
+		{"no common types", newFile(oshash("a")), newFile(md5("b")), true},
+		{"no fingerprints", newFile(), newFile(), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := contentsChanged(tt.oldFile, tt.f); got != tt.want {
+				t.Errorf("contentsChanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type relinkStore struct {
+	byFingerprints []*models.Scene
+	added          map[int][]file.ID
+}
+
+func (s *relinkStore) FindByFileID(ctx context.Context, fileID file.ID) ([]*models.Scene, error) {
+	return nil, nil
+}
+
+func (s *relinkStore) FindByFingerprints(ctx context.Context, fp []file.Fingerprint) ([]*models.Scene, error) {
+	return s.byFingerprints, nil
+}
+
+func (s *relinkStore) FindStubsByFingerprints(ctx context.Context, fp []file.Fingerprint) ([]*models.Scene, error) {
+	return nil, nil
+}
+
+func (s *relinkStore) Create(ctx context.Context, newScene *models.Scene, fileIDs []file.ID) error {
+	panic("not implemented")
+}
+
+func (s *relinkStore) UpdatePartial(ctx context.Context, id int, updatedScene models.ScenePartial) (*models.Scene, error) {
+	return &models.Scene{ID: id}, nil
+}
+
+func (s *relinkStore) AddFileID(ctx context.Context, id int, fileID file.ID) error {
+	s.added[id] = append(s.added[id], fileID)
+	return nil
+}
+
+func (s *relinkStore) GetFiles(ctx context.Context, relatedID int) ([]*file.VideoFile, error) {
+	return nil, nil
+}
+
+type relinkStudioMatcher struct {
+	sceneIDs []int
+}
+
+func (m *relinkStudioMatcher) MatchStudio(ctx context.Context, sceneIDs []int, f *file.VideoFile) error {
+	m.sceneIDs = append(m.sceneIDs, sceneIDs...)
+	return nil
+}
+
+type relinkGalleryLinker struct {
+	sceneIDs    []int
+	protectDate []bool
+}
+
+func (l *relinkGalleryLinker) LinkScene(ctx context.Context, s *models.Scene, f file.File, protectDate bool) error {
+	l.sceneIDs = append(l.sceneIDs, s.ID)
+	l.protectDate = append(l.protectDate, protectDate)
+	return nil
+}
+
+func TestScanHandler_Relink(t *testing.T) {
+	const (
+		noStudioSceneID = 1
+		studioSceneID   = 2
+	)
+
+	md5 := func(v string) file.Fingerprints {
+		return file.Fingerprints{{Type: file.FingerprintTypeMD5, Fingerprint: v}}
+	}
+
+	existingFile := &file.VideoFile{BaseFile: &file.BaseFile{ID: 1, Path: "existing.mp4", Fingerprints: md5("a")}}
+	unlinkedFile := &file.VideoFile{BaseFile: &file.BaseFile{ID: 2, Path: "unlinked.mp4", Fingerprints: md5("a")}}
+
+	newScenes := func() []*models.Scene {
+		studioID := 1
+		return []*models.Scene{
+			{ID: noStudioSceneID, PrimaryFileID: &existingFile.ID, Files: models.NewRelatedVideoFiles([]*file.VideoFile{existingFile})},
+			{ID: studioSceneID, StudioID: &studioID, PrimaryFileID: &existingFile.ID, Files: models.NewRelatedVideoFiles([]*file.VideoFile{existingFile})},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		byFingerprints  []*models.Scene
+		protectedFields []string
+		wantScenes      []int
+		wantStudio      []int
+	}{
+		{"matching scenes", newScenes(), nil, []int{noStudioSceneID, studioSceneID}, []int{noStudioSceneID}},
+		{"protected studio", newScenes(), []string{ScanFieldStudio}, []int{noStudioSceneID, studioSceneID}, nil},
+		{"no matching scenes", nil, nil, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &relinkStore{
+				byFingerprints: tt.byFingerprints,
+				added:          make(map[int][]file.ID),
+			}
+			studioMatcher := &relinkStudioMatcher{}
+			galleryLinker := &relinkGalleryLinker{}
+
+			h := &ScanHandler{
+				CreatorUpdater:  store,
+				PluginCache:     &plugin.Cache{},
+				StudioMatcher:   studioMatcher,
+				GalleryLinker:   galleryLinker,
+				ProtectedFields: tt.protectedFields,
+			}
+
+			var got []*models.Scene
+			err := txn.WithTxn(context.Background(), &mocks.TxnManager{}, func(ctx context.Context) error {
+				var err error
+				got, err = h.Relink(ctx, unlinkedFile)
+				return err
+			})
+
+			assert.NoError(t, err)
+
+			var gotIDs []int
+			wantAdded := make(map[int][]file.ID)
+			for _, s := range got {
+				gotIDs = append(gotIDs, s.ID)
+			}
+			for _, id := range tt.wantScenes {
+				wantAdded[id] = []file.ID{unlinkedFile.ID}
+			}
+
+			assert.Equal(t, tt.wantScenes, gotIDs)
+			assert.Equal(t, wantAdded, store.added)
+			assert.Equal(t, tt.wantScenes, galleryLinker.sceneIDs)
+			assert.Equal(t, tt.wantStudio, studioMatcher.sceneIDs)
+		})
+	}
+}
+
+func TestScanHandler_protectedFields(t *testing.T) {
+	const sceneID = 1
+
+	md5 := file.Fingerprints{{Type: file.FingerprintTypeMD5, Fingerprint: "a"}}
+	existingFile := &file.VideoFile{BaseFile: &file.BaseFile{ID: 1, Path: "existing.mp4", Fingerprints: md5}}
+	unlinkedFile := &file.VideoFile{BaseFile: &file.BaseFile{ID: 2, Path: "unlinked.mp4", Fingerprints: md5}}
+
+	tests := []struct {
+		name            string
+		protectedFields []string
+		wantStudio      []int
+		wantProtectDate []bool
+	}{
+		{"none", nil, []int{sceneID}, []bool{false}},
+		{"studio", []string{ScanFieldStudio}, nil, []bool{false}},
+		{"date", []string{ScanFieldDate}, []int{sceneID}, []bool{true}},
+		{"studio and date", []string{ScanFieldStudio, ScanFieldDate}, nil, []bool{true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &relinkStore{
+				byFingerprints: []*models.Scene{
+					{ID: sceneID, PrimaryFileID: &existingFile.ID, Files: models.NewRelatedVideoFiles([]*file.VideoFile{existingFile})},
+				},
+				added: make(map[int][]file.ID),
+			}
+			studioMatcher := &relinkStudioMatcher{}
+			galleryLinker := &relinkGalleryLinker{}
+
+			h := &ScanHandler{
+				CreatorUpdater:  store,
+				PluginCache:     &plugin.Cache{},
+				StudioMatcher:   studioMatcher,
+				GalleryLinker:   galleryLinker,
+				ProtectedFields: tt.protectedFields,
+			}
+
+			err := txn.WithTxn(context.Background(), &mocks.TxnManager{}, func(ctx context.Context) error {
+				_, err := h.Relink(ctx, unlinkedFile)
+				return err
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStudio, studioMatcher.sceneIDs)
+			assert.Equal(t, tt.wantProtectDate, galleryLinker.protectDate)
+		})
+	}
+}
+
+type testCollisionReporter struct {
+	collisions []file.OshashCollision
+}
+
+func (r *testCollisionReporter) ReportOshashCollision(ctx context.Context, c file.OshashCollision) {
+	r.collisions = append(r.collisions, c)
+}
+
+func TestScanHandler_excludeCollisions(t *testing.T) {
+	const (
+		matchingID  = 1
+		collisionID = 2
+	)
+
+	newFile := func(path string, size int64, md5 string) *file.VideoFile {
+		fps := file.Fingerprints{
+			{Type: file.FingerprintTypeOshash, Fingerprint: "oshash"},
+		}
+		if md5 != "" {
+			fps = append(fps, file.Fingerprint{Type: file.FingerprintTypeMD5, Fingerprint: md5})
+		}
+
+		return &file.VideoFile{
+			BaseFile: &file.BaseFile{
+				Path:         path,
+				Size:         size,
+				Fingerprints: fps,
+			},
+		}
+	}
+
+	f := newFile("new.mp4", 100, "md5")
+	existing := []*models.Scene{
+		{
+			ID:    matchingID,
+			Files: models.NewRelatedVideoFiles([]*file.VideoFile{newFile("same.mp4", 100, "md5")}),
+		},
+		{
+			ID:    collisionID,
+			Files: models.NewRelatedVideoFiles([]*file.VideoFile{newFile("other.mp4", 100, "other")}),
+		},
+	}
+
+	reporter := &testCollisionReporter{}
+	h := &ScanHandler{
+		CollisionReporter: reporter,
+	}
+
+	got, err := h.excludeCollisions(context.Background(), existing, f)
+	if err != nil {
+		t.Fatalf("excludeCollisions() error = %v", err)
+	}
+
+	assert.Equal(t, []*models.Scene{existing[0]}, got)
+
+	sceneID := collisionID
+	assert.Equal(t, []file.OshashCollision{
+		{Path: "new.mp4", OtherPath: "other.mp4", SceneID: &sceneID},
+	}, reporter.collisions)
+}
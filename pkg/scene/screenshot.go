@@ -37,7 +37,7 @@ func (ss *PathsCoverSetter) SetScreenshot(scene *models.Scene, imageData []byte)
 		return nil
 	}
 	checksum := scene.GetHash(ss.FileNamingAlgorithm)
-	return SetScreenshot(ss.Paths, checksum, imageData)
+	return SetScreenshot(ss.Paths.ForPath(scene.Path), checksum, imageData)
 }
 
 func writeImage(path string, imageData []byte) error {
@@ -25,8 +25,17 @@ type CoverUpdater interface {
 	UpdateCover(ctx context.Context, sceneID int, cover []byte) error
 }
 
+// PrimaryFileConfig provides the policy used to select the primary file of
+// scenes.
+type PrimaryFileConfig interface {
+	GetPrimaryFilePolicy() models.PrimaryFilePolicy
+	GetPrimaryFilePathPriority() []string
+	GetPrimaryFileExtensionPriority() []string
+}
+
 type Config interface {
 	GetVideoFileNamingAlgorithm() models.HashAlgorithm
+	PrimaryFileConfig
 }
 
 type Repository interface {
@@ -0,0 +1,125 @@
+package scene
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// RepairSharedFile ensures that a file linked to more than one scene is only
+// linked to the most complete of the scenes, detaching the file from the
+// other scenes.
+func (s *Service) RepairSharedFile(ctx context.Context, sf *models.SceneSharedFile) error {
+	scenes, err := s.Repository.FindMany(ctx, sf.SceneIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, scn := range scenes {
+		if err := scn.LoadRelationships(ctx, s.Repository); err != nil {
+			return fmt.Errorf("loading scene relationships from %d: %w", scn.ID, err)
+		}
+	}
+
+	keep := mostCompleteScene(scenes)
+
+	logger.Infof("File %d is linked to %d scenes. Keeping link to scene %s", sf.FileID, len(scenes), keep.DisplayName())
+
+	// removes the file from all other scenes
+	if err := s.Repository.AssignFiles(ctx, keep.ID, []file.ID{sf.FileID}); err != nil {
+		return err
+	}
+
+	for _, scn := range scenes {
+		wasPrimary := scn.PrimaryFileID != nil && *scn.PrimaryFileID == sf.FileID
+
+		if scn == keep {
+			if scn.PrimaryFileID == nil || wasPrimary {
+				if err := s.setPrimaryFile(ctx, scn.ID, sf.FileID); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if !wasPrimary {
+			continue
+		}
+
+		// use another file as the primary file
+		files, err := s.Repository.GetFiles(ctx, scn.ID)
+		if err != nil {
+			return err
+		}
+
+		if len(files) == 0 {
+			logger.Warnf("Scene %s has no files after detaching file %d", scn.DisplayName(), sf.FileID)
+			continue
+		}
+
+		if err := s.setPrimaryFile(ctx, scn.ID, files[0].ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) setPrimaryFile(ctx context.Context, sceneID int, fileID file.ID) error {
+	p := models.NewScenePartial()
+	p.PrimaryFileID = &fileID
+	if _, err := s.Repository.UpdatePartial(ctx, sceneID, p); err != nil {
+		return fmt.Errorf("setting primary file of scene %d: %w", sceneID, err)
+	}
+
+	return nil
+}
+
+// mostCompleteScene returns the scene with the most fields set. The first of
+// the most complete scenes is returned. The relationships of the scenes must
+// be loaded.
+func mostCompleteScene(scenes []*models.Scene) *models.Scene {
+	var ret *models.Scene
+	best := -1
+	for _, s := range scenes {
+		if c := completeness(s); c > best {
+			ret = s
+			best = c
+		}
+	}
+
+	return ret
+}
+
+// completeness returns the number of metadata fields of the scene that are
+// set.
+func completeness(s *models.Scene) int {
+	set := []bool{
+		s.Title != "",
+		s.Code != "",
+		s.Details != "",
+		s.Director != "",
+		s.URL != "",
+		s.Date != nil,
+		s.Rating != nil,
+		s.Organized,
+		s.StudioID != nil,
+		len(s.GalleryIDs.List()) > 0,
+		len(s.TagIDs.List()) > 0,
+		len(s.PerformerIDs.List()) > 0,
+		len(s.Movies.List()) > 0,
+		len(s.StashIDs.List()) > 0,
+	}
+
+	ret := 0
+	for _, v := range set {
+		if v {
+			ret++
+		}
+	}
+
+	return ret
+}
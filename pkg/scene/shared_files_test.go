@@ -0,0 +1,138 @@
+package scene
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMostCompleteScene(t *testing.T) {
+	rating := 60
+	date := models.NewDate("2020-01-02")
+
+	newScene := func(id int) *models.Scene {
+		return &models.Scene{
+			ID:           id,
+			GalleryIDs:   models.NewRelatedIDs([]int{}),
+			TagIDs:       models.NewRelatedIDs([]int{}),
+			PerformerIDs: models.NewRelatedIDs([]int{}),
+			Movies:       models.NewRelatedMovies([]models.MoviesScenes{}),
+			StashIDs:     models.NewRelatedStashIDs([]models.StashID{}),
+		}
+	}
+
+	empty := newScene(1)
+
+	titled := newScene(2)
+	titled.Title = "title"
+
+	complete := newScene(3)
+	complete.Title = "title"
+	complete.Date = &date
+	complete.Rating = &rating
+	complete.TagIDs = models.NewRelatedIDs([]int{1, 2})
+
+	otherComplete := newScene(4)
+	otherComplete.Details = "details"
+	otherComplete.Organized = true
+	otherComplete.PerformerIDs = models.NewRelatedIDs([]int{1})
+	otherComplete.StashIDs = models.NewRelatedStashIDs([]models.StashID{{Endpoint: "endpoint", StashID: "id"}})
+
+	tests := []struct {
+		name   string
+		scenes []*models.Scene
+		want   *models.Scene
+	}{
+		{"empty", []*models.Scene{empty, titled}, titled},
+		{"complete", []*models.Scene{titled, complete, empty}, complete},
+		{"tie keeps first", []*models.Scene{complete, otherComplete}, complete},
+		{"all empty", []*models.Scene{empty, newScene(5)}, empty},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Same(t, tt.want, mostCompleteScene(tt.scenes))
+		})
+	}
+}
+
+// sharedFileRepository adds the file methods missing from the scene mock.
+type sharedFileRepository struct {
+	*mocks.SceneReaderWriter
+	assigned map[int][]file.ID
+}
+
+func (r *sharedFileRepository) FindByFileID(ctx context.Context, fileID file.ID) ([]*models.Scene, error) {
+	panic("not implemented")
+}
+
+func (r *sharedFileRepository) AssignFiles(ctx context.Context, sceneID int, fileIDs []file.ID) error {
+	r.assigned[sceneID] = fileIDs
+	return nil
+}
+
+func TestService_RepairSharedFile(t *testing.T) {
+	const (
+		sharedFileID file.ID = 10
+		otherFileID  file.ID = 11
+
+		emptySceneID  = 1
+		titledSceneID = 2
+	)
+
+	newScene := func(id int, primaryFileID *file.ID, files ...*file.VideoFile) *models.Scene {
+		return &models.Scene{
+			ID:            id,
+			PrimaryFileID: primaryFileID,
+			Files:         models.NewRelatedVideoFiles(files),
+			GalleryIDs:    models.NewRelatedIDs([]int{}),
+			TagIDs:        models.NewRelatedIDs([]int{}),
+			PerformerIDs:  models.NewRelatedIDs([]int{}),
+			Movies:        models.NewRelatedMovies([]models.MoviesScenes{}),
+			StashIDs:      models.NewRelatedStashIDs([]models.StashID{}),
+		}
+	}
+
+	sharedFile := &file.VideoFile{BaseFile: &file.BaseFile{ID: sharedFileID}}
+	otherFile := &file.VideoFile{BaseFile: &file.BaseFile{ID: otherFileID}}
+
+	sharedID := sharedFileID
+
+	// the shared file is the primary file of the less complete scene
+	empty := newScene(emptySceneID, &sharedID, sharedFile, otherFile)
+	titled := newScene(titledSceneID, nil, sharedFile)
+	titled.Title = "title"
+
+	ctx := context.Background()
+	sceneRW := &mocks.SceneReaderWriter{}
+	repo := &sharedFileRepository{
+		SceneReaderWriter: sceneRW,
+		assigned:          make(map[int][]file.ID),
+	}
+
+	primaryFileIs := func(fileID file.ID) interface{} {
+		return mock.MatchedBy(func(p models.ScenePartial) bool {
+			return p.PrimaryFileID != nil && *p.PrimaryFileID == fileID
+		})
+	}
+
+	sceneRW.On("FindMany", ctx, []int{emptySceneID, titledSceneID}).Return([]*models.Scene{empty, titled}, nil).Once()
+	sceneRW.On("GetFiles", ctx, emptySceneID).Return([]*file.VideoFile{otherFile}, nil).Once()
+	sceneRW.On("UpdatePartial", ctx, titledSceneID, primaryFileIs(sharedFileID)).Return(titled, nil).Once()
+	sceneRW.On("UpdatePartial", ctx, emptySceneID, primaryFileIs(otherFileID)).Return(empty, nil).Once()
+
+	s := &Service{Repository: repo}
+	err := s.RepairSharedFile(ctx, &models.SceneSharedFile{
+		FileID:   sharedFileID,
+		SceneIDs: []int{emptySceneID, titledSceneID},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[int][]file.ID{titledSceneID: {sharedFileID}}, repo.assigned)
+	sceneRW.AssertExpectations(t)
+}
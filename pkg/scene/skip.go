@@ -0,0 +1,42 @@
+package scene
+
+import (
+	"errors"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+var (
+	ErrNegativeSkipTime = errors.New("intro end and outro start must not be negative")
+	ErrInvalidSkipRange = errors.New("outro start must be after intro end")
+)
+
+// ValidateSkipRange returns an error if the intro end or outro start of a
+// scene are invalid. Either may be nil if not set.
+func ValidateSkipRange(introEnd, outroStart *float64) error {
+	if (introEnd != nil && *introEnd < 0) || (outroStart != nil && *outroStart < 0) {
+		return ErrNegativeSkipTime
+	}
+
+	if introEnd != nil && outroStart != nil && *outroStart <= *introEnd {
+		return ErrInvalidSkipRange
+	}
+
+	return nil
+}
+
+// ValidateUpdateSkipRange validates the intro end and outro start of s after
+// applying partial. Values not set in partial are taken from s.
+func ValidateUpdateSkipRange(s *models.Scene, partial models.ScenePartial) error {
+	introEnd := s.IntroEnd
+	if partial.IntroEnd.Set {
+		introEnd = partial.IntroEnd.Ptr()
+	}
+
+	outroStart := s.OutroStart
+	if partial.OutroStart.Set {
+		outroStart = partial.OutroStart.Ptr()
+	}
+
+	return ValidateSkipRange(introEnd, outroStart)
+}
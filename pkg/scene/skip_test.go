@@ -0,0 +1,74 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSkipRange(t *testing.T) {
+	f := func(v float64) *float64 {
+		return &v
+	}
+
+	tests := []struct {
+		name       string
+		introEnd   *float64
+		outroStart *float64
+		want       error
+	}{
+		{"unset", nil, nil, nil},
+		{"intro only", f(30), nil, nil},
+		{"outro only", nil, f(600), nil},
+		{"valid range", f(30), f(600), nil},
+		{"negative intro", f(-1), nil, ErrNegativeSkipTime},
+		{"negative outro", nil, f(-1), ErrNegativeSkipTime},
+		{"outro before intro", f(600), f(30), ErrInvalidSkipRange},
+		{"outro at intro", f(30), f(30), ErrInvalidSkipRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidateSkipRange(tt.introEnd, tt.outroStart))
+		})
+	}
+}
+
+func TestValidateUpdateSkipRange(t *testing.T) {
+	f := func(v float64) *float64 {
+		return &v
+	}
+
+	stored := &models.Scene{
+		IntroEnd:   f(30),
+		OutroStart: f(600),
+	}
+
+	tests := []struct {
+		name    string
+		partial models.ScenePartial
+		want    error
+	}{
+		{"unchanged", models.ScenePartial{}, nil},
+		{"intro before stored outro", models.ScenePartial{IntroEnd: models.NewOptionalFloat64(60)}, nil},
+		{"intro after stored outro", models.ScenePartial{IntroEnd: models.NewOptionalFloat64(700)}, ErrInvalidSkipRange},
+		{"outro before stored intro", models.ScenePartial{OutroStart: models.NewOptionalFloat64(10)}, ErrInvalidSkipRange},
+		{"outro after stored intro", models.ScenePartial{OutroStart: models.NewOptionalFloat64(900)}, nil},
+		{"intro cleared", models.ScenePartial{
+			IntroEnd:   models.NewOptionalFloat64Ptr(nil),
+			OutroStart: models.NewOptionalFloat64(10),
+		}, nil},
+		{"both replaced", models.ScenePartial{
+			IntroEnd:   models.NewOptionalFloat64(700),
+			OutroStart: models.NewOptionalFloat64(800),
+		}, nil},
+		{"negative outro", models.ScenePartial{OutroStart: models.NewOptionalFloat64(-1)}, ErrNegativeSkipTime},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidateUpdateSkipRange(stored, tt.partial))
+		})
+	}
+}
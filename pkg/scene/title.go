@@ -0,0 +1,90 @@
+package scene
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+// Tokens that may be used in display title formats.
+const (
+	TitleTokenStudio     = "studio"
+	TitleTokenDate       = "date"
+	TitleTokenCode       = "code"
+	TitleTokenPerformers = "performers"
+	TitleTokenBasename   = "basename"
+)
+
+// TitleTokens are the tokens that may be used in display title formats.
+var TitleTokens = []string{TitleTokenStudio, TitleTokenDate, TitleTokenCode, TitleTokenPerformers, TitleTokenBasename}
+
+var titleTokenRE = regexp.MustCompile(`\{(\w+)\}`)
+
+// ValidateTitleFormat returns an error if the display title format contains
+// an unknown token, or does not contain any tokens.
+func ValidateTitleFormat(format string) error {
+	matches := titleTokenRE.FindAllStringSubmatch(format, -1)
+	if len(matches) == 0 {
+		return fmt.Errorf("title format %q does not contain any tokens", format)
+	}
+
+	for _, m := range matches {
+		if !stringslice.StrInclude(TitleTokens, m[1]) {
+			return fmt.Errorf("unknown token %q in title format %q", m[0], format)
+		}
+	}
+
+	return nil
+}
+
+// FormatDisplayTitle returns the first of the display title formats for
+// which all of the tokens have a value, with the tokens replaced by their
+// values. Tokens are written as {token}, for example "{studio} {date}".
+// getValue returns the value of a token, and is called at most once for
+// each token. Returns an empty string if no format applies.
+func FormatDisplayTitle(formats []string, getValue func(token string) (string, error)) (string, error) {
+	values := make(map[string]string)
+	value := func(token string) (string, error) {
+		if v, found := values[token]; found {
+			return v, nil
+		}
+
+		v, err := getValue(token)
+		if err != nil {
+			return "", err
+		}
+		values[token] = v
+		return v, nil
+	}
+
+	for _, format := range formats {
+		complete := true
+		for _, m := range titleTokenRE.FindAllStringSubmatch(format, -1) {
+			v, err := value(m[1])
+			if err != nil {
+				return "", err
+			}
+
+			if v == "" {
+				complete = false
+				break
+			}
+		}
+
+		if !complete {
+			continue
+		}
+
+		ret := titleTokenRE.ReplaceAllStringFunc(format, func(s string) string {
+			return values[s[1:len(s)-1]]
+		})
+
+		if ret = strings.TrimSpace(ret); ret != "" {
+			return ret, nil
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,74 @@
+package scene
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTitleFormat(t *testing.T) {
+	tests := []struct {
+		format  string
+		wantErr bool
+	}{
+		{"{studio} {date}", false},
+		{"{basename}", false},
+		{"{code} - {performers}", false},
+		{"no tokens", true},
+		{"{studio} {unknown}", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if err := ValidateTitleFormat(tt.format); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTitleFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFormatDisplayTitle(t *testing.T) {
+	values := map[string]string{
+		TitleTokenStudio:   "Studio",
+		TitleTokenDate:     "2001-02-03",
+		TitleTokenBasename: "file.mp4",
+	}
+
+	tests := []struct {
+		name    string
+		formats []string
+		want    string
+	}{
+		{"first format", []string{"{studio} {date}", "{basename}"}, "Studio 2001-02-03"},
+		{"missing value", []string{"{studio} {code}", "{basename}"}, "file.mp4"},
+		{"no format applies", []string{"{code}", "{performers}"}, ""},
+		{"no formats", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := make(map[string]int)
+			got, err := FormatDisplayTitle(tt.formats, func(token string) (string, error) {
+				calls[token]++
+				return values[token], nil
+			})
+			if err != nil {
+				t.Errorf("FormatDisplayTitle() error = %v", err)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+			for token, n := range calls {
+				assert.Equal(t, 1, n, "token %s", token)
+			}
+		})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		_, err := FormatDisplayTitle([]string{"{studio}"}, func(token string) (string, error) {
+			return "", errors.New("error")
+		})
+		assert.NotNil(t, err)
+	})
+}
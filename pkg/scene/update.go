@@ -138,5 +138,36 @@ func (s *Service) AssignFile(ctx context.Context, sceneID int, fileID file.ID) e
 		return errors.New("cannot reassign primary file")
 	}
 
-	return s.Repository.AssignFiles(ctx, sceneID, []file.ID{fileID})
+	if err := s.Repository.AssignFiles(ctx, sceneID, []file.ID{fileID}); err != nil {
+		return err
+	}
+
+	return s.updatePrimaryFile(ctx, sceneID)
+}
+
+// updatePrimaryFile sets the primary file of the scene to the file selected
+// by the configured primary file policy.
+func (s *Service) updatePrimaryFile(ctx context.Context, sceneID int) error {
+	scene, err := s.Repository.Find(ctx, sceneID)
+	if err != nil {
+		return err
+	}
+
+	if scene == nil {
+		return fmt.Errorf("scene with id %d not found", sceneID)
+	}
+
+	if err := scene.LoadFiles(ctx, s.Repository); err != nil {
+		return err
+	}
+
+	primary := NewPrimaryFileSelector(s.Config).Select(scene.Files.List(), scene.PrimaryFileID)
+	if primary == nil || (scene.PrimaryFileID != nil && *scene.PrimaryFileID == primary.ID) {
+		return nil
+	}
+
+	_, err = s.Repository.UpdatePartial(ctx, sceneID, models.ScenePartial{
+		PrimaryFileID: &primary.ID,
+	})
+	return err
 }
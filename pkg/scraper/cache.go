@@ -41,6 +41,9 @@ type GlobalConfig interface {
 	GetScraperCDPPath() string
 	GetScraperCertCheck() bool
 	GetPythonPath() string
+	GetScraperDetailsTemplate() string
+	IsScraperDetailsTemplateForced() bool
+	IsScraperSanitizeOutput() bool
 }
 
 func isCDPPathHTTP(c GlobalConfig) bool {
@@ -260,7 +263,12 @@ func (c Cache) ScrapeFragment(ctx context.Context, id string, input Input) (Scra
 		return nil, fmt.Errorf("error while fragment scraping with scraper %s: %w", id, err)
 	}
 
-	return c.postScrape(ctx, content)
+	sceneDetails := ""
+	if input.Scene != nil && input.Scene.Details != nil {
+		sceneDetails = *input.Scene.Details
+	}
+
+	return c.postScrape(ctx, s, content, sceneDetails)
 }
 
 // ScrapeURL scrapes a given url for the given content. Searches the scraper cache
@@ -282,7 +290,7 @@ func (c Cache) ScrapeURL(ctx context.Context, url string, ty ScrapeContentType)
 				return ret, nil
 			}
 
-			return c.postScrape(ctx, ret)
+			return c.postScrape(ctx, s, ret, "")
 		}
 	}
 
@@ -300,6 +308,7 @@ func (c Cache) ScrapeID(ctx context.Context, scraperID string, id int, ty Scrape
 	}
 
 	var ret ScrapedContent
+	sceneDetails := ""
 	switch ty {
 	case ScrapeContentTypeScene:
 		ss, ok := s.(sceneScraper)
@@ -312,6 +321,10 @@ func (c Cache) ScrapeID(ctx context.Context, scraperID string, id int, ty Scrape
 			return nil, fmt.Errorf("scraper %s: unable to load scene id %v: %w", scraperID, id, err)
 		}
 
+		if scene != nil {
+			sceneDetails = scene.Details
+		}
+
 		// don't assign nil concrete pointer to ret interface, otherwise nil
 		// detection is harder
 		scraped, err := ss.viaScene(ctx, c.client, scene)
@@ -345,7 +358,7 @@ func (c Cache) ScrapeID(ctx context.Context, scraperID string, id int, ty Scrape
 		}
 	}
 
-	return c.postScrape(ctx, ret)
+	return c.postScrape(ctx, s, ret, sceneDetails)
 }
 
 func (c Cache) getScene(ctx context.Context, sceneID int) (*models.Scene, error) {
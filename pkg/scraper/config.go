@@ -48,6 +48,9 @@ type config struct {
 	// Configuration for querying a movie by a URL
 	MovieByURL []*scrapeByURLConfig `yaml:"movieByURL"`
 
+	// Configuration for querying a studio by a URL
+	StudioByURL []*scrapeByURLConfig `yaml:"studioByURL"`
+
 	// Scraper debugging options
 	DebugOptions *scraperDebugOptions `yaml:"debug"`
 
@@ -62,6 +65,10 @@ type config struct {
 
 	// Scraping driver options
 	DriverOptions *scraperDriverOptions `yaml:"driver"`
+
+	// Overrides the global setting to sanitize scraped titles and details
+	// if set
+	Sanitize *bool `yaml:"sanitize"`
 }
 
 func (c config) validate() error {
@@ -105,6 +112,12 @@ func (c config) validate() error {
 		}
 	}
 
+	for _, s := range c.StudioByURL {
+		if err := s.validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -301,6 +314,18 @@ func (c config) spec() Scraper {
 		ret.Movie = &movie
 	}
 
+	studio := ScraperSpec{}
+	if len(c.StudioByURL) > 0 {
+		studio.SupportedScrapes = append(studio.SupportedScrapes, ScrapeTypeURL)
+		for _, v := range c.StudioByURL {
+			studio.Urls = append(studio.Urls, v.URL...)
+		}
+	}
+
+	if len(studio.SupportedScrapes) > 0 {
+		ret.Studio = &studio
+	}
+
 	return ret
 }
 
@@ -314,6 +339,8 @@ func (c config) supports(ty ScrapeContentType) bool {
 		return c.GalleryByFragment != nil || len(c.GalleryByURL) > 0
 	case ScrapeContentTypeMovie:
 		return len(c.MovieByURL) > 0
+	case ScrapeContentTypeStudio:
+		return len(c.StudioByURL) > 0
 	}
 
 	panic("Unhandled ScrapeContentType")
@@ -345,6 +372,12 @@ func (c config) matchesURL(url string, ty ScrapeContentType) bool {
 				return true
 			}
 		}
+	case ScrapeContentTypeStudio:
+		for _, scraper := range c.StudioByURL {
+			if scraper.matchesURL(url) {
+				return true
+			}
+		}
 	}
 
 	return false
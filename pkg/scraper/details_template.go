@@ -0,0 +1,117 @@
+package scraper
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+)
+
+// detailsTemplateData is the data made available to the details template.
+type detailsTemplateData struct {
+	Title      string
+	Code       string
+	Details    string
+	Director   string
+	URL        string
+	Date       string
+	Duration   int
+	Studio     string
+	Performers []string
+	Tags       []string
+	Movies     []string
+}
+
+func newDetailsTemplateData(s *ScrapedScene) detailsTemplateData {
+	str := func(v *string) string {
+		if v == nil {
+			return ""
+		}
+		return *v
+	}
+
+	ret := detailsTemplateData{
+		Title:    str(s.Title),
+		Code:     str(s.Code),
+		Details:  str(s.Details),
+		Director: str(s.Director),
+		URL:      str(s.URL),
+		Date:     str(s.Date),
+	}
+
+	if s.Duration != nil {
+		ret.Duration = *s.Duration
+	}
+
+	if s.Studio != nil {
+		ret.Studio = s.Studio.Name
+	}
+
+	for _, p := range s.Performers {
+		if p != nil && p.Name != nil {
+			ret.Performers = append(ret.Performers, *p.Name)
+		}
+	}
+
+	for _, t := range s.Tags {
+		if t != nil {
+			ret.Tags = append(ret.Tags, t.Name)
+		}
+	}
+
+	for _, m := range s.Movies {
+		if m != nil && m.Name != nil {
+			ret.Movies = append(ret.Movies, *m.Name)
+		}
+	}
+
+	return ret
+}
+
+var detailsTemplateFuncs = template.FuncMap{
+	"join": func(sep string, s []string) string {
+		return strings.Join(s, sep)
+	},
+}
+
+func parseDetailsTemplate(tmpl string) (*template.Template, error) {
+	return template.New("details").Funcs(detailsTemplateFuncs).Parse(tmpl)
+}
+
+// ValidateDetailsTemplate returns an error if the provided details template
+// cannot be parsed.
+func ValidateDetailsTemplate(tmpl string) error {
+	_, err := parseDetailsTemplate(tmpl)
+	return err
+}
+
+// applyDetailsTemplate sets the details of the scraped scene by executing the
+// provided template over the scraped fields. sceneDetails are the stored
+// details of the local scene being scraped, if known. Unless force is true,
+// the template is only applied if neither the stored nor the scraped details
+// are set. Does nothing if the template is empty.
+func applyDetailsTemplate(s *ScrapedScene, tmpl string, sceneDetails string, force bool) error {
+	if tmpl == "" {
+		return nil
+	}
+
+	if !force && (sceneDetails != "" || (s.Details != nil && *s.Details != "")) {
+		return nil
+	}
+
+	t, err := parseDetailsTemplate(tmpl)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, newDetailsTemplateData(s)); err != nil {
+		return err
+	}
+
+	details := strings.TrimSpace(buf.String())
+	if details != "" {
+		s.Details = &details
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package scraper
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyDetailsTemplate(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	const tmpl = `{{.Title}} ({{.Date}}) by {{.Studio}}. Starring {{join ", " .Performers}}.`
+
+	makeScene := func(details *string) *ScrapedScene {
+		return &ScrapedScene{
+			Title:   strPtr("Title"),
+			Date:    strPtr("2022-01-02"),
+			Details: details,
+			Studio: &models.ScrapedStudio{
+				Name: "Studio",
+			},
+			Performers: []*models.ScrapedPerformer{
+				{Name: strPtr("Performer 1")},
+				{Name: strPtr("Performer 2")},
+			},
+		}
+	}
+
+	const templated = "Title (2022-01-02) by Studio. Starring Performer 1, Performer 2."
+
+	tests := []struct {
+		name         string
+		scene        *ScrapedScene
+		tmpl         string
+		sceneDetails string
+		force        bool
+		want         *string
+		wantErr      bool
+	}{
+		{"no template", makeScene(nil), "", "", false, nil, false},
+		{"no details", makeScene(nil), tmpl, "", false, strPtr(templated), false},
+		{"empty details", makeScene(strPtr("")), tmpl, "", false, strPtr(templated), false},
+		{"existing details", makeScene(strPtr("details")), tmpl, "", false, strPtr("details"), false},
+		{"stored details", makeScene(nil), tmpl, "stored", false, nil, false},
+		{"stored and scraped details", makeScene(strPtr("details")), tmpl, "stored", false, strPtr("details"), false},
+		{"forced", makeScene(strPtr("details")), tmpl, "", true, strPtr(templated), false},
+		{"forced over stored details", makeScene(nil), tmpl, "stored", true, strPtr(templated), false},
+		{"uses details", makeScene(strPtr("details")), "{{.Title}}: {{.Details}}", "", true, strPtr("Title: details"), false},
+		{"empty result", makeScene(nil), "  {{.Code}} ", "", false, nil, false},
+		{"invalid template", makeScene(nil), "{{.Title", "", false, nil, true},
+		{"unknown field", makeScene(nil), "{{.Unknown}}", "", false, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := applyDetailsTemplate(tt.scene, tt.tmpl, tt.sceneDetails, tt.force)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("applyDetailsTemplate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, tt.scene.Details)
+		})
+	}
+}
@@ -723,6 +723,58 @@ func (c mappedScraperAttrConfig) postProcess(ctx context.Context, value string,
 	return value
 }
 
+type mappedStudioScraperConfig struct {
+	mappedConfig
+
+	Parent mappedConfig `yaml:"Parent"`
+}
+type _mappedStudioScraperConfig mappedStudioScraperConfig
+
+const (
+	mappedScraperConfigStudioParent = "Parent"
+)
+
+func (s *mappedStudioScraperConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	// HACK - unmarshal to map first, then remove known studio sub-fields, then
+	// remarshal to yaml and pass that down to the base map
+	parentMap := make(map[string]interface{})
+	if err := unmarshal(parentMap); err != nil {
+		return err
+	}
+
+	// move the known sub-fields to a separate map
+	thisMap := make(map[string]interface{})
+
+	thisMap[mappedScraperConfigStudioParent] = parentMap[mappedScraperConfigStudioParent]
+
+	delete(parentMap, mappedScraperConfigStudioParent)
+
+	// re-unmarshal the sub-fields
+	yml, err := yaml.Marshal(thisMap)
+	if err != nil {
+		return err
+	}
+
+	// needs to be a different type to prevent infinite recursion
+	c := _mappedStudioScraperConfig{}
+	if err := yaml.Unmarshal(yml, &c); err != nil {
+		return err
+	}
+
+	*s = mappedStudioScraperConfig(c)
+
+	yml, err = yaml.Marshal(parentMap)
+	if err != nil {
+		return err
+	}
+
+	if err := yaml.Unmarshal(yml, &s.mappedConfig); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 type mappedScrapers map[string]*mappedScraper
 
 type mappedScraper struct {
@@ -731,6 +783,7 @@ type mappedScraper struct {
 	Gallery   *mappedGalleryScraperConfig   `yaml:"gallery"`
 	Performer *mappedPerformerScraperConfig `yaml:"performer"`
 	Movie     *mappedMovieScraperConfig     `yaml:"movie"`
+	Studio    *mappedStudioScraperConfig    `yaml:"studio"`
 }
 
 type mappedResult map[string]string
@@ -1022,3 +1075,38 @@ func (s mappedScraper) scrapeMovie(ctx context.Context, q mappedQuery) (*models.
 
 	return ret, nil
 }
+
+func (s mappedScraper) scrapeStudio(ctx context.Context, q mappedQuery) (*models.ScrapedStudio, error) {
+	var ret *models.ScrapedStudio
+
+	studioScraperConfig := s.Studio
+	if studioScraperConfig == nil {
+		return nil, nil
+	}
+
+	studioMap := studioScraperConfig.mappedConfig
+	if studioMap == nil {
+		return nil, nil
+	}
+
+	studioParentMap := studioScraperConfig.Parent
+
+	results := studioMap.process(ctx, q, s.Common)
+	if len(results) > 0 {
+		ret = &models.ScrapedStudio{}
+		results[0].apply(ret)
+
+		if studioParentMap != nil {
+			logger.Debug(`Processing studio parent:`)
+			parentResults := studioParentMap.process(ctx, q, s.Common)
+
+			if len(parentResults) > 0 {
+				parent := &models.ScrapedStudio{}
+				parentResults[0].apply(parent)
+				ret.Parent = parent
+			}
+		}
+	}
+
+	return ret, nil
+}
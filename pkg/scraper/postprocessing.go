@@ -12,8 +12,13 @@ import (
 
 // postScrape handles post-processing of scraped content. If the content
 // requires post-processing, this function fans out to the given content
-// type and post-processes it.
-func (c Cache) postScrape(ctx context.Context, content ScrapedContent) (ScrapedContent, error) {
+// type and post-processes it. sceneDetails are the stored details of the
+// scene being scraped, if any.
+func (c Cache) postScrape(ctx context.Context, s scraper, content ScrapedContent, sceneDetails string) (ScrapedContent, error) {
+	if c.sanitizeOutput(s) {
+		content = sanitizeScrapedContent(content)
+	}
+
 	// Analyze the concrete type, call the right post-processing function
 	switch v := content.(type) {
 	case *models.ScrapedPerformer:
@@ -24,10 +29,10 @@ func (c Cache) postScrape(ctx context.Context, content ScrapedContent) (ScrapedC
 		return c.postScrapePerformer(ctx, v)
 	case *ScrapedScene:
 		if v != nil {
-			return c.postScrapeScene(ctx, *v)
+			return c.postScrapeScene(ctx, *v, sceneDetails)
 		}
 	case ScrapedScene:
-		return c.postScrapeScene(ctx, v)
+		return c.postScrapeScene(ctx, v, sceneDetails)
 	case *ScrapedGallery:
 		if v != nil {
 			return c.postScrapeGallery(ctx, *v)
@@ -40,12 +45,28 @@ func (c Cache) postScrape(ctx context.Context, content ScrapedContent) (ScrapedC
 		}
 	case models.ScrapedMovie:
 		return c.postScrapeMovie(ctx, v)
+	case *models.ScrapedStudio:
+		if v != nil {
+			return c.postScrapeStudio(ctx, *v)
+		}
+	case models.ScrapedStudio:
+		return c.postScrapeStudio(ctx, v)
 	}
 
 	// If nothing matches, pass the content through
 	return content, nil
 }
 
+// sanitizeOutput returns true if the titles and details scraped by s should
+// be sanitized.
+func (c Cache) sanitizeOutput(s scraper) bool {
+	if g, ok := s.(group); ok && g.config.Sanitize != nil {
+		return *g.config.Sanitize
+	}
+
+	return c.globalConfig.IsScraperSanitizeOutput()
+}
+
 func (c Cache) postScrapePerformer(ctx context.Context, p models.ScrapedPerformer) (ScrapedContent, error) {
 	if err := txn.WithReadTxn(ctx, c.txnManager, func(ctx context.Context) error {
 		tqb := c.repository.TagFinder
@@ -91,6 +112,31 @@ func (c Cache) postScrapeMovie(ctx context.Context, m models.ScrapedMovie) (Scra
 	return m, nil
 }
 
+func (c Cache) postScrapeStudio(ctx context.Context, s models.ScrapedStudio) (ScrapedContent, error) {
+	if err := txn.WithReadTxn(ctx, c.txnManager, func(ctx context.Context) error {
+		sqb := c.repository.StudioFinder
+
+		if err := match.ScrapedStudio(ctx, sqb, &s, nil); err != nil {
+			return err
+		}
+
+		if s.Parent != nil {
+			return match.ScrapedStudio(ctx, sqb, s.Parent, nil)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// post-process - set the image if applicable
+	if err := setStudioImage(ctx, c.client, &s, c.globalConfig); err != nil {
+		logger.Warnf("could not set image using URL %s: %v", *s.Image, err)
+	}
+
+	return s, nil
+}
+
 func (c Cache) postScrapeScenePerformer(ctx context.Context, p models.ScrapedPerformer) error {
 	tqb := c.repository.TagFinder
 
@@ -105,7 +151,7 @@ func (c Cache) postScrapeScenePerformer(ctx context.Context, p models.ScrapedPer
 	return nil
 }
 
-func (c Cache) postScrapeScene(ctx context.Context, scene ScrapedScene) (ScrapedContent, error) {
+func (c Cache) postScrapeScene(ctx context.Context, scene ScrapedScene, sceneDetails string) (ScrapedContent, error) {
 	if err := txn.WithReadTxn(ctx, c.txnManager, func(ctx context.Context) error {
 		pqb := c.repository.PerformerFinder
 		mqb := c.repository.MovieFinder
@@ -156,6 +202,10 @@ func (c Cache) postScrapeScene(ctx context.Context, scene ScrapedScene) (Scraped
 		logger.Warnf("Could not set image using URL %s: %v", *scene.Image, err)
 	}
 
+	if err := applyDetailsTemplate(&scene, c.globalConfig.GetScraperDetailsTemplate(), sceneDetails, c.globalConfig.IsScraperDetailsTemplateForced()); err != nil {
+		logger.Warnf("Could not apply details template: %v", err)
+	}
+
 	return scene, nil
 }
 
@@ -0,0 +1,109 @@
+package scraper
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+var (
+	htmlLineBreakRE = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|li|h[1-6])\s*>`)
+	htmlTagRE       = regexp.MustCompile(`<[^>]*>`)
+	spacesRE        = regexp.MustCompile(`[^\S\n]+`)
+	blankLinesRE    = regexp.MustCompile(`\n{3,}`)
+)
+
+// sanitizeText strips html tags from s, decodes html entities and
+// normalizes whitespace. Line breaks are kept, with at most one blank line
+// between paragraphs.
+func sanitizeText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = htmlLineBreakRE.ReplaceAllString(s, "\n")
+	s = htmlTagRE.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimSpace(spacesRE.ReplaceAllString(l, " "))
+	}
+	s = strings.Join(lines, "\n")
+
+	return strings.TrimSpace(blankLinesRE.ReplaceAllString(s, "\n\n"))
+}
+
+// sanitizeLine is sanitizeText for single line values such as titles.
+func sanitizeLine(s string) string {
+	return strings.Join(strings.Fields(sanitizeText(s)), " ")
+}
+
+func sanitizeTextPtr(s *string, fn func(string) string) {
+	if s != nil {
+		*s = fn(*s)
+	}
+}
+
+// sanitizeScrapedContent sanitizes the titles, names and details of the
+// scraped content.
+func sanitizeScrapedContent(content ScrapedContent) ScrapedContent {
+	switch v := content.(type) {
+	case *models.ScrapedPerformer:
+		if v != nil {
+			sanitizePerformer(v)
+		}
+	case models.ScrapedPerformer:
+		sanitizePerformer(&v)
+		return v
+	case *ScrapedScene:
+		if v != nil {
+			sanitizeScene(v)
+		}
+	case ScrapedScene:
+		sanitizeScene(&v)
+		return v
+	case *ScrapedGallery:
+		if v != nil {
+			sanitizeGallery(v)
+		}
+	case ScrapedGallery:
+		sanitizeGallery(&v)
+		return v
+	case *models.ScrapedMovie:
+		if v != nil {
+			sanitizeMovie(v)
+		}
+	case models.ScrapedMovie:
+		sanitizeMovie(&v)
+		return v
+	case *models.ScrapedStudio:
+		if v != nil {
+			v.Name = sanitizeLine(v.Name)
+		}
+	case models.ScrapedStudio:
+		v.Name = sanitizeLine(v.Name)
+		return v
+	}
+
+	return content
+}
+
+func sanitizePerformer(p *models.ScrapedPerformer) {
+	sanitizeTextPtr(p.Name, sanitizeLine)
+	sanitizeTextPtr(p.Details, sanitizeText)
+}
+
+func sanitizeScene(s *ScrapedScene) {
+	sanitizeTextPtr(s.Title, sanitizeLine)
+	sanitizeTextPtr(s.Details, sanitizeText)
+}
+
+func sanitizeGallery(g *ScrapedGallery) {
+	sanitizeTextPtr(g.Title, sanitizeLine)
+	sanitizeTextPtr(g.Details, sanitizeText)
+}
+
+func sanitizeMovie(m *models.ScrapedMovie) {
+	sanitizeTextPtr(m.Name, sanitizeLine)
+	sanitizeTextPtr(m.Synopsis, sanitizeText)
+}
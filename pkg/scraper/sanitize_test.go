@@ -0,0 +1,54 @@
+package scraper
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeText(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{"plain", "details", "details"},
+		{"tags", "<b>bold</b> and <a href=\"x\">link</a>", "bold and link"},
+		{"entities", "Tom &amp; Jerry &quot;quoted&quot; &#39;s", "Tom & Jerry \"quoted\" 's"},
+		{"line breaks", "line 1<br>line 2<br/>line 3", "line 1\nline 2\nline 3"},
+		{"paragraphs", "<p>para 1</p><p>para 2</p>", "para 1\npara 2"},
+		{"spaces", "  lots \t of   spaces  ", "lots of spaces"},
+		{"blank lines", "para 1\r\n\r\n\r\n\r\npara 2", "para 1\n\npara 2"},
+		{"indented lines", "line 1\n   line 2  \n", "line 1\nline 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sanitizeText(tt.s))
+		})
+	}
+}
+
+func TestSanitizeLine(t *testing.T) {
+	assert.Equal(t, "A Title & More", sanitizeLine("  A <i>Title</i>\n&amp;  More "))
+}
+
+func TestSanitizeScrapedContent(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	scene := &ScrapedScene{
+		Title:   strPtr(" <b>Title</b> "),
+		Details: strPtr("<p>Details &amp; more</p>"),
+		URL:     strPtr("http://example.com/?a=1&amp;b=2"),
+	}
+
+	got := sanitizeScrapedContent(scene)
+	assert.Same(t, scene, got)
+	assert.Equal(t, "Title", *scene.Title)
+	assert.Equal(t, "Details & more", *scene.Details)
+	// other fields are not sanitized
+	assert.Equal(t, "http://example.com/?a=1&amp;b=2", *scene.URL)
+
+	studio := sanitizeScrapedContent(models.ScrapedStudio{Name: "Studio &amp; Co"})
+	assert.Equal(t, models.ScrapedStudio{Name: "Studio & Co"}, studio)
+}
@@ -33,6 +33,7 @@ const (
 	ScrapeContentTypeMovie     ScrapeContentType = "MOVIE"
 	ScrapeContentTypePerformer ScrapeContentType = "PERFORMER"
 	ScrapeContentTypeScene     ScrapeContentType = "SCENE"
+	ScrapeContentTypeStudio    ScrapeContentType = "STUDIO"
 )
 
 var AllScrapeContentType = []ScrapeContentType{
@@ -40,11 +41,12 @@ var AllScrapeContentType = []ScrapeContentType{
 	ScrapeContentTypeMovie,
 	ScrapeContentTypePerformer,
 	ScrapeContentTypeScene,
+	ScrapeContentTypeStudio,
 }
 
 func (e ScrapeContentType) IsValid() bool {
 	switch e {
-	case ScrapeContentTypeGallery, ScrapeContentTypeMovie, ScrapeContentTypePerformer, ScrapeContentTypeScene:
+	case ScrapeContentTypeGallery, ScrapeContentTypeMovie, ScrapeContentTypePerformer, ScrapeContentTypeScene, ScrapeContentTypeStudio:
 		return true
 	}
 	return false
@@ -82,6 +84,8 @@ type Scraper struct {
 	Gallery *ScraperSpec `json:"gallery"`
 	// Details for movie scraper
 	Movie *ScraperSpec `json:"movie"`
+	// Details for studio scraper
+	Studio *ScraperSpec `json:"studio"`
 }
 
 type ScraperSpec struct {
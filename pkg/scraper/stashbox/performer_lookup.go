@@ -0,0 +1,52 @@
+package stashbox
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// FindPerformersByIDs finds the performers with the provided stash ids. The
+// results are returned in the same order as the provided stash ids. A result
+// is nil if the performer does not exist.
+//
+// Each distinct stash id is looked up once, using the same batching as
+// CheckSceneStashIDs. The returned performers do not have their images
+// fetched.
+func (c Client) FindPerformersByIDs(ctx context.Context, stashIDs []string) ([]*models.ScrapedPerformer, error) {
+	var distinct []string
+	performers := make(map[string]*models.ScrapedPerformer)
+	for _, id := range stashIDs {
+		if _, found := performers[id]; !found {
+			performers[id] = nil
+			distinct = append(distinct, id)
+		}
+	}
+
+	found := make([]*models.ScrapedPerformer, len(distinct))
+	if err := lookupBatched(ctx, len(distinct), func(ctx context.Context, i int) error {
+		performer, err := c.findPerformerByID(ctx, distinct[i])
+		if err != nil {
+			return err
+		}
+
+		if performer.FindPerformer != nil {
+			found[i] = performerFragmentToScrapedScenePerformer(*performer.FindPerformer)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for i, id := range distinct {
+		performers[id] = found[i]
+	}
+
+	ret := make([]*models.ScrapedPerformer, len(stashIDs))
+	for i, id := range stashIDs {
+		ret[i] = performers[id]
+	}
+
+	return ret, nil
+}
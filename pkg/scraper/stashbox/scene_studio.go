@@ -0,0 +1,58 @@
+package stashbox
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// FindSceneStudios finds the studios of the scenes with the provided stash
+// ids. The results are returned in the same order as the provided stash ids.
+// A result is nil if the scene does not exist or does not have a studio.
+//
+// Each distinct stash id is looked up once, using the same batching as
+// CheckSceneStashIDs. The returned studios are not matched against local
+// studios.
+func (c Client) FindSceneStudios(ctx context.Context, stashIDs []string) ([]*models.ScrapedStudio, error) {
+	var distinct []string
+	studios := make(map[string]*models.ScrapedStudio)
+	for _, id := range stashIDs {
+		if _, found := studios[id]; !found {
+			studios[id] = nil
+			distinct = append(distinct, id)
+		}
+	}
+
+	found := make([]*models.ScrapedStudio, len(distinct))
+	if err := lookupBatched(ctx, len(distinct), func(ctx context.Context, i int) error {
+		scene, err := c.findSceneByID(ctx, distinct[i])
+		if err != nil {
+			return err
+		}
+
+		if scene.FindScene != nil && scene.FindScene.Studio != nil {
+			s := scene.FindScene.Studio
+			studioID := s.ID
+			found[i] = &models.ScrapedStudio{
+				Name:         s.Name,
+				URL:          findURL(s.Urls, "HOME"),
+				RemoteSiteID: &studioID,
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for i, id := range distinct {
+		studios[id] = found[i]
+	}
+
+	ret := make([]*models.ScrapedStudio, len(stashIDs))
+	for i, id := range stashIDs {
+		ret[i] = studios[id]
+	}
+
+	return ret, nil
+}
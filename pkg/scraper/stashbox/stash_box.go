@@ -238,13 +238,18 @@ func (c Client) findStashBoxScenesByFingerprints(ctx context.Context, scenes [][
 	return results, nil
 }
 
-func (c Client) SubmitStashBoxFingerprints(ctx context.Context, sceneIDs []string, endpoint string) (bool, error) {
+// SubmitStashBoxFingerprints submits the fingerprints of the files of the
+// scenes with a stash ID for the endpoint. If organizedOnly is true, then
+// scenes that are not organized are skipped. Returns the IDs of the skipped
+// scenes.
+func (c Client) SubmitStashBoxFingerprints(ctx context.Context, sceneIDs []string, endpoint string, organizedOnly bool) ([]int, error) {
 	ids, err := stringslice.StringSliceToIntSlice(sceneIDs)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	var fingerprints []graphql.FingerprintSubmission
+	var skipped []int
 
 	if err := txn.WithReadTxn(ctx, c.txnManager, func(ctx context.Context) error {
 		qb := c.repository.Scene
@@ -276,6 +281,12 @@ func (c Client) SubmitStashBoxFingerprints(ctx context.Context, sceneIDs []strin
 				}
 			}
 
+			if sceneStashID != "" && organizedOnly && !scene.Organized {
+				logger.Infof("Not submitting fingerprints of scene %s as it is not organized", scene.DisplayName())
+				skipped = append(skipped, scene.ID)
+				continue
+			}
+
 			if sceneStashID != "" {
 				for _, f := range scene.Files.List() {
 					duration := f.Duration
@@ -323,10 +334,14 @@ func (c Client) SubmitStashBoxFingerprints(ctx context.Context, sceneIDs []strin
 
 		return nil
 	}); err != nil {
-		return false, err
+		return nil, err
+	}
+
+	if _, err := c.submitStashBoxFingerprints(ctx, fingerprints); err != nil {
+		return nil, err
 	}
 
-	return c.submitStashBoxFingerprints(ctx, fingerprints)
+	return skipped, nil
 }
 
 func (c Client) submitStashBoxFingerprints(ctx context.Context, fingerprints []graphql.FingerprintSubmission) (bool, error) {
@@ -757,6 +772,21 @@ func (c Client) sceneFragmentToScrapedScene(ctx context.Context, s *graphql.Scen
 	return ss, nil
 }
 
+// FindStashBoxSceneByID returns the scene with the provided stash-box id.
+// Returns nil if the scene is not found.
+func (c Client) FindStashBoxSceneByID(ctx context.Context, id string) (*scraper.ScrapedScene, error) {
+	scene, err := c.client.FindSceneByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if scene.FindScene == nil {
+		return nil, nil
+	}
+
+	return c.sceneFragmentToScrapedScene(ctx, scene.FindScene)
+}
+
 func (c Client) FindStashBoxPerformerByID(ctx context.Context, id string) (*models.ScrapedPerformer, error) {
 	performer, err := c.client.FindPerformerByID(ctx, id)
 	if err != nil {
@@ -0,0 +1,106 @@
+package stashbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stashapp/stash/pkg/scraper/stashbox/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSubmitStashBoxFingerprints(t *testing.T) {
+	const endpoint = "endpoint"
+
+	newScene := func(id int, organized bool, stashID string) *models.Scene {
+		stashIDs := []models.StashID{}
+		if stashID != "" {
+			stashIDs = append(stashIDs, models.StashID{Endpoint: endpoint, StashID: stashID})
+		}
+
+		return &models.Scene{
+			ID:        id,
+			Organized: organized,
+			StashIDs:  models.NewRelatedStashIDs(stashIDs),
+			Files: models.NewRelatedVideoFiles([]*file.VideoFile{
+				{
+					BaseFile: &file.BaseFile{
+						Fingerprints: file.Fingerprints{
+							{Type: file.FingerprintTypeOshash, Fingerprint: fmt.Sprintf("oshash%d", id)},
+						},
+					},
+					Duration: 60,
+				},
+			}),
+		}
+	}
+
+	scenes := []*models.Scene{
+		newScene(1, true, "organized"),
+		newScene(2, false, "unorganized"),
+		// scenes without a stash ID are neither submitted nor skipped
+		newScene(3, false, ""),
+	}
+
+	tests := []struct {
+		name          string
+		organizedOnly bool
+		wantSubmitted []string
+		wantSkipped   []int
+	}{
+		{"all", false, []string{"organized", "unorganized"}, nil},
+		{"organized only", true, []string{"organized"}, []int{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var submitted []string
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req struct {
+					Variables struct {
+						Input graphql.FingerprintSubmission `json:"input"`
+					} `json:"variables"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+
+				mu.Lock()
+				submitted = append(submitted, req.Variables.Input.SceneID)
+				mu.Unlock()
+
+				fmt.Fprint(w, `{"data":{"submitFingerprint":true}}`)
+			}))
+			defer srv.Close()
+
+			sceneReader := &mocks.SceneReaderWriter{}
+			for _, s := range scenes {
+				sceneReader.On("Find", mock.Anything, s.ID).Return(s, nil).Once()
+			}
+
+			c := NewClient(models.StashBox{Endpoint: srv.URL}, &mocks.TxnManager{}, Repository{
+				Scene: sceneReader,
+			})
+
+			skipped, err := c.SubmitStashBoxFingerprints(context.Background(), []string{"1", "2", "3"}, endpoint, tt.organizedOnly)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, tt.wantSkipped, skipped)
+			assert.Equal(t, tt.wantSubmitted, submitted)
+			sceneReader.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package stashbox
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Yamashou/gqlgenc/client"
+	"github.com/stashapp/stash/pkg/scraper/stashbox/graphql"
+)
+
+const (
+	// lookupBatchSize is the number of stash ids that are looked up
+	// concurrently.
+	lookupBatchSize = 5
+	// lookupMaxRetries is the number of times a rate limited request is
+	// retried.
+	lookupMaxRetries = 3
+)
+
+var (
+	// lookupBatchInterval is the minimum time between the start of two
+	// batches of lookups.
+	lookupBatchInterval = time.Second
+	// lookupRetryDelay is the delay before the first retry of a rate limited
+	// request. It is doubled for each subsequent retry.
+	lookupRetryDelay = 2 * time.Second
+)
+
+// StashIDCheckResult is the result of checking that a stash id resolves on a
+// stash-box instance.
+type StashIDCheckResult struct {
+	StashID string
+	// Found is false if the stash id does not exist on the stash-box instance.
+	Found bool
+	// RedirectID is set if the stash id resolves to an object with a
+	// different id. This happens when the object was merged into another.
+	RedirectID *string
+}
+
+// CheckSceneStashIDs checks that each of the provided scene stash ids
+// resolves on the stash-box instance. The results are returned in the same
+// order as the provided stash ids.
+//
+// Stash ids are checked in small concurrent batches, and batches are spaced
+// out so that the server is not flooded with requests. Requests that are
+// rate limited by the server are retried with an increasing delay.
+func (c Client) CheckSceneStashIDs(ctx context.Context, stashIDs []string) ([]StashIDCheckResult, error) {
+	ret := make([]StashIDCheckResult, len(stashIDs))
+
+	if err := lookupBatched(ctx, len(stashIDs), func(ctx context.Context, i int) error {
+		var err error
+		ret[i], err = c.checkSceneStashID(ctx, stashIDs[i])
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (c Client) checkSceneStashID(ctx context.Context, stashID string) (StashIDCheckResult, error) {
+	ret := StashIDCheckResult{
+		StashID: stashID,
+	}
+
+	scene, err := c.findSceneByID(ctx, stashID)
+	if err != nil {
+		return ret, err
+	}
+
+	if scene.FindScene != nil {
+		ret.Found = true
+		if scene.FindScene.ID != stashID {
+			id := scene.FindScene.ID
+			ret.RedirectID = &id
+		}
+	}
+
+	return ret, nil
+}
+
+// findSceneByID finds the scene with the provided stash id, retrying if the
+// request is rate limited by the server.
+func (c Client) findSceneByID(ctx context.Context, stashID string) (*graphql.FindSceneByID, error) {
+	delay := lookupRetryDelay
+	for retry := 0; ; retry++ {
+		scene, err := c.client.FindSceneByID(ctx, stashID)
+		if err != nil && retry < lookupMaxRetries && isRateLimited(err) {
+			if err := sleepContext(ctx, delay); err != nil {
+				return nil, err
+			}
+			delay *= 2
+			continue
+		}
+
+		return scene, err
+	}
+}
+
+// findPerformerByID finds the performer with the provided stash id,
+// retrying if the request is rate limited by the server.
+func (c Client) findPerformerByID(ctx context.Context, stashID string) (*graphql.FindPerformerByID, error) {
+	delay := lookupRetryDelay
+	for retry := 0; ; retry++ {
+		performer, err := c.client.FindPerformerByID(ctx, stashID)
+		if err != nil && retry < lookupMaxRetries && isRateLimited(err) {
+			if err := sleepContext(ctx, delay); err != nil {
+				return nil, err
+			}
+			delay *= 2
+			continue
+		}
+
+		return performer, err
+	}
+}
+
+// lookupBatched calls fn for each index from 0 to n-1. Calls are made in
+// small concurrent batches, and batches are spaced out so that the server is
+// not flooded with requests. Returns the first error encountered.
+func lookupBatched(ctx context.Context, n int, fn func(ctx context.Context, i int) error) error {
+	var last time.Time
+	for start := 0; start < n; start += lookupBatchSize {
+		if err := sleepContext(ctx, time.Until(last.Add(lookupBatchInterval))); err != nil {
+			return err
+		}
+		last = time.Now()
+
+		end := start + lookupBatchSize
+		if end > n {
+			end = n
+		}
+
+		var wg sync.WaitGroup
+		errs := make([]error, end-start)
+		for i := start; i < end; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i-start] = fn(ctx, i)
+			}(i)
+		}
+		wg.Wait()
+
+		for _, err := range errs {
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func isRateLimited(err error) bool {
+	var errResponse *client.ErrorResponse
+	return errors.As(err, &errResponse) && errResponse.NetworkError != nil && errResponse.NetworkError.Code == http.StatusTooManyRequests
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,186 @@
+package stashbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckSceneStashIDs(t *testing.T) {
+	lookupBatchInterval = 0
+	lookupRetryDelay = time.Millisecond
+
+	var mu sync.Mutex
+	rateLimited := false
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables struct {
+				ID string `json:"id"`
+			} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		id := req.Variables.ID
+		switch id {
+		case "missing":
+			fmt.Fprint(w, `{"data":{"findScene":null}}`)
+			return
+		case "merged":
+			id = "target"
+		case "limited":
+			mu.Lock()
+			limited := !rateLimited
+			rateLimited = true
+			mu.Unlock()
+
+			if limited {
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+		}
+
+		fmt.Fprintf(w, `{"data":{"findScene":{"id":%q}}}`, id)
+	}))
+	defer srv.Close()
+
+	c := NewClient(models.StashBox{Endpoint: srv.URL}, nil, Repository{})
+
+	target := "target"
+	want := []StashIDCheckResult{
+		{StashID: "found", Found: true},
+		{StashID: "missing"},
+		{StashID: "merged", Found: true, RedirectID: &target},
+		{StashID: "limited", Found: true},
+		{StashID: "a", Found: true},
+		{StashID: "b", Found: true},
+	}
+
+	var ids []string
+	for _, w := range want {
+		ids = append(ids, w.StashID)
+	}
+
+	got, err := c.CheckSceneStashIDs(context.Background(), ids)
+	if assert.NoError(t, err) {
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestFindSceneStudios(t *testing.T) {
+	lookupBatchInterval = 0
+
+	var mu sync.Mutex
+	requests := make(map[string]int)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables struct {
+				ID string `json:"id"`
+			} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		id := req.Variables.ID
+		mu.Lock()
+		requests[id]++
+		mu.Unlock()
+
+		switch id {
+		case "missing":
+			fmt.Fprint(w, `{"data":{"findScene":null}}`)
+		case "nostudio":
+			fmt.Fprintf(w, `{"data":{"findScene":{"id":%q}}}`, id)
+		default:
+			fmt.Fprintf(w, `{"data":{"findScene":{"id":%q,"studio":{"id":"studio-%s","name":"Studio %s"}}}}`, id, id, id)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(models.StashBox{Endpoint: srv.URL}, nil, Repository{})
+
+	got, err := c.FindSceneStudios(context.Background(), []string{"a", "missing", "nostudio", "b", "a"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	studioA := "studio-a"
+	studioB := "studio-b"
+	want := []*models.ScrapedStudio{
+		{Name: "Studio a", RemoteSiteID: &studioA},
+		nil,
+		nil,
+		{Name: "Studio b", RemoteSiteID: &studioB},
+		{Name: "Studio a", RemoteSiteID: &studioA},
+	}
+	assert.Equal(t, want, got)
+	assert.Equal(t, 1, requests["a"])
+}
+
+func TestFindPerformersByIDs(t *testing.T) {
+	lookupBatchInterval = 0
+	lookupRetryDelay = time.Millisecond
+
+	var mu sync.Mutex
+	requests := make(map[string]int)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables struct {
+				ID string `json:"id"`
+			} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		id := req.Variables.ID
+		mu.Lock()
+		requests[id]++
+		limited := id == "limited" && requests[id] == 1
+		mu.Unlock()
+
+		switch {
+		case id == "missing":
+			fmt.Fprint(w, `{"data":{"findPerformer":null}}`)
+		case limited:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			fmt.Fprintf(w, `{"data":{"findPerformer":{"id":%q,"name":"Performer %s","country":"US"}}}`, id, id)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(models.StashBox{Endpoint: srv.URL}, nil, Repository{})
+
+	got, err := c.FindPerformersByIDs(context.Background(), []string{"a", "missing", "limited", "a"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if assert.Len(t, got, 4) {
+		assert.Equal(t, "Performer a", *got[0].Name)
+		assert.Equal(t, "US", *got[0].Country)
+		assert.Equal(t, "a", *got[0].RemoteSiteID)
+		assert.Nil(t, got[1])
+		assert.Equal(t, "Performer limited", *got[2].Name)
+		assert.Same(t, got[0], got[3])
+	}
+	assert.Equal(t, 1, requests["a"])
+	assert.Equal(t, 2, requests["limited"])
+}
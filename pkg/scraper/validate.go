@@ -0,0 +1,290 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"gopkg.in/yaml.v2"
+)
+
+// ScraperURLCheck is the result of checking that a host used by a scraper
+// can be reached.
+type ScraperURLCheck struct {
+	URL       string  `json:"url"`
+	Reachable bool    `json:"reachable"`
+	Error     *string `json:"error"`
+}
+
+// ScraperValidationResult is the result of validating a scraper
+// configuration file.
+type ScraperValidationResult struct {
+	ID        string             `json:"id"`
+	Path      *string            `json:"path"`
+	Valid     bool               `json:"valid"`
+	Errors    []string           `json:"errors"`
+	Warnings  []string           `json:"warnings"`
+	URLChecks []*ScraperURLCheck `json:"url_checks"`
+}
+
+// ValidateScraper parses and validates the configuration file of the scraper
+// with the provided id. The file is read from disk, so that scrapers which
+// failed to load can also be validated. If checkURLs is true, then the hosts
+// referenced by the configuration are checked for connectivity.
+//
+// Returns ErrNotFound if no configuration file exists for the id.
+func (c Cache) ValidateScraper(ctx context.Context, id string, checkURLs bool) (*ScraperValidationResult, error) {
+	path, err := c.findScraperConfigPath(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if path == "" {
+		return nil, fmt.Errorf("%w: id %s", ErrNotFound, id)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	ret := validateConfigFromYAML(id, f)
+	ret.Path = &path
+
+	if checkURLs && ret.config != nil {
+		ret.URLChecks = checkScraperURLs(ctx, c.client, ret.config.urls())
+	}
+
+	return &ret.ScraperValidationResult, nil
+}
+
+// findScraperConfigPath returns the path of the configuration file for the
+// scraper with the provided id. Returns an empty string if not found.
+func (c Cache) findScraperConfigPath(id string) (string, error) {
+	var ret string
+	err := fsutil.SymWalk(c.globalConfig.GetScrapersPath(), func(fp string, f os.FileInfo, err error) error {
+		if ret == "" && filepath.Ext(fp) == ".yml" {
+			base := filepath.Base(fp)
+			if base[:strings.LastIndex(base, ".")] == id {
+				ret = fp
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return "", fmt.Errorf("reading scraper configs: %w", err)
+	}
+
+	return ret, nil
+}
+
+type configValidation struct {
+	ScraperValidationResult
+	config *config
+}
+
+func validateConfigFromYAML(id string, reader io.Reader) configValidation {
+	ret := configValidation{
+		ScraperValidationResult: ScraperValidationResult{
+			ID:       id,
+			Errors:   []string{},
+			Warnings: []string{},
+		},
+	}
+
+	conf := &config{}
+	parser := yaml.NewDecoder(reader)
+	parser.SetStrict(true)
+	if err := parser.Decode(&conf); err != nil {
+		ret.Errors = append(ret.Errors, err.Error())
+		return ret
+	}
+
+	conf.ID = id
+	ret.config = conf
+
+	for _, err := range conf.validationErrors() {
+		ret.Errors = append(ret.Errors, err.Error())
+	}
+
+	ret.Warnings = append(ret.Warnings, conf.validationWarnings()...)
+
+	ret.Valid = len(ret.Errors) == 0
+	return ret
+}
+
+// validationErrors returns all problems found in the configuration. Unlike
+// validate, it does not stop at the first error, and it includes problems
+// that would otherwise only be reported at scrape time.
+func (c config) validationErrors() []error {
+	var ret []error
+
+	if strings.TrimSpace(c.Name) == "" {
+		ret = append(ret, errors.New("name must not be empty"))
+	}
+
+	checkType := func(name string, s *scraperTypeConfig) {
+		if s == nil {
+			return
+		}
+
+		if err := s.validate(); err != nil {
+			ret = append(ret, fmt.Errorf("%s: %w", name, err))
+			return
+		}
+
+		if err := c.validateReference(*s); err != nil {
+			ret = append(ret, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+
+	checkURLType := func(name string, s []*scrapeByURLConfig) {
+		for i, ss := range s {
+			n := fmt.Sprintf("%s[%d]", name, i)
+			if len(ss.URL) == 0 {
+				ret = append(ret, fmt.Errorf("%s: url is mandatory for scrape by url scrapers", n))
+				continue
+			}
+
+			checkType(n, &ss.scraperTypeConfig)
+		}
+	}
+
+	checkType("performerByName", c.PerformerByName)
+	checkType("performerByFragment", c.PerformerByFragment)
+	checkURLType("performerByURL", c.PerformerByURL)
+	checkType("sceneByFragment", c.SceneByFragment)
+	checkType("sceneByName", c.SceneByName)
+	checkType("sceneByQueryFragment", c.SceneByQueryFragment)
+	checkURLType("sceneByURL", c.SceneByURL)
+	checkType("galleryByFragment", c.GalleryByFragment)
+	checkURLType("galleryByURL", c.GalleryByURL)
+	checkURLType("movieByURL", c.MovieByURL)
+	checkURLType("studioByURL", c.StudioByURL)
+
+	return ret
+}
+
+// validationWarnings returns the problems found in the configuration which
+// do not prevent the scraper from loading.
+func (c config) validationWarnings() []string {
+	var ret []string
+
+	// scene name scraping is only supported if both are configured
+	if (c.SceneByName == nil) != (c.SceneByQueryFragment == nil) {
+		ret = append(ret, "sceneByName and sceneByQueryFragment must be configured together to scrape scenes by name")
+	}
+
+	return ret
+}
+
+// validateReference checks that the scraper referenced by the provided
+// type configuration is defined.
+func (c config) validateReference(s scraperTypeConfig) error {
+	switch s.Action {
+	case scraperActionXPath:
+		if _, found := c.XPathScrapers[s.Scraper]; !found {
+			return fmt.Errorf("xpath scraper %q is not defined in xPathScrapers", s.Scraper)
+		}
+	case scraperActionJson:
+		if _, found := c.JsonScrapers[s.Scraper]; !found {
+			return fmt.Errorf("json scraper %q is not defined in jsonScrapers", s.Scraper)
+		}
+	case scraperActionStash:
+		if c.StashServer == nil || c.StashServer.URL == "" {
+			return errors.New("stashServer url is mandatory for stash scraper action")
+		}
+	}
+
+	return nil
+}
+
+// urls returns the URLs referenced by the configuration.
+func (c config) urls() []string {
+	var ret []string
+
+	for _, s := range [][]*scrapeByURLConfig{c.PerformerByURL, c.SceneByURL, c.GalleryByURL, c.MovieByURL, c.StudioByURL} {
+		for _, ss := range s {
+			ret = append(ret, ss.URL...)
+		}
+	}
+
+	for _, s := range []*scraperTypeConfig{c.PerformerByName, c.PerformerByFragment, c.SceneByFragment, c.SceneByName, c.SceneByQueryFragment, c.GalleryByFragment} {
+		if s != nil && s.QueryURL != "" {
+			ret = append(ret, s.QueryURL)
+		}
+	}
+
+	if c.StashServer != nil && c.StashServer.URL != "" {
+		ret = append(ret, c.StashServer.URL)
+	}
+
+	return ret
+}
+
+// checkScraperURLs checks the connectivity of the hosts of the provided
+// URLs. Each host is only checked once. URLs without a scheme are assumed
+// to be https.
+func checkScraperURLs(ctx context.Context, client *http.Client, urls []string) []*ScraperURLCheck {
+	hosts := make(map[string]bool)
+	for _, u := range urls {
+		if !strings.Contains(u, "://") {
+			u = "https://" + u
+		}
+
+		parsed, err := url.Parse(u)
+		if err != nil || parsed.Host == "" {
+			continue
+		}
+
+		hosts[parsed.Scheme+"://"+parsed.Host] = true
+	}
+
+	ret := []*ScraperURLCheck{}
+	for h := range hosts {
+		check := &ScraperURLCheck{
+			URL: h,
+		}
+
+		if err := checkURL(ctx, client, h); err != nil {
+			errStr := err.Error()
+			check.Error = &errStr
+		} else {
+			check.Reachable = true
+		}
+
+		ret = append(ret, check)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].URL < ret[j].URL
+	})
+
+	return ret
+}
+
+func checkURL(ctx context.Context, client *http.Client, u string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, u, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	// any response from the server means that it is reachable
+	return nil
+}
@@ -0,0 +1,140 @@
+package scraper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateConfigFromYAML(t *testing.T) {
+	tests := []struct {
+		name         string
+		yaml         string
+		wantValid    bool
+		wantErrors   []string
+		wantWarnings []string
+	}{
+		{
+			"valid",
+			`name: Test
+sceneByURL:
+  - action: scrapeXPath
+    url:
+      - example.com/scenes/
+    scraper: sceneScraper
+xPathScrapers:
+  sceneScraper:
+    scene:
+      Title: //h1
+`,
+			true,
+			nil,
+			nil,
+		},
+		{
+			"syntax error",
+			`name: Test
+sceneByURL: [
+`,
+			false,
+			[]string{"yaml:"},
+			nil,
+		},
+		{
+			"unknown field",
+			`name: Test
+sceneByUrl:
+  - action: scrapeXPath
+`,
+			false,
+			[]string{"field sceneByUrl not found"},
+			nil,
+		},
+		{
+			"multiple errors",
+			`name: ""
+performerByURL:
+  - action: scrapeXPath
+    scraper: performerScraper
+sceneByURL:
+  - action: scrapeJson
+    url:
+      - example.com
+    scraper: missing
+sceneByName:
+  action: script
+`,
+			false,
+			[]string{
+				"name must not be empty",
+				"performerByURL[0]: url is mandatory",
+				"sceneByName: script is mandatory",
+				"sceneByURL[0]: json scraper \"missing\" is not defined",
+			},
+			[]string{
+				"sceneByName and sceneByQueryFragment must be configured together",
+			},
+		},
+		{
+			"scene by name without query fragment",
+			`name: Test
+sceneByName:
+  action: scrapeXPath
+  queryURL: https://example.com/search?q={}
+  scraper: sceneSearch
+xPathScrapers:
+  sceneSearch:
+    scene:
+      Title: //h1
+`,
+			true,
+			nil,
+			[]string{
+				"sceneByName and sceneByQueryFragment must be configured together",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validateConfigFromYAML("test", strings.NewReader(tt.yaml))
+
+			assert.Equal(t, tt.wantValid, got.Valid)
+			assert.Len(t, got.Errors, len(tt.wantErrors))
+			for i, e := range tt.wantErrors {
+				if i < len(got.Errors) {
+					assert.Contains(t, got.Errors[i], e)
+				}
+			}
+
+			assert.Len(t, got.Warnings, len(tt.wantWarnings))
+			for i, w := range tt.wantWarnings {
+				if i < len(got.Warnings) {
+					assert.Contains(t, got.Warnings[i], w)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigURLs(t *testing.T) {
+	c := config{
+		SceneByURL: []*scrapeByURLConfig{
+			{URL: []string{"example.com/scenes/", "https://other.com/"}},
+		},
+		PerformerByName: &scraperTypeConfig{
+			QueryURL: "https://example.com/search?q={}",
+		},
+		StashServer: &stashServer{
+			URL: "http://localhost:9999",
+		},
+	}
+
+	assert.Equal(t, []string{
+		"example.com/scenes/",
+		"https://other.com/",
+		"https://example.com/search?q={}",
+		"http://localhost:9999",
+	}, c.urls())
+}
@@ -71,6 +71,8 @@ func (s *xpathScraper) scrapeByURL(ctx context.Context, url string, ty ScrapeCon
 		return scraper.scrapeGallery(ctx, q)
 	case ScrapeContentTypeMovie:
 		return scraper.scrapeMovie(ctx, q)
+	case ScrapeContentTypeStudio:
+		return scraper.scrapeStudio(ctx, q)
 	}
 
 	return nil, ErrNotSupported
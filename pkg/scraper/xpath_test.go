@@ -830,6 +830,18 @@ func (mockGlobalConfig) GetScraperExcludeTagPatterns() []string {
 	return nil
 }
 
+func (mockGlobalConfig) GetScraperDetailsTemplate() string {
+	return ""
+}
+
+func (mockGlobalConfig) IsScraperDetailsTemplateForced() bool {
+	return false
+}
+
+func (mockGlobalConfig) IsScraperSanitizeOutput() bool {
+	return false
+}
+
 func (mockGlobalConfig) GetPythonPath() string {
 	return ""
 }
@@ -904,3 +916,69 @@ xPathScrapers:
 
 	verifyField(t, "The name", performer.Name, "Name")
 }
+
+func TestScrapeStudioXPath(t *testing.T) {
+	studioHTML := `
+	<div class="studio">
+		<h1>The Studio</h1>
+		<a class="network" href="http://example.com/network">The Network</a>
+	</div>
+	`
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, studioHTML)
+	}))
+	defer ts.Close()
+
+	yamlStr := `name: Test
+studioByURL:
+  - action: scrapeXPath
+    url:
+      - ` + ts.URL + `
+    scraper: studioScraper
+xPathScrapers:
+  studioScraper:
+    studio:
+      Name: //div[@class="studio"]/h1
+      URL: //div[@class="studio"]/a/@href
+      Parent:
+        Name: //div[@class="studio"]/a
+        URL: //div[@class="studio"]/a/@href
+`
+
+	c := &config{}
+	if err := yaml.Unmarshal([]byte(yamlStr), &c); err != nil {
+		t.Errorf("Error loading yaml: %s", err.Error())
+		return
+	}
+
+	s := newGroupScraper(*c, mockGlobalConfig{})
+	assert.True(t, s.supports(ScrapeContentTypeStudio))
+	assert.True(t, s.supportsURL(ts.URL, ScrapeContentTypeStudio))
+	assert.False(t, s.supportsURL(ts.URL, ScrapeContentTypeMovie))
+
+	us, ok := s.(urlScraper)
+	if !ok {
+		t.Error("couldn't convert scraper into url scraper")
+		return
+	}
+
+	content, err := us.viaURL(context.Background(), &http.Client{}, ts.URL, ScrapeContentTypeStudio)
+	if err != nil {
+		t.Errorf("Error scraping studio: %s", err.Error())
+		return
+	}
+
+	studio, ok := content.(*models.ScrapedStudio)
+	if !ok {
+		t.Error("couldn't convert scraped content into a studio")
+		return
+	}
+
+	assert.Equal(t, "The Studio", studio.Name)
+	verifyField(t, "http://example.com/network", studio.URL, "URL")
+	if assert.NotNil(t, studio.Parent) {
+		assert.Equal(t, "The Network", studio.Parent.Name)
+		verifyField(t, "http://example.com/network", studio.Parent.URL, "Parent.URL")
+	}
+}
@@ -85,6 +85,21 @@ func StrUnique(vs []string) []string {
 	return ret
 }
 
+// StrUniqueFold returns the vs string slice with values that are equal to an
+// earlier value, ignoring case, removed.
+func StrUniqueFold(vs []string) []string {
+	distinctValues := make(map[string]struct{})
+	var ret []string
+	for _, v := range vs {
+		k := strings.ToLower(v)
+		if _, exists := distinctValues[k]; !exists {
+			distinctValues[k] = struct{}{}
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
 // StrDelete returns the vs string slice with toDel values removed.
 func StrDelete(vs []string, toDel string) []string {
 	var ret []string
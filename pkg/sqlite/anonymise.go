@@ -582,6 +582,12 @@ func (db *Anonymiser) anonymiseStudios(ctx context.Context) error {
 }
 
 func (db *Anonymiser) anonymiseAliases(ctx context.Context, table exp.IdentifierExpression, idColumn string) error {
+	return db.anonymiseStrings(ctx, table, idColumn, "alias")
+}
+
+// anonymiseStrings anonymises the string column of a table joining an id to
+// a list of strings, such as an alias table.
+func (db *Anonymiser) anonymiseStrings(ctx context.Context, table exp.IdentifierExpression, idColumn string, stringColumn string) error {
 	lastID := 0
 	lastAlias := ""
 	total := 0
@@ -591,8 +597,8 @@ func (db *Anonymiser) anonymiseAliases(ctx context.Context, table exp.Identifier
 		if err := txn.WithTxn(ctx, db, func(ctx context.Context) error {
 			query := dialect.From(table).Select(
 				table.Col(idColumn),
-				table.Col("alias"),
-			).Where(goqu.L("(" + idColumn + ", alias)").Gt(goqu.L("(?, ?)", lastID, lastAlias))).Limit(1000)
+				table.Col(stringColumn),
+			).Where(goqu.L("(" + idColumn + ", " + stringColumn + ")").Gt(goqu.L("(?, ?)", lastID, lastAlias))).Limit(1000)
 
 			gotSome = false
 
@@ -611,12 +617,12 @@ func (db *Anonymiser) anonymiseAliases(ctx context.Context, table exp.Identifier
 				}
 
 				set := goqu.Record{}
-				db.obfuscateNullString(set, "alias", alias)
+				db.obfuscateNullString(set, stringColumn, alias)
 
 				if len(set) > 0 {
 					stmt := dialect.Update(table).Set(set).Where(
 						table.Col(idColumn).Eq(id),
-						table.Col("alias").Eq(alias),
+						table.Col(stringColumn).Eq(alias),
 					)
 
 					if _, err := exec(ctx, stmt); err != nil {
@@ -630,7 +636,7 @@ func (db *Anonymiser) anonymiseAliases(ctx context.Context, table exp.Identifier
 				total++
 
 				if total%logEvery == 0 {
-					logger.Infof("Anonymised %d %s aliases", total, table.GetTable())
+					logger.Infof("Anonymised %d %s rows", total, table.GetTable())
 				}
 
 				return nil
@@ -707,6 +713,10 @@ func (db *Anonymiser) anonymiseTags(ctx context.Context) error {
 		return err
 	}
 
+	if err := db.anonymiseStrings(ctx, goqu.T(tagAutoTagKeywordsTable), "tag_id", tagAutoTagKeywordColumn); err != nil {
+		return err
+	}
+
 	return nil
 }
 
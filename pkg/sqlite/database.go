@@ -21,7 +21,7 @@ import (
 	"github.com/stashapp/stash/pkg/logger"
 )
 
-var appSchemaVersion uint = 43
+var appSchemaVersion uint = 50
 
 //go:embed migrations/*.sql
 var migrationsBox embed.FS
@@ -472,9 +472,12 @@ func registerCustomDriver() {
 
 				// COLLATE NATURAL_CS - Case sensitive natural sort
 				err := conn.RegisterCollation("NATURAL_CS", func(s string, s2 string) int {
-					if sortorder.NaturalLess(s, s2) {
+					switch {
+					case s == s2:
+						return 0
+					case sortorder.NaturalLess(s, s2):
 						return -1
-					} else {
+					default:
 						return 1
 					}
 				})
@@ -62,6 +62,12 @@ type videoFileRow struct {
 	BitRate          int64    `db:"bit_rate"`
 	Interactive      bool     `db:"interactive"`
 	InteractiveSpeed null.Int `db:"interactive_speed"`
+
+	InteractiveAverageSpeed null.Int `db:"interactive_average_speed"`
+	InteractiveRange        null.Int `db:"interactive_range"`
+	InteractiveDensity      null.Int `db:"interactive_density"`
+
+	Loudness null.Float `db:"loudness"`
 }
 
 func (f *videoFileRow) fromVideoFile(ff file.VideoFile) {
@@ -76,13 +82,18 @@ func (f *videoFileRow) fromVideoFile(ff file.VideoFile) {
 	f.BitRate = ff.BitRate
 	f.Interactive = ff.Interactive
 	f.InteractiveSpeed = intFromPtr(ff.InteractiveSpeed)
+	f.InteractiveAverageSpeed = intFromPtr(ff.InteractiveAverageSpeed)
+	f.InteractiveRange = intFromPtr(ff.InteractiveRange)
+	f.InteractiveDensity = intFromPtr(ff.InteractiveDensity)
+	f.Loudness = null.FloatFromPtr(ff.Loudness)
 }
 
 type imageFileRow struct {
-	FileID file.ID `db:"file_id"`
-	Format string  `db:"format"`
-	Width  int     `db:"width"`
-	Height int     `db:"height"`
+	FileID    file.ID `db:"file_id"`
+	Format    string  `db:"format"`
+	Width     int     `db:"width"`
+	Height    int     `db:"height"`
+	Oversized bool    `db:"oversized"`
 }
 
 func (f *imageFileRow) fromImageFile(ff file.ImageFile) {
@@ -90,6 +101,7 @@ func (f *imageFileRow) fromImageFile(ff file.ImageFile) {
 	f.Format = ff.Format
 	f.Width = ff.Width
 	f.Height = ff.Height
+	f.Oversized = ff.Oversized
 }
 
 // we redefine this to change the columns around
@@ -106,6 +118,12 @@ type videoFileQueryRow struct {
 	BitRate          null.Int    `db:"bit_rate"`
 	Interactive      null.Bool   `db:"interactive"`
 	InteractiveSpeed null.Int    `db:"interactive_speed"`
+
+	InteractiveAverageSpeed null.Int `db:"interactive_average_speed"`
+	InteractiveRange        null.Int `db:"interactive_range"`
+	InteractiveDensity      null.Int `db:"interactive_density"`
+
+	Loudness null.Float `db:"loudness"`
 }
 
 func (f *videoFileQueryRow) resolve() *file.VideoFile {
@@ -120,6 +138,12 @@ func (f *videoFileQueryRow) resolve() *file.VideoFile {
 		BitRate:          f.BitRate.Int64,
 		Interactive:      f.Interactive.Bool,
 		InteractiveSpeed: nullIntPtr(f.InteractiveSpeed),
+
+		InteractiveAverageSpeed: nullIntPtr(f.InteractiveAverageSpeed),
+		InteractiveRange:        nullIntPtr(f.InteractiveRange),
+		InteractiveDensity:      nullIntPtr(f.InteractiveDensity),
+
+		Loudness: f.Loudness.Ptr(),
 	}
 }
 
@@ -137,15 +161,20 @@ func videoFileQueryColumns() []interface{} {
 		table.Col("bit_rate"),
 		table.Col("interactive"),
 		table.Col("interactive_speed"),
+		table.Col("interactive_average_speed"),
+		table.Col("interactive_range"),
+		table.Col("interactive_density"),
+		table.Col("loudness"),
 	}
 }
 
 // we redefine this to change the columns around
 // otherwise, we collide with the video file columns
 type imageFileQueryRow struct {
-	Format null.String `db:"image_format"`
-	Width  null.Int    `db:"image_width"`
-	Height null.Int    `db:"image_height"`
+	Format    null.String `db:"image_format"`
+	Width     null.Int    `db:"image_width"`
+	Height    null.Int    `db:"image_height"`
+	Oversized null.Bool   `db:"image_oversized"`
 }
 
 func (imageFileQueryRow) columns(table *table) []interface{} {
@@ -154,14 +183,16 @@ func (imageFileQueryRow) columns(table *table) []interface{} {
 		ex.Col("format").As("image_format"),
 		ex.Col("width").As("image_width"),
 		ex.Col("height").As("image_height"),
+		ex.Col("oversized").As("image_oversized"),
 	}
 }
 
 func (f *imageFileQueryRow) resolve() *file.ImageFile {
 	return &file.ImageFile{
-		Format: f.Format.String,
-		Width:  int(f.Width.Int64),
-		Height: int(f.Height.Int64),
+		Format:    f.Format.String,
+		Width:     int(f.Width.Int64),
+		Height:    int(f.Height.Int64),
+		Oversized: f.Oversized.Bool,
 	}
 }
 
@@ -686,6 +717,31 @@ func (qb *FileStore) FindByFingerprint(ctx context.Context, fp file.Fingerprint)
 	return qb.findBySubquery(ctx, sq)
 }
 
+// FindUnlinkedVideoFiles returns the video files which are not linked to
+// any scene.
+func (qb *FileStore) FindUnlinkedVideoFiles(ctx context.Context) ([]*file.VideoFile, error) {
+	videoFileTable := videoFileTableMgr.table
+
+	sq := dialect.From(videoFileTable).LeftJoin(
+		scenesFilesJoinTable,
+		goqu.On(scenesFilesJoinTable.Col(fileIDColumn).Eq(videoFileTable.Col(fileIDColumn))),
+	).Select(videoFileTable.Col(fileIDColumn)).Where(
+		scenesFilesJoinTable.Col(fileIDColumn).IsNull(),
+	)
+
+	files, err := qb.findBySubquery(ctx, sq)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*file.VideoFile, len(files))
+	for i, f := range files {
+		ret[i] = f.(*file.VideoFile)
+	}
+
+	return ret, nil
+}
+
 func (qb *FileStore) FindByZipFileID(ctx context.Context, zipFileID file.ID) ([]file.File, error) {
 	table := qb.table()
 
@@ -356,6 +356,36 @@ func Test_fileStore_Update(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"oversized image file",
+			&file.ImageFile{
+				BaseFile: &file.BaseFile{
+					ID: fileIDs[fileIdxStartImageFiles],
+					DirEntry: file.DirEntry{
+						ZipFileID: &fileIDs[fileIdxZip],
+						ZipFile:   makeZipFileWithID(fileIdxZip),
+						ModTime:   fileModTime,
+					},
+					Path:           getFilePath(folderIdxWithFiles, basename),
+					ParentFolderID: folderIDs[folderIdxWithFiles],
+					Basename:       basename,
+					Size:           size,
+					Fingerprints: []file.Fingerprint{
+						{
+							Type:        fingerprintType,
+							Fingerprint: fingerprintValue,
+						},
+					},
+					CreatedAt: createdAt,
+					UpdatedAt: updatedAt,
+				},
+				Format:    format,
+				Width:     width,
+				Height:    height,
+				Oversized: true,
+			},
+			false,
+		},
 		{
 			"duplicate path",
 			&file.BaseFile{
@@ -662,3 +692,37 @@ func TestFileStore_IsPrimary(t *testing.T) {
 		})
 	}
 }
+
+func TestFileStore_FindUnlinkedVideoFiles(t *testing.T) {
+	runWithRollbackTxn(t, "unlinked", func(t *testing.T, ctx context.Context) {
+		assert := assert.New(t)
+		qb := db.File
+
+		const basename = "unlinked.mp4"
+		f := &file.VideoFile{
+			BaseFile: &file.BaseFile{
+				Path:           getFilePath(folderIdxWithFiles, basename),
+				ParentFolderID: folderIDs[folderIdxWithFiles],
+				Basename:       basename,
+			},
+		}
+		if err := qb.Create(ctx, f); err != nil {
+			t.Errorf("FileStore.Create() error = %v", err)
+			return
+		}
+
+		got, err := qb.FindUnlinkedVideoFiles(ctx)
+		if err != nil {
+			t.Errorf("FileStore.FindUnlinkedVideoFiles() error = %v", err)
+			return
+		}
+
+		var gotIDs []file.ID
+		for _, ff := range got {
+			gotIDs = append(gotIDs, ff.ID)
+		}
+
+		assert.Contains(gotIDs, f.ID)
+		assert.NotContains(gotIDs, sceneFileIDs[sceneIdx1WithPerformer])
+	})
+}
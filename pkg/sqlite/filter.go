@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/utils"
@@ -525,6 +526,18 @@ func intCriterionHandler(c *models.IntCriterionInput, column string, addJoinFn f
 	}
 }
 
+func floatCriterionHandler(c *models.FloatCriterionInput, column string, addJoinFn func(f *filterBuilder)) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if c != nil {
+			if addJoinFn != nil {
+				addJoinFn(f)
+			}
+			clause, args := getFloatCriterionWhereClause(column, *c)
+			f.addWhere(clause, args...)
+		}
+	}
+}
+
 func boolCriterionHandler(c *bool, column string, addJoinFn func(f *filterBuilder)) criterionHandlerFunc {
 	return func(ctx context.Context, f *filterBuilder) {
 		if c != nil {
@@ -574,12 +587,56 @@ func dateCriterionHandler(c *models.DateCriterionInput, column string) criterion
 func timestampCriterionHandler(c *models.TimestampCriterionInput, column string) criterionHandlerFunc {
 	return func(ctx context.Context, f *filterBuilder) {
 		if c != nil {
+			if err := validateTimestampCriterion(*c); err != nil {
+				f.setError(err)
+				return
+			}
+
 			clause, args := getTimestampCriterionWhereClause(column, *c)
 			f.addWhere(clause, args...)
 		}
 	}
 }
 
+// timestampCriterionLayouts are the accepted formats of timestamp criterion
+// values. These are all understood by the sqlite datetime function.
+var timestampCriterionLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+func validateTimestampCriterion(c models.TimestampCriterionInput) error {
+	validateValue := func(v string) error {
+		for _, layout := range timestampCriterionLayouts {
+			if _, err := time.Parse(layout, v); err == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid timestamp value %q", v)
+	}
+
+	switch c.Modifier {
+	case models.CriterionModifierIsNull, models.CriterionModifierNotNull:
+		return nil
+	case models.CriterionModifierEquals, models.CriterionModifierNotEquals, models.CriterionModifierLessThan, models.CriterionModifierGreaterThan:
+		return validateValue(c.Value)
+	case models.CriterionModifierBetween, models.CriterionModifierNotBetween:
+		if err := validateValue(c.Value); err != nil {
+			return err
+		}
+		if c.Value2 != nil {
+			return validateValue(*c.Value2)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unsupported timestamp modifier %s", c.Modifier)
+}
+
 // handle for MultiCriterion where there is a join table between the new
 // objects
 type joinedMultiCriterionHandlerBuilder struct {
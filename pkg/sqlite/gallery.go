@@ -14,6 +14,7 @@ import (
 	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/sliceutil/intslice"
+	"github.com/stashapp/stash/pkg/utils"
 	"gopkg.in/guregu/null.v4"
 	"gopkg.in/guregu/null.v4/zero"
 )
@@ -29,6 +30,14 @@ const (
 	galleryIDColumn          = "gallery_id"
 )
 
+var findAllGalleryImageFingerprintsQuery = `
+SELECT galleries_images.gallery_id as id, files_fingerprints.fingerprint as fingerprint
+FROM galleries_images
+INNER JOIN images_files ON (galleries_images.image_id = images_files.image_id AND images_files."primary" = 1)
+INNER JOIN files_fingerprints ON (images_files.file_id = files_fingerprints.file_id AND files_fingerprints.type = 'md5')
+ORDER BY galleries_images.gallery_id
+`
+
 type galleryRow struct {
 	ID      int               `db:"id" goqu:"skipinsert"`
 	Title   zero.String       `db:"title"`
@@ -519,6 +528,26 @@ func (qb *GalleryStore) FindByFolderID(ctx context.Context, folderID file.Folder
 	return ret, nil
 }
 
+// FindByParentFolderID returns the galleries with a file directly in the
+// folder. Folder-based galleries are found using FindByFolderID.
+func (qb *GalleryStore) FindByParentFolderID(ctx context.Context, folderID file.FolderID) ([]*models.Gallery, error) {
+	filesTable := fileTableMgr.table
+
+	sq := dialect.From(galleriesFilesJoinTable).InnerJoin(
+		filesTable,
+		goqu.On(filesTable.Col(idColumn).Eq(galleriesFilesJoinTable.Col(fileIDColumn))),
+	).Select(galleriesFilesJoinTable.Col(galleryIDColumn)).Where(
+		filesTable.Col("parent_folder_id").Eq(folderID),
+	)
+
+	ret, err := qb.findBySubquery(ctx, sq)
+	if err != nil {
+		return nil, fmt.Errorf("getting galleries for parent folder %d: %w", folderID, err)
+	}
+
+	return ret, nil
+}
+
 func (qb *GalleryStore) FindBySceneID(ctx context.Context, sceneID int) ([]*models.Gallery, error) {
 	sq := dialect.From(galleriesScenesJoinTable).Select(galleriesScenesJoinTable.Col(galleryIDColumn)).Where(
 		galleriesScenesJoinTable.Col(sceneIDColumn).Eq(sceneID),
@@ -545,6 +574,49 @@ func (qb *GalleryStore) FindByImageID(ctx context.Context, imageID int) ([]*mode
 	return ret, nil
 }
 
+// FindDuplicates returns groups of galleries which share at least the
+// provided overlap of the MD5 fingerprints of their images. Overlap must be
+// between 0 and 1.
+func (qb *GalleryStore) FindDuplicates(ctx context.Context, overlap float64) ([]*models.GalleryDuplicateGroup, error) {
+	var fingerprints []utils.GalleryFingerprints
+
+	if err := qb.queryFunc(ctx, findAllGalleryImageFingerprintsQuery, nil, false, func(rows *sqlx.Rows) error {
+		var row struct {
+			ID          int    `db:"id"`
+			Fingerprint string `db:"fingerprint"`
+		}
+		if err := rows.StructScan(&row); err != nil {
+			return err
+		}
+
+		// rows are ordered by gallery id
+		n := len(fingerprints)
+		if n == 0 || fingerprints[n-1].GalleryID != row.ID {
+			fingerprints = append(fingerprints, utils.GalleryFingerprints{GalleryID: row.ID})
+			n++
+		}
+		fingerprints[n-1].Fingerprints = append(fingerprints[n-1].Fingerprints, row.Fingerprint)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting gallery image fingerprints: %w", err)
+	}
+
+	var ret []*models.GalleryDuplicateGroup
+	for _, group := range utils.FindGalleryDuplicates(fingerprints, overlap) {
+		galleries, err := qb.FindMany(ctx, group.GalleryIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, &models.GalleryDuplicateGroup{
+			Galleries: galleries,
+			Overlap:   group.Overlap,
+		})
+	}
+
+	return ret, nil
+}
+
 func (qb *GalleryStore) CountByImageID(ctx context.Context, imageID int) (int, error) {
 	joinTable := galleriesImagesJoinTable
 
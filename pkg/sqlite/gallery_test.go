@@ -12,6 +12,7 @@ import (
 
 	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil/intslice"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -1149,6 +1150,29 @@ func Test_galleryQueryBuilder_FindByPath(t *testing.T) {
 	}
 }
 
+func Test_galleryQueryBuilder_FindByParentFolderID(t *testing.T) {
+	withTxn(func(ctx context.Context) error {
+		qb := db.Gallery
+
+		got, err := qb.FindByParentFolderID(ctx, folderIDs[folderIdxWithGalleryFiles])
+		if err != nil {
+			t.Errorf("galleryQueryBuilder.FindByParentFolderID() error = %v", err)
+			return nil
+		}
+		// one gallery has no file
+		assert.Len(t, got, totalGalleries-1)
+
+		got, err = qb.FindByParentFolderID(ctx, folderIDs[folderIdxWithSceneFiles])
+		if err != nil {
+			t.Errorf("galleryQueryBuilder.FindByParentFolderID() error = %v", err)
+			return nil
+		}
+		assert.Len(t, got, 0)
+
+		return nil
+	})
+}
+
 func Test_galleryQueryBuilder_FindBySceneID(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -2458,6 +2482,41 @@ func TestGalleryQuerySorting(t *testing.T) {
 	}
 }
 
+func TestGalleryStore_FindDuplicates(t *testing.T) {
+	withTxn(func(ctx context.Context) error {
+		got, err := db.Gallery.FindDuplicates(ctx, 1)
+		if err != nil {
+			t.Errorf("GalleryStore.FindDuplicates() error = %v", err)
+			return nil
+		}
+
+		// the galleries sharing the same single image are duplicates
+		want := []int{galleryIDs[galleryIdx1WithImage], galleryIDs[galleryIdx2WithImage]}
+
+		var found bool
+		for _, group := range got {
+			var ids []int
+			for _, g := range group.Galleries {
+				ids = append(ids, g.ID)
+			}
+
+			assert.NotContains(t, ids, galleryIDs[galleryIdxWithTwoImages])
+
+			if intslice.IntInclude(ids, want[0]) {
+				found = true
+				assert.ElementsMatch(t, want, ids)
+				assert.Equal(t, 1.0, group.Overlap)
+			}
+		}
+
+		if !found {
+			t.Errorf("GalleryStore.FindDuplicates() did not return group for galleries %v", want)
+		}
+
+		return nil
+	})
+}
+
 func TestGalleryStore_AddImages(t *testing.T) {
 	tests := []struct {
 		name      string
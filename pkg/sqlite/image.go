@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"path/filepath"
+	"sync/atomic"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/stashapp/stash/pkg/file"
@@ -111,6 +112,12 @@ type ImageStore struct {
 	oCounterManager
 
 	fileStore *FileStore
+
+	// lexicalPathSort is true if images are sorted by path lexically
+	// rather than in natural order.
+	lexicalPathSort atomic.Bool
+
+	resolutionLabelBreakpoints
 }
 
 func NewImageStore(fileStore *FileStore) *ImageStore {
@@ -125,6 +132,31 @@ func NewImageStore(fileStore *FileStore) *ImageStore {
 	}
 }
 
+// SetNaturalPathSort sets whether images are sorted by path in natural
+// order, so that numbers in paths are sorted numerically. This applies to
+// the path sort and to the images of a gallery. Defaults to true.
+func (qb *ImageStore) SetNaturalPathSort(natural bool) {
+	qb.lexicalPathSort.Store(!natural)
+}
+
+// pathCollation returns the collation clause used when sorting by path.
+func (qb *ImageStore) pathCollation() string {
+	if qb.lexicalPathSort.Load() {
+		return ""
+	}
+
+	return " COLLATE NATURAL_CS"
+}
+
+// pathOrder returns the ascending order of the path column col.
+func (qb *ImageStore) pathOrder(col exp.IdentifierExpression) exp.OrderedExpression {
+	if qb.lexicalPathSort.Load() {
+		return col.Asc()
+	}
+
+	return goqu.L("? COLLATE NATURAL_CS", col).Asc()
+}
+
 func (qb *ImageStore) table() exp.IdentifierExpression {
 	return qb.tableMgr.table
 }
@@ -490,7 +522,7 @@ func (qb *ImageStore) FindByGalleryID(ctx context.Context, galleryID int) ([]*mo
 		table.Col(idColumn).Eq(
 			sq,
 		),
-	).Order(folderTable.Col("path").Asc(), fileTable.Col("basename").Asc())
+	).Order(qb.pathOrder(folderTable.Col("path")), qb.pathOrder(fileTable.Col("basename")))
 
 	ret, err := qb.getMany(ctx, q)
 	if err != nil {
@@ -652,6 +684,8 @@ func (qb *ImageStore) makeFilter(ctx context.Context, imageFilter *models.ImageF
 	query.handleCriterion(ctx, stringCriterionHandler(imageFilter.URL, "images.url"))
 
 	query.handleCriterion(ctx, resolutionCriterionHandler(imageFilter.Resolution, "image_files.height", "image_files.width", qb.addImageFilesTable))
+	query.handleCriterion(ctx, resolutionLabelCriterionHandler(imageFilter.ResolutionLabel, qb.getResolutionLabelBreakpoints(), "image_files.height", "image_files.width", qb.addImageFilesTable))
+	query.handleCriterion(ctx, orientationCriterionHandler(imageFilter.Orientation, "image_files.height", "image_files.width", qb.addImageFilesTable))
 	query.handleCriterion(ctx, imageIsMissingCriterionHandler(qb, imageFilter.IsMissing))
 
 	query.handleCriterion(ctx, imageTagsCriterionHandler(qb, imageFilter.Tags))
@@ -825,9 +859,8 @@ func imageIsMissingCriterionHandler(qb *ImageStore, isMissing *string) criterion
 			case "performers":
 				qb.performersRepository().join(f, "performers_join", "images.id")
 				f.addWhere("performers_join.image_id IS NULL")
-			case "galleries":
-				qb.galleriesRepository().join(f, "galleries_join", "images.id")
-				f.addWhere("galleries_join.image_id IS NULL")
+			case "galleries", "gallery":
+				f.addWhere("NOT EXISTS (SELECT 1 FROM " + galleriesImagesTable + " WHERE " + galleriesImagesTable + ".image_id = images.id)")
 			case "tags":
 				qb.tagsRepository().join(f, "tags_join", "images.id")
 				f.addWhere("tags_join.image_id IS NULL")
@@ -1018,7 +1051,7 @@ func (qb *ImageStore) setImageSortAndPagination(q *queryBuilder, findFilter *mod
 		case "path":
 			addFilesJoin()
 			addFolderJoin()
-			sortClause = " ORDER BY folders.path " + direction + ", files.basename " + direction
+			sortClause = " ORDER BY folders.path" + qb.pathCollation() + " " + direction + ", files.basename" + qb.pathCollation() + " " + direction
 		case "file_count":
 			sortClause = getCountSort(imageTable, imagesFilesTable, imageIDColumn, direction)
 		case "tag_count":
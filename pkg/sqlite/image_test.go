@@ -5,12 +5,14 @@ package sqlite_test
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"strconv"
 	"testing"
 	"time"
 
 	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stretchr/testify/assert"
 )
@@ -1876,6 +1878,145 @@ func verifyImagesResolution(t *testing.T, resolution models.ResolutionEnum) {
 	})
 }
 
+func TestImageQueryOrientation(t *testing.T) {
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Image
+
+		createImage := func(width, height int) *models.Image {
+			f := &file.ImageFile{
+				BaseFile: &file.BaseFile{
+					Basename:       fmt.Sprintf("TestImageQueryOrientation %d %d", width, height),
+					ParentFolderID: folderIDs[folderIdxWithImageFiles],
+				},
+				Width:  width,
+				Height: height,
+			}
+			if err := db.File.Create(ctx, f); err != nil {
+				t.Errorf("Error creating image file: %v", err)
+				return nil
+			}
+
+			image := &models.Image{}
+			if err := qb.Create(ctx, &models.ImageCreateInput{
+				Image:   image,
+				FileIDs: []file.ID{f.ID},
+			}); err != nil {
+				t.Errorf("Error creating image: %v", err)
+			}
+
+			return image
+		}
+
+		noResolution := createImage(0, 0)
+		portrait := createImage(600, 800)
+		landscape := createImage(800, 600)
+		square := createImage(800, 790)
+
+		query := func(value models.OrientationEnum) []*models.Image {
+			images, _, err := queryImagesWithCount(ctx, qb, &models.ImageFilterType{
+				Orientation: &models.OrientationCriterionInput{
+					Value: []models.OrientationEnum{value},
+				},
+			}, nil)
+			if err != nil {
+				t.Errorf("Error querying images: %v", err)
+			}
+			return images
+		}
+
+		// each of the excluded images must not be returned
+		assertExcluded := func(images []*models.Image, excluded ...*models.Image) {
+			for _, e := range excluded {
+				assert.NotContains(t, images, e)
+			}
+		}
+
+		images := query(models.OrientationEnumPortrait)
+		assert.Contains(t, images, portrait)
+		assertExcluded(images, noResolution, landscape, square)
+
+		images = query(models.OrientationEnumLandscape)
+		assert.Contains(t, images, landscape)
+		assertExcluded(images, noResolution, portrait, square)
+
+		images = query(models.OrientationEnumSquare)
+		assert.Contains(t, images, square)
+		assertExcluded(images, noResolution, portrait, landscape)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestImageFindGalleryCover(t *testing.T) {
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Image
+
+		gallery := &models.Gallery{}
+		if err := db.Gallery.Create(ctx, gallery, nil); err != nil {
+			return fmt.Errorf("creating gallery: %w", err)
+		}
+
+		createImage := func(basename string, width, height int) *models.Image {
+			f := &file.ImageFile{
+				BaseFile: &file.BaseFile{
+					Basename:       basename,
+					ParentFolderID: folderIDs[folderIdxWithImageFiles],
+				},
+				Width:  width,
+				Height: height,
+			}
+			if err := db.File.Create(ctx, f); err != nil {
+				t.Errorf("Error creating image file: %v", err)
+				return nil
+			}
+
+			img := &models.Image{
+				GalleryIDs: models.NewRelatedIDs([]int{gallery.ID}),
+			}
+			if err := qb.Create(ctx, &models.ImageCreateInput{
+				Image:   img,
+				FileIDs: []file.ID{f.ID},
+			}); err != nil {
+				t.Errorf("Error creating image: %v", err)
+			}
+
+			return img
+		}
+
+		findCover := func(minResolution *models.ResolutionEnum) *models.Image {
+			cover, err := image.FindGalleryCover(ctx, qb, gallery.ID, minResolution)
+			if err != nil {
+				t.Errorf("Error finding gallery cover: %v", err)
+			}
+			return cover
+		}
+
+		small := createImage("TestImageFindGalleryCover a.jpg", 100, 80)
+		large := createImage("TestImageFindGalleryCover b.jpg", 1000, 800)
+
+		standard := models.ResolutionEnumStandard
+		fourK := models.ResolutionEnumFourK
+		webHD := models.ResolutionEnumWebHd
+
+		assert.Equal(t, small.ID, findCover(nil).ID)
+		assert.Equal(t, large.ID, findCover(&standard).ID)
+		// the large image is in the WEB_HD range
+		assert.Equal(t, large.ID, findCover(&webHD).ID)
+		// fall back to the first image if none qualify
+		assert.Equal(t, small.ID, findCover(&fourK).ID)
+
+		// cover.jpg is always used
+		cover := createImage("TestImageFindGalleryCover cover.jpg", 50, 50)
+		assert.Equal(t, cover.ID, findCover(&standard).ID)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 func verifyImageResolution(t *testing.T, height int, resolution models.ResolutionEnum) {
 	if !resolution.IsValid() {
 		return
@@ -1898,9 +2039,16 @@ func verifyImageResolution(t *testing.T, height int, resolution models.Resolutio
 }
 
 func TestImageQueryIsMissingGalleries(t *testing.T) {
+	for _, isMissing := range []string{"galleries", "gallery"} {
+		t.Run(isMissing, func(t *testing.T) {
+			testImageQueryIsMissingGalleries(t, isMissing)
+		})
+	}
+}
+
+func testImageQueryIsMissingGalleries(t *testing.T, isMissing string) {
 	withTxn(func(ctx context.Context) error {
 		sqb := db.Image
-		isMissing := "galleries"
 		imageFilter := models.ImageFilterType{
 			IsMissing: &isMissing,
 		}
@@ -2633,6 +2781,67 @@ func TestImageQuerySorting(t *testing.T) {
 	}
 }
 
+func TestImageStore_FindByGalleryID_naturalSort(t *testing.T) {
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Image
+		defer qb.SetNaturalPathSort(true)
+
+		g := &models.Gallery{}
+		if err := db.Gallery.Create(ctx, g, nil); err != nil {
+			return fmt.Errorf("creating gallery: %w", err)
+		}
+
+		var want []int
+		for _, basename := range []string{"img2.jpg", "img10.jpg", "img1.jpg"} {
+			f := &file.ImageFile{
+				BaseFile: &file.BaseFile{
+					Basename:       "TestImageStore_FindByGalleryID_naturalSort " + basename,
+					ParentFolderID: folderIDs[folderIdxWithImageFiles],
+				},
+			}
+			if err := db.File.Create(ctx, f); err != nil {
+				return fmt.Errorf("creating image file: %w", err)
+			}
+
+			image := &models.Image{
+				GalleryIDs: models.NewRelatedIDs([]int{g.ID}),
+			}
+			if err := qb.Create(ctx, &models.ImageCreateInput{
+				Image:   image,
+				FileIDs: []file.ID{f.ID},
+			}); err != nil {
+				return fmt.Errorf("creating image: %w", err)
+			}
+
+			want = append(want, image.ID)
+		}
+
+		ids := func() []int {
+			images, err := qb.FindByGalleryID(ctx, g.ID)
+			if err != nil {
+				t.Errorf("ImageStore.FindByGalleryID() error = %v", err)
+			}
+
+			var ret []int
+			for _, i := range images {
+				ret = append(ret, i.ID)
+			}
+			return ret
+		}
+
+		// img1, img2, img10
+		assert.Equal(t, []int{want[2], want[0], want[1]}, ids())
+
+		// img1, img10, img2
+		qb.SetNaturalPathSort(false)
+		assert.Equal(t, []int{want[2], want[1], want[0]}, ids())
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 func TestImageQueryPagination(t *testing.T) {
 	withTxn(func(ctx context.Context) error {
 		perPage := 1
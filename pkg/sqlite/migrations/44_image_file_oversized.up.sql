@@ -0,0 +1 @@
+ALTER TABLE `image_files` ADD COLUMN `oversized` boolean not null default '0';
@@ -0,0 +1,11 @@
+ALTER TABLE `scenes` ADD COLUMN `stub` boolean not null default '0';
+
+CREATE TABLE `scene_stub_fingerprints` (
+  `scene_id` integer NOT NULL,
+  `type` varchar(255) NOT NULL,
+  `fingerprint` blob NOT NULL,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  PRIMARY KEY (`scene_id`, `type`, `fingerprint`)
+);
+
+CREATE INDEX `index_scene_stub_fingerprints_on_fingerprint` ON `scene_stub_fingerprints` (`type`, `fingerprint`);
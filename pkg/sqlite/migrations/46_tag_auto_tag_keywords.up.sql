@@ -0,0 +1,8 @@
+CREATE TABLE `tag_auto_tag_keywords` (
+  `tag_id` integer NOT NULL,
+  `keyword` varchar(255) NOT NULL,
+  foreign key(`tag_id`) references `tags`(`id`) on delete CASCADE,
+  PRIMARY KEY(`tag_id`, `keyword`)
+);
+
+CREATE INDEX `tag_auto_tag_keywords_keyword` on `tag_auto_tag_keywords` (`keyword`);
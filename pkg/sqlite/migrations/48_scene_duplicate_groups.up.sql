@@ -0,0 +1,8 @@
+CREATE TABLE `scene_duplicate_groups` (
+  `group_id` integer NOT NULL,
+  `scene_id` integer NOT NULL,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  PRIMARY KEY(`group_id`, `scene_id`)
+);
+
+CREATE INDEX `scene_duplicate_groups_scene_id` on `scene_duplicate_groups` (`scene_id`);
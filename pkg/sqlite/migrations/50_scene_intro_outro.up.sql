@@ -0,0 +1,2 @@
+ALTER TABLE `scenes` ADD COLUMN `intro_end` real;
+ALTER TABLE `scenes` ADD COLUMN `outro_start` real;
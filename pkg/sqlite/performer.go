@@ -446,6 +446,48 @@ func (qb *PerformerStore) All(ctx context.Context) ([]*models.Performer, error)
 	return qb.getMany(ctx, qb.selectDataset().Order(table.Col("name").Asc()))
 }
 
+func (qb *PerformerStore) TagProfile(ctx context.Context, performerID int, limit int) ([]*models.TagCount, error) {
+	scenesTags := scenesTagsJoinTable
+	performersScenes := scenesPerformersJoinTable
+	countCol := goqu.COUNT(goqu.DISTINCT(scenesTags.Col(sceneIDColumn)))
+
+	q := dialect.From(performersScenes).Select(
+		scenesTags.Col(tagIDColumn).As("tag_id"),
+		countCol.As("count"),
+	).InnerJoin(scenesTags, goqu.On(
+		scenesTags.Col(sceneIDColumn).Eq(performersScenes.Col(sceneIDColumn)),
+	)).InnerJoin(tagTableMgr.table, goqu.On(
+		tagTableMgr.table.Col(idColumn).Eq(scenesTags.Col(tagIDColumn)),
+	)).Where(
+		performersScenes.Col(performerIDColumn).Eq(performerID),
+	).GroupBy(
+		scenesTags.Col(tagIDColumn),
+	).Order(
+		countCol.Desc(),
+		tagTableMgr.table.Col("name").Asc(),
+	)
+
+	if limit > 0 {
+		q = q.Limit(uint(limit))
+	}
+
+	const single = false
+	var ret []*models.TagCount
+	if err := queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+		var v models.TagCount
+		if err := rows.StructScan(&v); err != nil {
+			return err
+		}
+
+		ret = append(ret, &v)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting tag profile of performer %d: %w", performerID, err)
+	}
+
+	return ret, nil
+}
+
 func (qb *PerformerStore) QueryForAutoTag(ctx context.Context, words []string) ([]*models.Performer, error) {
 	// TODO - Query needs to be changed to support queries of this type, and
 	// this method should be removed
@@ -611,12 +653,52 @@ func (qb *PerformerStore) makeFilter(ctx context.Context, filter *models.Perform
 	query.handleCriterion(ctx, performerGalleryCountCriterionHandler(qb, filter.GalleryCount))
 	query.handleCriterion(ctx, dateCriterionHandler(filter.Birthdate, tableName+".birthdate"))
 	query.handleCriterion(ctx, dateCriterionHandler(filter.DeathDate, tableName+".death_date"))
+	query.handleCriterion(ctx, performerActiveCriterionHandler(filter.Active))
+	query.handleCriterion(ctx, performerCoverageGapCriterionHandler(filter.CoverageGap))
 	query.handleCriterion(ctx, timestampCriterionHandler(filter.CreatedAt, tableName+".created_at"))
 	query.handleCriterion(ctx, timestampCriterionHandler(filter.UpdatedAt, tableName+".updated_at"))
 
 	return query
 }
 
+// performerActiveCriterionHandler filters on whether the performer is active.
+// Performers with no death date are considered active.
+func performerActiveCriterionHandler(active *bool) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if active == nil {
+			return
+		}
+
+		if *active {
+			f.addWhere(performerTable + ".death_date IS NULL")
+		} else {
+			f.addWhere(performerTable + ".death_date IS NOT NULL")
+		}
+	}
+}
+
+// performerCoverageGapCriterionHandler filters on performers that appear in
+// galleries but not scenes, or in scenes but not galleries.
+func performerCoverageGapCriterionHandler(gap *models.PerformerCoverageGapEnum) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if gap == nil {
+			return
+		}
+
+		scenesExist := fmt.Sprintf("EXISTS (SELECT 1 FROM %s WHERE %s.performer_id = %s.id)", performersScenesTable, performersScenesTable, performerTable)
+		galleriesExist := fmt.Sprintf("EXISTS (SELECT 1 FROM %s WHERE %s.performer_id = %s.id)", performersGalleriesTable, performersGalleriesTable, performerTable)
+
+		switch *gap {
+		case models.PerformerCoverageGapEnumGalleriesWithoutScenes:
+			f.addWhere(galleriesExist + " AND NOT " + scenesExist)
+		case models.PerformerCoverageGapEnumScenesWithoutGalleries:
+			f.addWhere(scenesExist + " AND NOT " + galleriesExist)
+		default:
+			f.setError(fmt.Errorf("invalid coverage gap %q", gap.String()))
+		}
+	}
+}
+
 func (qb *PerformerStore) Query(ctx context.Context, performerFilter *models.PerformerFilterType, findFilter *models.FindFilterType) ([]*models.Performer, int, error) {
 	if performerFilter == nil {
 		performerFilter = &models.PerformerFilterType{}
@@ -899,6 +981,10 @@ func (qb *PerformerStore) GetImage(ctx context.Context, performerID int) ([]byte
 	return qb.imageRepository().get(ctx, performerID)
 }
 
+func (qb *PerformerStore) HasImage(ctx context.Context, performerID int) (bool, error) {
+	return qb.imageRepository().exists(ctx, performerID)
+}
+
 func (qb *PerformerStore) UpdateImage(ctx context.Context, performerID int, image []byte) error {
 	return qb.imageRepository().replace(ctx, performerID, image)
 }
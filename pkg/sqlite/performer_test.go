@@ -583,6 +583,57 @@ func TestPerformerFindBySceneID(t *testing.T) {
 	})
 }
 
+func TestPerformerTagProfile(t *testing.T) {
+	runWithRollbackTxn(t, "TagProfile", func(t *testing.T, ctx context.Context) {
+		addTags := func(sceneIdx int, tagIdxs ...int) {
+			var ids []int
+			for _, idx := range tagIdxs {
+				ids = append(ids, tagIDs[idx])
+			}
+
+			if _, err := db.Scene.UpdatePartial(ctx, sceneIDs[sceneIdx], models.ScenePartial{
+				TagIDs: &models.UpdateIDs{
+					IDs:  ids,
+					Mode: models.RelationshipUpdateModeAdd,
+				},
+			}); err != nil {
+				t.Fatalf("adding scene tags: %v", err)
+			}
+		}
+
+		addTags(sceneIdx1WithPerformer, tagIdxWithScene, tagIdx1WithScene)
+		addTags(sceneIdx2WithPerformer, tagIdxWithScene)
+
+		performerID := performerIDs[performerIdxWithTwoScenes]
+
+		got, err := db.Performer.TagProfile(ctx, performerID, 0)
+		if err != nil {
+			t.Fatalf("PerformerStore.TagProfile() error = %v", err)
+		}
+
+		assert.Equal(t, []*models.TagCount{
+			{TagID: tagIDs[tagIdxWithScene], Count: 2},
+			{TagID: tagIDs[tagIdx1WithScene], Count: 1},
+		}, got)
+
+		got, err = db.Performer.TagProfile(ctx, performerID, 1)
+		if err != nil {
+			t.Fatalf("PerformerStore.TagProfile() error = %v", err)
+		}
+
+		assert.Equal(t, []*models.TagCount{
+			{TagID: tagIDs[tagIdxWithScene], Count: 2},
+		}, got)
+
+		got, err = db.Performer.TagProfile(ctx, performerIDs[performerIdxWithScene], 0)
+		if err != nil {
+			t.Fatalf("PerformerStore.TagProfile() error = %v", err)
+		}
+
+		assert.Len(t, got, 0)
+	})
+}
+
 func TestPerformerFindByImageID(t *testing.T) {
 	withTxn(func(ctx context.Context) error {
 		pqb := db.Performer
@@ -888,10 +939,39 @@ func TestPerformerQueryIgnoreAutoTag(t *testing.T) {
 	})
 }
 
+func TestPerformerQueryActive(t *testing.T) {
+	withTxn(func(ctx context.Context) error {
+		active := false
+		performerFilter := models.PerformerFilterType{
+			Active: &active,
+		}
+
+		performers := queryPerformers(ctx, t, &performerFilter, nil)
+
+		assert.Len(t, performers, 1)
+		for _, p := range performers {
+			assert.NotNil(t, p.DeathDate)
+		}
+
+		active = true
+		performers = queryPerformers(ctx, t, &performerFilter, nil)
+
+		assert.Len(t, performers, totalPerformers-1)
+		for _, p := range performers {
+			assert.Nil(t, p.DeathDate)
+		}
+
+		return nil
+	})
+}
+
 func TestPerformerQuery(t *testing.T) {
 	var (
 		endpoint = performerStashID(performerIdxWithGallery).Endpoint
 		stashID  = performerStashID(performerIdxWithGallery).StashID
+
+		galleriesWithoutScenes = models.PerformerCoverageGapEnumGalleriesWithoutScenes
+		scenesWithoutGalleries = models.PerformerCoverageGapEnumScenesWithoutGalleries
 	)
 
 	tests := []struct {
@@ -969,6 +1049,26 @@ func TestPerformerQuery(t *testing.T) {
 			[]int{performerIdxWithScene},
 			false,
 		},
+		{
+			"galleries without scenes",
+			nil,
+			&models.PerformerFilterType{
+				CoverageGap: &galleriesWithoutScenes,
+			},
+			[]int{performerIdxWithGallery, performerIdxWithTwoGalleries},
+			[]int{performerIdxWithScene, performerIdxWithTag, performerIdxWithImage},
+			false,
+		},
+		{
+			"scenes without galleries",
+			nil,
+			&models.PerformerFilterType{
+				CoverageGap: &scenesWithoutGalleries,
+			},
+			[]int{performerIdxWithScene, performerIdxWithTwoScenes},
+			[]int{performerIdxWithGallery, performerIdxWithTag, performerIdxWithImage},
+			false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1042,6 +1142,12 @@ func TestPerformerUpdatePerformerImage(t *testing.T) {
 		}
 		assert.Equal(t, storedImage, image)
 
+		hasImage, err := qb.HasImage(ctx, performer.ID)
+		if err != nil {
+			return fmt.Errorf("Error checking image: %s", err.Error())
+		}
+		assert.True(t, hasImage)
+
 		// set nil image
 		err = qb.UpdateImage(ctx, performer.ID, nil)
 		if err == nil {
@@ -1086,6 +1192,12 @@ func TestPerformerDestroyPerformerImage(t *testing.T) {
 		}
 		assert.Nil(t, storedImage)
 
+		hasImage, err := qb.HasImage(ctx, performer.ID)
+		if err != nil {
+			return fmt.Errorf("Error checking image: %s", err.Error())
+		}
+		assert.False(t, hasImage)
+
 		return nil
 	}); err != nil {
 		t.Error(err.Error())
@@ -0,0 +1,68 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// resolutionLabelBreakpoints holds the breakpoints used when filtering by
+// resolution label. The default breakpoints are used if none are set.
+type resolutionLabelBreakpoints struct {
+	breakpoints atomic.Pointer[[]int]
+}
+
+// SetResolutionLabelBreakpoints sets the minimum size of the shorter side of
+// media for each resolution label above SD. The breakpoints must be
+// validated with models.ValidateResolutionLabelBreakpoints.
+func (b *resolutionLabelBreakpoints) SetResolutionLabelBreakpoints(breakpoints []int) {
+	b.breakpoints.Store(&breakpoints)
+}
+
+func (b *resolutionLabelBreakpoints) getResolutionLabelBreakpoints() []int {
+	if ret := b.breakpoints.Load(); ret != nil {
+		return *ret
+	}
+
+	return models.DefaultResolutionLabelBreakpoints
+}
+
+func resolutionLabelCriterionHandler(label *models.ResolutionLabelCriterionInput, breakpoints []int, heightColumn string, widthColumn string, addJoinFn func(f *filterBuilder)) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if label == nil || !label.Value.IsValid() {
+			return
+		}
+
+		if addJoinFn != nil {
+			addJoinFn(f)
+		}
+
+		min := label.Value.GetMinResolution(breakpoints)
+		max := label.Value.GetMaxResolution(breakpoints)
+
+		widthHeight := fmt.Sprintf("MIN(%s, %s)", widthColumn, heightColumn)
+
+		inRange := fmt.Sprintf("%s >= %d", widthHeight, min)
+		if max >= 0 {
+			inRange = fmt.Sprintf("%s BETWEEN %d AND %d", widthHeight, min, max)
+		}
+
+		switch label.Modifier {
+		case models.CriterionModifierEquals:
+			f.addWhere(inRange)
+		case models.CriterionModifierNotEquals:
+			f.addWhere(fmt.Sprintf("NOT (%s)", inRange))
+		case models.CriterionModifierLessThan:
+			f.addWhere(fmt.Sprintf("%s < %d", widthHeight, min))
+		case models.CriterionModifierGreaterThan:
+			if max < 0 {
+				// nothing is above the highest label
+				f.addWhere("0")
+			} else {
+				f.addWhere(fmt.Sprintf("%s > %d", widthHeight, max))
+			}
+		}
+	}
+}
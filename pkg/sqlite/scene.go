@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -17,6 +18,8 @@ import (
 	"gopkg.in/guregu/null.v4/zero"
 
 	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/hash/audiofingerprint"
+	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/sliceutil/intslice"
 	"github.com/stashapp/stash/pkg/utils"
@@ -43,15 +46,135 @@ HAVING COUNT(files_fingerprints.fingerprint) > 1 AND COUNT(DISTINCT scenes.id) >
 ORDER BY SUM(files.size) DESC;
 `
 
+// bitratePerPixelExpression is the bitrate of the video file divided by the
+// number of pixels per second. Null if the dimensions or frame rate are not
+// known.
+const bitratePerPixelExpression = "(video_files.bit_rate * 1.0 / NULLIF(video_files.width * video_files.height * video_files.frame_rate, 0))"
+
 var findAllPhashesQuery = `
-SELECT scenes.id as id, files_fingerprints.fingerprint as phash
+SELECT scenes.id as id, files_fingerprints.fingerprint as phash, video_files.duration as duration
 FROM scenes
 INNER JOIN scenes_files ON (scenes.id = scenes_files.scene_id) 
 INNER JOIN files ON (scenes_files.file_id = files.id) 
+INNER JOIN video_files ON (files.id = video_files.file_id)
 INNER JOIN files_fingerprints ON (scenes_files.file_id = files_fingerprints.file_id AND files_fingerprints.type = 'phash')
 ORDER BY files.size DESC
 `
 
+var findAllAudioFingerprintsQuery = `
+SELECT scenes.id as id, video_files.duration as duration, files_fingerprints.fingerprint as fingerprint
+FROM scenes
+INNER JOIN scenes_files ON (scenes.id = scenes_files.scene_id) 
+INNER JOIN files ON (scenes_files.file_id = files.id) 
+INNER JOIN video_files ON (scenes_files.file_id = video_files.file_id)
+INNER JOIN files_fingerprints ON (scenes_files.file_id = files_fingerprints.file_id AND files_fingerprints.type = 'audio')
+ORDER BY files.size DESC
+`
+
+var findStudioGroupsByPerformerQuery = `
+SELECT scenes.studio_id as studio_id, GROUP_CONCAT(scenes.id) as ids
+FROM scenes
+INNER JOIN performers_scenes ON (scenes.id = performers_scenes.scene_id)
+WHERE performers_scenes.performer_id = ?
+GROUP BY scenes.studio_id
+ORDER BY COUNT(scenes.id) DESC, scenes.studio_id ASC
+`
+
+var findSharedFilesQuery = `
+SELECT file_id, GROUP_CONCAT(scene_id) as ids
+FROM scenes_files
+GROUP BY file_id
+HAVING COUNT(scene_id) > 1
+ORDER BY file_id
+`
+
+// oshashPhashMatchesQuery selects the scene files without a phash, and the
+// phash of another scene file with the same oshash.
+var oshashPhashMatchesQuery = `
+SELECT oshash.file_id AS file_id, MIN(sibling_phash.fingerprint) AS phash
+FROM scenes_files
+INNER JOIN files_fingerprints AS oshash ON (oshash.file_id = scenes_files.file_id AND oshash.type = 'oshash')
+INNER JOIN files_fingerprints AS sibling ON (sibling.type = 'oshash' AND sibling.fingerprint = oshash.fingerprint AND sibling.file_id != oshash.file_id)
+INNER JOIN scenes_files AS sibling_scenes_files ON (sibling_scenes_files.file_id = sibling.file_id)
+INNER JOIN files_fingerprints AS sibling_phash ON (sibling_phash.file_id = sibling.file_id AND sibling_phash.type = 'phash')
+WHERE NOT EXISTS (
+  SELECT 1 FROM files_fingerprints AS phash WHERE phash.file_id = scenes_files.file_id AND phash.type = 'phash'
+)
+GROUP BY oshash.file_id
+`
+
+var findMissingPhashWithOSHashMatchQuery = `
+SELECT DISTINCT scene_id FROM scenes_files
+WHERE file_id IN (SELECT file_id FROM (` + oshashPhashMatchesQuery + `))
+ORDER BY scene_id
+`
+
+var backfillPhashFromOSHashQuery = `
+INSERT INTO files_fingerprints (file_id, type, fingerprint)
+SELECT file_id, 'phash', phash FROM (` + oshashPhashMatchesQuery + `)
+`
+
+// unlinkedPerformerNamesQuery selects the names and aliases of performers
+// that are not ignored by auto tag.
+var unlinkedPerformerNamesQuery = `
+SELECT performers.id AS performer_id, TRIM(performers.name) AS name FROM performers
+WHERE performers.ignore_auto_tag = 0
+UNION
+SELECT performers.id AS performer_id, TRIM(performer_aliases.alias) AS name FROM performer_aliases
+INNER JOIN performers ON (performers.id = performer_aliases.performer_id)
+WHERE performers.ignore_auto_tag = 0
+`
+
+// findUnlinkedPerformerCandidatesQuery selects the scenes where the first
+// word of a performer name or alias appears in the title or details, and
+// the performer is not linked to the scene.
+var findUnlinkedPerformerCandidatesQuery = `
+SELECT DISTINCT scenes.id AS scene_id, names.performer_id AS performer_id
+FROM scenes
+INNER JOIN (
+  SELECT performer_id, SUBSTR(name, 1, INSTR(name || ' ', ' ') - 1) AS word
+  FROM (` + unlinkedPerformerNamesQuery + `)
+  WHERE LENGTH(name) >= 3
+) AS names ON (
+  scenes.title LIKE '%' || names.word || '%' OR scenes.details LIKE '%' || names.word || '%'
+)
+WHERE NOT EXISTS (
+  SELECT 1 FROM performers_scenes
+  WHERE performers_scenes.scene_id = scenes.id AND performers_scenes.performer_id = names.performer_id
+)
+ORDER BY scenes.id, names.performer_id
+`
+
+// markerCoverageQuery returns the query selecting the marker coverage of
+// each scene with a primary file. maxLength is included as a literal so that
+// the query can be joined before filter arguments.
+func markerCoverageQuery(maxLength float64) string {
+	length := "spans.length"
+	if maxLength > 0 {
+		length = "MIN(spans.length, " + strconv.FormatFloat(maxLength, 'g', -1, 64) + ")"
+	}
+
+	return `
+SELECT scenes_files.scene_id AS scene_id,
+  CASE WHEN video_files.duration > 0 THEN COALESCE(SUM(` + length + `), 0) / video_files.duration ELSE 0 END AS coverage,
+  (SELECT COUNT(*) FROM scene_markers WHERE scene_markers.scene_id = scenes_files.scene_id) AS marker_count
+FROM scenes_files
+INNER JOIN video_files ON (video_files.file_id = scenes_files.file_id)
+LEFT JOIN (
+  SELECT scene_id, COALESCE(LEAD(start) OVER (PARTITION BY scene_id ORDER BY start), duration) - start AS length
+  FROM (
+    SELECT scene_markers.scene_id AS scene_id, MAX(scene_markers.seconds, 0) AS start, video_files.duration AS duration
+    FROM scene_markers
+    INNER JOIN scenes_files ON (scenes_files.scene_id = scene_markers.scene_id AND scenes_files."primary" = 1)
+    INNER JOIN video_files ON (video_files.file_id = scenes_files.file_id)
+    WHERE scene_markers.seconds < video_files.duration
+  )
+) AS spans ON (spans.scene_id = scenes_files.scene_id)
+WHERE scenes_files."primary" = 1
+GROUP BY scenes_files.scene_id
+`
+}
+
 type sceneRow struct {
 	ID       int               `db:"id" goqu:"skipinsert"`
 	Title    zero.String       `db:"title"`
@@ -65,12 +188,16 @@ type sceneRow struct {
 	Organized    bool                       `db:"organized"`
 	OCounter     int                        `db:"o_counter"`
 	StudioID     null.Int                   `db:"studio_id,omitempty"`
+	Stub         bool                       `db:"stub"`
 	CreatedAt    models.SQLiteTimestamp     `db:"created_at"`
 	UpdatedAt    models.SQLiteTimestamp     `db:"updated_at"`
 	LastPlayedAt models.NullSQLiteTimestamp `db:"last_played_at"`
 	ResumeTime   float64                    `db:"resume_time"`
 	PlayDuration float64                    `db:"play_duration"`
 	PlayCount    int                        `db:"play_count"`
+
+	IntroEnd   null.Float `db:"intro_end"`
+	OutroStart null.Float `db:"outro_start"`
 }
 
 func (r *sceneRow) fromScene(o models.Scene) {
@@ -87,6 +214,7 @@ func (r *sceneRow) fromScene(o models.Scene) {
 	r.Organized = o.Organized
 	r.OCounter = o.OCounter
 	r.StudioID = intFromPtr(o.StudioID)
+	r.Stub = o.Stub
 	r.CreatedAt = models.SQLiteTimestamp{Timestamp: o.CreatedAt}
 	r.UpdatedAt = models.SQLiteTimestamp{Timestamp: o.UpdatedAt}
 	if o.LastPlayedAt != nil {
@@ -98,6 +226,8 @@ func (r *sceneRow) fromScene(o models.Scene) {
 	r.ResumeTime = o.ResumeTime
 	r.PlayDuration = o.PlayDuration
 	r.PlayCount = o.PlayCount
+	r.IntroEnd = null.FloatFromPtr(o.IntroEnd)
+	r.OutroStart = null.FloatFromPtr(o.OutroStart)
 }
 
 type sceneQueryRow struct {
@@ -122,6 +252,7 @@ func (r *sceneQueryRow) resolve() *models.Scene {
 		Organized: r.Organized,
 		OCounter:  r.OCounter,
 		StudioID:  nullIntPtr(r.StudioID),
+		Stub:      r.Stub,
 
 		PrimaryFileID: nullIntFileIDPtr(r.PrimaryFileID),
 		OSHash:        r.PrimaryFileOshash.String,
@@ -133,6 +264,9 @@ func (r *sceneQueryRow) resolve() *models.Scene {
 		ResumeTime:   r.ResumeTime,
 		PlayDuration: r.PlayDuration,
 		PlayCount:    r.PlayCount,
+
+		IntroEnd:   r.IntroEnd.Ptr(),
+		OutroStart: r.OutroStart.Ptr(),
 	}
 
 	if r.PrimaryFileFolderPath.Valid && r.PrimaryFileBasename.Valid {
@@ -161,12 +295,15 @@ func (r *sceneRowRecord) fromPartial(o models.ScenePartial) {
 	r.setBool("organized", o.Organized)
 	r.setInt("o_counter", o.OCounter)
 	r.setNullInt("studio_id", o.StudioID)
+	r.setBool("stub", o.Stub)
 	r.setSQLiteTimestamp("created_at", o.CreatedAt)
 	r.setSQLiteTimestamp("updated_at", o.UpdatedAt)
 	r.setSQLiteTimestamp("last_played_at", o.LastPlayedAt)
 	r.setFloat64("resume_time", o.ResumeTime)
 	r.setFloat64("play_duration", o.PlayDuration)
 	r.setInt("play_count", o.PlayCount)
+	r.setNullFloat64("intro_end", o.IntroEnd)
+	r.setNullFloat64("outro_start", o.OutroStart)
 }
 
 type SceneStore struct {
@@ -176,6 +313,8 @@ type SceneStore struct {
 	oCounterManager
 
 	fileStore *FileStore
+
+	resolutionLabelBreakpoints
 }
 
 func NewSceneStore(fileStore *FileStore) *SceneStore {
@@ -494,6 +633,37 @@ func (qb *SceneStore) GetManyFileIDs(ctx context.Context, ids []int) ([][]file.I
 	return qb.filesRepository().getMany(ctx, ids, primaryOnly)
 }
 
+// FindSceneIDsByFolder returns the ids of the scenes with a file directly
+// in each folder, keyed by folder id. Folders without scene files are not
+// included.
+func (qb *SceneStore) FindSceneIDsByFolder(ctx context.Context) (map[file.FolderID][]int, error) {
+	table := fileTableMgr.table
+	q := dialect.From(scenesFilesJoinTable).InnerJoin(
+		table,
+		goqu.On(table.Col(idColumn).Eq(scenesFilesJoinTable.Col(fileIDColumn))),
+	).Select(
+		table.Col("parent_folder_id"),
+		scenesFilesJoinTable.Col(sceneIDColumn),
+	).Distinct()
+
+	ret := make(map[file.FolderID][]int)
+	const single = false
+	if err := queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+		var folderID file.FolderID
+		var sceneID int
+		if err := rows.Scan(&folderID, &sceneID); err != nil {
+			return err
+		}
+
+		ret[folderID] = append(ret[folderID], sceneID)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting scene ids by folder: %w", err)
+	}
+
+	return ret, nil
+}
+
 func (qb *SceneStore) find(ctx context.Context, id int) (*models.Scene, error) {
 	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
 
@@ -566,6 +736,55 @@ func (qb *SceneStore) FindByFingerprints(ctx context.Context, fp []file.Fingerpr
 	return ret, nil
 }
 
+// AddStubFingerprints adds the expected fingerprints of the file of a stub
+// scene. These are used to attach a file to the stub when it is scanned.
+func (qb *SceneStore) AddStubFingerprints(ctx context.Context, sceneID int, fp []file.Fingerprint) error {
+	table := scenesStubFingerprintsJoinTable
+
+	for _, v := range fp {
+		q := dialect.Insert(table).Cols(sceneIDColumn, "type", "fingerprint").
+			Vals(goqu.Vals{sceneID, v.Type, v.Fingerprint}).
+			OnConflict(goqu.DoNothing())
+
+		if _, err := exec(ctx, q); err != nil {
+			return fmt.Errorf("adding stub fingerprint: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// FindStubsByFingerprints returns the stub scenes expecting a file with any
+// of the provided fingerprints.
+func (qb *SceneStore) FindStubsByFingerprints(ctx context.Context, fp []file.Fingerprint) ([]*models.Scene, error) {
+	table := scenesStubFingerprintsJoinTable
+
+	var ex []exp.Expression
+
+	for _, v := range fp {
+		ex = append(ex, goqu.And(
+			table.Col("type").Eq(v.Type),
+			table.Col("fingerprint").Eq(v.Fingerprint),
+		))
+	}
+
+	if len(ex) == 0 {
+		return nil, nil
+	}
+
+	sq := dialect.From(table).
+		InnerJoin(qb.table(), goqu.On(qb.table().Col(idColumn).Eq(table.Col(sceneIDColumn)))).
+		Select(table.Col(sceneIDColumn)).
+		Where(qb.table().Col("stub").IsTrue(), goqu.Or(ex...))
+
+	ret, err := qb.findBySubquery(ctx, sq)
+	if err != nil {
+		return nil, fmt.Errorf("getting stub scenes by fingerprints: %w", err)
+	}
+
+	return ret, nil
+}
+
 func (qb *SceneStore) FindByChecksum(ctx context.Context, checksum string) ([]*models.Scene, error) {
 	return qb.FindByFingerprints(ctx, []file.Fingerprint{
 		{
@@ -613,6 +832,25 @@ func (qb *SceneStore) FindByPath(ctx context.Context, p string) ([]*models.Scene
 	return ret, nil
 }
 
+// FindByParentFolderID returns the scenes with a file directly in the folder.
+func (qb *SceneStore) FindByParentFolderID(ctx context.Context, folderID file.FolderID) ([]*models.Scene, error) {
+	filesTable := fileTableMgr.table
+
+	sq := dialect.From(scenesFilesJoinTable).InnerJoin(
+		filesTable,
+		goqu.On(filesTable.Col(idColumn).Eq(scenesFilesJoinTable.Col(fileIDColumn))),
+	).Select(scenesFilesJoinTable.Col(sceneIDColumn)).Where(
+		filesTable.Col("parent_folder_id").Eq(folderID),
+	)
+
+	ret, err := qb.findBySubquery(ctx, sq)
+	if err != nil {
+		return nil, fmt.Errorf("getting scenes for parent folder %d: %w", folderID, err)
+	}
+
+	return ret, nil
+}
+
 func (qb *SceneStore) findBySubquery(ctx context.Context, sq *goqu.SelectDataset) ([]*models.Scene, error) {
 	table := qb.table()
 
@@ -658,6 +896,199 @@ func (qb *SceneStore) CountByPerformerID(ctx context.Context, performerID int) (
 	return count(ctx, q)
 }
 
+// CountByPerformerIDs returns the number of scenes of each of the provided
+// performers, in the same order as performerIDs.
+func (qb *SceneStore) CountByPerformerIDs(ctx context.Context, performerIDs []int) ([]int, error) {
+	return countByValues(ctx, scenesPerformersJoinTable, performerIDColumn, performerIDs)
+}
+
+func (qb *SceneStore) FindStudioGroupsByPerformerID(ctx context.Context, performerID int) ([]*models.SceneStudioGroup, error) {
+	var ret []*models.SceneStudioGroup
+	if err := qb.queryFunc(ctx, findStudioGroupsByPerformerQuery, []interface{}{performerID}, false, func(rows *sqlx.Rows) error {
+		var row struct {
+			StudioID null.Int `db:"studio_id"`
+			IDs      string   `db:"ids"`
+		}
+		if err := rows.StructScan(&row); err != nil {
+			return err
+		}
+
+		g := &models.SceneStudioGroup{
+			StudioID: nullIntPtr(row.StudioID),
+		}
+		for _, id := range strings.Split(row.IDs, ",") {
+			sceneID, err := strconv.Atoi(id)
+			if err != nil {
+				return err
+			}
+			g.SceneIDs = append(g.SceneIDs, sceneID)
+		}
+
+		ret = append(ret, g)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// FindMissingPhashWithOSHashMatch returns the scenes with a file without a
+// phash, where another scene file with the same oshash has a phash.
+func (qb *SceneStore) FindMissingPhashWithOSHashMatch(ctx context.Context) ([]*models.Scene, error) {
+	var ids []int
+	if err := qb.tx.Select(ctx, &ids, findMissingPhashWithOSHashMatchQuery); err != nil {
+		return nil, fmt.Errorf("finding scenes missing phash: %w", err)
+	}
+
+	return qb.FindMany(ctx, ids)
+}
+
+// BackfillPhashFromOSHash sets the phash of scene files without one from
+// another scene file with the same oshash. Returns the number of files
+// updated.
+func (qb *SceneStore) BackfillPhashFromOSHash(ctx context.Context) (int, error) {
+	result, err := qb.tx.Exec(ctx, backfillPhashFromOSHashQuery)
+	if err != nil {
+		return 0, fmt.Errorf("back-filling phashes: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(n), nil
+}
+
+// FindUnlinkedPerformerCandidates returns the ids of performers that may be
+// named in the title or details of a scene without being linked to it, keyed
+// by scene id. This is a coarse filter: the first word of a performer name or
+// alias must appear in the text, ignoring ASCII case. Candidates must be
+// checked with a match.PerformerTextMatcher.
+func (qb *SceneStore) FindUnlinkedPerformerCandidates(ctx context.Context) (map[int][]int, error) {
+	ret := make(map[int][]int)
+	if err := qb.queryFunc(ctx, findUnlinkedPerformerCandidatesQuery, nil, false, func(rows *sqlx.Rows) error {
+		var sceneID, performerID int
+		if err := rows.Scan(&sceneID, &performerID); err != nil {
+			return err
+		}
+
+		ret[sceneID] = append(ret[sceneID], performerID)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("finding unlinked performer candidates: %w", err)
+	}
+
+	return ret, nil
+}
+
+// QueryMarkerCoverage returns the scenes matching the filter where markers
+// cover at most options.MaxCoverage of the primary file duration, ordered by
+// coverage, lowest first. Scenes without markers have zero coverage. Also
+// returns the total number of matching scenes.
+func (qb *SceneStore) QueryMarkerCoverage(ctx context.Context, options models.SceneMarkerCoverageOptions) ([]*models.SceneMarkerCoverage, int, error) {
+	sceneFilter := options.SceneFilter
+	findFilter := options.FindFilter
+
+	if sceneFilter == nil {
+		sceneFilter = &models.SceneFilterType{}
+	}
+	if findFilter == nil {
+		findFilter = &models.FindFilterType{}
+	}
+
+	coverageQuery := markerCoverageQuery(options.MaxMarkerLength)
+
+	query := qb.newQuery()
+	distinctIDs(&query, sceneTable)
+	query.addJoins(join{
+		table:    "(" + coverageQuery + ")",
+		as:       "marker_coverage",
+		onClause: "marker_coverage.scene_id = scenes.id",
+		joinType: "INNER",
+	})
+
+	// added before the filter so that the argument precedes filter arguments
+	query.addWhere("marker_coverage.coverage <= ?")
+	query.addArg(options.MaxCoverage)
+
+	if err := qb.validateFilter(sceneFilter); err != nil {
+		return nil, 0, err
+	}
+	query.addFilter(qb.makeFilter(ctx, sceneFilter))
+
+	query.sortAndPagination = " ORDER BY marker_coverage.coverage ASC, scenes.id ASC" + getPagination(findFilter)
+
+	ids, count, err := query.executeFind(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(ids) == 0 {
+		return []*models.SceneMarkerCoverage{}, count, nil
+	}
+
+	byID := make(map[int]*models.SceneMarkerCoverage, len(ids))
+	q := "SELECT scene_id, coverage, marker_count FROM (" + coverageQuery + ") WHERE scene_id IN " + getInBinding(len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	if err := qb.queryFunc(ctx, q, args, false, func(rows *sqlx.Rows) error {
+		v := &models.SceneMarkerCoverage{}
+		if err := rows.Scan(&v.SceneID, &v.Coverage, &v.MarkerCount); err != nil {
+			return err
+		}
+
+		byID[v.SceneID] = v
+		return nil
+	}); err != nil {
+		return nil, 0, fmt.Errorf("querying marker coverage: %w", err)
+	}
+
+	ret := make([]*models.SceneMarkerCoverage, 0, len(ids))
+	for _, id := range ids {
+		if v := byID[id]; v != nil {
+			ret = append(ret, v)
+		}
+	}
+
+	return ret, count, nil
+}
+
+func (qb *SceneStore) FindSharedFiles(ctx context.Context) ([]*models.SceneSharedFile, error) {
+	var ret []*models.SceneSharedFile
+	if err := qb.queryFunc(ctx, findSharedFilesQuery, nil, false, func(rows *sqlx.Rows) error {
+		var row struct {
+			FileID file.ID `db:"file_id"`
+			IDs    string  `db:"ids"`
+		}
+		if err := rows.StructScan(&row); err != nil {
+			return err
+		}
+
+		f := &models.SceneSharedFile{
+			FileID: row.FileID,
+		}
+		for _, id := range strings.Split(row.IDs, ",") {
+			sceneID, err := strconv.Atoi(id)
+			if err != nil {
+				return err
+			}
+			f.SceneIDs = append(f.SceneIDs, sceneID)
+		}
+		sort.Ints(f.SceneIDs)
+
+		ret = append(ret, f)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("finding shared scene files: %w", err)
+	}
+
+	return ret, nil
+}
+
 func (qb *SceneStore) FindByMovieID(ctx context.Context, movieID int) ([]*models.Scene, error) {
 	sq := dialect.From(scenesMoviesJoinTable).Select(scenesMoviesJoinTable.Col(sceneIDColumn)).Where(
 		scenesMoviesJoinTable.Col(movieIDColumn).Eq(movieID),
@@ -731,6 +1162,12 @@ func (qb *SceneStore) CountByStudioID(ctx context.Context, studioID int) (int, e
 	return count(ctx, q)
 }
 
+// CountByStudioIDs returns the number of scenes of each of the provided
+// studios, in the same order as studioIDs.
+func (qb *SceneStore) CountByStudioIDs(ctx context.Context, studioIDs []int) ([]int, error) {
+	return countByValues(ctx, qb.table(), studioIDColumn, studioIDs)
+}
+
 func (qb *SceneStore) CountByTagID(ctx context.Context, tagID int) (int, error) {
 	joinTable := scenesTagsJoinTable
 
@@ -738,6 +1175,12 @@ func (qb *SceneStore) CountByTagID(ctx context.Context, tagID int) (int, error)
 	return count(ctx, q)
 }
 
+// CountByTagIDs returns the number of scenes with each of the provided tags,
+// in the same order as tagIDs.
+func (qb *SceneStore) CountByTagIDs(ctx context.Context, tagIDs []int) ([]int, error) {
+	return countByValues(ctx, scenesTagsJoinTable, tagIDColumn, tagIDs)
+}
+
 func (qb *SceneStore) countMissingFingerprints(ctx context.Context, fpType string) (int, error) {
 	fpTable := fingerprintTableMgr.table.As("fingerprints_temp")
 
@@ -835,6 +1278,7 @@ func (qb *SceneStore) makeFilter(ctx context.Context, sceneFilter *models.SceneF
 
 	query.handleCriterion(ctx, intCriterionHandler(sceneFilter.ID, "scenes.id", nil))
 	query.handleCriterion(ctx, pathCriterionHandler(sceneFilter.Path, "folders.path", "files.basename", qb.addFoldersTable))
+	query.handleCriterion(ctx, sceneDirectoryCriterionHandler(qb, sceneFilter.Directory))
 	query.handleCriterion(ctx, sceneFileCountCriterionHandler(qb, sceneFilter.FileCount))
 	query.handleCriterion(ctx, stringCriterionHandler(sceneFilter.Title, "scenes.title"))
 	query.handleCriterion(ctx, stringCriterionHandler(sceneFilter.Code, "scenes.code"))
@@ -876,9 +1320,14 @@ func (qb *SceneStore) makeFilter(ctx context.Context, sceneFilter *models.SceneF
 	query.handleCriterion(ctx, rating5CriterionHandler(sceneFilter.Rating, "scenes.rating", nil))
 	query.handleCriterion(ctx, intCriterionHandler(sceneFilter.OCounter, "scenes.o_counter", nil))
 	query.handleCriterion(ctx, boolCriterionHandler(sceneFilter.Organized, "scenes.organized", nil))
+	query.handleCriterion(ctx, boolCriterionHandler(sceneFilter.Stub, "scenes.stub", nil))
 
 	query.handleCriterion(ctx, floatIntCriterionHandler(sceneFilter.Duration, "video_files.duration", qb.addVideoFilesTable))
+	query.handleCriterion(ctx, floatCriterionHandler(sceneFilter.BitratePerPixel, bitratePerPixelExpression, qb.addVideoFilesTable))
+	query.handleCriterion(ctx, floatCriterionHandler(sceneFilter.Loudness, "video_files.loudness", qb.addVideoFilesTable))
 	query.handleCriterion(ctx, resolutionCriterionHandler(sceneFilter.Resolution, "video_files.height", "video_files.width", qb.addVideoFilesTable))
+	query.handleCriterion(ctx, resolutionLabelCriterionHandler(sceneFilter.ResolutionLabel, qb.getResolutionLabelBreakpoints(), "video_files.height", "video_files.width", qb.addVideoFilesTable))
+	query.handleCriterion(ctx, orientationCriterionHandler(sceneFilter.Orientation, "video_files.height", "video_files.width", qb.addVideoFilesTable))
 
 	query.handleCriterion(ctx, hasMarkersCriterionHandler(sceneFilter.HasMarkers))
 	query.handleCriterion(ctx, sceneIsMissingCriterionHandler(qb, sceneFilter.IsMissing))
@@ -899,6 +1348,9 @@ func (qb *SceneStore) makeFilter(ctx context.Context, sceneFilter *models.SceneF
 
 	query.handleCriterion(ctx, boolCriterionHandler(sceneFilter.Interactive, "video_files.interactive", qb.addVideoFilesTable))
 	query.handleCriterion(ctx, intCriterionHandler(sceneFilter.InteractiveSpeed, "video_files.interactive_speed", qb.addVideoFilesTable))
+	query.handleCriterion(ctx, intCriterionHandler(sceneFilter.InteractiveAverageSpeed, "video_files.interactive_average_speed", qb.addVideoFilesTable))
+	query.handleCriterion(ctx, intCriterionHandler(sceneFilter.InteractiveRange, "video_files.interactive_range", qb.addVideoFilesTable))
+	query.handleCriterion(ctx, intCriterionHandler(sceneFilter.InteractiveDensity, "video_files.interactive_density", qb.addVideoFilesTable))
 
 	query.handleCriterion(ctx, sceneCaptionCriterionHandler(qb, sceneFilter.Captions))
 
@@ -910,6 +1362,7 @@ func (qb *SceneStore) makeFilter(ctx context.Context, sceneFilter *models.SceneF
 	query.handleCriterion(ctx, sceneTagCountCriterionHandler(qb, sceneFilter.TagCount))
 	query.handleCriterion(ctx, scenePerformersCriterionHandler(qb, sceneFilter.Performers))
 	query.handleCriterion(ctx, scenePerformerCountCriterionHandler(qb, sceneFilter.PerformerCount))
+	query.handleCriterion(ctx, sceneMarkerCountCriterionHandler(qb, sceneFilter.MarkerCount))
 	query.handleCriterion(ctx, sceneStudioCriterionHandler(qb, sceneFilter.Studios))
 	query.handleCriterion(ctx, sceneMoviesCriterionHandler(qb, sceneFilter.Movies))
 	query.handleCriterion(ctx, scenePerformerTagsCriterionHandler(qb, sceneFilter.PerformerTags))
@@ -1080,6 +1533,44 @@ func sceneFileCountCriterionHandler(qb *SceneStore, fileCount *models.IntCriteri
 	return h.handler(fileCount)
 }
 
+func sceneDirectoryCriterionHandler(qb *SceneStore, directory *models.DirectoryCriterionInput) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if directory == nil {
+			return
+		}
+
+		qb.addFoldersTable(f)
+
+		sep := string(filepath.Separator)
+		p := directory.Value
+		if len(p) > 1 {
+			p = strings.TrimSuffix(p, sep)
+		}
+
+		// prefix of the subdirectories, accounting for the root directory
+		prefix := p
+		if !strings.HasSuffix(prefix, sep) {
+			prefix += sep
+		}
+
+		depth := -1
+		if directory.Depth != nil {
+			depth = *directory.Depth
+		}
+
+		switch {
+		case depth == 0:
+			f.addWhere("folders.path = ?", p)
+		case depth > 0:
+			// limit the number of path separators below the directory
+			f.addWhere("(folders.path = ? OR (folders.path LIKE ? AND LENGTH(folders.path) - LENGTH(REPLACE(folders.path, ?, '')) <= ?))",
+				p, prefix+"%", sep, strings.Count(prefix, sep)-1+depth)
+		default:
+			f.addWhere("(folders.path = ? OR folders.path LIKE ?)", p, prefix+"%")
+		}
+	}
+}
+
 func scenePhashDuplicatedCriterionHandler(duplicatedFilter *models.PHashDuplicationCriterionInput, addJoinFn func(f *filterBuilder)) criterionHandlerFunc {
 	return func(ctx context.Context, f *filterBuilder) {
 		// TODO: Wishlist item: Implement Distance matching
@@ -1138,6 +1629,45 @@ func resolutionCriterionHandler(resolution *models.ResolutionCriterionInput, hei
 	}
 }
 
+// orientationSquareTolerance is the maximum difference between width and
+// height, relative to the larger of the two, for media to be considered
+// square.
+const orientationSquareTolerance = 0.02
+
+func orientationCriterionHandler(orientation *models.OrientationCriterionInput, heightColumn string, widthColumn string, addJoinFn func(f *filterBuilder)) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if orientation == nil || len(orientation.Value) == 0 {
+			return
+		}
+
+		if addJoinFn != nil {
+			addJoinFn(f)
+		}
+
+		square := fmt.Sprintf("ABS(%[1]s - %[2]s) <= MAX(%[1]s, %[2]s) * %[3]v", widthColumn, heightColumn, orientationSquareTolerance)
+
+		var clauses []sqlClause
+		for _, o := range orientation.Value {
+			switch o {
+			case models.OrientationEnumPortrait:
+				clauses = append(clauses, makeClause(fmt.Sprintf("%s < %s AND NOT %s", widthColumn, heightColumn, square)))
+			case models.OrientationEnumLandscape:
+				clauses = append(clauses, makeClause(fmt.Sprintf("%s > %s AND NOT %s", widthColumn, heightColumn, square)))
+			case models.OrientationEnumSquare:
+				clauses = append(clauses, makeClause(square))
+			}
+		}
+
+		if len(clauses) == 0 {
+			return
+		}
+
+		// exclude media without dimensions
+		f.addWhere(fmt.Sprintf("%s > 0 AND %s > 0", widthColumn, heightColumn))
+		f.whereClauses = append(f.whereClauses, orClauses(clauses...))
+	}
+}
+
 func hasMarkersCriterionHandler(hasMarkers *string) criterionHandlerFunc {
 	return func(ctx context.Context, f *filterBuilder) {
 		if hasMarkers != nil {
@@ -1262,6 +1792,16 @@ func scenePerformerCountCriterionHandler(qb *SceneStore, performerCount *models.
 	return h.handler(performerCount)
 }
 
+func sceneMarkerCountCriterionHandler(qb *SceneStore, markerCount *models.IntCriterionInput) criterionHandlerFunc {
+	h := countCriterionHandlerBuilder{
+		primaryTable: sceneTable,
+		joinTable:    sceneMarkerTable,
+		primaryFK:    sceneIDColumn,
+	}
+
+	return h.handler(markerCount)
+}
+
 func scenePerformerFavoriteCriterionHandler(performerfavorite *bool) criterionHandlerFunc {
 	return func(ctx context.Context, f *filterBuilder) {
 		if performerfavorite != nil {
@@ -1434,13 +1974,16 @@ func (qb *SceneStore) setSceneSort(query *queryBuilder, findFilter *models.FindF
 		sort = "frame_rate"
 		addVideoFileTable()
 		query.sortAndPagination += getSort(sort, direction, videoFileTable)
+	case "bitrate_per_pixel":
+		addVideoFileTable()
+		query.sortAndPagination += " ORDER BY " + bitratePerPixelExpression + " " + getSortDirection(direction)
 	case "filesize":
 		addFileTable()
 		query.sortAndPagination += getSort(sort, direction, fileTable)
 	case "duration":
 		addVideoFileTable()
 		query.sortAndPagination += getSort(sort, direction, videoFileTable)
-	case "interactive", "interactive_speed":
+	case "interactive", "interactive_speed", "interactive_average_speed", "interactive_range", "interactive_density", "loudness":
 		addVideoFileTable()
 		query.sortAndPagination += getSort(sort, direction, videoFileTable)
 	case "title":
@@ -1678,23 +2221,11 @@ func (qb *SceneStore) FindDuplicates(ctx context.Context, distance int) ([][]*mo
 			}
 		}
 	} else {
-		var hashes []*utils.Phash
-
-		if err := qb.queryFunc(ctx, findAllPhashesQuery, nil, false, func(rows *sqlx.Rows) error {
-			phash := utils.Phash{
-				Bucket: -1,
-			}
-			if err := rows.StructScan(&phash); err != nil {
-				return err
-			}
-
-			hashes = append(hashes, &phash)
-			return nil
-		}); err != nil {
+		var err error
+		dupeIds, err = qb.findPhashDuplicates(ctx, distance, 0)
+		if err != nil {
 			return nil, err
 		}
-
-		dupeIds = utils.FindDuplicates(hashes, distance)
 	}
 
 	var duplicates [][]*models.Scene
@@ -1706,3 +2237,132 @@ func (qb *SceneStore) FindDuplicates(ctx context.Context, distance int) ([][]*mo
 
 	return duplicates, nil
 }
+
+func (qb *SceneStore) findPhashDuplicates(ctx context.Context, distance int, durationTolerance float64) ([][]int, error) {
+	var hashes []*utils.Phash
+
+	if err := qb.queryFunc(ctx, findAllPhashesQuery, nil, false, func(rows *sqlx.Rows) error {
+		phash := utils.Phash{
+			Bucket: -1,
+		}
+		if err := rows.StructScan(&phash); err != nil {
+			return err
+		}
+
+		hashes = append(hashes, &phash)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return utils.FindDuplicates(hashes, distance, durationTolerance), nil
+}
+
+// FindDuplicateIDs returns groups of the ids of scenes with phashes within
+// distance of each other. If durationTolerance is positive, then the
+// durations of the scenes must also be within durationTolerance seconds.
+func (qb *SceneStore) FindDuplicateIDs(ctx context.Context, distance int, durationTolerance float64) ([][]int, error) {
+	return qb.findPhashDuplicates(ctx, distance, durationTolerance)
+}
+
+// SetDuplicateGroups replaces the stored groups of duplicate scenes with the
+// provided groups of scene ids.
+func (qb *SceneStore) SetDuplicateGroups(ctx context.Context, groups [][]int) error {
+	table := sceneDuplicateGroupsTable
+	if _, err := exec(ctx, dialect.Delete(table)); err != nil {
+		return fmt.Errorf("clearing duplicate groups: %w", err)
+	}
+
+	for i, sceneIDs := range groups {
+		groupID := i + 1
+		for _, sceneID := range sceneIDs {
+			q := dialect.Insert(table).Cols("group_id", sceneIDColumn).
+				Vals(goqu.Vals{groupID, sceneID}).
+				OnConflict(goqu.DoNothing())
+
+			if _, err := exec(ctx, q); err != nil {
+				return fmt.Errorf("adding duplicate group: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// FindDuplicateGroups returns the groups of duplicate scenes stored by the
+// last SetDuplicateGroups. Groups with fewer than two remaining scenes
+// are not returned.
+func (qb *SceneStore) FindDuplicateGroups(ctx context.Context) ([][]*models.Scene, error) {
+	table := sceneDuplicateGroupsTable
+	q := dialect.From(table).Select(table.Col("group_id"), table.Col(sceneIDColumn)).
+		Order(table.Col("group_id").Asc(), goqu.I("rowid").Asc())
+
+	var groups [][]int
+	lastGroupID := 0
+	if err := queryFunc(ctx, q, false, func(rows *sqlx.Rows) error {
+		var groupID, sceneID int
+		if err := rows.Scan(&groupID, &sceneID); err != nil {
+			return err
+		}
+
+		if len(groups) == 0 || groupID != lastGroupID {
+			groups = append(groups, nil)
+			lastGroupID = groupID
+		}
+
+		groups[len(groups)-1] = append(groups[len(groups)-1], sceneID)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var duplicates [][]*models.Scene
+	for _, sceneIDs := range groups {
+		if len(sceneIDs) < 2 {
+			continue
+		}
+
+		scenes, err := qb.FindMany(ctx, sceneIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		duplicates = append(duplicates, scenes)
+	}
+
+	return duplicates, nil
+}
+
+// AllAudioFingerprints returns the audio fingerprints of all scene files.
+// Invalid fingerprints are logged and skipped.
+func (qb *SceneStore) AllAudioFingerprints(ctx context.Context) ([]audiofingerprint.Fingerprint, error) {
+	var fingerprints []audiofingerprint.Fingerprint
+
+	if err := qb.queryFunc(ctx, findAllAudioFingerprintsQuery, nil, false, func(rows *sqlx.Rows) error {
+		var row struct {
+			ID          int     `db:"id"`
+			Duration    float64 `db:"duration"`
+			Fingerprint string  `db:"fingerprint"`
+		}
+		if err := rows.StructScan(&row); err != nil {
+			return err
+		}
+
+		fp, err := audiofingerprint.Parse(row.Fingerprint)
+		if err != nil {
+			logger.Warnf("Invalid audio fingerprint for scene %d: %v", row.ID, err)
+			return nil
+		}
+
+		fingerprints = append(fingerprints, audiofingerprint.Fingerprint{
+			SceneID:     row.ID,
+			Duration:    row.Duration,
+			Fingerprint: fp,
+		})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return fingerprints, nil
+}
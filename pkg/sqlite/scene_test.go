@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"testing"
 	"time"
@@ -18,6 +19,7 @@ import (
 	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/sliceutil/intslice"
+	"github.com/stashapp/stash/pkg/sqlite"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -83,6 +85,8 @@ func Test_sceneQueryBuilder_Create(t *testing.T) {
 		resumeTime   = 10.0
 		playCount    = 3
 		playDuration = 34.0
+		introEnd     = 15.0
+		outroStart   = 900.0
 		createdAt    = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
 		updatedAt    = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
 		sceneIndex   = 123
@@ -144,6 +148,8 @@ func Test_sceneQueryBuilder_Create(t *testing.T) {
 				ResumeTime:   float64(resumeTime),
 				PlayCount:    playCount,
 				PlayDuration: playDuration,
+				IntroEnd:     &introEnd,
+				OutroStart:   &outroStart,
 			},
 			false,
 		},
@@ -192,6 +198,8 @@ func Test_sceneQueryBuilder_Create(t *testing.T) {
 				ResumeTime:   resumeTime,
 				PlayCount:    playCount,
 				PlayDuration: playDuration,
+				IntroEnd:     &introEnd,
+				OutroStart:   &outroStart,
 			},
 			false,
 		},
@@ -322,6 +330,8 @@ func Test_sceneQueryBuilder_Update(t *testing.T) {
 		resumeTime   = 10.0
 		playCount    = 3
 		playDuration = 34.0
+		introEnd     = 15.0
+		outroStart   = 900.0
 		createdAt    = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
 		updatedAt    = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
 		sceneIndex   = 123
@@ -382,6 +392,8 @@ func Test_sceneQueryBuilder_Update(t *testing.T) {
 				ResumeTime:   resumeTime,
 				PlayCount:    playCount,
 				PlayDuration: playDuration,
+				IntroEnd:     &introEnd,
+				OutroStart:   &outroStart,
 			},
 			false,
 		},
@@ -538,6 +550,8 @@ func Test_sceneQueryBuilder_UpdatePartial(t *testing.T) {
 		resumeTime   = 10.0
 		playCount    = 3
 		playDuration = 34.0
+		introEnd     = 15.0
+		outroStart   = 900.0
 		createdAt    = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
 		updatedAt    = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
 		sceneIndex   = 123
@@ -615,6 +629,8 @@ func Test_sceneQueryBuilder_UpdatePartial(t *testing.T) {
 				ResumeTime:   models.NewOptionalFloat64(resumeTime),
 				PlayCount:    models.NewOptionalInt(playCount),
 				PlayDuration: models.NewOptionalFloat64(playDuration),
+				IntroEnd:     models.NewOptionalFloat64(introEnd),
+				OutroStart:   models.NewOptionalFloat64(outroStart),
 			},
 			models.Scene{
 				ID: sceneIDs[sceneIdxWithSpacedName],
@@ -660,6 +676,8 @@ func Test_sceneQueryBuilder_UpdatePartial(t *testing.T) {
 				ResumeTime:   resumeTime,
 				PlayCount:    playCount,
 				PlayDuration: playDuration,
+				IntroEnd:     &introEnd,
+				OutroStart:   &outroStart,
 			},
 			false,
 		},
@@ -1804,6 +1822,28 @@ func Test_sceneQueryBuilder_FindByPath(t *testing.T) {
 	}
 }
 
+func Test_sceneQueryBuilder_FindByParentFolderID(t *testing.T) {
+	withTxn(func(ctx context.Context) error {
+		qb := db.Scene
+
+		got, err := qb.FindByParentFolderID(ctx, folderIDs[folderIdxWithSceneFiles])
+		if err != nil {
+			t.Errorf("sceneQueryBuilder.FindByParentFolderID() error = %v", err)
+			return nil
+		}
+		assert.Len(t, got, totalScenes)
+
+		got, err = qb.FindByParentFolderID(ctx, folderIDs[folderIdxWithGalleryFiles])
+		if err != nil {
+			t.Errorf("sceneQueryBuilder.FindByParentFolderID() error = %v", err)
+			return nil
+		}
+		assert.Len(t, got, 0)
+
+		return nil
+	})
+}
+
 func Test_sceneQueryBuilder_FindByGalleryID(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -1870,6 +1910,63 @@ func TestSceneCountByPerformerID(t *testing.T) {
 	})
 }
 
+func TestSceneFindStudioGroupsByPerformerID(t *testing.T) {
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		sqb := db.Scene
+
+		performer := &models.Performer{
+			Name: "TestSceneFindStudioGroupsByPerformerID",
+		}
+		if err := db.Performer.Create(ctx, performer); err != nil {
+			return fmt.Errorf("creating performer: %w", err)
+		}
+
+		studioA, err := createStudio(ctx, sqlite.StudioReaderWriter, "TestSceneFindStudioGroupsByPerformerID A", nil)
+		if err != nil {
+			return fmt.Errorf("creating studio: %w", err)
+		}
+		studioB, err := createStudio(ctx, sqlite.StudioReaderWriter, "TestSceneFindStudioGroupsByPerformerID B", nil)
+		if err != nil {
+			return fmt.Errorf("creating studio: %w", err)
+		}
+
+		createScene := func(studioID *int) int {
+			s := &models.Scene{
+				StudioID:     studioID,
+				PerformerIDs: models.NewRelatedIDs([]int{performer.ID}),
+				GalleryIDs:   models.NewRelatedIDs([]int{}),
+				TagIDs:       models.NewRelatedIDs([]int{}),
+				Movies:       models.NewRelatedMovies([]models.MoviesScenes{}),
+				StashIDs:     models.NewRelatedStashIDs([]models.StashID{}),
+			}
+			if err := sqb.Create(ctx, s, nil); err != nil {
+				t.Errorf("Error creating scene: %v", err)
+			}
+			return s.ID
+		}
+
+		a1 := createScene(&studioA.ID)
+		b1 := createScene(&studioB.ID)
+		a2 := createScene(&studioA.ID)
+		none := createScene(nil)
+
+		groups, err := sqb.FindStudioGroupsByPerformerID(ctx, performer.ID)
+		if err != nil {
+			return err
+		}
+
+		assert.Equal(t, []*models.SceneStudioGroup{
+			{StudioID: &studioA.ID, SceneIDs: []int{a1, a2}},
+			{StudioID: nil, SceneIDs: []int{none}},
+			{StudioID: &studioB.ID, SceneIDs: []int{b1}},
+		}, groups)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 func scenesToIDs(i []*models.Scene) []int {
 	ret := make([]int, len(i))
 	for i, v := range i {
@@ -1879,6 +1976,276 @@ func scenesToIDs(i []*models.Scene) []int {
 	return ret
 }
 
+func TestSceneStore_FindSharedFiles(t *testing.T) {
+	runWithRollbackTxn(t, "FindSharedFiles", func(t *testing.T, ctx context.Context) {
+		qb := db.Scene
+
+		got, err := qb.FindSharedFiles(ctx)
+		if err != nil {
+			t.Fatalf("SceneStore.FindSharedFiles() error = %v", err)
+		}
+		assert.Len(t, got, 0)
+
+		fileID := sceneFileIDs[sceneIdxWithGallery]
+		if err := qb.AddFileID(ctx, sceneIDs[sceneIdxWithMovie], fileID); err != nil {
+			t.Fatalf("SceneStore.AddFileID() error = %v", err)
+		}
+
+		got, err = qb.FindSharedFiles(ctx)
+		if err != nil {
+			t.Fatalf("SceneStore.FindSharedFiles() error = %v", err)
+		}
+
+		want := []int{sceneIDs[sceneIdxWithGallery], sceneIDs[sceneIdxWithMovie]}
+		sort.Ints(want)
+
+		assert.Equal(t, []*models.SceneSharedFile{
+			{
+				FileID:   fileID,
+				SceneIDs: want,
+			},
+		}, got)
+	})
+}
+
+func TestSceneStore_FindUnlinkedPerformerCandidates(t *testing.T) {
+	runWithRollbackTxn(t, "FindUnlinkedPerformerCandidates", func(t *testing.T, ctx context.Context) {
+		qb := db.Scene
+
+		performer := &models.Performer{
+			Name:    "Zebedee Unlinked",
+			Aliases: models.NewRelatedStrings([]string{"Zeboid Alias"}),
+		}
+		if err := db.Performer.Create(ctx, performer); err != nil {
+			t.Fatalf("Error creating performer: %v", err)
+		}
+
+		ignored := &models.Performer{
+			Name:          "Xanthippe Ignored",
+			IgnoreAutoTag: true,
+		}
+		if err := db.Performer.Create(ctx, ignored); err != nil {
+			t.Fatalf("Error creating performer: %v", err)
+		}
+
+		createScene := func(title, details string, performerIDs []int) int {
+			s := &models.Scene{
+				Title:        title,
+				Details:      details,
+				PerformerIDs: models.NewRelatedIDs(performerIDs),
+			}
+			if err := qb.Create(ctx, s, nil); err != nil {
+				t.Fatalf("Error creating scene: %v", err)
+			}
+			return s.ID
+		}
+
+		inTitle := createScene("ZEBEDEE.Unlinked at home", "", []int{})
+		inDetails := createScene("", "featuring zeboid alias", []int{})
+		linked := createScene("Zebedee Unlinked", "", []int{performer.ID})
+		ignoredOnly := createScene("Xanthippe Ignored", "", []int{})
+
+		got, err := qb.FindUnlinkedPerformerCandidates(ctx)
+		if err != nil {
+			t.Fatalf("SceneStore.FindUnlinkedPerformerCandidates() error = %v", err)
+		}
+
+		assert.Contains(t, got[inTitle], performer.ID)
+		assert.Contains(t, got[inDetails], performer.ID)
+		assert.NotContains(t, got[linked], performer.ID)
+		assert.NotContains(t, got[ignoredOnly], ignored.ID)
+	})
+}
+
+func TestSceneStore_QueryMarkerCoverage(t *testing.T) {
+	runWithRollbackTxn(t, "QueryMarkerCoverage", func(t *testing.T, ctx context.Context) {
+		qb := db.Scene
+
+		const titlePrefix = "TestSceneStore_QueryMarkerCoverage"
+
+		createScene := func(name string, duration float64, markerSeconds ...float64) int {
+			f := &file.VideoFile{
+				BaseFile: &file.BaseFile{
+					Basename:       titlePrefix + name,
+					ParentFolderID: folderIDs[folderIdxWithSceneFiles],
+				},
+				Duration: duration,
+			}
+			if err := db.File.Create(ctx, f); err != nil {
+				t.Fatalf("Error creating video file: %v", err)
+			}
+
+			s := &models.Scene{
+				Title: titlePrefix + name,
+			}
+			if err := qb.Create(ctx, s, []file.ID{f.ID}); err != nil {
+				t.Fatalf("Error creating scene: %v", err)
+			}
+
+			for _, seconds := range markerSeconds {
+				if _, err := sqlite.SceneMarkerReaderWriter.Create(ctx, models.SceneMarker{
+					SceneID:      sql.NullInt64{Int64: int64(s.ID), Valid: true},
+					PrimaryTagID: tagIDs[tagIdxWithPrimaryMarkers],
+					Seconds:      seconds,
+				}); err != nil {
+					t.Fatalf("Error creating marker: %v", err)
+				}
+			}
+
+			return s.ID
+		}
+
+		noMarkers := createScene("noMarkers", 100)
+		gapBeforeFirst := createScene("gapBeforeFirst", 100, 40, 20)
+		markerAtStart := createScene("markerAtStart", 100, 0)
+		duplicates := createScene("duplicates", 100, 50, 50)
+		afterEnd := createScene("afterEnd", 100, 50, 150)
+		spaced := createScene("spaced", 100, 0, 10, 50)
+		noDuration := createScene("noDuration", 0, 0)
+
+		sceneFilter := &models.SceneFilterType{
+			Title: &models.StringCriterionInput{
+				Value:    titlePrefix,
+				Modifier: models.CriterionModifierIncludes,
+			},
+		}
+
+		query := func(maxCoverage, maxLength float64, findFilter *models.FindFilterType) ([]*models.SceneMarkerCoverage, int) {
+			got, count, err := qb.QueryMarkerCoverage(ctx, models.SceneMarkerCoverageOptions{
+				SceneFilter:     sceneFilter,
+				FindFilter:      findFilter,
+				MaxCoverage:     maxCoverage,
+				MaxMarkerLength: maxLength,
+			})
+			if err != nil {
+				t.Fatalf("SceneStore.QueryMarkerCoverage() error = %v", err)
+			}
+			return got, count
+		}
+
+		coverageByID := func(got []*models.SceneMarkerCoverage) map[int]float64 {
+			ret := make(map[int]float64)
+			for _, c := range got {
+				ret[c.SceneID] = c.Coverage
+			}
+			return ret
+		}
+
+		got, count := query(1, 0, nil)
+		assert.Equal(t, 7, count)
+		byID := coverageByID(got)
+		assert.InDelta(t, 0, byID[noMarkers], 1e-9)
+		assert.InDelta(t, 0.8, byID[gapBeforeFirst], 1e-9)
+		assert.InDelta(t, 1, byID[markerAtStart], 1e-9)
+		assert.InDelta(t, 0.5, byID[duplicates], 1e-9)
+		assert.InDelta(t, 0.5, byID[afterEnd], 1e-9)
+		assert.InDelta(t, 1, byID[spaced], 1e-9)
+		assert.InDelta(t, 0, byID[noDuration], 1e-9)
+
+		// ordered by coverage, lowest first
+		for i := 1; i < len(got); i++ {
+			assert.LessOrEqual(t, got[i-1].Coverage, got[i].Coverage)
+		}
+
+		for _, c := range got {
+			if c.SceneID == afterEnd {
+				assert.Equal(t, 2, c.MarkerCount)
+			}
+			if c.SceneID == noMarkers {
+				assert.Equal(t, 0, c.MarkerCount)
+			}
+		}
+
+		// each marker covers at most 20 seconds
+		got, _ = query(1, 20, nil)
+		assert.InDelta(t, 0.5, coverageByID(got)[spaced], 1e-9)
+
+		// scenes without markers are included
+		got, count = query(0.5, 0, nil)
+		assert.Equal(t, 4, count)
+		assert.ElementsMatch(t, []int{noMarkers, noDuration, duplicates, afterEnd}, scenesCoverageIDs(got))
+
+		// paginated
+		page := 2
+		perPage := 2
+		got, count = query(0.5, 0, &models.FindFilterType{
+			Page:    &page,
+			PerPage: &perPage,
+		})
+		assert.Equal(t, 4, count)
+		assert.ElementsMatch(t, []int{duplicates, afterEnd}, scenesCoverageIDs(got))
+	})
+}
+
+func scenesCoverageIDs(coverage []*models.SceneMarkerCoverage) []int {
+	var ret []int
+	for _, c := range coverage {
+		ret = append(ret, c.SceneID)
+	}
+	return ret
+}
+
+func TestSceneStore_BackfillPhashFromOSHash(t *testing.T) {
+	runWithRollbackTxn(t, "BackfillPhashFromOSHash", func(t *testing.T, ctx context.Context) {
+		qb := db.Scene
+
+		got, err := qb.FindMissingPhashWithOSHashMatch(ctx)
+		if err != nil {
+			t.Fatalf("SceneStore.FindMissingPhashWithOSHashMatch() error = %v", err)
+		}
+		assert.Len(t, got, 0)
+
+		// add a file with the same oshash and a phash to another scene
+		const phash = int64(4321)
+		f := &file.VideoFile{
+			BaseFile: &file.BaseFile{
+				Basename:       "TestSceneStore_BackfillPhashFromOSHash",
+				ParentFolderID: folderIDs[folderIdxWithSceneFiles],
+				Fingerprints: []file.Fingerprint{
+					{
+						Type:        file.FingerprintTypeOshash,
+						Fingerprint: getSceneStringValue(sceneIdxMissingPhash, "oshash"),
+					},
+					{
+						Type:        file.FingerprintTypePhash,
+						Fingerprint: phash,
+					},
+				},
+			},
+		}
+		if err := db.File.Create(ctx, f); err != nil {
+			t.Fatalf("Error creating video file: %v", err)
+		}
+		if err := qb.AddFileID(ctx, sceneIDs[sceneIdxWithMovie], f.ID); err != nil {
+			t.Fatalf("SceneStore.AddFileID() error = %v", err)
+		}
+
+		got, err = qb.FindMissingPhashWithOSHashMatch(ctx)
+		if err != nil {
+			t.Fatalf("SceneStore.FindMissingPhashWithOSHashMatch() error = %v", err)
+		}
+		assert.Equal(t, []int{sceneIDs[sceneIdxMissingPhash]}, scenesToIDs(got))
+
+		n, err := qb.BackfillPhashFromOSHash(ctx)
+		if err != nil {
+			t.Fatalf("SceneStore.BackfillPhashFromOSHash() error = %v", err)
+		}
+		assert.Equal(t, 1, n)
+
+		files, err := db.File.Find(ctx, sceneFileIDs[sceneIdxMissingPhash])
+		if err != nil {
+			t.Fatalf("FileStore.Find() error = %v", err)
+		}
+		assert.Equal(t, phash, files[0].Base().Fingerprints.Get(file.FingerprintTypePhash))
+
+		got, err = qb.FindMissingPhashWithOSHashMatch(ctx)
+		if err != nil {
+			t.Fatalf("SceneStore.FindMissingPhashWithOSHashMatch() error = %v", err)
+		}
+		assert.Len(t, got, 0)
+	})
+}
+
 func Test_sceneStore_FindByFileID(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -2363,6 +2730,186 @@ func TestSceneQueryPath(t *testing.T) {
 	}
 }
 
+func TestSceneQueryDirectory(t *testing.T) {
+	const sceneIdx = 1
+	parent := folderPaths[folderIdxForObjectFiles]
+	folder := folderPaths[folderIdxWithSceneFiles]
+
+	intPtr := func(v int) *int { return &v }
+
+	tests := []struct {
+		name        string
+		input       models.DirectoryCriterionInput
+		mustInclude []int
+		mustExclude []int
+	}{
+		{
+			"directory",
+			models.DirectoryCriterionInput{
+				Value: folder,
+				Depth: intPtr(0),
+			},
+			[]int{sceneIdx},
+			nil,
+		},
+		{
+			"trailing separator",
+			models.DirectoryCriterionInput{
+				Value: folder + string(filepath.Separator),
+				Depth: intPtr(0),
+			},
+			[]int{sceneIdx},
+			nil,
+		},
+		{
+			"parent all depths",
+			models.DirectoryCriterionInput{
+				Value: parent,
+			},
+			[]int{sceneIdx},
+			nil,
+		},
+		{
+			"parent depth 1",
+			models.DirectoryCriterionInput{
+				Value: parent,
+				Depth: intPtr(1),
+			},
+			[]int{sceneIdx},
+			nil,
+		},
+		{
+			"parent only",
+			models.DirectoryCriterionInput{
+				Value: parent,
+				Depth: intPtr(0),
+			},
+			nil,
+			[]int{sceneIdx},
+		},
+		{
+			"other directory",
+			models.DirectoryCriterionInput{
+				Value: folderPaths[folderIdxWithImageFiles],
+			},
+			nil,
+			[]int{sceneIdx},
+		},
+		{
+			"partial directory name",
+			models.DirectoryCriterionInput{
+				Value: folder[:len(folder)-1],
+			},
+			nil,
+			[]int{sceneIdx},
+		},
+	}
+
+	qb := db.Scene
+
+	for _, tt := range tests {
+		runWithRollbackTxn(t, tt.name, func(t *testing.T, ctx context.Context) {
+			assert := assert.New(t)
+			got, err := qb.Query(ctx, models.SceneQueryOptions{
+				SceneFilter: &models.SceneFilterType{
+					Directory: &tt.input,
+				},
+			})
+
+			if err != nil {
+				t.Errorf("SceneStore.Query() error = %v", err)
+				return
+			}
+
+			for _, id := range indexesToIDs(sceneIDs, tt.mustInclude) {
+				assert.Contains(got.IDs, id)
+			}
+			for _, id := range indexesToIDs(sceneIDs, tt.mustExclude) {
+				assert.NotContains(got.IDs, id)
+			}
+		})
+	}
+}
+
+func TestSceneFindSceneIDsByFolder(t *testing.T) {
+	withTxn(func(ctx context.Context) error {
+		got, err := db.Scene.FindSceneIDsByFolder(ctx)
+		if err != nil {
+			t.Errorf("SceneStore.FindSceneIDsByFolder() error = %v", err)
+			return nil
+		}
+
+		assert.Contains(t, got[folderIDs[folderIdxWithSceneFiles]], sceneIDs[1])
+		assert.NotContains(t, got, folderIDs[folderIdxForObjectFiles])
+
+		return nil
+	})
+}
+
+func TestSceneFindStubsByFingerprints(t *testing.T) {
+	const oshash = "stub_oshash"
+
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Scene
+
+		stub := &models.Scene{
+			Title: "stub",
+			Stub:  true,
+		}
+		if err := qb.Create(ctx, stub, nil); err != nil {
+			t.Errorf("SceneStore.Create() error = %v", err)
+			return nil
+		}
+
+		fp := []file.Fingerprint{
+			{
+				Type:        file.FingerprintTypeOshash,
+				Fingerprint: oshash,
+			},
+		}
+
+		if err := qb.AddStubFingerprints(ctx, stub.ID, fp); err != nil {
+			t.Errorf("SceneStore.AddStubFingerprints() error = %v", err)
+			return nil
+		}
+
+		// adding the same fingerprint again should not fail
+		if err := qb.AddStubFingerprints(ctx, stub.ID, fp); err != nil {
+			t.Errorf("SceneStore.AddStubFingerprints() error = %v", err)
+			return nil
+		}
+
+		got, err := qb.FindStubsByFingerprints(ctx, fp)
+		if err != nil {
+			t.Errorf("SceneStore.FindStubsByFingerprints() error = %v", err)
+			return nil
+		}
+
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, stub.ID, got[0].ID)
+			assert.True(t, got[0].Stub)
+		}
+
+		// scenes that are no longer stubs should not be returned
+		if _, err := qb.UpdatePartial(ctx, stub.ID, models.ScenePartial{
+			Stub: models.NewOptionalBool(false),
+		}); err != nil {
+			t.Errorf("SceneStore.UpdatePartial() error = %v", err)
+			return nil
+		}
+
+		got, err = qb.FindStubsByFingerprints(ctx, fp)
+		if err != nil {
+			t.Errorf("SceneStore.FindStubsByFingerprints() error = %v", err)
+			return nil
+		}
+
+		assert.Len(t, got, 0)
+
+		return nil
+	})
+}
+
 func TestSceneQueryURL(t *testing.T) {
 	const sceneIdx = 1
 	sceneURL := getSceneStringValue(sceneIdx, urlField)
@@ -2907,6 +3454,132 @@ func verifyScenesDuration(t *testing.T, durationCriterion models.IntCriterionInp
 	})
 }
 
+func TestSceneQueryBitratePerPixel(t *testing.T) {
+	// scene files have a bitrate of 3 * duration, a frame rate of
+	// 2 * duration and a width of 2 * height, so the bitrate per pixel is
+	// about 0.75 / height^2. Thresholds are between fixture heights.
+	bitratePerPixelForHeight := func(height float64) float64 {
+		return 0.75 / (height * height)
+	}
+
+	criterion := models.FloatCriterionInput{
+		Value:    bitratePerPixelForHeight(600),
+		Modifier: models.CriterionModifierGreaterThan,
+	}
+	verifyScenesBitratePerPixel(t, criterion)
+
+	criterion.Value = bitratePerPixelForHeight(1000)
+	criterion.Modifier = models.CriterionModifierLessThan
+	verifyScenesBitratePerPixel(t, criterion)
+
+	value2 := bitratePerPixelForHeight(750)
+	criterion.Value = bitratePerPixelForHeight(1300)
+	criterion.Value2 = &value2
+	criterion.Modifier = models.CriterionModifierBetween
+	verifyScenesBitratePerPixel(t, criterion)
+}
+
+func verifyScenesBitratePerPixel(t *testing.T, criterion models.FloatCriterionInput) {
+	withTxn(func(ctx context.Context) error {
+		sqb := db.Scene
+		sceneFilter := models.SceneFilterType{
+			BitratePerPixel: &criterion,
+		}
+
+		scenes := queryScene(ctx, t, sqb, &sceneFilter, nil)
+		assert.NotEmpty(t, scenes)
+
+		for _, scene := range scenes {
+			if err := scene.LoadPrimaryFile(ctx, db.File); err != nil {
+				t.Errorf("Error querying scene files: %v", err)
+				return nil
+			}
+
+			bpp := scene.Files.Primary().BitratePerPixel()
+			switch criterion.Modifier {
+			case models.CriterionModifierGreaterThan:
+				assert.Greater(t, bpp, criterion.Value)
+			case models.CriterionModifierLessThan:
+				assert.Less(t, bpp, criterion.Value)
+			case models.CriterionModifierBetween:
+				assert.GreaterOrEqual(t, bpp, criterion.Value)
+				assert.LessOrEqual(t, bpp, *criterion.Value2)
+			}
+		}
+
+		return nil
+	})
+}
+
+func TestSceneQueryLoudness(t *testing.T) {
+	criterion := models.FloatCriterionInput{
+		Value:    -20,
+		Modifier: models.CriterionModifierGreaterThan,
+	}
+	verifyScenesLoudness(t, criterion)
+
+	criterion.Modifier = models.CriterionModifierLessThan
+	verifyScenesLoudness(t, criterion)
+
+	value2 := -10.0
+	criterion.Value2 = &value2
+	criterion.Modifier = models.CriterionModifierBetween
+	verifyScenesLoudness(t, criterion)
+
+	criterion.Modifier = models.CriterionModifierIsNull
+	verifyScenesLoudness(t, criterion)
+}
+
+func verifyScenesLoudness(t *testing.T, criterion models.FloatCriterionInput) {
+	withTxn(func(ctx context.Context) error {
+		sqb := db.Scene
+		sceneFilter := models.SceneFilterType{
+			Loudness: &criterion,
+		}
+
+		scenes := queryScene(ctx, t, sqb, &sceneFilter, nil)
+		assert.NotEmpty(t, scenes)
+
+		for _, scene := range scenes {
+			if err := scene.LoadPrimaryFile(ctx, db.File); err != nil {
+				t.Errorf("Error querying scene files: %v", err)
+				return nil
+			}
+
+			// scenes without files have no loudness
+			f := scene.Files.Primary()
+			if criterion.Modifier == models.CriterionModifierIsNull {
+				if f != nil {
+					assert.Nil(t, f.Loudness)
+				}
+				continue
+			}
+
+			if !assert.NotNil(t, f) {
+				continue
+			}
+
+			loudness := f.Loudness
+
+			if !assert.NotNil(t, loudness) {
+				continue
+			}
+
+			switch criterion.Modifier {
+			case models.CriterionModifierGreaterThan:
+				assert.Greater(t, *loudness, criterion.Value)
+			case models.CriterionModifierLessThan:
+				assert.Less(t, *loudness, criterion.Value)
+			case models.CriterionModifierBetween:
+				assert.GreaterOrEqual(t, *loudness, criterion.Value)
+				assert.LessOrEqual(t, *loudness, *criterion.Value2)
+			}
+		}
+
+		return nil
+	})
+}
+
 func verifyFloat64(t *testing.T, value float64, criterion models.IntCriterionInput) {
 	assert := assert.New(t)
 	if criterion.Modifier == models.CriterionModifierEquals {
@@ -3006,6 +3679,65 @@ func verifySceneResolution(t *testing.T, height *int, resolution models.Resoluti
 	}
 }
 
+func TestSceneQueryResolutionLabel(t *testing.T) {
+	for _, label := range models.AllResolutionLabelEnum {
+		for _, modifier := range []models.CriterionModifier{
+			models.CriterionModifierEquals,
+			models.CriterionModifierNotEquals,
+			models.CriterionModifierLessThan,
+			models.CriterionModifierGreaterThan,
+		} {
+			verifyScenesResolutionLabel(t, label, modifier)
+		}
+	}
+}
+
+func verifyScenesResolutionLabel(t *testing.T, label models.ResolutionLabelEnum, modifier models.CriterionModifier) {
+	withTxn(func(ctx context.Context) error {
+		sqb := db.Scene
+		sceneFilter := models.SceneFilterType{
+			ResolutionLabel: &models.ResolutionLabelCriterionInput{
+				Value:    label,
+				Modifier: modifier,
+			},
+		}
+
+		scenes := queryScene(ctx, t, sqb, &sceneFilter, nil)
+
+		labelIdx := indexOfResolutionLabel(label)
+		for _, scene := range scenes {
+			if err := scene.LoadPrimaryFile(ctx, db.File); err != nil {
+				t.Errorf("Error querying scene files: %v", err)
+				return nil
+			}
+			f := scene.Files.Primary()
+			got := indexOfResolutionLabel(models.GetResolutionLabel(f.Width, f.Height, models.DefaultResolutionLabelBreakpoints))
+
+			switch modifier {
+			case models.CriterionModifierEquals:
+				assert.Equal(t, labelIdx, got)
+			case models.CriterionModifierNotEquals:
+				assert.NotEqual(t, labelIdx, got)
+			case models.CriterionModifierLessThan:
+				assert.Less(t, got, labelIdx)
+			case models.CriterionModifierGreaterThan:
+				assert.Greater(t, got, labelIdx)
+			}
+		}
+
+		return nil
+	})
+}
+
+func indexOfResolutionLabel(label models.ResolutionLabelEnum) int {
+	for i, l := range models.AllResolutionLabelEnum {
+		if l == label {
+			return i
+		}
+	}
+	return -1
+}
+
 func TestAllResolutionsHaveResolutionRange(t *testing.T) {
 	for _, resolution := range models.AllResolutionEnum {
 		assert.NotZero(t, resolution.GetMinResolution(), "Define resolution range for %s in extension_resolution.go", resolution)
@@ -3047,6 +3779,52 @@ func TestSceneQueryResolutionModifiers(t *testing.T) {
 	}
 }
 
+func TestSceneQueryOrientation(t *testing.T) {
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Scene
+		sceneNoResolution, _ := createScene(ctx, 0, 0)
+		portrait, _ := createScene(ctx, 1080, 1920)
+		landscape, _ := createScene(ctx, 1920, 1080)
+		square, _ := createScene(ctx, 1080, 1080)
+		nearSquare, _ := createScene(ctx, 1080, 1070)
+
+		query := func(values ...models.OrientationEnum) []*models.Scene {
+			return queryScene(ctx, t, qb, &models.SceneFilterType{
+				Orientation: &models.OrientationCriterionInput{
+					Value: values,
+				},
+			}, nil)
+		}
+
+		// each of the excluded scenes must not be returned
+		assertExcluded := func(scenes []*models.Scene, excluded ...*models.Scene) {
+			for _, e := range excluded {
+				assert.NotContains(t, scenes, e)
+			}
+		}
+
+		scenes := query(models.OrientationEnumPortrait)
+		assert.Contains(t, scenes, portrait)
+		assertExcluded(scenes, sceneNoResolution, landscape, square, nearSquare)
+
+		scenes = query(models.OrientationEnumLandscape)
+		assert.Contains(t, scenes, landscape)
+		assertExcluded(scenes, sceneNoResolution, portrait, square, nearSquare)
+
+		scenes = query(models.OrientationEnumSquare)
+		assert.Subset(t, scenes, []*models.Scene{square, nearSquare})
+		assertExcluded(scenes, sceneNoResolution, portrait, landscape)
+
+		scenes = query(models.OrientationEnumPortrait, models.OrientationEnumSquare)
+		assert.Subset(t, scenes, []*models.Scene{portrait, square, nearSquare})
+		assertExcluded(scenes, sceneNoResolution, landscape)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 func queryScenes(ctx context.Context, t *testing.T, queryBuilder models.SceneReaderWriter, resolution models.ResolutionEnum, modifier models.CriterionModifier) []*models.Scene {
 	sceneFilter := models.SceneFilterType{
 		Resolution: &models.ResolutionCriterionInput{
@@ -3437,6 +4215,87 @@ func TestSceneQueryTags(t *testing.T) {
 	})
 }
 
+func TestSceneQueryPerformerAge(t *testing.T) {
+	lower := -100
+	upper := 100
+
+	tests := []struct {
+		name      string
+		criterion models.IntCriterionInput
+		matches   func(age int) bool
+	}{
+		{
+			"between",
+			models.IntCriterionInput{Value: lower, Value2: &upper, Modifier: models.CriterionModifierBetween},
+			func(age int) bool { return age >= lower && age <= upper },
+		},
+		{
+			"greater than",
+			models.IntCriterionInput{Value: 0, Modifier: models.CriterionModifierGreaterThan},
+			func(age int) bool { return age > 0 },
+		},
+		{
+			"less than",
+			models.IntCriterionInput{Value: 0, Modifier: models.CriterionModifierLessThan},
+			func(age int) bool { return age < 0 },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withTxn(func(ctx context.Context) error {
+				sceneFilter := models.SceneFilterType{
+					PerformerAge: &tt.criterion,
+				}
+
+				scenes := queryScene(ctx, t, db.Scene, &sceneFilter, nil)
+				if tt.criterion.Modifier == models.CriterionModifierBetween {
+					assert.NotEmpty(t, scenes)
+				}
+
+				for _, s := range scenes {
+					if !assert.NotNil(t, s.Date) {
+						continue
+					}
+
+					if err := s.LoadPerformerIDs(ctx, db.Scene); err != nil {
+						t.Errorf("Error loading performer ids: %v", err)
+						return nil
+					}
+
+					found := false
+					for _, id := range s.PerformerIDs.List() {
+						p, err := db.Performer.Find(ctx, id)
+						if err != nil {
+							t.Errorf("Error finding performer: %v", err)
+							return nil
+						}
+
+						if p.Birthdate == nil {
+							continue
+						}
+
+						sd := s.Date.Time
+						bd := p.Birthdate.Time
+						age := sd.Year() - bd.Year()
+						if sd.Month() < bd.Month() || (sd.Month() == bd.Month() && sd.Day() < bd.Day()) {
+							age--
+						}
+
+						if tt.matches(age) {
+							found = true
+						}
+					}
+
+					assert.True(t, found, "scene %d has no performer with matching age", s.ID)
+				}
+
+				return nil
+			})
+		})
+	}
+}
+
 func TestSceneQueryPerformerTags(t *testing.T) {
 	withTxn(func(ctx context.Context) error {
 		sqb := db.Scene
@@ -3733,6 +4592,13 @@ func TestSceneQuerySorting(t *testing.T) {
 			-1,
 			-1,
 		},
+		{
+			"bitrate per pixel",
+			"bitrate_per_pixel",
+			models.SortDirectionEnumAsc,
+			-1,
+			-1,
+		},
 		{
 			"file mod time",
 			"file_mod_time",
@@ -3918,6 +4784,50 @@ func verifyScenesTagCount(t *testing.T, tagCountCriterion models.IntCriterionInp
 	})
 }
 
+func TestSceneQueryMarkerCount(t *testing.T) {
+	markerCountCriterion := models.IntCriterionInput{
+		Value:    0,
+		Modifier: models.CriterionModifierEquals,
+	}
+
+	verifyScenesMarkerCount(t, markerCountCriterion)
+
+	markerCountCriterion.Value = 1
+	verifyScenesMarkerCount(t, markerCountCriterion)
+
+	markerCountCriterion.Modifier = models.CriterionModifierNotEquals
+	verifyScenesMarkerCount(t, markerCountCriterion)
+
+	markerCountCriterion.Modifier = models.CriterionModifierGreaterThan
+	verifyScenesMarkerCount(t, markerCountCriterion)
+
+	markerCountCriterion.Modifier = models.CriterionModifierLessThan
+	verifyScenesMarkerCount(t, markerCountCriterion)
+}
+
+func verifyScenesMarkerCount(t *testing.T, markerCountCriterion models.IntCriterionInput) {
+	withTxn(func(ctx context.Context) error {
+		sqb := db.Scene
+		sceneFilter := models.SceneFilterType{
+			MarkerCount: &markerCountCriterion,
+		}
+
+		scenes := queryScene(ctx, t, sqb, &sceneFilter, nil)
+		assert.Greater(t, len(scenes), 0)
+
+		for _, scene := range scenes {
+			markers, err := sqlite.SceneMarkerReaderWriter.FindBySceneID(ctx, scene.ID)
+			if err != nil {
+				t.Errorf("FindBySceneID() error = %v", err)
+				return nil
+			}
+			verifyInt(t, len(markers), markerCountCriterion)
+		}
+
+		return nil
+	})
+}
+
 func TestSceneQueryPerformerCount(t *testing.T) {
 	const performerCount = 1
 	performerCountCriterion := models.IntCriterionInput{
@@ -3984,6 +4894,34 @@ func TestSceneCountByTagID(t *testing.T) {
 	})
 }
 
+func TestSceneCountByTagIDs(t *testing.T) {
+	withTxn(func(ctx context.Context) error {
+		sqb := db.Scene
+
+		ids := []int{tagIDs[tagIdx2WithScene], 0, tagIDs[tagIdxWithScene], tagIDs[tagIdx1WithScene]}
+		counts, err := sqb.CountByTagIDs(ctx, ids)
+
+		if err != nil {
+			t.Errorf("error calling CountByTagIDs: %s", err.Error())
+		}
+
+		// counts must match the unbatched count of each tag
+		for i, id := range ids {
+			expected, err := sqb.CountByTagID(ctx, id)
+			if err != nil {
+				t.Errorf("error calling CountByTagID: %s", err.Error())
+			}
+
+			assert.Equal(t, expected, counts[i])
+		}
+
+		assert.Equal(t, 1, counts[2])
+		assert.Equal(t, 0, counts[1])
+
+		return nil
+	})
+}
+
 func TestSceneCountByMovieID(t *testing.T) {
 	withTxn(func(ctx context.Context) error {
 		sqb := db.Scene
@@ -4302,6 +5240,65 @@ func TestSceneStore_FindDuplicates(t *testing.T) {
 	})
 }
 
+func TestSceneStore_DuplicateGroups(t *testing.T) {
+	qb := db.Scene
+
+	withRollbackTxn(func(ctx context.Context) error {
+		groups, err := qb.FindDuplicateIDs(ctx, 0, 0)
+		if err != nil {
+			t.Errorf("SceneStore.FindDuplicateIDs() error = %v", err)
+			return nil
+		}
+
+		assert.Len(t, groups, dupeScenePhashes)
+
+		// durations of the duplicate scenes differ by more than a second
+		withDuration, err := qb.FindDuplicateIDs(ctx, 0, 1)
+		if err != nil {
+			t.Errorf("SceneStore.FindDuplicateIDs() error = %v", err)
+			return nil
+		}
+
+		assert.Len(t, withDuration, 0)
+
+		if err := qb.SetDuplicateGroups(ctx, groups); err != nil {
+			t.Errorf("SceneStore.SetDuplicateGroups() error = %v", err)
+			return nil
+		}
+
+		got, err := qb.FindDuplicateGroups(ctx)
+		if err != nil {
+			t.Errorf("SceneStore.FindDuplicateGroups() error = %v", err)
+			return nil
+		}
+
+		assert.Len(t, got, dupeScenePhashes)
+		for i, group := range got {
+			var ids []int
+			for _, s := range group {
+				ids = append(ids, s.ID)
+			}
+			assert.Equal(t, groups[i], ids)
+		}
+
+		// replaces the existing groups
+		if err := qb.SetDuplicateGroups(ctx, groups[:1]); err != nil {
+			t.Errorf("SceneStore.SetDuplicateGroups() error = %v", err)
+			return nil
+		}
+
+		got, err = qb.FindDuplicateGroups(ctx)
+		if err != nil {
+			t.Errorf("SceneStore.FindDuplicateGroups() error = %v", err)
+			return nil
+		}
+
+		assert.Len(t, got, 1)
+
+		return nil
+	})
+}
+
 func TestSceneStore_AssignFiles(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -4495,3 +5492,74 @@ func TestSceneStore_SaveActivity(t *testing.T) {
 
 // TODO Count
 // TODO SizeCount
+
+func TestSceneQueryCreatedAt(t *testing.T) {
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Scene
+		sceneID := sceneIDs[sceneIdxWithGallery]
+
+		// stored in local time, queried in UTC
+		loc := time.FixedZone("test", 10*60*60)
+		createdAt := time.Date(2001, 2, 3, 9, 0, 0, 0, loc)
+		if _, err := qb.UpdatePartial(ctx, sceneID, models.ScenePartial{
+			CreatedAt: models.NewOptionalTime(createdAt),
+		}); err != nil {
+			return err
+		}
+
+		upper := "2001-02-03"
+		tests := []struct {
+			name      string
+			criterion models.TimestampCriterionInput
+			want      bool
+		}{
+			{"between", models.TimestampCriterionInput{Value: "2001-02-02T22:00:00Z", Value2: &upper, Modifier: models.CriterionModifierBetween}, true},
+			{"not between", models.TimestampCriterionInput{Value: "2001-02-02T22:00:00Z", Value2: &upper, Modifier: models.CriterionModifierNotBetween}, false},
+			{"greater than", models.TimestampCriterionInput{Value: "2001-02-02 22:59:59", Modifier: models.CriterionModifierGreaterThan}, true},
+			{"greater than same day", models.TimestampCriterionInput{Value: "2001-02-02T23:00:01Z", Modifier: models.CriterionModifierGreaterThan}, false},
+			{"less than", models.TimestampCriterionInput{Value: "2001-02-03T09:00:01+10:00", Modifier: models.CriterionModifierLessThan}, true},
+			{"equals", models.TimestampCriterionInput{Value: "2001-02-02T23:00:00Z", Modifier: models.CriterionModifierEquals}, true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				criterion := tt.criterion
+				scenes := queryScene(ctx, t, qb, &models.SceneFilterType{
+					CreatedAt: &criterion,
+				}, nil)
+
+				ids := scenesToIDs(scenes)
+				if tt.want {
+					assert.Contains(t, ids, sceneID)
+				} else {
+					assert.NotContains(t, ids, sceneID)
+				}
+			})
+		}
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestSceneQueryCreatedAtInvalid(t *testing.T) {
+	withTxn(func(ctx context.Context) error {
+		tests := []models.TimestampCriterionInput{
+			{Value: "yesterday", Modifier: models.CriterionModifierGreaterThan},
+			{Value: "2001-02-03", Modifier: models.CriterionModifierIncludes},
+		}
+
+		for _, criterion := range tests {
+			c := criterion
+			_, err := db.Scene.Query(ctx, models.SceneQueryOptions{
+				SceneFilter: &models.SceneFilterType{
+					CreatedAt: &c,
+				},
+			})
+			assert.Error(t, err)
+		}
+
+		return nil
+	})
+}
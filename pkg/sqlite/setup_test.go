@@ -932,12 +932,26 @@ func makeSceneFile(i int) *file.VideoFile {
 			ParentFolderID: folderIDs[folderIdxWithSceneFiles],
 			Fingerprints:   fp,
 		},
-		Duration: getSceneDuration(i),
-		Height:   getHeight(i),
-		Width:    getWidth(i),
+		Duration:  getSceneDuration(i),
+		Height:    getHeight(i),
+		Width:     getWidth(i),
+		FrameRate: getSceneDuration(i) * 2,
+		BitRate:   int64(getSceneDuration(i)) * 3,
+		Loudness:  getSceneLoudness(i),
 	}
 }
 
+// getSceneLoudness returns the loudness of the scene file at index, or nil
+// for every third scene to represent files that have not been measured.
+func getSceneLoudness(index int) *float64 {
+	if index%3 == 0 {
+		return nil
+	}
+
+	ret := -float64((index%5)+1) * 6.5
+	return &ret
+}
+
 func getScenePlayCount(index int) int {
 	return index % 5
 }
@@ -182,6 +182,37 @@ func getIntWhereClause(column string, modifier models.CriterionModifier, value i
 	panic("unsupported int modifier type " + modifier)
 }
 
+func getFloatCriterionWhereClause(column string, input models.FloatCriterionInput) (string, []interface{}) {
+	upper := 0.0
+	if input.Value2 != nil {
+		upper = *input.Value2
+	}
+
+	args := []interface{}{input.Value}
+	betweenArgs := []interface{}{input.Value, upper}
+
+	switch input.Modifier {
+	case models.CriterionModifierIsNull:
+		return fmt.Sprintf("%s IS NULL", column), nil
+	case models.CriterionModifierNotNull:
+		return fmt.Sprintf("%s IS NOT NULL", column), nil
+	case models.CriterionModifierEquals:
+		return fmt.Sprintf("%s = ?", column), args
+	case models.CriterionModifierNotEquals:
+		return fmt.Sprintf("%s != ?", column), args
+	case models.CriterionModifierBetween:
+		return fmt.Sprintf("%s BETWEEN ? AND ?", column), betweenArgs
+	case models.CriterionModifierNotBetween:
+		return fmt.Sprintf("%s NOT BETWEEN ? AND ?", column), betweenArgs
+	case models.CriterionModifierLessThan:
+		return fmt.Sprintf("%s < ?", column), args
+	case models.CriterionModifierGreaterThan:
+		return fmt.Sprintf("%s > ?", column), args
+	}
+
+	panic("unsupported float modifier type " + input.Modifier)
+}
+
 func getDateCriterionWhereClause(column string, input models.DateCriterionInput) (string, []interface{}) {
 	return getDateWhereClause(column, input.Modifier, input.Value, input.Value2)
 }
@@ -221,6 +252,10 @@ func getTimestampCriterionWhereClause(column string, input models.TimestampCrite
 	return getTimestampWhereClause(column, input.Modifier, input.Value, input.Value2)
 }
 
+// getTimestampWhereClause returns the where clause comparing the timestamp
+// column with the provided values. Timestamps are stored in different
+// formats depending on the table, so both sides of the comparison are
+// normalised to UTC using the datetime function.
 func getTimestampWhereClause(column string, modifier models.CriterionModifier, value string, upper *string) (string, []interface{}) {
 	if upper == nil {
 		u := time.Now().AddDate(0, 0, 1).Format(time.RFC3339)
@@ -236,17 +271,17 @@ func getTimestampWhereClause(column string, modifier models.CriterionModifier, v
 	case models.CriterionModifierNotNull:
 		return fmt.Sprintf("%s IS NOT NULL", column), nil
 	case models.CriterionModifierEquals:
-		return fmt.Sprintf("%s = ?", column), args
+		return fmt.Sprintf("datetime(%s) = datetime(?)", column), args
 	case models.CriterionModifierNotEquals:
-		return fmt.Sprintf("%s != ?", column), args
+		return fmt.Sprintf("datetime(%s) != datetime(?)", column), args
 	case models.CriterionModifierBetween:
-		return fmt.Sprintf("%s BETWEEN ? AND ?", column), betweenArgs
+		return fmt.Sprintf("datetime(%s) BETWEEN datetime(?) AND datetime(?)", column), betweenArgs
 	case models.CriterionModifierNotBetween:
-		return fmt.Sprintf("%s NOT BETWEEN ? AND ?", column), betweenArgs
+		return fmt.Sprintf("datetime(%s) NOT BETWEEN datetime(?) AND datetime(?)", column), betweenArgs
 	case models.CriterionModifierLessThan:
-		return fmt.Sprintf("%s < ?", column), args
+		return fmt.Sprintf("datetime(%s) < datetime(?)", column), args
 	case models.CriterionModifierGreaterThan:
-		return fmt.Sprintf("%s > ?", column), args
+		return fmt.Sprintf("datetime(%s) > datetime(?)", column), args
 	}
 
 	panic("unsupported date modifier type")
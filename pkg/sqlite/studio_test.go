@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/sqlite"
@@ -957,3 +958,56 @@ func TestStudioQueryFast(t *testing.T) {
 // TODO All
 // TODO AllSlim
 // TODO Query
+
+func TestStudioQueryCreatedAt(t *testing.T) {
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := sqlite.StudioReaderWriter
+		studioID := studioIDs[studioIdxWithMovie]
+
+		// studio timestamps are stored in RFC3339 format
+		loc := time.FixedZone("test", -5*60*60)
+		createdAt := models.SQLiteTimestamp{Timestamp: time.Date(2001, 2, 3, 20, 0, 0, 0, loc)}
+		if _, err := qb.Update(ctx, models.StudioPartial{
+			ID:        studioID,
+			CreatedAt: &createdAt,
+		}); err != nil {
+			return err
+		}
+
+		upper := "2001-02-04T02:00:00Z"
+		tests := []struct {
+			name      string
+			criterion models.TimestampCriterionInput
+			want      bool
+		}{
+			{"between", models.TimestampCriterionInput{Value: "2001-02-04", Value2: &upper, Modifier: models.CriterionModifierBetween}, true},
+			{"greater than", models.TimestampCriterionInput{Value: "2001-02-04 00:59:59", Modifier: models.CriterionModifierGreaterThan}, true},
+			{"greater than later", models.TimestampCriterionInput{Value: "2001-02-04T01:00:01Z", Modifier: models.CriterionModifierGreaterThan}, false},
+			{"less than", models.TimestampCriterionInput{Value: "2001-02-04T01:00:01Z", Modifier: models.CriterionModifierLessThan}, true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				criterion := tt.criterion
+				studios := queryStudio(ctx, t, qb, &models.StudioFilterType{
+					CreatedAt: &criterion,
+				}, nil)
+
+				var ids []int
+				for _, s := range studios {
+					ids = append(ids, s.ID)
+				}
+
+				if tt.want {
+					assert.Contains(t, ids, studioID)
+				} else {
+					assert.NotContains(t, ids, studioID)
+				}
+			})
+		}
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
@@ -701,6 +701,38 @@ func count(ctx context.Context, q *goqu.SelectDataset) (int, error) {
 	return count, nil
 }
 
+// countByValues returns the number of rows in table for each of the provided
+// values of col, in the same order as values.
+func countByValues(ctx context.Context, table exp.IdentifierExpression, col string, values []int) ([]int, error) {
+	q := dialect.Select(table.Col(col).As("value"), goqu.COUNT("*").As("count")).
+		From(table).
+		Where(table.Col(col).In(values)).
+		GroupBy(table.Col(col))
+
+	counts := make(map[int]int)
+	if err := queryFunc(ctx, q, false, func(rows *sqlx.Rows) error {
+		var row struct {
+			Value int `db:"value"`
+			Count int `db:"count"`
+		}
+		if err := rows.StructScan(&row); err != nil {
+			return err
+		}
+
+		counts[row.Value] = row.Count
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	ret := make([]int, len(values))
+	for i, v := range values {
+		ret[i] = counts[v]
+	}
+
+	return ret, nil
+}
+
 func queryFunc(ctx context.Context, query *goqu.SelectDataset, single bool, f func(rows *sqlx.Rows) error) error {
 	q, args, err := query.ToSQL()
 	if err != nil {
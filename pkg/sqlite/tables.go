@@ -25,6 +25,9 @@ var (
 	scenesStashIDsJoinTable   = goqu.T("scene_stash_ids")
 	scenesMoviesJoinTable     = goqu.T(moviesScenesTable)
 
+	scenesStubFingerprintsJoinTable = goqu.T("scene_stub_fingerprints")
+	sceneDuplicateGroupsTable       = goqu.T("scene_duplicate_groups")
+
 	performersAliasesJoinTable  = goqu.T(performersAliasesTable)
 	performersTagsJoinTable     = goqu.T(performersTagsTable)
 	performersStashIDsJoinTable = goqu.T("performer_stash_ids")
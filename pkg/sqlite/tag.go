@@ -17,6 +17,8 @@ const tagTable = "tags"
 const tagIDColumn = "tag_id"
 const tagAliasesTable = "tag_aliases"
 const tagAliasColumn = "alias"
+const tagAutoTagKeywordsTable = "tag_auto_tag_keywords"
+const tagAutoTagKeywordColumn = "keyword"
 
 type tagQueryBuilder struct {
 	repository
@@ -85,6 +87,79 @@ func (qb *tagQueryBuilder) Destroy(ctx context.Context, id int) error {
 	return qb.destroyExisting(ctx, []int{id})
 }
 
+// Purge removes the tag from all objects and then destroys it. Scene markers
+// with the tag as their primary tag use one of their other tags as the
+// primary tag instead. Returns an error if any of these scene markers have no
+// other tags.
+func (qb *tagQueryBuilder) Purge(ctx context.Context, id int) (*models.TagPurgeCounts, error) {
+	primaryMarkerIDs, err := qb.runIdsQuery(ctx, "SELECT id FROM scene_markers WHERE primary_tag_id = ?", []interface{}{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(primaryMarkerIDs) > 0 {
+		const otherTagsQuery = "SELECT 1 FROM scene_markers_tags WHERE scene_marker_id = scene_markers.id AND tag_id != ?"
+
+		untagged, err := qb.runCountQuery(ctx, "SELECT COUNT(*) as count FROM scene_markers WHERE primary_tag_id = ? AND NOT EXISTS ("+otherTagsQuery+")", []interface{}{id, id})
+		if err != nil {
+			return nil, err
+		}
+		if untagged > 0 {
+			return nil, fmt.Errorf("%d scene markers with the tag as their primary tag have no other tags", untagged)
+		}
+
+		// use the first other tag as the primary tag
+		if _, err := qb.tx.Exec(ctx, `UPDATE scene_markers SET primary_tag_id = (
+  SELECT MIN(tag_id) FROM scene_markers_tags WHERE scene_marker_id = scene_markers.id AND tag_id != ?
+) WHERE primary_tag_id = ?`, id, id); err != nil {
+			return nil, err
+		}
+
+		// remove the new primary tag from the other tags
+		var args []interface{}
+		for _, markerID := range primaryMarkerIDs {
+			args = append(args, markerID)
+		}
+		if _, err := qb.tx.Exec(ctx, `DELETE FROM scene_markers_tags WHERE scene_marker_id IN `+getInBinding(len(args))+`
+  AND tag_id = (SELECT primary_tag_id FROM scene_markers WHERE scene_markers.id = scene_marker_id)`, args...); err != nil {
+			return nil, err
+		}
+	}
+
+	removeFrom := func(table string) (int, error) {
+		result, err := qb.tx.Exec(ctx, "DELETE FROM "+table+" WHERE tag_id = ?", id)
+		if err != nil {
+			return 0, err
+		}
+
+		n, err := result.RowsAffected()
+		return int(n), err
+	}
+
+	ret := &models.TagPurgeCounts{}
+	for _, c := range []struct {
+		table string
+		count *int
+	}{
+		{scenesTagsTable, &ret.Scenes},
+		{imagesTagsTable, &ret.Images},
+		{galleriesTagsTable, &ret.Galleries},
+		{performersTagsTable, &ret.Performers},
+		{"scene_markers_tags", &ret.SceneMarkers},
+	} {
+		if *c.count, err = removeFrom(c.table); err != nil {
+			return nil, fmt.Errorf("removing tag from %s: %w", c.table, err)
+		}
+	}
+	ret.SceneMarkers += len(primaryMarkerIDs)
+
+	if err := qb.Destroy(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (qb *tagQueryBuilder) Find(ctx context.Context, id int) (*models.Tag, error) {
 	var ret models.Tag
 	if err := qb.getByID(ctx, id, &ret); err != nil {
@@ -268,6 +343,10 @@ func (qb *tagQueryBuilder) QueryForAutoTag(ctx context.Context, words []string)
 		// include aliases
 		whereClauses = append(whereClauses, "tag_aliases.alias like ?")
 		args = append(args, ww)
+
+		// include auto-tag keywords
+		whereClauses = append(whereClauses, "tags.id IN (SELECT tag_id FROM tag_auto_tag_keywords WHERE keyword like ?)")
+		args = append(args, ww)
 	}
 
 	whereOr := "(" + strings.Join(whereClauses, " OR ") + ")"
@@ -676,10 +755,53 @@ func (qb *tagQueryBuilder) GetAliases(ctx context.Context, tagID int) ([]string,
 	return qb.aliasRepository().get(ctx, tagID)
 }
 
+// AllAliases returns the aliases of all tags, keyed by tag id. Tags without
+// aliases are not included.
+func (qb *tagQueryBuilder) AllAliases(ctx context.Context) (map[int][]string, error) {
+	query := "SELECT " + tagIDColumn + ", " + tagAliasColumn + " FROM " + tagAliasesTable + " ORDER BY " + tagIDColumn + ", " + tagAliasColumn
+
+	ret := make(map[int][]string)
+	if err := qb.queryFunc(ctx, query, nil, false, func(rows *sqlx.Rows) error {
+		var (
+			tagID int
+			alias string
+		)
+		if err := rows.Scan(&tagID, &alias); err != nil {
+			return err
+		}
+
+		ret[tagID] = append(ret[tagID], alias)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (qb *tagQueryBuilder) UpdateAliases(ctx context.Context, tagID int, aliases []string) error {
 	return qb.aliasRepository().replace(ctx, tagID, aliases)
 }
 
+func (qb *tagQueryBuilder) autoTagKeywordRepository() *stringRepository {
+	return &stringRepository{
+		repository: repository{
+			tx:        qb.tx,
+			tableName: tagAutoTagKeywordsTable,
+			idColumn:  tagIDColumn,
+		},
+		stringColumn: tagAutoTagKeywordColumn,
+	}
+}
+
+func (qb *tagQueryBuilder) GetAutoTagKeywords(ctx context.Context, tagID int) ([]string, error) {
+	return qb.autoTagKeywordRepository().get(ctx, tagID)
+}
+
+func (qb *tagQueryBuilder) UpdateAutoTagKeywords(ctx context.Context, tagID int, keywords []string) error {
+	return qb.autoTagKeywordRepository().replace(ctx, tagID, keywords)
+}
+
 func (qb *tagQueryBuilder) Merge(ctx context.Context, source []int, destination int) error {
 	if len(source) == 0 {
 		return nil
@@ -729,7 +851,9 @@ AND NOT EXISTS(SELECT 1 FROM `+table+` o WHERE o.`+idColumn+` = `+table+`.`+idCo
 		return err
 	}
 
-	_, err = qb.tx.Exec(ctx, "INSERT INTO "+tagAliasesTable+" (tag_id, alias) SELECT ?, name FROM "+tagTable+" WHERE id IN "+inBinding, args...)
+	// add the source names as aliases, skipping names that are already used
+	// as an alias or that only differ from the destination name by case
+	_, err = qb.tx.Exec(ctx, "INSERT OR IGNORE INTO "+tagAliasesTable+" (tag_id, alias) SELECT ?, name FROM "+tagTable+" WHERE id IN "+inBinding+" AND name != (SELECT name FROM "+tagTable+" WHERE id = ?) COLLATE NOCASE", args...)
 	if err != nil {
 		return err
 	}
@@ -739,6 +863,11 @@ AND NOT EXISTS(SELECT 1 FROM `+table+` o WHERE o.`+idColumn+` = `+table+`.`+idCo
 		return err
 	}
 
+	_, err = qb.tx.Exec(ctx, "UPDATE OR IGNORE "+tagAutoTagKeywordsTable+" SET tag_id = ? WHERE tag_id IN "+inBinding, args...)
+	if err != nil {
+		return err
+	}
+
 	for _, id := range source {
 		err = qb.Destroy(ctx, id)
 		if err != nil {
@@ -823,6 +952,25 @@ SELECT t.*, p.path FROM tags t INNER JOIN parents p ON t.id = p.parent_id
 	return ret, nil
 }
 
+// AllRelationships returns all parent/child relationships between tags.
+func (qb *tagQueryBuilder) AllRelationships(ctx context.Context) ([]models.TagRelationship, error) {
+	query := "SELECT parent_id, child_id FROM tags_relations ORDER BY parent_id, child_id"
+
+	var ret []models.TagRelationship
+	if err := qb.queryFunc(ctx, query, nil, false, func(rows *sqlx.Rows) error {
+		var r models.TagRelationship
+		if err := rows.StructScan(&r); err != nil {
+			return err
+		}
+		ret = append(ret, r)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 // FindAllDescendants returns a slice of TagPath objects, representing all
 // descendants of the tag with the provided id.
 func (qb *tagQueryBuilder) FindAllDescendants(ctx context.Context, tagID int, excludeIDs []int) ([]*models.TagPath, error) {
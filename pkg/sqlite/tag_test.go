@@ -906,6 +906,167 @@ func TestTagUpdateAlias(t *testing.T) {
 	}
 }
 
+func TestTagAllAliases(t *testing.T) {
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := sqlite.TagReaderWriter
+
+		created, err := qb.Create(ctx, models.Tag{
+			Name: "TestTagAllAliases",
+		})
+		if err != nil {
+			return fmt.Errorf("Error creating tag: %s", err.Error())
+		}
+
+		aliases := []string{"allalias1", "allalias2"}
+		if err := qb.UpdateAliases(ctx, created.ID, aliases); err != nil {
+			return fmt.Errorf("Error updating tag aliases: %s", err.Error())
+		}
+
+		allAliases, err := qb.AllAliases(ctx)
+		if err != nil {
+			return fmt.Errorf("Error getting all aliases: %s", err.Error())
+		}
+
+		assert.Equal(t, aliases, allAliases[created.ID])
+
+		// aliases of other tags match those returned for each tag
+		for tagID, tagAliases := range allAliases {
+			stored, err := qb.GetAliases(ctx, tagID)
+			if err != nil {
+				return fmt.Errorf("Error getting aliases: %s", err.Error())
+			}
+			assert.ElementsMatch(t, stored, tagAliases)
+		}
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestTagUpdateAutoTagKeywords(t *testing.T) {
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := sqlite.TagReaderWriter
+
+		// create tag to test against
+		const name = "TestTagUpdateAutoTagKeywords"
+		tag := models.Tag{
+			Name: name,
+		}
+		created, err := qb.Create(ctx, tag)
+		if err != nil {
+			return fmt.Errorf("Error creating tag: %s", err.Error())
+		}
+
+		keywords := []string{"autotagkeyword1", "autotagkeyword2"}
+		err = qb.UpdateAutoTagKeywords(ctx, created.ID, keywords)
+		if err != nil {
+			return fmt.Errorf("Error updating tag auto-tag keywords: %s", err.Error())
+		}
+
+		// ensure keywords set
+		storedKeywords, err := qb.GetAutoTagKeywords(ctx, created.ID)
+		if err != nil {
+			return fmt.Errorf("Error getting auto-tag keywords: %s", err.Error())
+		}
+		assert.Equal(t, keywords, storedKeywords)
+
+		// ensure tag is found by keyword
+		tags, err := qb.QueryForAutoTag(ctx, []string{"autotagkeyword2"})
+		if err != nil {
+			return fmt.Errorf("Error querying for auto-tag: %s", err.Error())
+		}
+		assert.Len(t, tags, 1)
+		if len(tags) > 0 {
+			assert.Equal(t, created.ID, tags[0].ID)
+		}
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestTagPurge(t *testing.T) {
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := sqlite.TagReaderWriter
+
+		// some markers with the tag as their primary tag have no other tags
+		_, err := qb.Purge(ctx, tagIDs[tagIdxWithPrimaryMarkers])
+		assert.NotNil(t, err)
+
+		tagID := tagIDs[tagIdxWithScene]
+		counts, err := qb.Purge(ctx, tagID)
+		if err != nil {
+			return fmt.Errorf("Error purging tag: %s", err.Error())
+		}
+		assert.Greater(t, counts.Scenes, 0)
+
+		counts, err = qb.Purge(ctx, tagIDs[tagIdxWithMarkers])
+		if err != nil {
+			return fmt.Errorf("Error purging tag: %s", err.Error())
+		}
+		assert.Greater(t, counts.SceneMarkers, 0)
+
+		found, err := qb.Find(ctx, tagID)
+		if err != nil {
+			return err
+		}
+		assert.Nil(t, found)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestTagMergeOverlappingAliases(t *testing.T) {
+	assert := assert.New(t)
+
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := sqlite.TagReaderWriter
+
+		const (
+			destName  = "overlap destination"
+			aliasName = "overlap alias"
+		)
+
+		dest, err := qb.Create(ctx, models.Tag{Name: destName})
+		if err != nil {
+			return err
+		}
+		if err := qb.UpdateAliases(ctx, dest.ID, []string{aliasName}); err != nil {
+			return err
+		}
+
+		// source names matching an existing alias, and the destination name
+		// with different case
+		src1, err := qb.Create(ctx, models.Tag{Name: aliasName})
+		if err != nil {
+			return err
+		}
+		src2, err := qb.Create(ctx, models.Tag{Name: "Overlap Destination"})
+		if err != nil {
+			return err
+		}
+
+		if err := qb.Merge(ctx, []int{src1.ID, src2.ID}, dest.ID); err != nil {
+			return err
+		}
+
+		aliases, err := qb.GetAliases(ctx, dest.ID)
+		if err != nil {
+			return err
+		}
+
+		assert.Equal([]string{aliasName}, aliases)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 func TestTagMerge(t *testing.T) {
 	assert := assert.New(t)
 
@@ -1034,3 +1195,25 @@ func TestTagMerge(t *testing.T) {
 // TODO All
 // TODO AllSlim
 // TODO Query
+
+func TestTagAllRelationships(t *testing.T) {
+	withTxn(func(ctx context.Context) error {
+		qb := sqlite.TagReaderWriter
+
+		relationships, err := qb.AllRelationships(ctx)
+		if err != nil {
+			t.Errorf("Error getting tag relationships: %s", err.Error())
+			return nil
+		}
+
+		assert.Len(t, relationships, len(tagParentLinks))
+		for _, link := range tagParentLinks {
+			assert.Contains(t, relationships, models.TagRelationship{
+				ParentID: tagIDs[link[0]],
+				ChildID:  tagIDs[link[1]],
+			})
+		}
+
+		return nil
+	})
+}
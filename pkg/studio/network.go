@@ -0,0 +1,73 @@
+package studio
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// Network is a top-level studio and the studios below it in the hierarchy.
+type Network struct {
+	Studio *models.Studio
+	// Members are the descendants of Studio at any depth, sorted by name.
+	Members []*models.Studio
+}
+
+// Networks groups studios into networks. A network is a studio without a
+// parent that has at least one child studio. Networks are sorted by name.
+func Networks(studios []*models.Studio) []Network {
+	children := make(map[int][]*models.Studio)
+	for _, s := range studios {
+		if s.ParentID.Valid {
+			parentID := int(s.ParentID.Int64)
+			children[parentID] = append(children[parentID], s)
+		}
+	}
+
+	var ret []Network
+	for _, s := range studios {
+		if s.ParentID.Valid || len(children[s.ID]) == 0 {
+			continue
+		}
+
+		n := Network{
+			Studio: s,
+		}
+
+		// walk the hierarchy breadth first, guarding against cycles
+		visited := map[int]bool{s.ID: true}
+		queue := children[s.ID]
+		for len(queue) > 0 {
+			c := queue[0]
+			queue = queue[1:]
+
+			if visited[c.ID] {
+				continue
+			}
+			visited[c.ID] = true
+
+			n.Members = append(n.Members, c)
+			queue = append(queue, children[c.ID]...)
+		}
+
+		sortStudiosByName(n.Members)
+		ret = append(ret, n)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return studioSortName(ret[i].Studio) < studioSortName(ret[j].Studio)
+	})
+
+	return ret
+}
+
+func studioSortName(s *models.Studio) string {
+	return strings.ToLower(s.Name.String)
+}
+
+func sortStudiosByName(studios []*models.Studio) {
+	sort.Slice(studios, func(i, j int) bool {
+		return studioSortName(studios[i]) < studioSortName(studios[j])
+	})
+}
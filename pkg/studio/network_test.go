@@ -0,0 +1,53 @@
+package studio
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworks(t *testing.T) {
+	makeStudio := func(id int, name string, parentID int) *models.Studio {
+		ret := &models.Studio{
+			ID:   id,
+			Name: sql.NullString{String: name, Valid: true},
+		}
+
+		if parentID != 0 {
+			ret.ParentID = sql.NullInt64{Int64: int64(parentID), Valid: true}
+		}
+
+		return ret
+	}
+
+	var (
+		network     = makeStudio(1, "Network", 0)
+		child       = makeStudio(2, "b child", 1)
+		grandchild  = makeStudio(3, "A grandchild", 2)
+		other       = makeStudio(4, "another network", 0)
+		otherChild  = makeStudio(5, "other child", 4)
+		independent = makeStudio(6, "Independent", 0)
+	)
+
+	got := Networks([]*models.Studio{
+		grandchild,
+		network,
+		child,
+		independent,
+		otherChild,
+		other,
+	})
+
+	assert.Equal(t, []Network{
+		{
+			Studio:  other,
+			Members: []*models.Studio{otherChild},
+		},
+		{
+			Studio:  network,
+			Members: []*models.Studio{grandchild, child},
+		},
+	}, got)
+}
@@ -61,3 +61,42 @@ func ByAlias(ctx context.Context, qb Queryer, alias string) (*models.Studio, err
 
 	return nil, nil
 }
+
+type ImageFinder interface {
+	Finder
+	HasImage(ctx context.Context, studioID int) (bool, error)
+}
+
+// FindImageAncestor returns the nearest ancestor of the provided studio that
+// has an image. Returns nil if no ancestor has an image.
+func FindImageAncestor(ctx context.Context, qb ImageFinder, s *models.Studio) (*models.Studio, error) {
+	visited := map[int]bool{s.ID: true}
+
+	for s.ParentID.Valid {
+		parentID := int(s.ParentID.Int64)
+
+		// guard against cycles in the hierarchy
+		if visited[parentID] {
+			return nil, nil
+		}
+		visited[parentID] = true
+
+		parent, err := qb.Find(ctx, parentID)
+		if err != nil || parent == nil {
+			return nil, err
+		}
+
+		hasImage, err := qb.HasImage(ctx, parent.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if hasImage {
+			return parent, nil
+		}
+
+		s = parent
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,59 @@
+package studio
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindImageAncestor(t *testing.T) {
+	const (
+		childID = iota + 1
+		noImageParentID
+		imageGrandparentID
+		cycleID
+		orphanID
+	)
+
+	parentOf := func(id int, parentID int) *models.Studio {
+		return &models.Studio{
+			ID:       id,
+			ParentID: sql.NullInt64{Int64: int64(parentID), Valid: true},
+		}
+	}
+
+	ctx := context.Background()
+	mockStudioReader := &mocks.StudioReaderWriter{}
+	mockStudioReader.On("Find", ctx, noImageParentID).Return(parentOf(noImageParentID, imageGrandparentID), nil)
+	mockStudioReader.On("Find", ctx, imageGrandparentID).Return(&models.Studio{ID: imageGrandparentID}, nil)
+	mockStudioReader.On("Find", ctx, cycleID).Return(parentOf(cycleID, childID), nil)
+	mockStudioReader.On("HasImage", ctx, noImageParentID).Return(false, nil)
+	mockStudioReader.On("HasImage", ctx, imageGrandparentID).Return(true, nil)
+	mockStudioReader.On("HasImage", ctx, cycleID).Return(false, nil)
+
+	tests := []struct {
+		name   string
+		studio *models.Studio
+		wantID int
+	}{
+		{"no parent", &models.Studio{ID: orphanID}, 0},
+		{"grandparent image", parentOf(childID, noImageParentID), imageGrandparentID},
+		{"cycle", parentOf(childID, cycleID), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindImageAncestor(ctx, mockStudioReader, tt.studio)
+			assert.Nil(t, err)
+
+			gotID := 0
+			if got != nil {
+				gotID = got.ID
+			}
+			assert.Equal(t, tt.wantID, gotID)
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 type FinderAliasImageGetter interface {
 	GetAliases(ctx context.Context, studioID int) ([]string, error)
+	GetAutoTagKeywords(ctx context.Context, tagID int) ([]string, error)
 	GetImage(ctx context.Context, tagID int) ([]byte, error)
 	FindByChildTagID(ctx context.Context, childID int) ([]*models.Tag, error)
 }
@@ -33,6 +34,13 @@ func ToJSON(ctx context.Context, reader FinderAliasImageGetter, tag *models.Tag)
 
 	newTagJSON.Aliases = aliases
 
+	keywords, err := reader.GetAutoTagKeywords(ctx, tag.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tag auto-tag keywords: %v", err)
+	}
+
+	newTagJSON.AutoTagKeywords = keywords
+
 	image, err := reader.GetImage(ctx, tag.ID)
 	if err != nil {
 		return nil, fmt.Errorf("error getting tag image: %v", err)
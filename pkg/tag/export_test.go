@@ -130,6 +130,12 @@ func TestToJSON(t *testing.T) {
 	mockTagReader.On("GetAliases", ctx, withParentsID).Return(nil, nil).Once()
 	mockTagReader.On("GetAliases", ctx, errParentsID).Return(nil, nil).Once()
 
+	mockTagReader.On("GetAutoTagKeywords", ctx, tagID).Return(nil, nil).Once()
+	mockTagReader.On("GetAutoTagKeywords", ctx, noImageID).Return(nil, nil).Once()
+	mockTagReader.On("GetAutoTagKeywords", ctx, errImageID).Return(nil, nil).Once()
+	mockTagReader.On("GetAutoTagKeywords", ctx, withParentsID).Return(nil, nil).Once()
+	mockTagReader.On("GetAutoTagKeywords", ctx, errParentsID).Return(nil, nil).Once()
+
 	mockTagReader.On("GetImage", ctx, tagID).Return(imageBytes, nil).Once()
 	mockTagReader.On("GetImage", ctx, noImageID).Return(nil, nil).Once()
 	mockTagReader.On("GetImage", ctx, errImageID).Return(nil, imageErr).Once()
@@ -0,0 +1,272 @@
+package tag
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/jsonschema"
+	"github.com/stashapp/stash/pkg/sliceutil/intslice"
+)
+
+type HierarchyReader interface {
+	All(ctx context.Context) ([]*models.Tag, error)
+	AllRelationships(ctx context.Context) ([]models.TagRelationship, error)
+	GetAliases(ctx context.Context, tagID int) ([]string, error)
+}
+
+// HierarchyToJSON returns the tag hierarchy as nested nodes. Tags without
+// parents are the roots of the hierarchy. Tags which are not reachable from
+// a root, because they or their ancestors form a cycle, are added as
+// additional roots. Tags are sorted by name at each level.
+func HierarchyToJSON(ctx context.Context, reader HierarchyReader) ([]*jsonschema.TagHierarchyNode, error) {
+	tags, err := reader.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	relationships, err := reader.AllRelationships(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Name < tags[j].Name
+	})
+
+	tagMap := make(map[int]*models.Tag)
+	for _, t := range tags {
+		tagMap[t.ID] = t
+	}
+
+	children := make(map[int][]*models.Tag)
+	hasParent := make(map[int]bool)
+	for _, r := range relationships {
+		children[r.ParentID] = append(children[r.ParentID], tagMap[r.ChildID])
+		hasParent[r.ChildID] = true
+	}
+
+	for _, c := range children {
+		sort.Slice(c, func(i, j int) bool {
+			return c[i].Name < c[j].Name
+		})
+	}
+
+	visited := make(map[int]bool)
+
+	var toNode func(t *models.Tag) (*jsonschema.TagHierarchyNode, error)
+	toNode = func(t *models.Tag) (*jsonschema.TagHierarchyNode, error) {
+		ret := &jsonschema.TagHierarchyNode{
+			Name: t.Name,
+		}
+
+		// only include the details of a tag the first time it appears
+		if visited[t.ID] {
+			return ret, nil
+		}
+		visited[t.ID] = true
+
+		ret.Description = t.Description.String
+		ret.IgnoreAutoTag = t.IgnoreAutoTag
+
+		aliases, err := reader.GetAliases(ctx, t.ID)
+		if err != nil {
+			return nil, fmt.Errorf("getting aliases of tag %s: %w", t.Name, err)
+		}
+		ret.Aliases = aliases
+
+		for _, c := range children[t.ID] {
+			child, err := toNode(c)
+			if err != nil {
+				return nil, err
+			}
+			ret.Children = append(ret.Children, child)
+		}
+
+		return ret, nil
+	}
+
+	var ret []*jsonschema.TagHierarchyNode
+	addRoot := func(t *models.Tag) error {
+		node, err := toNode(t)
+		if err != nil {
+			return err
+		}
+		ret = append(ret, node)
+		return nil
+	}
+
+	for _, t := range tags {
+		if !hasParent[t.ID] {
+			if err := addRoot(t); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, t := range tags {
+		if !visited[t.ID] {
+			if err := addRoot(t); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+type HierarchyImporterReaderWriter interface {
+	Queryer
+	FindByName(ctx context.Context, name string, nocase bool) (*models.Tag, error)
+	FindByChildTagID(ctx context.Context, childID int) ([]*models.Tag, error)
+	FindAllDescendants(ctx context.Context, tagID int, excludeIDs []int) ([]*models.TagPath, error)
+	Create(ctx context.Context, newTag models.Tag) (*models.Tag, error)
+	UpdateAliases(ctx context.Context, tagID int, aliases []string) error
+	UpdateParentTags(ctx context.Context, tagID int, parentIDs []int) error
+}
+
+// HierarchyImporter recreates a tag hierarchy from nested nodes.
+type HierarchyImporter struct {
+	ReaderWriter HierarchyImporterReaderWriter
+
+	counts models.TagHierarchyImportCounts
+	// ids of the tags resolved during the import, keyed by lowercase name
+	ids map[string]int
+}
+
+// Import creates the tags of the nodes, and their children, which do not
+// exist, and adds each tag to its parent. Existing tags are matched by name
+// or alias, ignoring case, and are otherwise left unchanged. Parents which
+// would make the hierarchy cyclic are not added.
+func (i *HierarchyImporter) Import(ctx context.Context, nodes []*jsonschema.TagHierarchyNode) (*models.TagHierarchyImportCounts, error) {
+	i.counts = models.TagHierarchyImportCounts{}
+	i.ids = make(map[string]int)
+
+	for _, n := range nodes {
+		if err := i.importNode(ctx, n, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	ret := i.counts
+	return &ret, nil
+}
+
+func (i *HierarchyImporter) importNode(ctx context.Context, n *jsonschema.TagHierarchyNode, parentID *int) error {
+	id, err := i.resolve(ctx, n)
+	if err != nil {
+		return err
+	}
+
+	if parentID != nil {
+		if err := i.addParent(ctx, id, n.Name, *parentID); err != nil {
+			return fmt.Errorf("adding parent to tag %s: %w", n.Name, err)
+		}
+	}
+
+	for _, c := range n.Children {
+		if err := i.importNode(ctx, c, &id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolve returns the id of the tag for the node, creating it if it does
+// not exist.
+func (i *HierarchyImporter) resolve(ctx context.Context, n *jsonschema.TagHierarchyNode) (int, error) {
+	name := strings.TrimSpace(n.Name)
+	if name == "" {
+		return 0, errors.New("tag name must not be blank")
+	}
+
+	key := strings.ToLower(name)
+	if id, found := i.ids[key]; found {
+		return id, nil
+	}
+
+	qb := i.ReaderWriter
+	existing, err := qb.FindByName(ctx, name, true)
+	if err != nil {
+		return 0, fmt.Errorf("finding tag %s: %w", name, err)
+	}
+
+	if existing == nil {
+		existing, err = ByAlias(ctx, qb, name)
+		if err != nil {
+			return 0, fmt.Errorf("finding tag by alias %s: %w", name, err)
+		}
+	}
+
+	if existing != nil {
+		i.counts.Matched++
+		i.ids[key] = existing.ID
+		return existing.ID, nil
+	}
+
+	now := time.Now()
+	created, err := qb.Create(ctx, models.Tag{
+		Name:          name,
+		Description:   sql.NullString{String: n.Description, Valid: true},
+		IgnoreAutoTag: n.IgnoreAutoTag,
+		CreatedAt:     models.SQLiteTimestamp{Timestamp: now},
+		UpdatedAt:     models.SQLiteTimestamp{Timestamp: now},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("creating tag %s: %w", name, err)
+	}
+
+	if len(n.Aliases) > 0 {
+		if err := EnsureAliasesUnique(ctx, created.ID, n.Aliases, qb); err != nil {
+			return 0, err
+		}
+
+		if err := qb.UpdateAliases(ctx, created.ID, n.Aliases); err != nil {
+			return 0, fmt.Errorf("setting aliases of tag %s: %w", name, err)
+		}
+	}
+
+	logger.Infof("Created tag %s from tag hierarchy", name)
+
+	i.counts.Created++
+	i.ids[key] = created.ID
+	return created.ID, nil
+}
+
+// addParent adds the parent to the tag, unless the tag already has the
+// parent or the parent is a descendant of the tag.
+func (i *HierarchyImporter) addParent(ctx context.Context, id int, name string, parentID int) error {
+	qb := i.ReaderWriter
+
+	parents, err := qb.FindByChildTagID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	parentIDs := GetIDs(parents)
+	if intslice.IntInclude(parentIDs, parentID) {
+		return nil
+	}
+
+	// descendants include the tag itself
+	descendants, err := qb.FindAllDescendants(ctx, id, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range descendants {
+		if d.ID == parentID {
+			logger.Warnf("Not adding parent %s to tag %s as it would create a cycle", d.Name, name)
+			return nil
+		}
+	}
+
+	return qb.UpdateParentTags(ctx, id, append(parentIDs, parentID))
+}
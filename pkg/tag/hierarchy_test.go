@@ -0,0 +1,156 @@
+package tag
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/jsonschema"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHierarchyToJSON(t *testing.T) {
+	ctx := context.Background()
+
+	tags := []*models.Tag{
+		{ID: 5, Name: "Eve"},
+		{ID: 4, Name: "Dan"},
+		{ID: 3, Name: "Cat"},
+		{ID: 2, Name: "Bob", IgnoreAutoTag: true},
+		{ID: 1, Name: "Amy", Description: sql.NullString{String: "root", Valid: true}},
+	}
+
+	relationships := []models.TagRelationship{
+		{ParentID: 1, ChildID: 3},
+		{ParentID: 1, ChildID: 2},
+		{ParentID: 2, ChildID: 3},
+		// cycle
+		{ParentID: 4, ChildID: 5},
+		{ParentID: 5, ChildID: 4},
+	}
+
+	mockTagReader := &mocks.TagReaderWriter{}
+	mockTagReader.On("All", ctx).Return(tags, nil).Once()
+	mockTagReader.On("AllRelationships", ctx).Return(relationships, nil).Once()
+	mockTagReader.On("GetAliases", ctx, 1).Return([]string{"Amelia"}, nil).Once()
+	for _, id := range []int{2, 3, 4, 5} {
+		mockTagReader.On("GetAliases", ctx, id).Return(nil, nil).Once()
+	}
+
+	got, err := HierarchyToJSON(ctx, mockTagReader)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []*jsonschema.TagHierarchyNode{
+		{
+			Name:        "Amy",
+			Description: "root",
+			Aliases:     []string{"Amelia"},
+			Children: []*jsonschema.TagHierarchyNode{
+				{
+					Name:          "Bob",
+					IgnoreAutoTag: true,
+					Children: []*jsonschema.TagHierarchyNode{
+						{Name: "Cat"},
+					},
+				},
+				{Name: "Cat"},
+			},
+		},
+		{
+			Name: "Dan",
+			Children: []*jsonschema.TagHierarchyNode{
+				{
+					Name: "Eve",
+					Children: []*jsonschema.TagHierarchyNode{
+						{Name: "Dan"},
+					},
+				},
+			},
+		},
+	}, got)
+
+	mockTagReader.AssertExpectations(t)
+}
+
+func TestHierarchyImporter_Import(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		outdoorID  = 1
+		locationID = 10
+		indoorID   = 11
+	)
+
+	nodes := []*jsonschema.TagHierarchyNode{
+		{
+			Name:    "Location",
+			Aliases: []string{"Place"},
+			Children: []*jsonschema.TagHierarchyNode{
+				{Name: "outdoor"},
+				{
+					Name: "Indoor",
+					Children: []*jsonschema.TagHierarchyNode{
+						// would create a cycle
+						{Name: "location"},
+					},
+				},
+			},
+		},
+	}
+
+	nameMatcher := func(name string) interface{} {
+		return mock.MatchedBy(func(t models.Tag) bool {
+			return t.Name == name
+		})
+	}
+
+	mockTagReaderWriter := &mocks.TagReaderWriter{}
+	mockTagReaderWriter.On("Query", ctx, mock.Anything, mock.Anything).Return(nil, 0, nil)
+
+	mockTagReaderWriter.On("FindByName", ctx, "Location", true).Return(nil, nil).Once()
+	mockTagReaderWriter.On("Create", ctx, nameMatcher("Location")).Return(&models.Tag{ID: locationID}, nil).Once()
+	mockTagReaderWriter.On("UpdateAliases", ctx, locationID, []string{"Place"}).Return(nil).Once()
+
+	mockTagReaderWriter.On("FindByName", ctx, "outdoor", true).Return(&models.Tag{ID: outdoorID, Name: "Outdoor"}, nil).Once()
+	mockTagReaderWriter.On("FindByChildTagID", ctx, outdoorID).Return(nil, nil).Once()
+	mockTagReaderWriter.On("FindAllDescendants", ctx, outdoorID, []int(nil)).Return([]*models.TagPath{
+		{Tag: models.Tag{ID: outdoorID}},
+	}, nil).Once()
+	mockTagReaderWriter.On("UpdateParentTags", ctx, outdoorID, []int{locationID}).Return(nil).Once()
+
+	mockTagReaderWriter.On("FindByName", ctx, "Indoor", true).Return(nil, nil).Once()
+	mockTagReaderWriter.On("Create", ctx, nameMatcher("Indoor")).Return(&models.Tag{ID: indoorID}, nil).Once()
+	mockTagReaderWriter.On("FindByChildTagID", ctx, indoorID).Return(nil, nil).Once()
+	mockTagReaderWriter.On("FindAllDescendants", ctx, indoorID, []int(nil)).Return([]*models.TagPath{
+		{Tag: models.Tag{ID: indoorID}},
+	}, nil).Once()
+	mockTagReaderWriter.On("UpdateParentTags", ctx, indoorID, []int{locationID}).Return(nil).Once()
+
+	mockTagReaderWriter.On("FindByChildTagID", ctx, locationID).Return(nil, nil).Once()
+	mockTagReaderWriter.On("FindAllDescendants", ctx, locationID, []int(nil)).Return([]*models.TagPath{
+		{Tag: models.Tag{ID: locationID}},
+		{Tag: models.Tag{ID: outdoorID}},
+		{Tag: models.Tag{ID: indoorID, Name: "Indoor"}},
+	}, nil).Once()
+
+	importer := &HierarchyImporter{
+		ReaderWriter: mockTagReaderWriter,
+	}
+
+	got, err := importer.Import(ctx, nodes)
+	assert.Nil(t, err)
+	assert.Equal(t, &models.TagHierarchyImportCounts{
+		Created: 2,
+		Matched: 1,
+	}, got)
+
+	mockTagReaderWriter.AssertExpectations(t)
+	mockTagReaderWriter.AssertNumberOfCalls(t, "UpdateParentTags", 2)
+
+	// blank names are rejected
+	_, err = importer.Import(ctx, []*jsonschema.TagHierarchyNode{{Name: " "}})
+	assert.NotNil(t, err)
+}
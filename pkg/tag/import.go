@@ -16,6 +16,7 @@ type NameFinderCreatorUpdater interface {
 	UpdateFull(ctx context.Context, updatedTag models.Tag) (*models.Tag, error)
 	UpdateImage(ctx context.Context, tagID int, image []byte) error
 	UpdateAliases(ctx context.Context, tagID int, aliases []string) error
+	UpdateAutoTagKeywords(ctx context.Context, tagID int, keywords []string) error
 	UpdateParentTags(ctx context.Context, tagID int, parentIDs []int) error
 }
 
@@ -71,6 +72,10 @@ func (i *Importer) PostImport(ctx context.Context, id int) error {
 		return fmt.Errorf("error setting tag aliases: %v", err)
 	}
 
+	if err := i.ReaderWriter.UpdateAutoTagKeywords(ctx, id, i.Input.AutoTagKeywords); err != nil {
+		return fmt.Errorf("error setting tag auto-tag keywords: %v", err)
+	}
+
 	parents, err := i.getParents(ctx)
 	if err != nil {
 		return err
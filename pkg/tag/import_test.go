@@ -63,7 +63,8 @@ func TestImporterPostImport(t *testing.T) {
 	i := Importer{
 		ReaderWriter: readerWriter,
 		Input: jsonschema.Tag{
-			Aliases: []string{"alias"},
+			Aliases:         []string{"alias"},
+			AutoTagKeywords: []string{"keyword"},
 		},
 		imageData: imageBytes,
 	}
@@ -77,6 +78,8 @@ func TestImporterPostImport(t *testing.T) {
 	readerWriter.On("UpdateAliases", testCtx, withParentsID, i.Input.Aliases).Return(nil).Once()
 	readerWriter.On("UpdateAliases", testCtx, errParentsID, i.Input.Aliases).Return(nil).Once()
 
+	readerWriter.On("UpdateAutoTagKeywords", testCtx, mock.Anything, i.Input.AutoTagKeywords).Return(nil)
+
 	readerWriter.On("UpdateImage", testCtx, tagID, imageBytes).Return(nil).Once()
 	readerWriter.On("UpdateImage", testCtx, errAliasID, imageBytes).Return(nil).Once()
 	readerWriter.On("UpdateImage", testCtx, errImageID, imageBytes).Return(updateTagImageErr).Once()
@@ -135,6 +138,7 @@ func TestImporterPostImportParentMissing(t *testing.T) {
 
 	readerWriter.On("UpdateImage", testCtx, mock.Anything, mock.Anything).Return(nil)
 	readerWriter.On("UpdateAliases", testCtx, mock.Anything, mock.Anything).Return(nil)
+	readerWriter.On("UpdateAutoTagKeywords", testCtx, mock.Anything, mock.Anything).Return(nil)
 
 	readerWriter.On("FindByName", testCtx, "Create", false).Return(nil, nil).Once()
 	readerWriter.On("FindByName", testCtx, "CreateError", false).Return(nil, nil).Once()
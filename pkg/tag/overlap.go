@@ -0,0 +1,96 @@
+package tag
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+type AllAliasFinder interface {
+	All(ctx context.Context) ([]*models.Tag, error)
+	AllAliases(ctx context.Context) (map[int][]string, error)
+}
+
+// FindOverlapClusters returns the groups of tags whose names or aliases
+// overlap, ignoring case. Tags are grouped transitively, so that a tag
+// overlapping with any tag of a group belongs to that group. Only groups of
+// more than one tag are returned. Groups are sorted by the name of their
+// first tag, and tags are sorted by name within each group.
+func FindOverlapClusters(ctx context.Context, r AllAliasFinder) ([][]*models.Tag, error) {
+	tags, err := r.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases, err := r.AllAliases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([][]string, len(tags))
+	for i, t := range tags {
+		names[i] = append([]string{t.Name}, aliases[t.ID]...)
+	}
+
+	return overlapClusters(tags, names), nil
+}
+
+// overlapClusters groups tags where names[i] holds the name and aliases of
+// tags[i].
+func overlapClusters(tags []*models.Tag, names [][]string) [][]*models.Tag {
+	// union-find over tag indexes
+	parent := make([]int, len(tags))
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	owner := make(map[string]int)
+	for i, tagNames := range names {
+		for _, n := range tagNames {
+			key := strings.ToLower(strings.TrimSpace(n))
+			if key == "" {
+				continue
+			}
+
+			if j, found := owner[key]; found {
+				parent[find(i)] = find(j)
+			} else {
+				owner[key] = i
+			}
+		}
+	}
+
+	groups := make(map[int][]*models.Tag)
+	for i, t := range tags {
+		root := find(i)
+		groups[root] = append(groups[root], t)
+	}
+
+	var ret [][]*models.Tag
+	for _, g := range groups {
+		if len(g) < 2 {
+			continue
+		}
+
+		sort.Slice(g, func(i, j int) bool {
+			return strings.ToLower(g[i].Name) < strings.ToLower(g[j].Name)
+		})
+		ret = append(ret, g)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return strings.ToLower(ret[i][0].Name) < strings.ToLower(ret[j][0].Name)
+	})
+
+	return ret
+}
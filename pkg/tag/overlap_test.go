@@ -0,0 +1,43 @@
+package tag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindOverlapClusters(t *testing.T) {
+	ctx := context.Background()
+
+	tags := []*models.Tag{
+		{ID: 1, Name: "Outdoor"},
+		{ID: 2, Name: "outdoors"},
+		{ID: 3, Name: "Outside"},
+		{ID: 4, Name: "Indoor"},
+		{ID: 5, Name: "Blonde"},
+		{ID: 6, Name: "blonde hair"},
+	}
+
+	aliases := map[int][]string{
+		1: {"outdoors"},
+		2: {"OUTSIDE"},
+		5: {" Blonde Hair "},
+	}
+
+	mockTagReader := &mocks.TagReaderWriter{}
+	mockTagReader.On("All", ctx).Return(tags, nil).Once()
+	mockTagReader.On("AllAliases", ctx).Return(aliases, nil).Once()
+
+	got, err := FindOverlapClusters(ctx, mockTagReader)
+	assert.Nil(t, err)
+
+	assert.Equal(t, [][]*models.Tag{
+		{tags[4], tags[5]},
+		{tags[0], tags[1], tags[2]},
+	}, got)
+
+	mockTagReader.AssertExpectations(t)
+}
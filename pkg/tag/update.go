@@ -3,6 +3,7 @@ package tag
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/stashapp/stash/pkg/models"
 )
@@ -222,3 +223,94 @@ func MergeHierarchy(ctx context.Context, destination int, sources []int, qb Rela
 
 	return mergedParents, mergedChildren, nil
 }
+
+// FindHierarchyCycles returns the groups of tags that form cycles in the
+// parent/child graph described by relationships. Each group contains the IDs
+// of all tags that are both ancestors and descendants of each other, sorted
+// in ascending order. A tag that is its own parent forms a group by itself.
+// Groups are sorted by their lowest tag ID.
+func FindHierarchyCycles(relationships []models.TagRelationship) [][]int {
+	children := make(map[int][]int)
+	var ids []int
+	addID := func(id int) {
+		if _, found := children[id]; !found {
+			children[id] = nil
+			ids = append(ids, id)
+		}
+	}
+
+	selfParent := make(map[int]bool)
+	for _, r := range relationships {
+		addID(r.ParentID)
+		addID(r.ChildID)
+		children[r.ParentID] = append(children[r.ParentID], r.ChildID)
+		if r.ParentID == r.ChildID {
+			selfParent[r.ParentID] = true
+		}
+	}
+
+	sort.Ints(ids)
+
+	// find the strongly connected components using Tarjan's algorithm
+	var (
+		ret     [][]int
+		stack   []int
+		next    int
+		index   = make(map[int]int)
+		lowLink = make(map[int]int)
+		onStack = make(map[int]bool)
+	)
+
+	var visit func(id int)
+	visit = func(id int) {
+		index[id] = next
+		lowLink[id] = next
+		next++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		for _, childID := range children[id] {
+			if _, visited := index[childID]; !visited {
+				visit(childID)
+				if lowLink[childID] < lowLink[id] {
+					lowLink[id] = lowLink[childID]
+				}
+			} else if onStack[childID] && index[childID] < lowLink[id] {
+				lowLink[id] = index[childID]
+			}
+		}
+
+		if lowLink[id] != index[id] {
+			return
+		}
+
+		// id is the root of a component - pop it from the stack
+		var component []int
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last] = false
+			component = append(component, last)
+			if last == id {
+				break
+			}
+		}
+
+		if len(component) > 1 || selfParent[id] {
+			sort.Ints(component)
+			ret = append(ret, component)
+		}
+	}
+
+	for _, id := range ids {
+		if _, visited := index[id]; !visited {
+			visit(id)
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i][0] < ret[j][0]
+	})
+
+	return ret
+}
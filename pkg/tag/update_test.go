@@ -287,3 +287,52 @@ func testEnsureHierarchy(t *testing.T, tc testUniqueHierarchyCase, queryParents,
 
 	mockTagReader.AssertExpectations(t)
 }
+
+func TestFindHierarchyCycles(t *testing.T) {
+	rel := func(parentID, childID int) models.TagRelationship {
+		return models.TagRelationship{ParentID: parentID, ChildID: childID}
+	}
+
+	tests := []struct {
+		name          string
+		relationships []models.TagRelationship
+		want          [][]int
+	}{
+		{
+			"none",
+			nil,
+			nil,
+		},
+		{
+			"acyclic",
+			[]models.TagRelationship{rel(1, 2), rel(2, 3), rel(1, 3), rel(4, 3)},
+			nil,
+		},
+		{
+			"self parent",
+			[]models.TagRelationship{rel(1, 2), rel(2, 2)},
+			[][]int{{2}},
+		},
+		{
+			"two tags",
+			[]models.TagRelationship{rel(1, 2), rel(2, 1), rel(2, 3)},
+			[][]int{{1, 2}},
+		},
+		{
+			"long cycle",
+			[]models.TagRelationship{rel(5, 1), rel(1, 3), rel(3, 4), rel(4, 5), rel(4, 6)},
+			[][]int{{1, 3, 4, 5}},
+		},
+		{
+			"multiple cycles",
+			[]models.TagRelationship{rel(6, 7), rel(7, 6), rel(1, 2), rel(2, 3), rel(3, 1), rel(3, 6)},
+			[][]int{{1, 2, 3}, {6, 7}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FindHierarchyCycles(tt.relationships))
+		})
+	}
+}
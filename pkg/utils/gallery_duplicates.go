@@ -0,0 +1,123 @@
+package utils
+
+import "sort"
+
+// GalleryFingerprints is the set of fingerprints of the images in a
+// gallery.
+type GalleryFingerprints struct {
+	GalleryID    int
+	Fingerprints []string
+}
+
+// GalleryDuplicateGroup is a group of galleries which share images.
+type GalleryDuplicateGroup struct {
+	GalleryIDs []int
+	// Overlap is the lowest overlap between the galleries that formed the
+	// group.
+	Overlap float64
+}
+
+// FindGalleryDuplicates returns the groups of galleries that share at least
+// minOverlap of their images. The overlap of two galleries is the number of
+// fingerprints they share, divided by the number of fingerprints of the
+// smaller gallery, so that a gallery containing a subset of the images of
+// another is a duplicate of it.
+func FindGalleryDuplicates(galleries []GalleryFingerprints, minOverlap float64) []GalleryDuplicateGroup {
+	// index the galleries by fingerprint
+	sizes := make([]int, len(galleries))
+	index := make(map[string][]int)
+	for i, g := range galleries {
+		seen := make(map[string]struct{})
+		for _, fp := range g.Fingerprints {
+			if _, found := seen[fp]; found {
+				continue
+			}
+			seen[fp] = struct{}{}
+			index[fp] = append(index[fp], i)
+		}
+		sizes[i] = len(seen)
+	}
+
+	// count the fingerprints shared by each pair of galleries
+	type pair struct{ a, b int }
+	shared := make(map[pair]int)
+	for _, indexes := range index {
+		for x := range indexes {
+			for y := x + 1; y < len(indexes); y++ {
+				shared[pair{indexes[x], indexes[y]}]++
+			}
+		}
+	}
+
+	pairs := make([]pair, 0, len(shared))
+	for p := range shared {
+		pairs = append(pairs, p)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].a != pairs[j].a {
+			return pairs[i].a < pairs[j].a
+		}
+		return pairs[i].b < pairs[j].b
+	})
+
+	// union-find over the gallery indexes
+	parent := make([]int, len(galleries))
+	overlap := make([]float64, len(galleries))
+	for i := range parent {
+		parent[i] = i
+		overlap[i] = 1
+	}
+
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for _, p := range pairs {
+		smaller := sizes[p.a]
+		if sizes[p.b] < smaller {
+			smaller = sizes[p.b]
+		}
+
+		v := float64(shared[p]) / float64(smaller)
+		if v < minOverlap {
+			continue
+		}
+
+		ra, rb := find(p.a), find(p.b)
+		if ra != rb {
+			parent[rb] = ra
+			if overlap[rb] < overlap[ra] {
+				overlap[ra] = overlap[rb]
+			}
+		}
+		if v < overlap[ra] {
+			overlap[ra] = v
+		}
+	}
+
+	groups := make(map[int][]int)
+	var roots []int
+	for i, g := range galleries {
+		root := find(i)
+		if _, found := groups[root]; !found {
+			roots = append(roots, root)
+		}
+		groups[root] = append(groups[root], g.GalleryID)
+	}
+
+	var ret []GalleryDuplicateGroup
+	for _, root := range roots {
+		if len(groups[root]) > 1 {
+			ret = append(ret, GalleryDuplicateGroup{
+				GalleryIDs: groups[root],
+				Overlap:    overlap[root],
+			})
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	galleries := []GalleryFingerprints{
+		{GalleryID: 1, Fingerprints: []string{"a", "b", "c", "d"}},
+		{GalleryID: 2, Fingerprints: []string{"e", "f"}},
+		// subset of 1
+		{GalleryID: 3, Fingerprints: []string{"a", "b", "c"}},
+		// shares half of 2
+		{GalleryID: 4, Fingerprints: []string{"e", "g", "g"}},
+		// shares three of four with 1
+		{GalleryID: 5, Fingerprints: []string{"b", "c", "d", "h"}},
+		{GalleryID: 6, Fingerprints: nil},
+	}
+
+	tests := []struct {
+		name       string
+		minOverlap float64
+		want       []GalleryDuplicateGroup
+	}{
+		{
+			"exact",
+			1,
+			[]GalleryDuplicateGroup{
+				{GalleryIDs: []int{1, 3}, Overlap: 1},
+			},
+		},
+		{
+			"partial",
+			0.75,
+			[]GalleryDuplicateGroup{
+				{GalleryIDs: []int{1, 3, 5}, Overlap: 0.75},
+			},
+		},
+		{
+			"half",
+			0.5,
+			[]GalleryDuplicateGroup{
+				{GalleryIDs: []int{1, 3, 5}, Overlap: 2.0 / 3},
+				{GalleryIDs: []int{2, 4}, Overlap: 0.5},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FindGalleryDuplicates(galleries, tt.minOverlap))
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"math"
 	"strconv"
 
 	"github.com/corona10/goimagehash"
@@ -8,16 +9,24 @@ import (
 )
 
 type Phash struct {
-	SceneID   int   `db:"id"`
-	Hash      int64 `db:"phash"`
+	SceneID   int     `db:"id"`
+	Hash      int64   `db:"phash"`
+	Duration  float64 `db:"duration"`
 	Neighbors []int
 	Bucket    int
 }
 
-func FindDuplicates(hashes []*Phash, distance int) [][]int {
+// FindDuplicates returns groups of scene ids with hashes within distance of
+// each other. If durationTolerance is positive, then the durations of
+// neighbouring scenes must also be within durationTolerance seconds.
+func FindDuplicates(hashes []*Phash, distance int, durationTolerance float64) [][]int {
 	for i, scene := range hashes {
 		sceneHash := goimagehash.NewImageHash(uint64(scene.Hash), goimagehash.PHash)
 		for j, neighbor := range hashes {
+			if durationTolerance > 0 && math.Abs(scene.Duration-neighbor.Duration) > durationTolerance {
+				continue
+			}
+
 			if i != j && scene.SceneID != neighbor.SceneID {
 				neighborHash := goimagehash.NewImageHash(uint64(neighbor.Hash), goimagehash.PHash)
 				neighborDistance, _ := sceneHash.Distance(neighborHash)